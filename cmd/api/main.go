@@ -52,7 +52,9 @@ func main() {
 
 	//arbitrage
 	arbitrageSpreadRepo := arbitragebot.NewArbitrageSpreadRepository(db)
-	arbitrageH := arbitragebot.NewHTTPHandlers(arbitrageSpreadRepo)
+	fundingRepo := arbitragebot.NewFundingRepository(db)
+	blacklistRepo := arbitragebot.NewBlacklistRepository(db)
+	arbitrageH := arbitragebot.NewHTTPHandlers(arbitrageSpreadRepo, fundingRepo, blacklistRepo)
 
 	r := gin.Default()
 	api := r.Group("/api")
@@ -67,6 +69,10 @@ func main() {
 			private.GET("/users/me", usersH.GetMe)
 			//
 			private.GET("/arbitrage-spreads", arbitrageH.GetSpreadsHandler)
+			private.GET("/funding-spreads", arbitrageH.GetFundingSpreadsHandler)
+			private.GET("/arbitrage-blacklist", arbitrageH.GetBlacklistHandler)
+			private.POST("/arbitrage-blacklist", arbitrageH.CreateBlacklistEntryHandler)
+			private.DELETE("/arbitrage-blacklist/:id", arbitrageH.DeleteBlacklistEntryHandler)
 		}
 	}
 
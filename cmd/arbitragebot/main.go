@@ -14,9 +14,12 @@ import (
 
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance"
 	"github.com/lucrumx/bot/internal/exchange/client/bingx"
 	"github.com/lucrumx/bot/internal/exchange/client/bybit"
+	"github.com/lucrumx/bot/internal/exchange/client/gate"
 	"github.com/lucrumx/bot/internal/exchange/client/mexc"
+	"github.com/lucrumx/bot/internal/exchange/client/okx"
 	"github.com/lucrumx/bot/internal/notifier"
 
 	"github.com/lucrumx/bot/internal/exchange/arbitragebot"
@@ -40,31 +43,43 @@ func main() {
 		bingXClient,
 		mexcClient,
 	}
+	if cfg.Exchange.Binance.Enabled {
+		clients = append(clients, binance.NewClient(cfg, logger))
+	}
+	if cfg.Exchange.OKX.Enabled {
+		clients = append(clients, okx.NewClient(cfg, logger))
+	}
+	if cfg.Exchange.Gate.Enabled {
+		clients = append(clients, gate.NewClient(cfg, logger))
+	}
 
 	db := storage.InitDB(cfg)
 	notif := notifier.NewTelegramNotifier(cfg)
-	arbitrageSpreadRepo := arbitragebot.NewArbitrageSpreadRepository(db)
+	paper := cfg.Exchange.ArbitrageBot.Paper.Enabled
+	arbitrageSpreadRepo := arbitragebot.NewArbitrageSpreadRepository(db).WithPaper(paper)
 	orderRepo := arbitragebot.NewOrderRepository(db)
-
-	bot := arbitragebot.NewBot(clients, logger, cfg, notif, db, arbitrageSpreadRepo, orderRepo)
+	fundingRepo := arbitragebot.NewFundingRepository(db)
+	tradeRepo := arbitragebot.NewTradeRepository(db).WithPaper(paper)
+	blacklistRepo := arbitragebot.NewBlacklistRepository(db)
+	symbolAliasRepo := arbitragebot.NewSymbolAliasRepository(db)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	aliases, err := arbitragebot.LoadSymbolAliases(ctx, cfg, symbolAliasRepo)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load symbol aliases")
+	}
+	logger.Info().Msgf("symbol aliases: %d", aliases.Len())
+	clients = arbitragebot.WithSymbolAliases(clients, aliases)
+	if paper {
+		clients = arbitragebot.WithPaperTrading(clients, cfg.Exchange.ArbitrageBot.Paper, logger)
+	}
+
+	bot := arbitragebot.NewBot(clients, logger, cfg, notif, db, arbitrageSpreadRepo, orderRepo, fundingRepo, tradeRepo, blacklistRepo)
+
 	if err = bot.Run(ctx); err != nil {
 		logger.Fatal().Err(err).Msg("Failed to run bot")
 	}
 
 }
-
-/*
-Фандинг
-# ByBit — все символы, поле fundingRate
-curl -s "https://api.bybit.com/v5/market/tickers?category=linear" | python3 -m json.tool | head -30
-
-# BingX — все символы, поле lastFundingRate
-curl -s "https://open-api.bingx.com/openApi/swap/v2/quote/premiumIndex" | python3 -m json.tool | head -30
-
-# MEXC — все символы, поле fundingRate
-curl -s "https://contract.mexc.com/api/v1/contract/funding_rate" | python3 -m json.tool | head -30
-*/
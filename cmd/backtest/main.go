@@ -0,0 +1,119 @@
+// Package main backtests the arbitrage bot over a dataset of the recorder with simulated execution and prints
+// the report.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/arbitragebot"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/exchange/simulator"
+)
+
+func main() {
+	defaults := simulator.DefaultConfig()
+
+	// declared before config.Load, it parses the flags
+	exchanges := flag.String("exchanges", "ByBit,BingX", "recorded exchanges traded against each other, comma separated")
+	dir := flag.String("dir", "", "dataset directory, exchange.recorder.dir by default")
+	from := flag.String("from", "", "start of the backtest, RFC3339, the start of the dataset by default")
+	to := flag.String("to", "", "end of the backtest, RFC3339, the end of the dataset by default")
+	speed := flag.Float64("speed", 1, "1 - original speed, N - N times faster; the engine must keep up")
+	drain := flag.Duration("drain", 5*time.Second, "time given to the engine to process the last fills after the last trade")
+	mode := flag.String("mode", "", "order mode: market or limit, exchange.arbitration_bot.order_mode by default")
+	minSpread := flag.Float64("min-spread", 0, "open threshold in percent, exchange.arbitration_bot.min_spread_percent by default")
+	closeSpread := flag.Float64("close-spread", 0, "close threshold in percent, exchange.arbitration_bot.percent_for_close_spread by default")
+	latency := flag.Duration("latency", defaults.Latency, "time for an order to reach the book")
+	slippage := flag.Float64("slippage", defaults.SlippagePercent, "slippage of market orders in percent")
+	fillRule := flag.String("fill-rule", string(defaults.FillRule), "trades filling resting limit orders: touch or through")
+	makerFee := flag.Float64("maker-fee", defaults.Fees.Maker, "maker fee rate")
+	takerFee := flag.Float64("taker-fee", defaults.Fees.Taker, "taker fee rate")
+	volumeLimited := flag.Bool("volume-limited", false, "fill resting limit orders by at most the volume of the crossing trade")
+	balance := flag.Float64("balance", defaults.Balance.InexactFloat64(), "initial USDT of each exchange and category")
+
+	zerolog.TimeFieldFormat = time.RFC3339
+	logger := log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	cfg, err := config.Load(logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error loading config")
+	}
+
+	if *mode != "" {
+		cfg.Exchange.ArbitrageBot.OrderMode = config.OrderMode(*mode)
+	}
+	if *minSpread > 0 {
+		cfg.Exchange.ArbitrageBot.MinSpreadPercent = *minSpread
+	}
+	if *closeSpread > 0 {
+		cfg.Exchange.ArbitrageBot.PercentForCloseSpread = *closeSpread
+	}
+
+	rule := simulator.FillRule(*fillRule)
+	if rule != simulator.FillTouch && rule != simulator.FillThrough {
+		logger.Fatal().Str("fill_rule", *fillRule).Msg("Unknown fill rule")
+	}
+
+	replayCfg := replay.Config{Dir: *dir, Speed: *speed}
+	if replayCfg.Dir == "" {
+		replayCfg.Dir = cfg.Exchange.Recorder.Dir
+	}
+	if replayCfg.Dir == "" {
+		replayCfg.Dir = recorder.DefaultConfig().Dir
+	}
+	if replayCfg.From, err = parseTime(*from); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -from")
+	}
+	if replayCfg.To, err = parseTime(*to); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -to")
+	}
+
+	feed, err := replay.NewFeed(replayCfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to open dataset")
+	}
+
+	btCfg := arbitragebot.BacktestConfig{
+		Exchanges: strings.Split(*exchanges, ","),
+		Simulator: simulator.Config{
+			Latency:         *latency,
+			SlippagePercent: *slippage,
+			Fees:            exchange.FeeRate{Maker: *makerFee, Taker: *takerFee},
+			FillRule:        rule,
+			VolumeLimited:   *volumeLimited,
+			Balance:         decimal.NewFromFloat(*balance),
+		},
+		Drain: *drain,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := arbitragebot.NewBacktest(cfg, btCfg, feed, logger).Run(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Backtest failed")
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to write report")
+	}
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,93 @@
+// Package main contains the main entry point for the market data recorder.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx"
+	"github.com/lucrumx/bot/internal/exchange/client/bybit"
+	"github.com/lucrumx/bot/internal/exchange/client/gate"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc"
+	"github.com/lucrumx/bot/internal/exchange/client/okx"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+func main() {
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	logger := log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	cfg, err := config.Load(logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error loading config")
+	}
+
+	clients := []exchange.Provider{
+		bybit.NewByBitClient(cfg, logger),
+		bingx.NewClient(cfg, logger),
+		mexc.NewClient(cfg, logger),
+	}
+	if cfg.Exchange.Binance.Enabled {
+		clients = append(clients, binance.NewClient(cfg, logger))
+	}
+	if cfg.Exchange.OKX.Enabled {
+		clients = append(clients, okx.NewClient(cfg, logger))
+	}
+	if cfg.Exchange.Gate.Enabled {
+		clients = append(clients, gate.NewClient(cfg, logger))
+	}
+
+	rec := recorder.NewRecorder(clients, loadRecorderConfig(cfg, clients), logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err = rec.Run(ctx); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to run recorder")
+	}
+}
+
+func loadRecorderConfig(cfg *config.Config, clients []exchange.Provider) recorder.Config {
+	defaults := recorder.DefaultConfig()
+	raw := cfg.Exchange.Recorder
+
+	if raw.Dir != "" {
+		defaults.Dir = raw.Dir
+	}
+	if raw.Partition > 0 {
+		defaults.Partition = raw.Partition
+	}
+	if raw.FlushInterval > 0 {
+		defaults.FlushInterval = raw.FlushInterval
+	}
+
+	for _, source := range raw.Sources {
+		category := exchange.CategoryLinear
+		if source.Category == string(exchange.CategorySpot) {
+			category = exchange.CategorySpot
+		}
+		defaults.Sources = append(defaults.Sources, recorder.Source{
+			Exchange: source.Exchange,
+			Category: category,
+			Symbols:  source.Symbols,
+		})
+	}
+	if len(defaults.Sources) == 0 {
+		for _, client := range clients {
+			defaults.Sources = append(defaults.Sources, recorder.Source{
+				Exchange: client.GetExchangeName(),
+				Category: exchange.CategoryLinear,
+			})
+		}
+	}
+
+	return defaults
+}
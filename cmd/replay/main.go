@@ -0,0 +1,131 @@
+// Package main replays a dataset of the recorder into the pump or manipulation bot and logs the alerts.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange/manipulationbot"
+	"github.com/lucrumx/bot/internal/exchange/pumpbot"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+func main() {
+	// declared before config.Load, it parses the flags
+	botName := flag.String("bot", "pump", "bot to replay: pump or manipulation")
+	exchangeName := flag.String("exchange", "ByBit", "recorded exchange")
+	dir := flag.String("dir", "", "dataset directory, exchange.recorder.dir by default")
+	from := flag.String("from", "", "start of the replay, RFC3339, the start of the dataset by default")
+	to := flag.String("to", "", "end of the replay, RFC3339, the end of the dataset by default")
+	speed := flag.Float64("speed", 1, "1 - original speed, N - N times faster; the bot must not drop trades")
+	drain := flag.Duration("drain", 5*time.Second, "time given to the bot to process its queues after the last trade")
+
+	zerolog.TimeFieldFormat = time.RFC3339
+	logger := log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	cfg, err := config.Load(logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error loading config")
+	}
+
+	replayCfg := replay.Config{Dir: *dir, Speed: *speed}
+	if replayCfg.Dir == "" {
+		replayCfg.Dir = cfg.Exchange.Recorder.Dir
+	}
+	if replayCfg.Dir == "" {
+		replayCfg.Dir = recorder.DefaultConfig().Dir
+	}
+	if replayCfg.From, err = parseTime(*from); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -from")
+	}
+	if replayCfg.To, err = parseTime(*to); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -to")
+	}
+
+	feed, err := replay.NewFeed(replayCfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to open dataset")
+	}
+	notif := notifier.NewLogNotifier(logger, feed.Clock())
+	provider := feed.Provider(*exchangeName)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	botCtx, stopBot := context.WithCancel(ctx)
+	defer stopBot()
+	botDone := make(chan struct{})
+
+	var subscriptions int
+	switch *botName {
+	case "pump":
+		bot := pumpbot.NewBot(provider, notif, cfg, logger)
+		bot.SetClock(feed.Clock())
+		bot.SetTradeTime()
+		subscriptions = 1
+
+		trades, err := bot.StartBot(botCtx)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start pump bot")
+		}
+		go func() {
+			defer close(botDone)
+			for range trades {
+			}
+		}()
+	case "manipulation":
+		botCfg := manipulationbot.ConfigFromYAML(cfg.Exchange.ManipulationBot)
+		// the dataset has no turnover
+		botCfg.MinPerpTurnover24h = 0
+		botCfg.MaxSpotTurnover24h = 0
+		bot := manipulationbot.NewBot(provider, notif, botCfg, logger)
+		bot.SetClock(feed.Clock())
+		bot.SetTradeTime()
+		subscriptions = 2
+
+		go func() {
+			defer close(botDone)
+			if err := bot.Run(botCtx); err != nil {
+				logger.Error().Err(err).Msg("Manipulation bot stopped")
+			}
+		}()
+	default:
+		logger.Fatal().Str("bot", *botName).Msg("Unknown bot")
+	}
+
+	stats, err := feed.Play(ctx, subscriptions)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Replay failed")
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(*drain):
+	}
+	stopBot()
+	<-botDone
+
+	logger.Info().
+		Int64("trades", stats.Trades).
+		Time("from", time.UnixMilli(stats.FromMs)).
+		Time("to", time.UnixMilli(stats.ToMs)).
+		Int64("alerts", notif.Count()).
+		Msg("Replay finished")
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,109 @@
+// Package main runs a detector over a dataset of the recorder for a grid or a random search of its
+// thresholds and prints the parameter sets ranked by simulated PnL.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/exchange/sweep"
+)
+
+// paramsFlag collects the repeated -param flags.
+type paramsFlag []string
+
+func (p *paramsFlag) String() string {
+	return strings.Join(*p, " ")
+}
+
+func (p *paramsFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+func main() {
+	var params paramsFlag
+
+	// declared before config.Load, it parses the flags
+	detector := flag.String("detector", string(sweep.DetectorArbitrage), "detector to tune: arbitrage, pump or manipulation")
+	flag.Var(&params, "param", "search space of a parameter, name=v1,v2 or name=min:max:step, repeatable")
+	exchanges := flag.String("exchanges", "", "recorded exchanges, comma separated, all by default")
+	dir := flag.String("dir", "", "dataset directory, exchange.recorder.dir by default")
+	from := flag.String("from", "", "start of the sweep, RFC3339, the start of the dataset by default")
+	to := flag.String("to", "", "end of the sweep, RFC3339, the end of the dataset by default")
+	random := flag.Int("random", 0, "number of random parameter sets, 0 - the full grid")
+	seed := flag.Int64("seed", 1, "seed of the random search")
+	workers := flag.Int("workers", 0, "parallel workers, the number of CPUs by default")
+	horizon := flag.Duration("horizon", 15*time.Minute, "holding time of the pump and manipulation signals")
+	takerFee := flag.Float64("taker-fee", 0.0005, "taker fee rate of every entry and exit")
+	top := flag.Int("top", 20, "number of ranked parameter sets to print, 0 - all")
+
+	zerolog.TimeFieldFormat = time.RFC3339
+	logger := log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	cfg, err := config.Load(logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error loading config")
+	}
+
+	sweepCfg := sweep.Config{
+		Detector: sweep.Detector(*detector),
+		Replay:   replay.Config{Dir: *dir},
+		Random:   *random,
+		Seed:     *seed,
+		Workers:  *workers,
+		Horizon:  *horizon,
+		TakerFee: *takerFee,
+	}
+	if *exchanges != "" {
+		sweepCfg.Exchanges = strings.Split(*exchanges, ",")
+	}
+	if sweepCfg.Replay.Dir == "" {
+		sweepCfg.Replay.Dir = cfg.Exchange.Recorder.Dir
+	}
+	if sweepCfg.Replay.Dir == "" {
+		sweepCfg.Replay.Dir = recorder.DefaultConfig().Dir
+	}
+	if sweepCfg.Replay.From, err = parseTime(*from); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -from")
+	}
+	if sweepCfg.Replay.To, err = parseTime(*to); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -to")
+	}
+	for _, spec := range params {
+		param, err := sweep.ParseParam(spec)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Invalid -param")
+		}
+		sweepCfg.Params = append(sweepCfg.Params, param)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := sweep.Run(ctx, cfg, sweepCfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Sweep failed")
+	}
+	if err := report.Write(os.Stdout, *top); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to write report")
+	}
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
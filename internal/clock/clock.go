@@ -0,0 +1,115 @@
+// Package clock provides the time source of the bots: the wall clock live, a manual clock driven by
+// the replayed data in replays and backtests.
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the current time and waits for durations of its own time.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has passed on the clock.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Real returns the wall clock.
+func Real() Clock {
+	return realClock{}
+}
+
+// Manual is a clock that is moved explicitly, e.g. to the timestamp of the last replayed trade.
+// It never moves back, waiters of After fire when the clock is moved past their time. Safe for concurrent use.
+type Manual struct {
+	ns atomic.Int64
+
+	mu      sync.Mutex
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewManual creates a Manual clock set to t.
+func NewManual(t time.Time) *Manual {
+	c := &Manual{}
+	c.ns.Store(t.UnixNano())
+	return c
+}
+
+// Now returns the time the clock was moved to.
+func (c *Manual) Now() time.Time {
+	return time.Unix(0, c.ns.Load())
+}
+
+// After returns a channel that receives the time of the clock once it is moved to now+d or later.
+func (c *Manual) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.Now()
+	at := now.Add(d)
+	if !at.After(now) {
+		ch <- now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: at, ch: ch})
+	return ch
+}
+
+// Set moves the clock to t, earlier times are ignored.
+func (c *Manual) Set(t time.Time) {
+	ns := t.UnixNano()
+	for {
+		cur := c.ns.Load()
+		if ns <= cur {
+			return
+		}
+		if c.ns.CompareAndSwap(cur, ns) {
+			break
+		}
+	}
+	c.fire()
+}
+
+// Advance moves the clock forward by d.
+func (c *Manual) Advance(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.ns.Add(int64(d))
+	c.fire()
+}
+
+// fire releases the waiters whose time has come.
+func (c *Manual) fire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.Now()
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- now
+	}
+	c.waiters = pending
+}
@@ -0,0 +1,58 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManual(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+	assert.True(t, c.Now().Equal(start))
+
+	c.Set(start.Add(time.Minute))
+	assert.True(t, c.Now().Equal(start.Add(time.Minute)))
+
+	// never moves back
+	c.Set(start)
+	assert.True(t, c.Now().Equal(start.Add(time.Minute)))
+
+	c.Advance(time.Second)
+	assert.True(t, c.Now().Equal(start.Add(time.Minute+time.Second)))
+}
+
+func TestManual_After(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+
+	ch := c.After(time.Second)
+	select {
+	case <-ch:
+		t.Fatal("fired before the clock was moved")
+	default:
+	}
+
+	c.Set(start.Add(500 * time.Millisecond))
+	select {
+	case <-ch:
+		t.Fatal("fired before its time")
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case at := <-ch:
+		assert.True(t, at.Equal(start.Add(1500*time.Millisecond)))
+	default:
+		t.Fatal("not fired after its time")
+	}
+
+	// zero duration fires at once
+	select {
+	case <-c.After(0):
+	default:
+		t.Fatal("zero duration not fired")
+	}
+}
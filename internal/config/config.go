@@ -102,10 +102,43 @@ func loadFromEnv(cfg *Config, logger zerolog.Logger) error {
 	}
 
 	mexc := MEXCConfig{
-		APIBaseURL: utils.GetEnv("MEXC_API_BASE_URL", ""),
-		WSUrl:      utils.GetEnv("MEXC_WS_URL", ""),
-		APIKey:     utils.GetEnv("MEXC_API_KEY", ""),
-		APISecret:  utils.GetEnv("MEXC_API_SECRET", ""),
+		APIBaseURL:     utils.GetEnv("MEXC_API_BASE_URL", ""),
+		SpotAPIBaseURL: utils.GetEnv("MEXC_SPOT_API_BASE_URL", ""),
+		WSUrl:          utils.GetEnv("MEXC_WS_URL", ""),
+		APIKey:         utils.GetEnv("MEXC_API_KEY", ""),
+		APISecret:      utils.GetEnv("MEXC_API_SECRET", ""),
+	}
+
+	// the exchanges added after ByBit, BingX and MEXC are opt-in
+	binanceEnabled, _ := strconv.ParseBool(utils.GetEnv("BINANCE_ENABLED", "false"))
+	okxEnabled, _ := strconv.ParseBool(utils.GetEnv("OKX_ENABLED", "false"))
+	gateEnabled, _ := strconv.ParseBool(utils.GetEnv("GATE_ENABLED", "false"))
+
+	binance := BinanceConfig{
+		Enabled:        binanceEnabled,
+		APIBaseURL:     utils.GetEnv("BINANCE_API_BASE_URL", ""),
+		SpotAPIBaseURL: utils.GetEnv("BINANCE_SPOT_API_BASE_URL", ""),
+		WSUrl:          utils.GetEnv("BINANCE_WS_URL", ""),
+		APIKey:         utils.GetEnv("BINANCE_API_KEY", ""),
+		APISecret:      utils.GetEnv("BINANCE_API_SECRET", ""),
+	}
+
+	okx := OKXConfig{
+		Enabled:      okxEnabled,
+		APIBaseURL:   utils.GetEnv("OKX_API_BASE_URL", ""),
+		WSUrl:        utils.GetEnv("OKX_WS_URL", ""),
+		WSPrivateURL: utils.GetEnv("OKX_WS_PRIVATE_URL", ""),
+		APIKey:       utils.GetEnv("OKX_API_KEY", ""),
+		APISecret:    utils.GetEnv("OKX_API_SECRET", ""),
+		Passphrase:   utils.GetEnv("OKX_PASSPHRASE", ""),
+	}
+
+	gate := GateConfig{
+		Enabled:    gateEnabled,
+		APIBaseURL: utils.GetEnv("GATE_API_BASE_URL", ""),
+		WSUrl:      utils.GetEnv("GATE_WS_URL", ""),
+		APIKey:     utils.GetEnv("GATE_API_KEY", ""),
+		APISecret:  utils.GetEnv("GATE_API_SECRET", ""),
 	}
 
 	wsClientBufferSize, err := strconv.Atoi(utils.GetEnv("WS_CLIENT_BUFFER_SIZE", "5000"))
@@ -185,9 +218,12 @@ func loadFromEnv(cfg *Config, logger zerolog.Logger) error {
 	}
 
 	cfg.Exchange = ExchangeConfig{
-		ByBit: byBit,
-		BingX: bingX,
-		MEXC:  mexc,
+		ByBit:   byBit,
+		BingX:   bingX,
+		MEXC:    mexc,
+		Binance: binance,
+		OKX:     okx,
+		Gate:    gate,
 		WsClient: WsClientConfig{
 			BufferSize: wsClientBufferSize,
 		},
@@ -279,6 +315,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.Exchange.MEXC.APISecret == "" {
 		return raiseErrorYAML("Exchange.MEXC.APISecret")
 	}
+	if cfg.Exchange.MEXC.SpotAPIBaseURL == "" {
+		cfg.Exchange.MEXC.SpotAPIBaseURL = "https://api.mexc.com"
+	}
 
 	// BingX
 	if cfg.Exchange.BingX.APIBaseURL == "" {
@@ -297,6 +336,66 @@ func validateConfig(cfg *Config) error {
 		return raiseErrorYAML("Exchange.BingX.APISecret")
 	}
 
+	// Binance
+	if cfg.Exchange.Binance.Enabled {
+		if cfg.Exchange.Binance.APIBaseURL == "" {
+			return raiseErrorYAML("Exchange.Binance.APIBaseURL")
+		}
+		if cfg.Exchange.Binance.WSUrl == "" {
+			return raiseErrorYAML("Exchange.Binance.WSUrl")
+		}
+		if cfg.Exchange.Binance.APIKey == "" {
+			return raiseErrorYAML("Exchange.Binance.APIKey")
+		}
+		if cfg.Exchange.Binance.APISecret == "" {
+			return raiseErrorYAML("Exchange.Binance.APISecret")
+		}
+	}
+	if cfg.Exchange.Binance.RecvWindow == 0 {
+		cfg.Exchange.Binance.RecvWindow = 5000
+	}
+	if cfg.Exchange.Binance.SpotAPIBaseURL == "" {
+		cfg.Exchange.Binance.SpotAPIBaseURL = "https://api.binance.com"
+	}
+
+	// OKX
+	if cfg.Exchange.OKX.Enabled {
+		if cfg.Exchange.OKX.APIBaseURL == "" {
+			return raiseErrorYAML("Exchange.OKX.APIBaseURL")
+		}
+		if cfg.Exchange.OKX.WSUrl == "" {
+			return raiseErrorYAML("Exchange.OKX.WSUrl")
+		}
+		if cfg.Exchange.OKX.WSPrivateURL == "" {
+			return raiseErrorYAML("Exchange.OKX.WSPrivateURL")
+		}
+		if cfg.Exchange.OKX.APIKey == "" {
+			return raiseErrorYAML("Exchange.OKX.APIKey")
+		}
+		if cfg.Exchange.OKX.APISecret == "" {
+			return raiseErrorYAML("Exchange.OKX.APISecret")
+		}
+		if cfg.Exchange.OKX.Passphrase == "" {
+			return raiseErrorYAML("Exchange.OKX.Passphrase")
+		}
+	}
+
+	// Gate
+	if cfg.Exchange.Gate.Enabled {
+		if cfg.Exchange.Gate.APIBaseURL == "" {
+			return raiseErrorYAML("Exchange.Gate.APIBaseURL")
+		}
+		if cfg.Exchange.Gate.WSUrl == "" {
+			return raiseErrorYAML("Exchange.Gate.WSUrl")
+		}
+		if cfg.Exchange.Gate.APIKey == "" {
+			return raiseErrorYAML("Exchange.Gate.APIKey")
+		}
+		if cfg.Exchange.Gate.APISecret == "" {
+			return raiseErrorYAML("Exchange.Gate.APISecret")
+		}
+	}
+
 	if cfg.Exchange.WsClient.BufferSize == 0 {
 		return raiseErrorYAML("Exchange.WsClient.BufferSize")
 	}
@@ -334,6 +433,89 @@ func validateConfig(cfg *Config) error {
 	if cfg.Exchange.ArbitrageBot.PercentForCloseSpread < 0 || cfg.Exchange.ArbitrageBot.PercentForCloseSpread > 0.5 {
 		return raiseErrorYAML("Exchange.ArbitrageBot.PercentForCloseSpread")
 	}
+	if cfg.Exchange.ArbitrageBot.SlippagePercent < 0 {
+		return raiseErrorYAML("Exchange.ArbitrageBot.SlippagePercent")
+	}
+	if cfg.Exchange.ArbitrageBot.Funding.Enabled {
+		if cfg.Exchange.ArbitrageBot.Funding.MinAnnualizedPercent <= 0 {
+			return raiseErrorYAML("Exchange.ArbitrageBot.Funding.MinAnnualizedPercent")
+		}
+		if cfg.Exchange.ArbitrageBot.Funding.PollInterval <= 0 {
+			cfg.Exchange.ArbitrageBot.Funding.PollInterval = 5 * time.Minute
+		}
+		if cfg.Exchange.ArbitrageBot.Funding.HoldingDays <= 0 {
+			cfg.Exchange.ArbitrageBot.Funding.HoldingDays = 7
+		}
+	}
+	if cfg.Exchange.ArbitrageBot.Reconcile.Enabled && cfg.Exchange.ArbitrageBot.Reconcile.Interval <= 0 {
+		cfg.Exchange.ArbitrageBot.Reconcile.Interval = time.Minute
+	}
+	if cfg.Exchange.ArbitrageBot.Risk.MaxPositions <= 0 {
+		cfg.Exchange.ArbitrageBot.Risk.MaxPositions = 3
+	}
+	if cfg.Exchange.ArbitrageBot.Risk.NotionalUSDT <= 0 {
+		cfg.Exchange.ArbitrageBot.Risk.NotionalUSDT = 10
+	}
+	if cfg.Exchange.ArbitrageBot.Leverage < 0 {
+		return raiseErrorYAML("Exchange.ArbitrageBot.Leverage (expected: 0 - not set by the bot, or positive)")
+	}
+	for symbol, leverage := range cfg.Exchange.ArbitrageBot.SymbolLeverage {
+		if leverage <= 0 {
+			return raiseErrorYAML("Exchange.ArbitrageBot.SymbolLeverage." + symbol)
+		}
+	}
+	switch cfg.Exchange.ArbitrageBot.MarginMode {
+	case "", "cross", "isolated":
+		// ok, empty - not set by the bot
+	default:
+		return raiseErrorYAML("Exchange.ArbitrageBot.MarginMode (expected: cross | isolated)")
+	}
+	if cfg.Exchange.ArbitrageBot.AccountMarginMode && cfg.Exchange.ArbitrageBot.MarginMode == "" {
+		return raiseErrorYAML("Exchange.ArbitrageBot.AccountMarginMode (needs margin_mode)")
+	}
+	if cfg.Exchange.ArbitrageBot.Blacklist.TTL <= 0 {
+		cfg.Exchange.ArbitrageBot.Blacklist.TTL = time.Hour
+	}
+	if cfg.Exchange.ArbitrageBot.Blacklist.RefreshInterval <= 0 {
+		cfg.Exchange.ArbitrageBot.Blacklist.RefreshInterval = time.Minute
+	}
+	if cfg.Exchange.ArbitrageBot.InstrumentRefreshInterval <= 0 {
+		cfg.Exchange.ArbitrageBot.InstrumentRefreshInterval = time.Hour
+	}
+	for i := range cfg.Exchange.ArbitrageBot.SymbolAliases {
+		alias := &cfg.Exchange.ArbitrageBot.SymbolAliases[i]
+		if alias.Symbol == "" || alias.Exchange == "" || alias.ExchangeSymbol == "" || alias.Multiplier < 0 {
+			return raiseErrorYAML(fmt.Sprintf("Exchange.ArbitrageBot.SymbolAliases[%d]", i))
+		}
+		if alias.Multiplier == 0 {
+			alias.Multiplier = 1
+		}
+	}
+	if paper := &cfg.Exchange.ArbitrageBot.Paper; paper.Enabled {
+		if cfg.Exchange.ArbitrageBot.SilentMode {
+			return raiseErrorYAML("Exchange.ArbitrageBot.SilentMode (paper trading needs silent_mode: false)")
+		}
+		if paper.Latency <= 0 {
+			paper.Latency = 50 * time.Millisecond
+		}
+		if paper.SlippagePercent < 0 {
+			return raiseErrorYAML("Exchange.ArbitrageBot.Paper.SlippagePercent")
+		}
+		switch paper.FillRule {
+		case "touch", "through":
+			// ok
+		case "":
+			paper.FillRule = "through"
+		default:
+			return raiseErrorYAML("Exchange.ArbitrageBot.Paper.FillRule (expected: touch | through)")
+		}
+		if paper.BalanceUSDT <= 0 {
+			paper.BalanceUSDT = 10_000
+		}
+	}
+	if cfg.Exchange.ArbitrageBot.Sizing.BalanceFraction < 0 || cfg.Exchange.ArbitrageBot.Sizing.BalanceFraction > 1 {
+		return raiseErrorYAML("Exchange.ArbitrageBot.Sizing.BalanceFraction (expected: 0..1)")
+	}
 	if cfg.Exchange.ArbitrageBot.MaxSpreadPercentForOpen <= 0 {
 		cfg.Exchange.ArbitrageBot.MaxSpreadPercentForOpen = 5
 	}
@@ -348,6 +530,29 @@ func validateConfig(cfg *Config) error {
 	if cfg.Exchange.ArbitrageBot.OrderMode == OrderModeLimit && cfg.Exchange.ArbitrageBot.LimitFillTimeoutMs <= 0 {
 		cfg.Exchange.ArbitrageBot.LimitFillTimeoutMs = 1000
 	}
+	switch cfg.Exchange.ArbitrageBot.PriceSource {
+	case PriceSourceBook, PriceSourceTrade:
+		// ok
+	case "":
+		cfg.Exchange.ArbitrageBot.PriceSource = PriceSourceBook
+	default:
+		return raiseErrorYAML("Exchange.ArbitrageBot.PriceSource (expected: book | trade)")
+	}
+	if len(cfg.Exchange.ArbitrageBot.SpotExchanges) > 0 {
+		// book ticker and order book streams are linear only
+		if cfg.Exchange.ArbitrageBot.PriceSource != PriceSourceTrade {
+			return raiseErrorYAML("Exchange.ArbitrageBot.PriceSource (spot_exchanges need price_source: trade)")
+		}
+		if cfg.Exchange.ArbitrageBot.DepthSizing {
+			return raiseErrorYAML("Exchange.ArbitrageBot.DepthSizing (not supported with spot_exchanges)")
+		}
+		// Gate spot market buy takes the amount in quote coin, the client places base coin qty only
+		for _, name := range cfg.Exchange.ArbitrageBot.SpotExchanges {
+			if strings.EqualFold(name, "gate") && cfg.Exchange.ArbitrageBot.OrderMode == OrderModeMarket {
+				return raiseErrorYAML("Exchange.ArbitrageBot.SpotExchanges (gate spot needs order_mode: limit)")
+			}
+		}
+	}
 
 	if cfg.Notifications.Telegram.BotToken == "" {
 		return raiseErrorYAML("Notifications.Telegram.BotToken")
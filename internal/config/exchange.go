@@ -30,12 +30,49 @@ type BingXConfig struct {
 // MEXCConfig contains configuration for MEXC exchange.
 type MEXCConfig struct {
 	APIBaseURL string `yaml:"api_base_url"`
-	WSUrl      string `yaml:"ws_url"`
+	// SpotAPIBaseURL is the host of MEXC spot REST API (spot venues of the arbitrage bot).
+	SpotAPIBaseURL string `yaml:"spot_api_base_url"`
+	WSUrl          string `yaml:"ws_url"`
 	//WSPrivateSwapURL string `yaml:"ws_private_swap_url"`
 	APIKey    string `yaml:"api_key"`
 	APISecret string `yaml:"api_secret"`
 }
 
+// BinanceConfig contains configuration for Binance USDT-M futures.
+type BinanceConfig struct {
+	// Enabled adds Binance to the exchanges of the arbitrage bot and the recorder.
+	Enabled    bool   `yaml:"enabled"`
+	APIBaseURL string `yaml:"api_base_url"`
+	// SpotAPIBaseURL is the host of Binance spot REST API (spot venues of the arbitrage bot).
+	SpotAPIBaseURL string `yaml:"spot_api_base_url"`
+	WSUrl          string `yaml:"ws_url"`
+	APIKey         string `yaml:"api_key"`
+	APISecret      string `yaml:"api_secret"`
+	RecvWindow     int64  `yaml:"recv_window"`
+}
+
+// OKXConfig contains configuration for OKX perpetual swaps.
+type OKXConfig struct {
+	// Enabled adds OKX to the exchanges of the arbitrage bot and the recorder.
+	Enabled      bool   `yaml:"enabled"`
+	APIBaseURL   string `yaml:"api_base_url"`
+	WSUrl        string `yaml:"ws_url"`
+	WSPrivateURL string `yaml:"ws_private_url"`
+	APIKey       string `yaml:"api_key"`
+	APISecret    string `yaml:"api_secret"`
+	Passphrase   string `yaml:"passphrase"`
+}
+
+// GateConfig contains configuration for Gate.io USDT futures.
+type GateConfig struct {
+	// Enabled adds Gate to the exchanges of the arbitrage bot and the recorder.
+	Enabled    bool   `yaml:"enabled"`
+	APIBaseURL string `yaml:"api_base_url"`
+	WSUrl      string `yaml:"ws_url"`
+	APIKey     string `yaml:"api_key"`
+	APISecret  string `yaml:"api_secret"`
+}
+
 // BotConfig contains configuration for the bot.
 type BotConfig struct {
 	CheckInterval         time.Duration `yaml:"check_interval"`
@@ -53,10 +90,20 @@ type OrderMode string
 const (
 	// OrderModeLimit opens both legs as limit GTC orders with watcher-based timeout cleanup.
 	OrderModeLimit OrderMode = "limit"
-	// OrderModeMarket opens both legs as market orders (no fill timeout watcher).
+	// OrderModeMarket opens both legs as market orders (cleaned up if not filled in 30s).
 	OrderModeMarket OrderMode = "market"
 )
 
+// PriceSource selects the market data the spread detector works on.
+type PriceSource string
+
+const (
+	// PriceSourceBook detects executable spreads: best bid on the sell exchange vs best ask on the buy exchange.
+	PriceSourceBook PriceSource = "book"
+	// PriceSourceTrade detects spreads on last trade prices.
+	PriceSourceTrade PriceSource = "trade"
+)
+
 // ArbitrageBotConfig contains configuration for arbitration bot.
 type ArbitrageBotConfig struct {
 	MaxAgeMs                int64     `yaml:"max_age_ms"`
@@ -70,6 +117,133 @@ type ArbitrageBotConfig struct {
 	// before cancelling unfilled remainder and emergency-closing partial fills.
 	// Only used when OrderMode == OrderModeLimit.
 	LimitFillTimeoutMs int64 `yaml:"limit_fill_timeout_ms"`
+	// PriceSource is the market data used for spread detection (book by default).
+	PriceSource PriceSource `yaml:"price_source"`
+	// DepthSizing limits trade qty by L2 order book depth of both exchanges, so that
+	// the spread between execution VWAPs stays >= MinSpreadPercent.
+	DepthSizing bool `yaml:"depth_sizing"`
+	// RebalanceLegs evens out the coin qty of the open legs after asymmetric fills with a market
+	// top-up or reduce-only order.
+	RebalanceLegs bool `yaml:"rebalance_legs"`
+	// SlippagePercent is the expected slippage for the whole round trip (open + close of both legs).
+	// Open/update/close thresholds are compared with the net spread:
+	// gross spread - taker fees of both legs for open and close - SlippagePercent.
+	SlippagePercent float64 `yaml:"slippage_percent"`
+	// Funding configures funding rate collection and funding-arbitrage signals.
+	Funding FundingArbitrageConfig `yaml:"funding"`
+	// SpotExchanges are exchanges whose spot market is traded as a separate venue next to perpetuals
+	// (spot buy / perp short basis positions). Spot venues need trade price source and no depth sizing.
+	SpotExchanges []string `yaml:"spot_exchanges"`
+	// Reconcile configures reconciliation of the bot positions with the exchange state.
+	Reconcile ReconcileConfig `yaml:"reconcile"`
+	// Risk configures the limits checked before every open and the kill-switch.
+	Risk RiskConfig `yaml:"risk"`
+	// Leverage of perpetual positions. It's set on the exchange before the first order on a symbol and
+	// applied to the free USDT margin when sizing trades. 0 (default) - the exchange setting is left as is
+	// and trades are sized without leverage.
+	Leverage int64 `yaml:"leverage"`
+	// SymbolLeverage overrides Leverage for the listed symbols.
+	SymbolLeverage map[string]int64 `yaml:"symbol_leverage"`
+	// MarginMode of perpetual positions: cross or isolated, set per symbol before the first open.
+	// Empty (default) - the exchange setting is left as is.
+	MarginMode string `yaml:"margin_mode"`
+	// AccountMarginMode switches exchanges with a single margin mode for the whole account (ByBit unified
+	// account) to MarginMode at startup. Off by default, the account mode is never changed implicitly.
+	AccountMarginMode bool `yaml:"account_margin_mode"`
+	// Sizing configures trade size from the live balances of both exchanges.
+	Sizing SizingConfig `yaml:"sizing"`
+	// Blacklist configures symbol bans after failed opens and their refresh from DB.
+	Blacklist BlacklistConfig `yaml:"blacklist"`
+	// InstrumentRefreshInterval is how often contract specs are reloaded to pick up new listings,
+	// delistings and parameter changes (1h by default).
+	InstrumentRefreshInterval time.Duration `yaml:"instrument_refresh_interval"`
+	// SymbolAliases map exchange tickers of the same asset to one canonical symbol, e.g. 1000PEPEUSDT
+	// on ByBit to PEPEUSDT. Aliases stored in DB override the ones with the same exchange symbol here.
+	SymbolAliases []SymbolAliasConfig `yaml:"symbol_aliases"`
+	// Paper configures paper trading: orders are filled by simulated exchanges instead of the real ones.
+	Paper PaperConfig `yaml:"paper"`
+}
+
+// PaperConfig contains configuration for paper trading. Orders are filled by in-process simulated exchanges
+// against the live trade and book streams with the fee schedules of the exchanges; spreads, orders and
+// trades are stored tagged as paper.
+type PaperConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Latency between an order request and the order reaching the simulated book (50ms by default).
+	Latency time.Duration `yaml:"latency"`
+	// SlippagePercent of market orders against the top of the book or the last trade.
+	SlippagePercent float64 `yaml:"slippage_percent"`
+	// FillRule tells which trades fill resting limit orders: through (default) - trades better than the
+	// limit price, touch - trades at it too.
+	FillRule string `yaml:"fill_rule"`
+	// VolumeLimited fills resting limit orders by at most the volume of the crossing trade.
+	VolumeLimited bool `yaml:"volume_limited"`
+	// BalanceUSDT is the initial USDT of every exchange and category (10000 by default).
+	BalanceUSDT float64 `yaml:"balance_usdt"`
+}
+
+// SymbolAliasConfig maps a symbol of one venue to a canonical symbol.
+type SymbolAliasConfig struct {
+	// Symbol is the canonical symbol the bot trades the asset under.
+	Symbol string `yaml:"symbol"`
+	// Exchange is the venue of the alias: an exchange name for perpetuals, "<exchange>:spot" for spot.
+	Exchange string `yaml:"exchange"`
+	// ExchangeSymbol is the symbol of the asset on the venue.
+	ExchangeSymbol string `yaml:"exchange_symbol"`
+	// Multiplier is the number of canonical coins in one coin of ExchangeSymbol (1000 for 1000PEPEUSDT,
+	// 1 by default): its price is Multiplier times the canonical one.
+	Multiplier float64 `yaml:"multiplier"`
+}
+
+// BlacklistConfig contains configuration for the symbol blacklist.
+type BlacklistConfig struct {
+	// TTL of the ban added after a failed open (1h by default).
+	TTL time.Duration `yaml:"ttl"`
+	// RefreshInterval is how often bans added or removed through the API are loaded from DB (1m by default).
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+}
+
+// SizingConfig contains configuration for position sizing from live balances.
+type SizingConfig struct {
+	// BalanceFraction of the free USDT margin with leverage applied is the trade notional, the smaller of
+	// the two exchanges is used and Risk.NotionalUSDT caps it. Zero - fixed Risk.NotionalUSDT.
+	BalanceFraction float64 `yaml:"balance_fraction"`
+	// DriftWarnPercent warns when the free USDT of the two exchanges differ by more than the percent
+	// of the larger one, so funds should be transferred. Zero - no warning.
+	DriftWarnPercent float64 `yaml:"drift_warn_percent"`
+}
+
+// RiskConfig contains risk limits of the arbitrage engine. Exposures are in USDT at the open price,
+// a zero limit is not checked.
+type RiskConfig struct {
+	MaxPositions int `yaml:"max_positions"`
+	// NotionalUSDT is the size of a single trade.
+	NotionalUSDT            float64 `yaml:"notional_usdt"`
+	MaxSymbolExposureUSDT   float64 `yaml:"max_symbol_exposure_usdt"`
+	MaxExchangeExposureUSDT float64 `yaml:"max_exchange_exposure_usdt"`
+	MaxTotalExposureUSDT    float64 `yaml:"max_total_exposure_usdt"`
+	// MaxDailyLossUSDT and MaxConsecutiveFailures trip the kill-switch: new opens are stopped until restart.
+	MaxDailyLossUSDT       float64 `yaml:"max_daily_loss_usdt"`
+	MaxConsecutiveFailures int     `yaml:"max_consecutive_failures"`
+}
+
+// ReconcileConfig contains configuration for position reconciliation against exchange state.
+type ReconcileConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+	// AutoFlatten closes naked perpetual legs by market and cancels orphan orders of the bot.
+	// When disabled they are only reported.
+	AutoFlatten bool `yaml:"auto_flatten"`
+}
+
+// FundingArbitrageConfig contains configuration for funding rate collection and funding-arbitrage signals.
+type FundingArbitrageConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// MinAnnualizedPercent is the signal threshold for the net annualized funding differential.
+	MinAnnualizedPercent float64 `yaml:"min_annualized_percent"`
+	// HoldingDays is the expected holding period, round trip taker fees are amortized over it.
+	HoldingDays float64 `yaml:"holding_days"`
 }
 
 // ManipulationBotConfig contains configuration for spot-vs-perp manipulation detector.
@@ -86,13 +260,32 @@ type ManipulationBotConfig struct {
 	RPSTimerInterval   time.Duration `yaml:"rps_timer_interval"`
 }
 
+// RecorderConfig contains configuration for the market data recorder.
+type RecorderConfig struct {
+	Dir           string                 `yaml:"dir"`
+	Partition     time.Duration          `yaml:"partition"`
+	FlushInterval time.Duration          `yaml:"flush_interval"`
+	Sources       []RecorderSourceConfig `yaml:"sources"`
+}
+
+// RecorderSourceConfig is a recorded trade stream: all symbols of the category when Symbols is empty.
+type RecorderSourceConfig struct {
+	Exchange string   `yaml:"exchange"`
+	Category string   `yaml:"category"`
+	Symbols  []string `yaml:"symbols"`
+}
+
 // ExchangeConfig contains a configuration for an exchange.
 type ExchangeConfig struct {
 	ByBit           ByBitConfig           `yaml:"bybit"`
 	BingX           BingXConfig           `yaml:"bingx"`
 	MEXC            MEXCConfig            `yaml:"mexc"`
+	Binance         BinanceConfig         `yaml:"binance"`
+	OKX             OKXConfig             `yaml:"okx"`
+	Gate            GateConfig            `yaml:"gate"`
 	WsClient        WsClientConfig        `yaml:"ws_client"`
 	Bot             BotConfig             `yaml:"bot"`
 	ArbitrageBot    ArbitrageBotConfig    `yaml:"arbitration_bot"`
 	ManipulationBot ManipulationBotConfig `yaml:"manipulation_bot"`
+	Recorder        RecorderConfig        `yaml:"recorder"`
 }
@@ -0,0 +1,238 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/exchange/simulator"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+// syntheticVolStep is the qty step of the instruments made up for datasets without them.
+var syntheticVolStep = decimal.New(1, -6)
+
+// BacktestConfig is the configuration of a backtest.
+type BacktestConfig struct {
+	// Exchanges are the recorded exchanges traded against each other, at least two.
+	Exchanges []string
+	// Simulator configures the execution on every exchange.
+	Simulator simulator.Config
+	// Drain is the wall time given to the engine after the last trade to process the last fills.
+	Drain time.Duration
+}
+
+// Backtest runs the arbitrage Engine over a replayed dataset: spreads are detected on the recorded trades
+// (whatever the configured price source), orders are executed by simulated exchanges filling them against
+// the same trades. Spreads, orders and trades are kept in memory. The dataset has no order books, so depth
+// sizing is off; funding is not simulated.
+//
+// The engine is driven by the replay clock, so the replay speed must let it keep up, see replay.Feed.
+// Positions still open when the dataset ends are reported as unfinished.
+type Backtest struct {
+	cfg    *config.Config
+	btCfg  BacktestConfig
+	feed   *replay.Feed
+	logger zerolog.Logger
+
+	sims    []*simulator.Exchange
+	orders  *memoryOrderRepository
+	spreads *memorySpreadRepository
+	trades  *memoryTradeRepository
+}
+
+// NewBacktest creates a backtest of the bot configured by cfg over the dataset of feed.
+func NewBacktest(cfg *config.Config, btCfg BacktestConfig, feed *replay.Feed, logger zerolog.Logger) *Backtest {
+	// a copy, the settings the dataset can't support are turned off
+	backtestCfg := *cfg
+	backtestCfg.Exchange.ArbitrageBot.DepthSizing = false
+
+	return &Backtest{
+		cfg:     &backtestCfg,
+		btCfg:   btCfg,
+		feed:    feed,
+		logger:  logger,
+		orders:  newMemoryOrderRepository(),
+		spreads: &memorySpreadRepository{},
+		trades:  newMemoryTradeRepository(),
+	}
+}
+
+// Run plays the dataset through the engine and returns the report.
+func (b *Backtest) Run(ctx context.Context) (BacktestReport, error) {
+	if len(b.btCfg.Exchanges) < 2 {
+		return BacktestReport{}, fmt.Errorf("backtest: at least two exchanges are needed, got %d", len(b.btCfg.Exchanges))
+	}
+
+	strategy := newOrderStrategy(b.cfg)
+	if err := strategy.Validate(); err != nil {
+		return BacktestReport{}, fmt.Errorf("backtest: invalid order strategy: %w", err)
+	}
+
+	clk := b.feed.Clock()
+	clients := make([]exchange.Provider, 0, len(b.btCfg.Exchanges))
+	for _, name := range b.btCfg.Exchanges {
+		sim := simulator.New(b.feed.Provider(name), b.btCfg.Simulator, clk, b.logger)
+		b.sims = append(b.sims, sim)
+		clients = append(clients, sim)
+	}
+	venues := buildVenues(clients, b.cfg.Exchange.ArbitrageBot.SpotExchanges)
+
+	if err := b.ensureInstruments(ctx, venues); err != nil {
+		return BacktestReport{}, err
+	}
+
+	// notifications only repeat the engine logs
+	engine := NewEngine(b.cfg, clients, b.orders, b.spreads, notifier.NewLogNotifier(zerolog.Nop(), clk), b.logger, strategy)
+	engine.SetClock(clk)
+	engine.SetTradeRepository(b.trades)
+
+	if err := engine.LoadInstruments(ctx, clients, venues); err != nil {
+		return BacktestReport{}, fmt.Errorf("backtest: failed to load instruments: %w", err)
+	}
+	if err := engine.LoadFees(ctx, clients); err != nil {
+		return BacktestReport{}, fmt.Errorf("backtest: failed to load fees: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := engine.ListenExecutions(runCtx); err != nil {
+		return BacktestReport{}, fmt.Errorf("backtest: failed to subscribe to executions: %w", err)
+	}
+
+	tradable := tradableSymbols(engine.Instruments())
+	if len(tradable) == 0 {
+		return BacktestReport{}, errors.New("backtest: no tradable symbols for exchanges")
+	}
+	symbols := make([]string, 0, len(tradable))
+	for s := range tradable {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+
+	detector := NewSpreadDetector(b.cfg)
+	detector.SetClock(clk)
+	detector.SetFees(engine.Fees())
+
+	go engine.Run(runCtx)
+
+	// unbuffered, so the replay clock is never ahead of the detector by more than a few trades
+	events := make(chan PriceChangeEvent)
+	subscriptions, err := b.subscribe(runCtx, engine, venues, symbols, events)
+	if err != nil {
+		return BacktestReport{}, err
+	}
+
+	detected := make(chan struct{})
+	go func() {
+		defer close(detected)
+		b.detect(runCtx, engine, detector, events)
+	}()
+
+	stats, err := b.feed.Play(ctx, subscriptions)
+	if err != nil {
+		return BacktestReport{}, fmt.Errorf("backtest: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(b.btCfg.Drain):
+	}
+	cancel()
+	<-detected
+
+	return b.report(ctx, engine, stats), nil
+}
+
+// ensureInstruments makes up instruments for the venues the dataset has none for: every recorded symbol
+// traded in coins with a fine qty step and no price step.
+func (b *Backtest) ensureInstruments(ctx context.Context, venues []Venue) error {
+	for _, venue := range venues {
+		provider := b.feed.Provider(venue.Exchange)
+		_, err := provider.GetInstruments(ctx, venue.Category)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, replay.ErrNotSupported) {
+			return fmt.Errorf("backtest: failed to load instruments of %s: %w", venue.Name(), err)
+		}
+
+		tickers, err := provider.GetTickers(ctx, nil, venue.Category)
+		if err != nil {
+			return fmt.Errorf("backtest: failed to list symbols of %s: %w", venue.Name(), err)
+		}
+		instruments := make(map[string]exchange.Instrument, len(tickers))
+		for _, ticker := range tickers {
+			instruments[ticker.Symbol] = exchange.Instrument{
+				Symbol:       ticker.Symbol,
+				VolStep:      syntheticVolStep,
+				MinVol:       syntheticVolStep,
+				ContractSize: decimal.NewFromInt(1),
+			}
+		}
+		b.feed.SetInstruments(venue.Exchange, venue.Category, instruments)
+		b.logger.Info().Str("venue", venue.Name()).Int("symbols", len(instruments)).Msg("backtest: no instruments recorded, using synthetic ones")
+	}
+	return nil
+}
+
+// subscribe subscribes to the trades of the symbols on all venues and forwards them to events as price
+// changes. Returns the number of subscriptions.
+func (b *Backtest) subscribe(ctx context.Context, engine *Engine, venues []Venue, symbols []string, events chan<- PriceChangeEvent) (int, error) {
+	instruments := engine.Instruments()
+
+	var subscriptions int
+	for _, venue := range venues {
+		venueSymbols := venueSymbols(instruments, venue.Name(), symbols)
+		if len(venueSymbols) == 0 {
+			continue
+		}
+
+		ch, err := engine.clientFor(venue.Name()).SubscribeTrades(ctx, venueSymbols, venue.Category)
+		if err != nil {
+			return 0, fmt.Errorf("backtest: failed to subscribe to trades on %s: %w", venue.Name(), err)
+		}
+		subscriptions++
+
+		go func(exchangeName string, ch <-chan exchange.Trade) {
+			for trade := range ch {
+				event := PriceChangeEvent{TsMs: trade.Ts, ExchangeName: exchangeName, Symbol: trade.Symbol, Price: trade.Price}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(venue.Name(), ch)
+	}
+	return subscriptions, nil
+}
+
+// detect runs the spread detector on every price change and hands the spread events to the engine,
+// as ArbitrageBot.Run does.
+func (b *Backtest) detect(ctx context.Context, engine *Engine, detector *SpreadDetector, events <-chan PriceChangeEvent) {
+	prices := make(Prices)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if prices[event.Symbol] == nil {
+				prices[event.Symbol] = map[string]PricePoint{}
+			}
+			prices[event.Symbol][event.ExchangeName] = PricePoint{Price: event.Price, TsMs: event.TsMs}
+			if spreadEvents := detector.Detect(event.Symbol, prices[event.Symbol]); spreadEvents != nil {
+				engine.HandleSignal(spreadEvents)
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// BacktestReport is the result of a backtest. PnL is in USDT.
+type BacktestReport struct {
+	Replay       replay.Stats
+	Signals      int             // spreads opened by the detector
+	Trades       []BacktestTrade // positions by open signal time
+	Closed       int
+	Failed       int   // failed to open, filled legs were flattened
+	FillTimeouts int64 // limit legs not filled within the fill timeout, counted in Failed once cleaned up
+	Unfinished   int   // still open at the end of the dataset or left for a manual check
+
+	PnL         decimal.Decimal // of the closed trades, fees included
+	WinRate     float64         // share of the closed trades with positive PnL
+	MaxDrawdown decimal.Decimal // largest drop of the cumulative PnL of the closed trades
+	// AccountPnL is the realized PnL less fees of all fills on all exchanges, it includes the cost of
+	// flattening failed trades.
+	AccountPnL decimal.Decimal
+}
+
+// BacktestTrade is a position of a backtest.
+type BacktestTrade struct {
+	Symbol       string
+	BuyExchange  string
+	SellExchange string
+	Status       models.ArbitrageTradeStatus
+	OpenSignalAt time.Time
+	ClosedAt     time.Time
+	QtyCoins     decimal.Decimal
+	PnL          decimal.Decimal // closed trades only, fees included
+}
+
+// report builds the report from the last states of the trades and the fills of the simulated exchanges.
+func (b *Backtest) report(ctx context.Context, engine *Engine, stats replay.Stats) BacktestReport {
+	report := BacktestReport{
+		Replay:       stats,
+		Signals:      b.spreads.count(),
+		FillTimeouts: engine.FillTimeouts(),
+	}
+	for _, sim := range b.sims {
+		simStats := sim.Stats()
+		report.AccountPnL = report.AccountPnL.Add(simStats.RealizedPnL).Add(simStats.Fees)
+	}
+
+	var closed []BacktestTrade
+	var wins int
+	for _, trade := range b.trades.all() {
+		t := BacktestTrade{
+			Symbol:       trade.Symbol,
+			BuyExchange:  trade.BuyExchange,
+			SellExchange: trade.SellExchange,
+			Status:       trade.Status,
+			OpenSignalAt: trade.OpenSignalAt,
+			ClosedAt:     trade.ClosedAt,
+			QtyCoins:     trade.QtyCoins,
+		}
+
+		switch trade.Status {
+		case models.ArbitrageTradeStatusClosed:
+			pnl, err := b.tradePnL(ctx, engine, trade)
+			if err != nil {
+				b.logger.Warn().Err(err).Str("symbol", trade.Symbol).Msg("backtest: failed to calculate trade PnL")
+			}
+			t.PnL = pnl
+			report.Closed++
+			report.PnL = report.PnL.Add(pnl)
+			if pnl.IsPositive() {
+				wins++
+			}
+			closed = append(closed, t)
+		case models.ArbitrageTradeStatusFailed:
+			report.Failed++
+		default:
+			report.Unfinished++
+		}
+		report.Trades = append(report.Trades, t)
+	}
+
+	sort.Slice(report.Trades, func(i, j int) bool {
+		return report.Trades[i].OpenSignalAt.Before(report.Trades[j].OpenSignalAt)
+	})
+	if report.Closed > 0 {
+		report.WinRate = float64(wins) / float64(report.Closed)
+	}
+	report.MaxDrawdown = maxDrawdown(closed)
+
+	return report
+}
+
+// tradePnL returns the PnL of the closed trade: price difference of the legs less the fees the exchanges
+// charged for its four orders.
+func (b *Backtest) tradePnL(ctx context.Context, engine *Engine, trade *models.ArbitrageTrade) (decimal.Decimal, error) {
+	pnl, _, _, err := engine.legsPnL(trade)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	orders := []struct {
+		id        uuid.UUID
+		venueName string
+	}{
+		{trade.OpenBuyOrderID, trade.BuyExchange},
+		{trade.CloseBuyOrderID, trade.BuyExchange},
+		{trade.OpenSellOrderID, trade.SellExchange},
+		{trade.CloseSellOrderID, trade.SellExchange},
+	}
+	for _, o := range orders {
+		venue := parseVenue(o.venueName)
+		info, err := engine.clientFor(o.venueName).GetOrder(ctx, o.id, "", trade.Symbol, venue.Category)
+		if err != nil {
+			return pnl, err
+		}
+		pnl = pnl.Add(info.Fees) // negative
+	}
+	return pnl, nil
+}
+
+// maxDrawdown returns the largest drop of the cumulative PnL of the trades in the order they closed.
+func maxDrawdown(trades []BacktestTrade) decimal.Decimal {
+	sorted := make([]BacktestTrade, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ClosedAt.Before(sorted[j].ClosedAt)
+	})
+
+	var cumulative, peak, drawdown decimal.Decimal
+	for _, trade := range sorted {
+		cumulative = cumulative.Add(trade.PnL)
+		peak = decimal.Max(peak, cumulative)
+		drawdown = decimal.Max(drawdown, peak.Sub(cumulative))
+	}
+	return drawdown
+}
+
+// Write prints the summary and the trades of the report.
+func (r BacktestReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	lines := []string{
+		fmt.Sprintf("Period\t%s - %s", time.UnixMilli(r.Replay.FromMs).UTC().Format(time.RFC3339), time.UnixMilli(r.Replay.ToMs).UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Replayed trades\t%d", r.Replay.Trades),
+		fmt.Sprintf("Signals\t%d", r.Signals),
+		fmt.Sprintf("Trades\t%d (closed %d, failed %d, unfinished %d)", len(r.Trades), r.Closed, r.Failed, r.Unfinished),
+		fmt.Sprintf("Fill timeouts\t%d", r.FillTimeouts),
+		fmt.Sprintf("PnL\t%s USDT", r.PnL.StringFixed(4)),
+		fmt.Sprintf("Win rate\t%.1f%%", r.WinRate*100),
+		fmt.Sprintf("Max drawdown\t%s USDT", r.MaxDrawdown.StringFixed(4)),
+		fmt.Sprintf("Account PnL\t%s USDT", r.AccountPnL.StringFixed(4)),
+		"",
+		"OPENED\tCLOSED\tSYMBOL\tBUY\tSELL\tQTY\tSTATUS\tPNL",
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range r.Trades {
+		closedAt, pnl := "-", "-"
+		if t.Status == models.ArbitrageTradeStatusClosed {
+			closedAt = t.ClosedAt.UTC().Format(time.RFC3339)
+			pnl = t.PnL.StringFixed(4)
+		}
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.OpenSignalAt.UTC().Format(time.RFC3339), closedAt, t.Symbol, t.BuyExchange, t.SellExchange, t.QtyCoins, t.Status, pnl)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,236 @@
+package arbitragebot
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// memoryOrderRepository is an in-memory OrderRepository of backtests.
+type memoryOrderRepository struct {
+	mu     sync.Mutex
+	orders map[uuid.UUID]*models.Order
+}
+
+func newMemoryOrderRepository() *memoryOrderRepository {
+	return &memoryOrderRepository{orders: make(map[uuid.UUID]*models.Order)}
+}
+
+func (r *memoryOrderRepository) GetByID(_ context.Context, id uuid.UUID) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	clone := *order
+	return &clone, nil
+}
+
+func (r *memoryOrderRepository) Create(_ context.Context, order *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *order
+	r.orders[order.ID] = &clone
+	return nil
+}
+
+func (r *memoryOrderRepository) UpdateFilled(ctx context.Context, id uuid.UUID, avgPrice decimal.Decimal, executedQty decimal.Decimal) error {
+	filled := models.OrderStatusFilled
+	return r.UpdatePartialy(ctx, id, OrderPatch{Status: &filled, AvgPrice: &avgPrice, ExecutedQuantity: &executedQty})
+}
+
+func (r *memoryOrderRepository) UpdatePartialy(_ context.Context, id uuid.UUID, patch OrderPatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if patch.Status != nil {
+		order.Status = *patch.Status
+	}
+	if patch.AvgPrice != nil {
+		order.AvgPrice = *patch.AvgPrice
+	}
+	if patch.ExecutedQuantity != nil {
+		order.ExecutedQuantity = *patch.ExecutedQuantity
+	}
+	if patch.Fees != nil {
+		order.Fees = *patch.Fees
+	}
+	if patch.Profit != nil {
+		order.Profit = *patch.Profit
+	}
+	if patch.HasErrors != nil {
+		order.HasErrors = *patch.HasErrors
+	}
+	return nil
+}
+
+// memorySpreadRepository is an in-memory ArbitrageSpreadRepository of backtests. Updates set the non-zero
+// fields of the patch, as GORM does.
+type memorySpreadRepository struct {
+	mu      sync.Mutex
+	spreads []*models.ArbitrageSpread
+}
+
+func (r *memorySpreadRepository) Create(_ context.Context, spread *models.ArbitrageSpread) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if spread.ID == uuid.Nil {
+		spread.ID = uuid.New()
+	}
+	clone := *spread
+	r.spreads = append(r.spreads, &clone)
+	return nil
+}
+
+func (r *memorySpreadRepository) Update(_ context.Context, patch *models.ArbitrageSpread, where FindFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, spread := range r.spreads {
+		if !where.matches(spread) {
+			continue
+		}
+		if patch.Status != "" {
+			spread.Status = patch.Status
+		}
+		if !patch.MaxSpreadPercent.IsZero() {
+			spread.MaxSpreadPercent = patch.MaxSpreadPercent
+		}
+		if !patch.MaxNetSpreadPercent.IsZero() {
+			spread.MaxNetSpreadPercent = patch.MaxNetSpreadPercent
+		}
+		if patch.ClosedAt != nil {
+			spread.ClosedAt = patch.ClosedAt
+		}
+		if !patch.UpdatedAt.IsZero() {
+			spread.UpdatedAt = patch.UpdatedAt
+		}
+		if patch.Profit != nil {
+			spread.Profit = patch.Profit
+		}
+		if patch.FundingProfit != nil {
+			spread.FundingProfit = patch.FundingProfit
+		}
+		if patch.CloseBuyOrderID != uuid.Nil {
+			spread.CloseBuyOrderID = patch.CloseBuyOrderID
+		}
+		if patch.CloseSellOrderID != uuid.Nil {
+			spread.CloseSellOrderID = patch.CloseSellOrderID
+		}
+		if patch.RebalanceOrderID != uuid.Nil {
+			spread.RebalanceOrderID = patch.RebalanceOrderID
+		}
+	}
+	return nil
+}
+
+func (r *memorySpreadRepository) FindAll(_ context.Context, f FindFilter) ([]*models.ArbitrageSpread, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []*models.ArbitrageSpread
+	for _, spread := range r.spreads {
+		if f.matches(spread) {
+			clone := *spread
+			found = append(found, &clone)
+		}
+	}
+	return found, nil
+}
+
+func (r *memorySpreadRepository) FindOne(ctx context.Context, f FindFilter) (*models.ArbitrageSpread, error) {
+	found, _ := r.FindAll(ctx, f)
+	if len(found) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return found[0], nil
+}
+
+func (r *memorySpreadRepository) FindOneByOrderID(_ context.Context, orderID uuid.UUID) (*models.ArbitrageSpread, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, spread := range r.spreads {
+		if spread.OpenBuyOrderID == orderID || spread.OpenSellOrderID == orderID ||
+			spread.CloseBuyOrderID == orderID || spread.CloseSellOrderID == orderID ||
+			spread.RebalanceOrderID == orderID {
+			clone := *spread
+			return &clone, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// count returns the number of spreads.
+func (r *memorySpreadRepository) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.spreads)
+}
+
+// matches reports whether the spread satisfies the filter.
+func (f FindFilter) matches(spread *models.ArbitrageSpread) bool {
+	switch {
+	case f.Symbol != "" && spread.Symbol != f.Symbol,
+		f.BuyEx != "" && spread.BuyOnExchange != f.BuyEx,
+		f.SellEx != "" && spread.SellOnExchange != f.SellEx,
+		len(f.Status) > 0 && !slices.Contains(f.Status, spread.Status),
+		len(f.NotInStatus) > 0 && slices.Contains(f.NotInStatus, spread.Status),
+		f.ID != uuid.Nil && spread.ID != f.ID,
+		f.OpenPosition && (spread.OpenBuyOrderID == uuid.Nil || spread.OpenSellOrderID == uuid.Nil),
+		f.Paper != nil && spread.Paper != *f.Paper:
+		return false
+	default:
+		return true
+	}
+}
+
+// memoryTradeRepository is an in-memory TradeRepository of backtests, it keeps the last state of every trade.
+type memoryTradeRepository struct {
+	mu     sync.Mutex
+	trades map[uuid.UUID]*models.ArbitrageTrade
+}
+
+func newMemoryTradeRepository() *memoryTradeRepository {
+	return &memoryTradeRepository{trades: make(map[uuid.UUID]*models.ArbitrageTrade)}
+}
+
+func (r *memoryTradeRepository) Save(_ context.Context, trade *models.ArbitrageTrade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *trade
+	r.trades[trade.ID] = &clone
+	return nil
+}
+
+func (r *memoryTradeRepository) FindActive(_ context.Context) ([]*models.ArbitrageTrade, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var active []*models.ArbitrageTrade
+	for _, trade := range r.trades {
+		if trade.Status != models.ArbitrageTradeStatusClosed && trade.Status != models.ArbitrageTradeStatusFailed {
+			clone := *trade
+			active = append(active, &clone)
+		}
+	}
+	return active, nil
+}
+
+// all returns the last states of all trades.
+func (r *memoryTradeRepository) all() []*models.ArbitrageTrade {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	trades := make([]*models.ArbitrageTrade, 0, len(r.trades))
+	for _, trade := range r.trades {
+		clone := *trade
+		trades = append(trades, &clone)
+	}
+	return trades
+}
@@ -0,0 +1,114 @@
+package arbitragebot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+	"github.com/lucrumx/bot/internal/exchange/simulator"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+var backtestStart = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// writeDataset writes the prices, one trade every 10 seconds, as a recorded dataset of the exchange.
+func writeDataset(t *testing.T, dir, exchangeName string, prices ...float64) {
+	t.Helper()
+
+	rel := filepath.Join(exchangeName, string(exchange.CategoryLinear), backtestStart.Format("2006-01-02"), "12.jsonl.gz")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(rel)), 0o755))
+
+	f, err := os.Create(filepath.Join(dir, rel))
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for i, price := range prices {
+		ts := backtestStart.Add(time.Duration(i) * 10 * time.Second).UnixMilli()
+		require.NoError(t, enc.Encode(recorder.Record{Ts: ts, RecvTs: ts, Symbol: "BTCUSDT", Price: price, Volume: 1, Side: exchange.Buy}))
+	}
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	entry := recorder.IndexEntry{
+		Path:     rel,
+		Exchange: exchangeName,
+		Category: exchange.CategoryLinear,
+		Symbols:  []string{"BTCUSDT"},
+		FromMs:   backtestStart.UnixMilli(),
+		ToMs:     backtestStart.Add(time.Duration(len(prices)-1) * 10 * time.Second).UnixMilli(),
+		Count:    int64(len(prices)),
+	}
+	line, err := json.Marshal(entry)
+	require.NoError(t, err)
+	index, err := os.OpenFile(filepath.Join(dir, recorder.IndexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = index.Write(append(line, '\n'))
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+}
+
+func TestBacktest_Run(t *testing.T) {
+	dir := t.TempDir()
+	// BingX is 3% above ByBit for 30 seconds, then the prices converge
+	writeDataset(t, dir, "ByBit", 100, 100, 100, 100, 100, 100, 100, 100)
+	writeDataset(t, dir, "BingX", 100, 103, 103, 103, 100, 100, 100, 100)
+
+	feed, err := replay.NewFeed(replay.Config{Dir: dir, Speed: 100}, zerolog.Nop())
+	require.NoError(t, err)
+
+	simCfg := simulator.DefaultConfig()
+	simCfg.Latency = 0
+	simCfg.SlippagePercent = 0
+
+	btCfg := BacktestConfig{
+		Exchanges: []string{"ByBit", "BingX"},
+		Simulator: simCfg,
+		Drain:     200 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.MaxSpreadPercentForOpen = 5
+
+	report, err := NewBacktest(cfg, btCfg, feed, zerolog.Nop()).Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(16), report.Replay.Trades)
+	assert.Equal(t, 1, report.Signals)
+	require.Len(t, report.Trades, 1)
+	assert.Equal(t, 1, report.Closed)
+	assert.Zero(t, report.Failed)
+	assert.Zero(t, report.Unfinished)
+
+	trade := report.Trades[0]
+	assert.Equal(t, "BTCUSDT", trade.Symbol)
+	assert.Equal(t, "ByBit", trade.BuyExchange)
+	assert.Equal(t, "BingX", trade.SellExchange)
+	assert.Equal(t, models.ArbitrageTradeStatusClosed, trade.Status)
+
+	// 0.1 BTC (10 USDT notional): 0.3 USDT of spread less taker fees of 40.3 USDT traded
+	assert.Equal(t, "0.1", trade.QtyCoins.String())
+	assert.Equal(t, "0.277835", trade.PnL.String())
+	assert.Equal(t, "0.277835", report.PnL.String())
+	assert.Equal(t, report.PnL.StringFixed(8), report.AccountPnL.StringFixed(8))
+	assert.InDelta(t, 1.0, report.WinRate, 1e-9)
+	assert.True(t, report.MaxDrawdown.IsZero())
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "BTCUSDT")
+}
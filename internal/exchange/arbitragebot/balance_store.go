@@ -5,7 +5,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/models"
@@ -13,19 +15,31 @@ import (
 
 type balanceStore struct {
 	mu       sync.RWMutex
-	balances map[string]map[string]models.Balance // exchange -> currency -> balance
+	balances map[string]map[string]models.Balance // venue -> currency -> balance
+	reserved map[uuid.UUID]reservation            // order id -> USDT margin held for the order
 	logger   zerolog.Logger
 }
 
+// reservation is the USDT margin of an open order sent after the last balance refresh. The refreshed
+// balances already account for the order, so the reservation lives until the order fills, fails or the
+// balances of its venue are refreshed.
+type reservation struct {
+	venue  string
+	amount decimal.Decimal
+	at     time.Time
+}
+
 func newBalanceStore(logger zerolog.Logger) *balanceStore {
 	return &balanceStore{
 		logger:   logger,
 		balances: make(map[string]map[string]models.Balance),
+		reserved: make(map[uuid.UUID]reservation),
 	}
 }
 
-func (bs *balanceStore) Start(ctx context.Context, clients []exchange.Provider) {
-	bs.retrieveBalances(ctx, clients)
+// Start retrieves balances of the venues and keeps refreshing them in background.
+func (bs *balanceStore) Start(ctx context.Context, clients []exchange.Provider, venues []Venue) {
+	bs.retrieveBalances(ctx, clients, venues)
 
 	go func() {
 		ticker := time.NewTicker(time.Second * 20)
@@ -35,22 +49,76 @@ func (bs *balanceStore) Start(ctx context.Context, clients []exchange.Provider)
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				bs.retrieveBalances(ctx, clients)
+				bs.retrieveBalances(ctx, clients, venues)
 			}
 		}
 	}()
 }
 
-func (bs *balanceStore) retrieveBalances(ctx context.Context, clients []exchange.Provider) {
-	for _, clients := range clients {
-		balances, err := clients.GetBalances(ctx)
+func (bs *balanceStore) retrieveBalances(ctx context.Context, clients []exchange.Provider, venues []Venue) {
+	clientMap := make(map[string]exchange.Provider, len(clients))
+	for _, client := range clients {
+		clientMap[client.GetExchangeName()] = client
+	}
+
+	for _, venue := range venues {
+		client, ok := clientMap[venue.Exchange]
+		if !ok {
+			continue
+		}
 
+		requestedAt := time.Now()
+		balances, err := client.GetBalances(ctx, venue.Category)
 		if err != nil {
-			bs.logger.Warn().Err(err).Msgf("can`t get balance for exchange %s", clients.GetExchangeName())
+			bs.logger.Warn().Err(err).Msgf("can`t get balance for venue %s", venue.Name())
 			continue
 		}
 
-		bs.Set(balances)
+		bs.SetVenue(venue.Name(), balances)
+		bs.releaseBefore(venue.Name(), requestedAt)
+	}
+}
+
+// Reserve holds amount USDT of the venue for the order until Release or the next balance refresh.
+func (bs *balanceStore) Reserve(venueName string, orderID uuid.UUID, amount decimal.Decimal) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	bs.reserved[orderID] = reservation{venue: venueName, amount: amount, at: time.Now()}
+}
+
+// Release drops the reservation of the order, no-op for orders without one.
+func (bs *balanceStore) Release(orderID uuid.UUID) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	delete(bs.reserved, orderID)
+}
+
+// Reserved returns the USDT reserved for the open orders on the venue.
+func (bs *balanceStore) Reserved(venueName string) decimal.Decimal {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	total := decimal.Zero
+	for _, r := range bs.reserved {
+		if r.venue == venueName {
+			total = total.Add(r.amount)
+		}
+	}
+	return total
+}
+
+// releaseBefore drops the reservations of the venue made before its balances were requested, the fresh
+// balances already hold their margin.
+func (bs *balanceStore) releaseBefore(venueName string, requestedAt time.Time) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	for orderID, r := range bs.reserved {
+		if r.venue == venueName && r.at.Before(requestedAt) {
+			delete(bs.reserved, orderID)
+		}
 	}
 }
 
@@ -89,17 +157,30 @@ func (bs *balanceStore) Get(exchangeName string) ([]models.Balance, bool) {
 	return b, true
 }
 
+// Set stores balances of linear venues, keyed by the exchange name of the balance.
 func (bs *balanceStore) Set(balances []models.Balance) {
 	bs.mu.Lock()
 	defer bs.mu.Unlock()
 
 	for _, balance := range balances {
-		exchangeName := balance.ExchangeName
+		bs.set(balance.ExchangeName, balance)
+	}
+}
 
-		if bs.balances[exchangeName] == nil {
-			bs.balances[exchangeName] = make(map[string]models.Balance)
-		}
+// SetVenue stores balances of the venue, e.g. spot balances of the exchange under "ByBit:spot".
+func (bs *balanceStore) SetVenue(venueName string, balances []models.Balance) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
 
-		bs.balances[exchangeName][balance.Asset] = balance
+	for _, balance := range balances {
+		bs.set(venueName, balance)
 	}
 }
+
+func (bs *balanceStore) set(venueName string, balance models.Balance) {
+	if bs.balances[venueName] == nil {
+		bs.balances[venueName] = make(map[string]models.Balance)
+	}
+
+	bs.balances[venueName][balance.Asset] = balance
+}
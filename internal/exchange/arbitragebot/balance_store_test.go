@@ -5,6 +5,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -66,7 +67,8 @@ func TestBalanceStore_RetrieveBalances(t *testing.T) {
 	ctx := t.Context()
 
 	okProvider := exchangeMocks.NewMockProvider(t)
-	okProvider.EXPECT().GetBalances(ctx).Return([]models.Balance{
+	okProvider.EXPECT().GetExchangeName().Return("ByBit")
+	okProvider.EXPECT().GetBalances(ctx, exchange.CategoryLinear).Return([]models.Balance{
 		{
 			ExchangeName: "ByBit",
 			Asset:        "BTCUSDT",
@@ -74,7 +76,7 @@ func TestBalanceStore_RetrieveBalances(t *testing.T) {
 		},
 	}, nil)
 
-	store.retrieveBalances(ctx, []exchange.Provider{okProvider})
+	store.retrieveBalances(ctx, []exchange.Provider{okProvider}, []Venue{{Exchange: "ByBit", Category: exchange.CategoryLinear}})
 
 	got, ok := store.Get("ByBit")
 	assert.True(t, ok)
@@ -82,3 +84,61 @@ func TestBalanceStore_RetrieveBalances(t *testing.T) {
 	assert.True(t, got[0].Total.Equal(decimal.RequireFromString("10")))
 	assert.Equal(t, got[0].Asset, "BTCUSDT")
 }
+
+func TestBalanceStore_RetrieveBalances_SpotVenue(t *testing.T) {
+	store := newBalanceStore(zerolog.New(io.Discard))
+
+	ctx := t.Context()
+
+	provider := exchangeMocks.NewMockProvider(t)
+	provider.EXPECT().GetExchangeName().Return("ByBit")
+	provider.EXPECT().GetBalances(ctx, exchange.CategoryLinear).Return([]models.Balance{
+		{ExchangeName: "ByBit", Asset: "USDT", Free: decimal.RequireFromString("100")},
+	}, nil)
+	provider.EXPECT().GetBalances(ctx, exchange.CategorySpot).Return([]models.Balance{
+		{ExchangeName: "ByBit", Asset: "USDT", Free: decimal.RequireFromString("40")},
+		{ExchangeName: "ByBit", Asset: "BTC", Free: decimal.RequireFromString("0.01")},
+	}, nil)
+
+	store.retrieveBalances(ctx, []exchange.Provider{provider}, []Venue{
+		{Exchange: "ByBit", Category: exchange.CategoryLinear},
+		{Exchange: "ByBit", Category: exchange.CategorySpot},
+	})
+
+	linear, ok := store.GetForAsset("ByBit", "USDT")
+	assert.True(t, ok)
+	assert.Equal(t, "100", linear.Free.String())
+
+	spot, ok := store.GetForAsset("ByBit:spot", "USDT")
+	assert.True(t, ok)
+	assert.Equal(t, "40", spot.Free.String())
+
+	_, ok = store.GetForAsset("ByBit", "BTC")
+	assert.False(t, ok)
+}
+
+func TestBalanceStore_RetrieveBalances_ReleasesReservations(t *testing.T) {
+	store := newBalanceStore(zerolog.New(io.Discard))
+
+	ctx := t.Context()
+
+	linearID, spotID := uuid.New(), uuid.New()
+	store.Reserve("ByBit", linearID, decimal.RequireFromString("8"))
+	store.Reserve("ByBit:spot", spotID, decimal.RequireFromString("40"))
+	assert.Equal(t, "8", store.Reserved("ByBit").String())
+
+	provider := exchangeMocks.NewMockProvider(t)
+	provider.EXPECT().GetExchangeName().Return("ByBit")
+	provider.EXPECT().GetBalances(ctx, exchange.CategoryLinear).Return([]models.Balance{
+		{ExchangeName: "ByBit", Asset: "USDT", Free: decimal.RequireFromString("92")},
+	}, nil)
+
+	// the refreshed balance already holds the margin of the order
+	store.retrieveBalances(ctx, []exchange.Provider{provider}, []Venue{{Exchange: "ByBit", Category: exchange.CategoryLinear}})
+
+	assert.True(t, store.Reserved("ByBit").IsZero())
+	assert.Equal(t, "40", store.Reserved("ByBit:spot").String())
+
+	store.Release(spotID)
+	assert.True(t, store.Reserved("ByBit:spot").IsZero())
+}
@@ -0,0 +1,61 @@
+package arbitragebot
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// BlacklistRepository represents a db repository for symbol blacklist entries.
+type BlacklistRepository interface {
+	Create(ctx context.Context, entry *models.BlacklistEntry) error
+	FindActive(ctx context.Context, now time.Time) ([]*models.BlacklistEntry, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// GormBlacklistRepository is a GORM implementation of BlacklistRepository interface.
+type GormBlacklistRepository struct {
+	db *gorm.DB
+}
+
+// NewBlacklistRepository creates a new GormBlacklistRepository.
+func NewBlacklistRepository(db *gorm.DB) *GormBlacklistRepository {
+	return &GormBlacklistRepository{db: db}
+}
+
+// Create inserts the entry, its ID and CreatedAt are set by the db.
+func (r *GormBlacklistRepository) Create(ctx context.Context, entry *models.BlacklistEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// FindActive finds entries not expired at now, newest first.
+func (r *GormBlacklistRepository) FindActive(ctx context.Context, now time.Time) ([]*models.BlacklistEntry, error) {
+	var entries []*models.BlacklistEntry
+
+	err := r.db.WithContext(ctx).
+		Model(&models.BlacklistEntry{}).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("created_at DESC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Delete deletes the entry, returns gorm.ErrRecordNotFound if there is no entry with the id.
+func (r *GormBlacklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.BlacklistEntry{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -28,10 +29,14 @@ const (
 type ArbitrageBot struct {
 	logger              zerolog.Logger
 	clients             []exchange.Provider
+	venues              []Venue
 	cfg                 *config.Config
 	db                  *gorm.DB
 	arbitrageSpreadRepo ArbitrageSpreadRepository
 	orderRepo           OrderRepository
+	fundingRepo         FundingRepository
+	tradeRepo           TradeRepository
+	notif               notifier.Notifier
 	tradeCount          int64
 	engine              *Engine
 }
@@ -45,6 +50,9 @@ func NewBot(
 	db *gorm.DB,
 	arbitrageSpreadRepo ArbitrageSpreadRepository,
 	orderRepo OrderRepository,
+	fundingRepo FundingRepository,
+	tradeRepo TradeRepository,
+	blacklistRepo BlacklistRepository,
 ) *ArbitrageBot {
 
 	silentModeTxt := "off"
@@ -56,7 +64,16 @@ func NewBot(
 	strategy := newOrderStrategy(cfg)
 	fmt.Printf("Arbitrage bot order mode is %s\n", cfg.Exchange.ArbitrageBot.OrderMode)
 
+	paper := cfg.Exchange.ArbitrageBot.Paper.Enabled
+	if paper {
+		fmt.Println("Arbitrage bot paper trading is on")
+		notify = paperNotifier{Notifier: notify}
+	}
+
 	engine := NewEngine(cfg, clients, orderRepo, arbitrageSpreadRepo, notify, logger, strategy)
+	engine.SetPaper(paper)
+	engine.SetTradeRepository(tradeRepo)
+	engine.SetBlacklistRepository(blacklistRepo)
 	return &ArbitrageBot{
 		logger:              logger,
 		clients:             clients,
@@ -64,6 +81,9 @@ func NewBot(
 		db:                  db,
 		arbitrageSpreadRepo: arbitrageSpreadRepo,
 		orderRepo:           orderRepo,
+		fundingRepo:         fundingRepo,
+		tradeRepo:           tradeRepo,
+		notif:               notify,
 		engine:              engine,
 	}
 }
@@ -81,11 +101,14 @@ func newOrderStrategy(cfg *config.Config) OrderStrategy {
 }
 
 // PriceChangeEvent represents an event triggered by a change in price on a specific exchange.
+// Price is set for trade events, Bid/Ask - for book ticker events.
 type PriceChangeEvent struct {
 	TsMs         int64
 	ExchangeName string
 	Symbol       string
 	Price        float64
+	Bid          float64
+	Ask          float64
 }
 
 // Prices represent a map of prices for a specific symbol on different exchanges.
@@ -121,43 +144,119 @@ func (a *ArbitrageBot) Run(ctx context.Context) error {
 	}
 	a.logger.Info().Msgf("uniq clients: %s", uniqNames)
 
+	a.venues = buildVenues(a.clients, a.cfg.Exchange.ArbitrageBot.SpotExchanges)
+
 	// Start retriving balances
 	balanceStore := newBalanceStore(a.logger)
-	balanceStore.Start(ctx, a.clients)
-	a.checkBalances(ctx)
+	balanceStore.Start(ctx, a.clients, a.venues)
+	a.engine.SetBalanceStore(balanceStore)
+	a.checkBalances(balanceStore)
 
-	if err := a.engine.LoadInstruments(ctx, a.clients); err != nil {
+	if err := a.engine.LoadInstruments(ctx, a.clients, a.venues); err != nil {
 		return fmt.Errorf("failed to load instruments: %w", err)
 	}
 	a.logger.Info().Msg("instrument cache loaded")
 
+	if err := a.engine.LoadFees(ctx, a.clients); err != nil {
+		return fmt.Errorf("failed to load fees: %w", err)
+	}
+	a.logger.Info().Msg("fee schedules loaded")
+
+	if err := a.engine.SetAccountMarginModes(ctx); err != nil {
+		return fmt.Errorf("failed to set margin mode: %w", err)
+	}
+
 	if err := a.engine.ListenExecutions(ctx); err != nil {
 		return fmt.Errorf("failed to subscribe to executions: %w", err)
 	}
 
-	commonSymbols := commonSymbols(a.engine.Instruments())
-	if len(commonSymbols) < 1 {
-		return fmt.Errorf("no common symbols for exchanges")
+	tradable := tradableSymbols(a.engine.Instruments())
+	if len(tradable) < 1 {
+		return fmt.Errorf("no tradable symbols for exchanges")
 	}
-	a.logger.Info().Msgf("common symbols: %d", len(commonSymbols))
+	a.logger.Info().Msgf("tradable symbols: %d", len(tradable))
 
 	//
 	// websocket subscription
-	symbols := make([]string, 0, len(commonSymbols))
-	for s := range commonSymbols {
+	symbols := make([]string, 0, len(tradable))
+	for s := range tradable {
 		symbols = append(symbols, s)
 	}
+	sort.Strings(symbols)
+
+	spreadDetector := NewSpreadDetector(a.cfg)
+	spreadDetector.SetFees(a.engine.Fees())
+
+	// restore before the engine starts, so restored positions block overlapping opens
+	for _, spread := range a.engine.RestorePositions(ctx) {
+		spreadDetector.Restore(spread.Symbol, spread.BuyOnExchange, spread.SellOnExchange, spread.MaxNetSpreadPercent.InexactFloat64())
+	}
+
+	if err := a.engine.LoadBlacklist(ctx); err != nil {
+		return err
+	}
+	go a.engine.RunBlacklistRefresh(ctx, a.cfg.Exchange.ArbitrageBot.Blacklist.RefreshInterval)
+
+	if a.cfg.Exchange.ArbitrageBot.Reconcile.Enabled {
+		reconciler := NewReconciler(
+			a.engine,
+			a.venues,
+			a.arbitrageSpreadRepo,
+			a.orderRepo,
+			a.notif,
+			a.logger,
+			a.cfg.Exchange.ArbitrageBot.Reconcile.Interval,
+			a.cfg.Exchange.ArbitrageBot.Reconcile.AutoFlatten,
+		)
+		// reconcile before the engine starts, so the first check doesn't race with new positions
+		for _, spread := range reconciler.Restore(ctx) {
+			spreadDetector.Restore(spread.Symbol, spread.BuyOnExchange, spread.SellOnExchange, spread.MaxNetSpreadPercent.InexactFloat64())
+		}
+		a.logger.Info().Msgf("positions reconciled, active: %d", a.engine.pm.Count())
+		go reconciler.Run(ctx)
+	}
 
 	tradeEventsCh := make(chan PriceChangeEvent, 2000)
 	errCh := make(chan error, len(a.clients))
 
 	go a.engine.Run(ctx)
 	go a.updateOrderInfoAndCalcSpreadProfit(ctx)
-	go a.grabTrade(ctx, symbols, tradeEventsCh, errCh)
+	feed := newMarketDataFeed(a, tradeEventsCh, errCh)
+	feed.Add(ctx, symbols)
 	go a.logTradeCount(ctx)
 
+	symbolsCh := make(chan symbolsChange, 1)
+	if interval := a.cfg.Exchange.ArbitrageBot.InstrumentRefreshInterval; interval > 0 {
+		refresher := NewInstrumentRefresher(
+			a.engine,
+			a.venues,
+			a.notif,
+			a.logger,
+			interval,
+			tradable,
+			func(ctx context.Context, added, removed []string) {
+				select {
+				case symbolsCh <- symbolsChange{added: added, removed: removed}:
+				case <-ctx.Done():
+				}
+			},
+		)
+		go refresher.Run(ctx)
+	}
+	if a.cfg.Exchange.ArbitrageBot.Funding.Enabled {
+		fundingMonitor := NewFundingMonitor(
+			a.clients,
+			a.fundingRepo,
+			NewFundingDetector(a.cfg, a.engine.Fees()),
+			a.notif,
+			a.logger,
+			a.cfg.Exchange.ArbitrageBot.Funding.PollInterval,
+			tradable,
+		)
+		go fundingMonitor.Run(ctx)
+	}
+
 	prices := make(Prices)
-	spreadDetector := NewSpreadDetector(a.cfg)
 
 	for {
 		select {
@@ -165,14 +264,26 @@ func (a *ArbitrageBot) Run(ctx context.Context) error {
 			return nil
 		case err := <-errCh:
 			return fmt.Errorf("failed to grab trade: %w", err)
+		case change := <-symbolsCh:
+			feed.Add(ctx, change.added)
+			feed.Remove(ctx, change.removed)
+			for _, symbol := range change.removed {
+				delete(prices, symbol)
+			}
+			a.logger.Info().Strs("added", change.added).Strs("removed", change.removed).Msg("market data subscriptions updated")
 		case event := <-tradeEventsCh:
 			atomic.AddInt64(&a.tradeCount, 1)
+			if !feed.Has(event.Symbol) {
+				continue
+			}
 
 			if prices[event.Symbol] == nil {
 				prices[event.Symbol] = map[string]PricePoint{}
 			}
 			prices[event.Symbol][event.ExchangeName] = PricePoint{
 				Price: event.Price,
+				Bid:   event.Bid,
+				Ask:   event.Ask,
 				TsMs:  event.TsMs,
 			}
 			spreadEvents := spreadDetector.Detect(event.Symbol, prices[event.Symbol])
@@ -195,9 +306,19 @@ func (a *ArbitrageBot) grabTrade(ctx context.Context, symbols []string, tradeEve
 		}
 	}
 
-	for _, client := range a.clients {
-		exchangeName := client.GetExchangeName()
-		tradeCh, err := client.SubscribeTrades(subCtx, symbols, exchange.CategoryLinear)
+	instruments := a.engine.Instruments()
+
+	for _, venue := range a.venues {
+		exchangeName := venue.Name()
+		client := a.engine.clientFor(exchangeName)
+
+		venueSymbols := venueSymbols(instruments, exchangeName, symbols)
+		if len(venueSymbols) == 0 {
+			a.logger.Info().Msgf("no tradable symbols on %s, skip", exchangeName)
+			continue
+		}
+
+		tradeCh, err := client.SubscribeTrades(subCtx, venueSymbols, venue.Category)
 		if err != nil {
 			sendErrNonBlocking(fmt.Errorf("failed to subscribe to trades on %s: %w", exchangeName, err))
 			return
@@ -213,6 +334,9 @@ func (a *ArbitrageBot) grabTrade(ctx context.Context, symbols []string, tradeEve
 					return
 				case trade, ok := <-ch:
 					if !ok {
+						if subCtx.Err() != nil {
+							return
+						}
 						sendErrNonBlocking(fmt.Errorf("trade channel closed on %s", exchangeName))
 						return
 					}
@@ -237,6 +361,103 @@ func (a *ArbitrageBot) grabTrade(ctx context.Context, symbols []string, tradeEve
 	<-subCtx.Done()
 }
 
+func (a *ArbitrageBot) grabBookTicker(ctx context.Context, symbols []string, tradeEventsCh chan<- PriceChangeEvent, errCh chan<- error) {
+	subCtx, subCancel := context.WithCancel(ctx)
+	defer subCancel()
+
+	sendErrNonBlocking := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+			a.logger.Warn().Err(err).Msg("drop err: errCh is full")
+		}
+	}
+
+	instruments := a.engine.Instruments()
+
+	for _, client := range a.clients {
+		exchangeName := client.GetExchangeName()
+		venueSymbols := venueSymbols(instruments, exchangeName, symbols)
+		if len(venueSymbols) == 0 {
+			a.logger.Info().Msgf("no tradable symbols on %s, skip", exchangeName)
+			continue
+		}
+
+		bookCh, err := client.SubscribeBookTicker(subCtx, venueSymbols)
+		if err != nil {
+			sendErrNonBlocking(fmt.Errorf("failed to subscribe to book ticker on %s: %w", exchangeName, err))
+			return
+		}
+
+		a.logger.Info().Msgf("subscribed to book ticker on %s", exchangeName)
+
+		go func(exchangeName string, ch <-chan exchange.BookTop) {
+			defer subCancel()
+			for {
+				select {
+				case <-subCtx.Done():
+					return
+				case top, ok := <-ch:
+					if !ok {
+						if subCtx.Err() != nil {
+							return
+						}
+						sendErrNonBlocking(fmt.Errorf("book ticker channel closed on %s", exchangeName))
+						return
+					}
+
+					event := PriceChangeEvent{
+						Symbol:       top.Symbol,
+						TsMs:         top.Ts,
+						ExchangeName: exchangeName,
+						Bid:          top.BidPrice,
+						Ask:          top.AskPrice,
+					}
+
+					select {
+					case tradeEventsCh <- event:
+					case <-subCtx.Done():
+						return
+					}
+				}
+			}
+		}(exchangeName, bookCh)
+	}
+
+	<-subCtx.Done()
+}
+
+// grabOrderBooks feeds L2 order books of all exchanges into the engine (used for depth-aware sizing).
+func (a *ArbitrageBot) grabOrderBooks(ctx context.Context, symbols []string, errCh chan<- error) {
+	instruments := a.engine.Instruments()
+
+	for _, client := range a.clients {
+		exchangeName := client.GetExchangeName()
+		venueSymbols := venueSymbols(instruments, exchangeName, symbols)
+		if len(venueSymbols) == 0 {
+			continue
+		}
+
+		bookCh, err := client.SubscribeOrderBook(ctx, venueSymbols)
+		if err != nil {
+			select {
+			case errCh <- fmt.Errorf("failed to subscribe to order book on %s: %w", exchangeName, err):
+			default:
+				a.logger.Warn().Err(err).Msg("drop err: errCh is full")
+			}
+			return
+		}
+
+		a.logger.Info().Msgf("subscribed to order book on %s", exchangeName)
+
+		go func(exchangeName string, ch <-chan exchange.OrderBookUpdate) {
+			for update := range ch {
+				a.engine.ApplyOrderBook(exchangeName, update)
+			}
+		}(exchangeName, bookCh)
+	}
+}
+
 func checkUniqClient(clients []exchange.Provider) (bool, string, string) {
 	cnt := make(map[string]int)
 	var names string
@@ -286,23 +507,22 @@ func (a *ArbitrageBot) logTradeCount(ctx context.Context) {
 	}
 }
 
-func (a *ArbitrageBot) checkBalances(ctx context.Context) {
+// checkBalances drops exchanges and spot venues without enough USDT for trading.
+func (a *ArbitrageBot) checkBalances(balanceStore *balanceStore) {
 	minBalance := decimal.NewFromInt(minBalanceForTrading)
 
-	balanceStore := newBalanceStore(a.logger)
-	balanceStore.Start(ctx, a.clients)
+	enough := func(venueName string) bool {
+		balance, ok := balanceStore.GetForAsset(venueName, "USDT")
+		return ok && balance.Free.GreaterThanOrEqual(minBalance)
+	}
 
 	cl := make([]exchange.Provider, 0, len(a.clients))
+	clientSet := make(map[string]struct{}, len(a.clients))
 
 	for _, client := range a.clients {
-		balances, ok := balanceStore.Get(client.GetExchangeName())
-
-		if ok {
-			for _, balance := range balances {
-				if balance.Asset == "USDT" && balance.Free.GreaterThanOrEqual(minBalance) {
-					cl = append(cl, client)
-				}
-			}
+		if enough(client.GetExchangeName()) {
+			cl = append(cl, client)
+			clientSet[client.GetExchangeName()] = struct{}{}
 		}
 	}
 
@@ -314,7 +534,20 @@ func (a *ArbitrageBot) checkBalances(ctx context.Context) {
 		a.logger.Info().Msgf("enough balance for trading on %s", client.GetExchangeName())
 	}
 
+	venues := make([]Venue, 0, len(a.venues))
+	for _, venue := range a.venues {
+		if _, ok := clientSet[venue.Exchange]; !ok {
+			continue
+		}
+		if venue.IsSpot() && !enough(venue.Name()) {
+			a.logger.Info().Msgf("not enough balance for trading on %s, skip", venue.Name())
+			continue
+		}
+		venues = append(venues, venue)
+	}
+
 	a.clients = cl
+	a.venues = venues
 }
 
 func (a *ArbitrageBot) skipExchange() []exchange.Provider {
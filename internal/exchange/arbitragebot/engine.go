@@ -3,9 +3,13 @@ package arbitragebot
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/models"
@@ -18,20 +22,44 @@ import (
 //	engine_signals.go      — spread event handlers (handleOpen / handleUpdate / handleClose)
 //	engine_execution.go    — order submission and execution event routing
 //	engine_fill_timeout.go — limit fill timeout watcher and cleanup
-//	engine_persistence.go  — DB writes for spreads and orders
+//	engine_rebalance.go    — top-up / reduce-only order evening out asymmetric open leg fills
+//	engine_persistence.go  — DB writes for spreads, orders and trades
+//	engine_recovery.go     — restoring positions persisted in trades after restart
+//	engine_risk.go         — risk limits, realized PnL and the kill-switch
 //	engine_helpers.go      — instrument math, order construction, price alignment
+//	engine_depth_sizing.go — trade size limited by L2 order book depth
+//	engine_sizing.go       — trade notional from live balances, margin check and reservations, balance drift warning
+//	engine_leverage.go     — margin mode and leverage set on the exchange before the first order on a symbol
+//	engine_blacklist.go    — symbol bans after failed opens, persisted and refreshed from DB
 type Engine struct {
-	cfg         *config.Config
-	clients     map[string]exchange.Provider
-	instruments map[string]map[string]exchange.Instrument // [exchange][symbol]
-	orderRepo   OrderRepository
-	spreadRepo  ArbitrageSpreadRepository
-	notif       notifier.Notifier
-	logger      zerolog.Logger
-	strategy    OrderStrategy
+	cfg           *config.Config
+	clients       map[string]exchange.Provider              // by exchange name
+	instruments   map[string]map[string]exchange.Instrument // [venue][symbol], guarded by instrumentsMu
+	instrumentsMu sync.RWMutex
+	fees          FeeSchedules
+	balances      *balanceStore // free USDT for sizing and the margin check, nil - fixed notional and no check
+	orderRepo     OrderRepository
+	spreadRepo    ArbitrageSpreadRepository
+	tradeRepo     TradeRepository     // nil - positions are not persisted
+	tradeMu       sync.Mutex          // serializes trade snapshots with their writes, so a stale state never overwrites a newer one
+	blacklistRepo BlacklistRepository // nil - bans are kept in memory only
+	notif         notifier.Notifier
+	logger        zerolog.Logger
+	strategy      OrderStrategy
+	risk          *riskLimits
+	clock         clock.Clock // time of positions, timeouts and bans; the replayed one in backtests
+	fillTimeouts  atomic.Int64
+	paper         bool // spreads, orders and trades are tagged as paper
+
+	driftMu       sync.Mutex
+	driftWarnedAt map[string]time.Time // exchange pair → last balance drift warning
+
+	leverageMu  sync.Mutex
+	leverageSet map[string]int64 // venue#symbol → leverage set on the exchange
 
 	signalCh chan *SpreadEvent
 	pm       *PositionManager
+	books    *orderBookStore
 }
 
 // NewEngine creates a new Engine with the given order strategy.
@@ -52,33 +80,114 @@ func NewEngine(
 		cfg:         cfg,
 		clients:     clientMap,
 		instruments: make(map[string]map[string]exchange.Instrument),
+		fees:        make(FeeSchedules),
 		orderRepo:   orderRepo,
 		spreadRepo:  spreadRepo,
 		notif:       notif,
 		logger:      logger,
 		strategy:    strategy,
-		signalCh:    make(chan *SpreadEvent, 1000),
-		pm:          newPositionManager(),
+		risk:        newRiskLimits(cfg.Exchange.ArbitrageBot.Risk),
+		clock:       clock.Real(),
+
+		driftWarnedAt: make(map[string]time.Time),
+		leverageSet:   make(map[string]int64),
+		signalCh:      make(chan *SpreadEvent, 1000),
+		pm:            newPositionManager(),
+		books:         newOrderBookStore(),
 	}
 }
 
-// LoadInstruments fetches contract specifications from all exchanges.
-func (e *Engine) LoadInstruments(ctx context.Context, clients []exchange.Provider) error {
+// LoadInstruments fetches contract specifications of all venues, venues are traded by the given clients.
+func (e *Engine) LoadInstruments(ctx context.Context, clients []exchange.Provider, venues []Venue) error {
 	for _, client := range clients {
 		e.clients[client.GetExchangeName()] = client
+	}
 
-		instruments, err := client.GetInstruments(ctx)
+	for _, venue := range venues {
+		client := e.clientFor(venue.Name())
+		if client == nil {
+			return fmt.Errorf("no client for venue %s", venue.Name())
+		}
+
+		instruments, err := client.GetInstruments(ctx, venue.Category)
 		if err != nil {
-			return fmt.Errorf("failed to load instruments from %s: %w", client.GetExchangeName(), err)
+			return fmt.Errorf("failed to load instruments from %s: %w", venue.Name(), err)
 		}
-		e.instruments[client.GetExchangeName()] = instruments
+		e.SetInstruments(venue.Name(), instruments)
 	}
 	return nil
 }
 
-// Instruments returns the cached instrument data per exchange (exchange → symbol → Instrument).
+// SetInstruments replaces the cached instruments of the venue.
+func (e *Engine) SetInstruments(venueName string, instruments map[string]exchange.Instrument) {
+	e.instrumentsMu.Lock()
+	defer e.instrumentsMu.Unlock()
+	e.instruments[venueName] = instruments
+}
+
+// SetBalanceStore sets balances used to size trades and check the free margin before opening a position.
+func (e *Engine) SetBalanceStore(balances *balanceStore) {
+	e.balances = balances
+}
+
+// SetClock sets the time source of the engine. Must be called before Run.
+func (e *Engine) SetClock(c clock.Clock) {
+	e.clock = c
+}
+
+// SetPaper tags the spreads, orders and trades of the engine as paper: its clients are simulated exchanges,
+// see WithPaperTrading.
+func (e *Engine) SetPaper(paper bool) {
+	e.paper = paper
+}
+
+// SetTradeRepository sets the repository positions are persisted to on every state transition.
+func (e *Engine) SetTradeRepository(repo TradeRepository) {
+	e.tradeRepo = repo
+}
+
+// SetBlacklistRepository sets the repository symbol bans are persisted to and loaded from.
+func (e *Engine) SetBlacklistRepository(repo BlacklistRepository) {
+	e.blacklistRepo = repo
+}
+
+// FillTimeouts returns the number of positions whose open legs didn't fill within the fill timeout.
+func (e *Engine) FillTimeouts() int64 {
+	return e.fillTimeouts.Load()
+}
+
+// clientFor returns the client trading on the venue.
+func (e *Engine) clientFor(venueName string) exchange.Provider {
+	return e.clients[parseVenue(venueName).Exchange]
+}
+
+// Instruments returns a snapshot of the cached instrument data per venue (venue → symbol → Instrument).
+// Instruments of a venue are replaced as a whole on refresh, so the inner maps are never modified.
 func (e *Engine) Instruments() map[string]map[string]exchange.Instrument {
-	return e.instruments
+	e.instrumentsMu.RLock()
+	defer e.instrumentsMu.RUnlock()
+	instruments := make(map[string]map[string]exchange.Instrument, len(e.instruments))
+	for venueName, bySymbol := range e.instruments {
+		instruments[venueName] = bySymbol
+	}
+	return instruments
+}
+
+// LoadFees fetches fee schedules from all exchanges.
+func (e *Engine) LoadFees(ctx context.Context, clients []exchange.Provider) error {
+	for _, client := range clients {
+		fees, err := client.GetFeeSchedule(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load fees from %s: %w", client.GetExchangeName(), err)
+		}
+		e.fees[client.GetExchangeName()] = fees
+	}
+	return nil
+}
+
+// Fees returns the cached fee schedules per exchange.
+func (e *Engine) Fees() FeeSchedules {
+	return e.fees
 }
 
 // ListenExecutions subscribes to execution events from all clients.
@@ -117,6 +226,11 @@ func (e *Engine) Run(ctx context.Context) {
 	}
 }
 
+// ApplyOrderBook applies L2 order book update of the exchange, books are used for depth-aware sizing.
+func (e *Engine) ApplyOrderBook(exchangeName string, update exchange.OrderBookUpdate) {
+	e.books.Apply(exchangeName, update)
+}
+
 // HandleSignal enqueues spread events for processing.
 func (e *Engine) HandleSignal(events []*SpreadEvent) {
 	for _, ev := range events {
@@ -0,0 +1,71 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// blacklist bans the position symbol for the configured TTL (no TTL - until removed) and removes the position.
+// An empty exchangeName bans the symbol on all exchanges. The ban is persisted, so it survives restart.
+func (e *Engine) blacklist(pos *Position, exchangeName, reason string) {
+	entry := &models.BlacklistEntry{
+		Symbol:       pos.Symbol,
+		ExchangeName: exchangeName,
+		Reason:       reason,
+	}
+	if ttl := e.cfg.Exchange.ArbitrageBot.Blacklist.TTL; ttl > 0 {
+		expiresAt := e.clock.Now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	// saved before it's shared with the position manager, Create sets the ID
+	if e.blacklistRepo != nil {
+		if err := e.blacklistRepo.Create(context.Background(), entry); err != nil {
+			e.logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("blacklist: failed to save entry")
+		}
+	}
+	e.pm.Blacklist(pos, entry)
+
+	e.logger.Warn().
+		Str("symbol", pos.Symbol).
+		Str("exchange", exchangeName).
+		Any("expires_at", entry.ExpiresAt).
+		Str("reason", reason).
+		Msg("execution: symbol blacklisted after failed open")
+}
+
+// LoadBlacklist loads the active blacklist entries from DB. No-op without a repository.
+func (e *Engine) LoadBlacklist(ctx context.Context) error {
+	if e.blacklistRepo == nil {
+		return nil
+	}
+
+	entries, err := e.blacklistRepo.FindActive(ctx, e.clock.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load blacklist: %w", err)
+	}
+	e.pm.SetBlacklist(entries)
+
+	return nil
+}
+
+// RunBlacklistRefresh reloads the blacklist from DB every interval, so entries added or removed through
+// the API are applied without restart. Blocks until ctx is cancelled.
+func (e *Engine) RunBlacklistRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.LoadBlacklist(ctx); err != nil {
+				e.logger.Warn().Err(err).Msg("blacklist: refresh failed")
+			}
+		}
+	}
+}
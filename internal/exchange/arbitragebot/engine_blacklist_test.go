@@ -0,0 +1,160 @@
+package arbitragebot
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+type blacklistRepoStub struct {
+	mu      sync.Mutex
+	entries []*models.BlacklistEntry
+}
+
+func (r *blacklistRepoStub) Create(_ context.Context, entry *models.BlacklistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *blacklistRepoStub) FindActive(_ context.Context, now time.Time) ([]*models.BlacklistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var active []*models.BlacklistEntry
+	for _, entry := range r.entries {
+		if entry.IsActive(now) {
+			active = append(active, entry)
+		}
+	}
+	return active, nil
+}
+
+func (r *blacklistRepoStub) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, entry := range r.entries {
+		if entry.ID == id {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func TestPositionManager_IsBlacklisted(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+
+	pm := newPositionManager()
+	pm.SetBlacklist([]*models.BlacklistEntry{
+		{ID: uuid.New(), Symbol: "BTCUSDT", ExchangeName: "MEXC"},
+		{ID: uuid.New(), Symbol: "ETHUSDT", ExpiresAt: &expired},
+		{ID: uuid.New(), Symbol: "SOLUSDT", ExchangeName: "ByBit:spot"},
+	})
+
+	assert.True(t, pm.IsBlacklisted("BTCUSDT", "ByBit", "MEXC", now))
+	assert.False(t, pm.IsBlacklisted("BTCUSDT", "ByBit", "OKX", now))
+	assert.False(t, pm.IsBlacklisted("ETHUSDT", "ByBit", "MEXC", now))
+	// a venue ban doesn't cover the other venue of the exchange, an exchange ban covers all of them
+	assert.True(t, pm.IsBlacklisted("SOLUSDT", "ByBit:spot", "MEXC", now))
+	assert.False(t, pm.IsBlacklisted("SOLUSDT", "ByBit", "MEXC", now))
+	assert.True(t, pm.IsBlacklisted("BTCUSDT", "MEXC:spot", "OKX", now))
+
+	// not persisted bans survive the refresh, persisted ones are replaced
+	pos := &Position{Symbol: "XRPUSDT", BuyExchange: "ByBit", SellExchange: "OKX"}
+	pm.Add(pos)
+	pm.Blacklist(pos, &models.BlacklistEntry{Symbol: "XRPUSDT"})
+	assert.Zero(t, pm.Count())
+
+	pm.SetBlacklist(nil)
+	assert.True(t, pm.IsBlacklisted("XRPUSDT", "ByBit", "OKX", now))
+	assert.False(t, pm.IsBlacklisted("BTCUSDT", "ByBit", "MEXC", now))
+}
+
+func TestEngine_Blacklist(t *testing.T) {
+	repo := &blacklistRepoStub{}
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Blacklist.TTL = time.Hour
+
+	engine := NewEngine(cfg, nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.SetBlacklistRepository(repo)
+
+	pos := &Position{Symbol: "BTCUSDT", BuyExchange: "ByBit", SellExchange: "MEXC"}
+	engine.pm.Add(pos)
+	engine.blacklist(pos, "MEXC", "insufficient margin")
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, "MEXC", entry.ExchangeName)
+	assert.Equal(t, "insufficient margin", entry.Reason)
+	require.NotNil(t, entry.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *entry.ExpiresAt, time.Minute)
+	assert.True(t, engine.pm.IsBlacklisted("BTCUSDT", "MEXC", "OKX", time.Now()))
+	assert.False(t, engine.pm.IsBlacklisted("BTCUSDT", "ByBit", "OKX", time.Now()))
+
+	// removed through the API, applied on refresh
+	require.NoError(t, repo.Delete(t.Context(), entry.ID))
+	require.NoError(t, engine.LoadBlacklist(t.Context()))
+	assert.False(t, engine.pm.IsBlacklisted("BTCUSDT", "MEXC", "OKX", time.Now()))
+
+	// restored after restart
+	restarted := NewEngine(cfg, nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	restarted.SetBlacklistRepository(repo)
+	engine.blacklist(pos, "", "both legs failed")
+	require.NoError(t, restarted.LoadBlacklist(t.Context()))
+	assert.True(t, restarted.pm.IsBlacklisted("BTCUSDT", "ByBit", "OKX", time.Now()))
+}
+
+func TestHTTPHandlers_Blacklist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &blacklistRepoStub{}
+	h := NewHTTPHandlers(nil, nil, repo)
+
+	r := gin.New()
+	r.GET("/arbitrage-blacklist", h.GetBlacklistHandler)
+	r.POST("/arbitrage-blacklist", h.CreateBlacklistEntryHandler)
+	r.DELETE("/arbitrage-blacklist/:id", h.DeleteBlacklistEntryHandler)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/arbitrage-blacklist", `{"symbol":"btcusdt","exchange":"MEXC","reason":"delisting","ttl":"24h"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, repo.entries, 1)
+	assert.Equal(t, "BTCUSDT", repo.entries[0].Symbol)
+	require.NotNil(t, repo.entries[0].ExpiresAt)
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/arbitrage-blacklist", `{"symbol":"ETHUSDT","reason":"x","ttl":"soon"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/arbitrage-blacklist", `{"symbol":"ETHUSDT"}`).Code)
+
+	w = do(http.MethodGet, "/arbitrage-blacklist", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"symbol":"BTCUSDT","exchange":"MEXC","reason":"delisting"`)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/arbitrage-blacklist/"+repo.entries[0].ID.String(), "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/arbitrage-blacklist/"+uuid.NewString(), "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodDelete, "/arbitrage-blacklist/42", "").Code)
+	assert.Empty(t, repo.entries)
+}
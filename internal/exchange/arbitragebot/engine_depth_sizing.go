@@ -0,0 +1,115 @@
+package arbitragebot
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// depthQty returns the largest qty (in coins) that can be bought on the buy exchange asks and sold on the sell
+// exchange bids while the net spread between sell and buy VWAPs (after round trip taker fees and slippage)
+// is still >= MinSpreadPercent.
+func (e *Engine) depthQty(event *SpreadEvent) (decimal.Decimal, error) {
+	asks, err := e.bookLevelsInCoins(event.Symbol, event.BuyOnExchange, exchange.Buy)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	bids, err := e.bookLevelsInCoins(event.Symbol, event.SellOnExchange, exchange.Sell)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	minGrossSpreadPercent := e.cfg.Exchange.ArbitrageBot.MinSpreadPercent +
+		e.fees.roundTripFeePercent(event.Symbol, event.BuyOnExchange, event.SellOnExchange) +
+		e.cfg.Exchange.ArbitrageBot.SlippagePercent
+
+	qty := depthSizedQty(asks, bids, minGrossSpreadPercent)
+
+	return decimal.NewFromFloat(qty), nil
+}
+
+// bookLevelsInCoins returns the side of the book a market order of the given side is executed against
+// (asks for buy, bids for sell) with quantities converted from exchange units to coins.
+func (e *Engine) bookLevelsInCoins(symbol, exchangeName string, side exchange.Side) ([]exchange.PriceLevel, error) {
+	book, ok := e.books.Get(exchangeName, symbol)
+	if !ok {
+		return nil, fmt.Errorf("no order book for %s on %s", symbol, exchangeName)
+	}
+
+	if e.clock.Now().UnixMilli()-book.Ts() > e.cfg.Exchange.ArbitrageBot.MaxAgeMs {
+		return nil, fmt.Errorf("order book for %s on %s is stale", symbol, exchangeName)
+	}
+
+	inst, err := e.instrumentFor(symbol, exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	contractSize := inst.ContractSize.InexactFloat64()
+
+	levels := book.Asks()
+	if side == exchange.Sell {
+		levels = book.Bids()
+	}
+
+	for i := range levels {
+		levels[i].Qty *= contractSize
+	}
+
+	return levels, nil
+}
+
+// depthSizedQty walks asks of the buy exchange and bids of the sell exchange (both sorted from the best level,
+// qty in coins) and returns the largest qty at which (sellVWAP - buyVWAP) / buyVWAP * 100 >= minSpreadPercent.
+func depthSizedQty(asks, bids []exchange.PriceLevel, minSpreadPercent float64) float64 {
+	k := 1 + minSpreadPercent/100
+
+	var qty, buyValue, sellValue float64
+
+	i, j := 0, 0
+	var askLeft, bidLeft float64
+	if len(asks) > 0 {
+		askLeft = asks[0].Qty
+	}
+	if len(bids) > 0 {
+		bidLeft = bids[0].Qty
+	}
+
+	for i < len(asks) && j < len(bids) {
+		askPrice, bidPrice := asks[i].Price, bids[j].Price
+		step := min(askLeft, bidLeft)
+
+		// the next step keeps the spread above the threshold while
+		// sellValue + bidPrice*q >= k * (buyValue + askPrice*q)
+		marginal := bidPrice - k*askPrice
+		if marginal < 0 {
+			maxStep := (sellValue - k*buyValue) / -marginal
+			if maxStep < step {
+				return qty + max(maxStep, 0)
+			}
+		}
+
+		qty += step
+		buyValue += askPrice * step
+		sellValue += bidPrice * step
+
+		askLeft -= step
+		bidLeft -= step
+		if askLeft <= 0 {
+			i++
+			if i < len(asks) {
+				askLeft = asks[i].Qty
+			}
+		}
+		if bidLeft <= 0 {
+			j++
+			if j < len(bids) {
+				bidLeft = bids[j].Qty
+			}
+		}
+	}
+
+	return qty
+}
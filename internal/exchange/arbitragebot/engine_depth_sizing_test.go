@@ -0,0 +1,100 @@
+package arbitragebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestDepthSizedQty(t *testing.T) {
+	tests := []struct {
+		name             string
+		asks             []exchange.PriceLevel
+		bids             []exchange.PriceLevel
+		minSpreadPercent float64
+		want             float64
+	}{
+		{
+			name:             "empty book",
+			asks:             nil,
+			bids:             []exchange.PriceLevel{{Price: 103, Qty: 1}},
+			minSpreadPercent: 1,
+			want:             0,
+		},
+		{
+			name:             "no spread on top of the book",
+			asks:             []exchange.PriceLevel{{Price: 100, Qty: 1}},
+			bids:             []exchange.PriceLevel{{Price: 100.5, Qty: 1}},
+			minSpreadPercent: 1,
+			want:             0,
+		},
+		{
+			name:             "whole depth is profitable",
+			asks:             []exchange.PriceLevel{{Price: 100, Qty: 1}, {Price: 100.5, Qty: 2}},
+			bids:             []exchange.PriceLevel{{Price: 105, Qty: 2}, {Price: 104, Qty: 2}},
+			minSpreadPercent: 1,
+			want:             3,
+		},
+		{
+			// 2 coins: buy 200, sell 206 -> 3%. Next levels ask 102 / bid 102 add zero spread,
+			// spread stays >= 2% while (206 + 102q) >= 1.02 * (200 + 102q) -> q <= 2/2.04
+			name:             "partially consumes the level where spread falls below threshold",
+			asks:             []exchange.PriceLevel{{Price: 100, Qty: 2}, {Price: 102, Qty: 10}},
+			bids:             []exchange.PriceLevel{{Price: 103, Qty: 2}, {Price: 102, Qty: 10}},
+			minSpreadPercent: 2,
+			want:             2 + 2/2.04,
+		},
+	}
+
+	for _, tt := range tests {
+		got := depthSizedQty(tt.asks, tt.bids, tt.minSpreadPercent)
+		assert.InDelta(t, tt.want, got, 1e-9, tt.name)
+	}
+}
+
+func TestEngine_DepthQty_ConvertsContractsToCoins(t *testing.T) {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			ArbitrageBot: config.ArbitrageBotConfig{
+				MaxAgeMs:         60_000,
+				MinSpreadPercent: 1,
+			},
+		},
+	}
+	engine := NewEngine(cfg, nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(10)}},
+	}
+
+	now := time.Now().UnixMilli()
+	engine.ApplyOrderBook("ByBit", exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Ts:       now,
+		Snapshot: true,
+		Asks:     []exchange.PriceLevel{{Price: 100, Qty: 5}},
+		Bids:     []exchange.PriceLevel{{Price: 99, Qty: 5}},
+	})
+	// 2 contracts = 20 coins
+	engine.ApplyOrderBook("MEXC", exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Ts:       now,
+		Snapshot: true,
+		Asks:     []exchange.PriceLevel{{Price: 106, Qty: 2}},
+		Bids:     []exchange.PriceLevel{{Price: 105, Qty: 2}},
+	})
+
+	qty, err := engine.depthQty(&SpreadEvent{Symbol: "BTCUSDT", BuyOnExchange: "ByBit", SellOnExchange: "MEXC"})
+	require.NoError(t, err)
+	assert.Equal(t, "5", qty.String())
+
+	_, err = engine.depthQty(&SpreadEvent{Symbol: "ETHUSDT", BuyOnExchange: "ByBit", SellOnExchange: "MEXC"})
+	assert.Error(t, err)
+}
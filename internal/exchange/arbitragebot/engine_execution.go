@@ -2,9 +2,11 @@ package arbitragebot
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
@@ -13,8 +15,8 @@ import (
 
 // canBeOpened checks pre-conditions before opening a new arbitrage position.
 func (e *Engine) canBeOpened(event *SpreadEvent) bool {
-	if e.pm.Count() >= 3 {
-		e.logger.Info().Msg("execution: already have 3 open positions, skipping")
+	if err := e.risk.canOpen(e.pm.Count()); err != nil {
+		e.logger.Info().Err(err).Str("symbol", event.Symbol).Msg("execution: risk limit, skipping")
 		return false
 	}
 
@@ -56,8 +58,29 @@ func (e *Engine) openPosition(ctx context.Context, event *SpreadEvent) *Position
 		return nil
 	}
 
-	notional := decimal.NewFromInt(e.notional())
+	notional, err := e.tradeNotional(event.Symbol, event.BuyOnExchange, event.SellOnExchange)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("symbol", event.Symbol).Msg("execution: failed to size by balances, skipping")
+		return nil
+	}
 	rawQty := notional.Div(decimal.NewFromFloat(event.BuyPrice))
+
+	if e.cfg.Exchange.ArbitrageBot.DepthSizing {
+		depthQty, err := e.depthQty(event)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("symbol", event.Symbol).Msg("execution: failed to size by order book depth, skipping")
+			return nil
+		}
+		if depthQty.LessThan(rawQty) {
+			e.logger.Info().
+				Str("symbol", event.Symbol).
+				Stringer("notional_qty", rawQty).
+				Stringer("depth_qty", depthQty).
+				Msg("execution: qty limited by order book depth")
+			rawQty = depthQty
+		}
+	}
+
 	qty := rawQty.Div(step).Floor().Mul(step)
 
 	if qty.IsZero() {
@@ -78,6 +101,19 @@ func (e *Engine) openPosition(ctx context.Context, event *SpreadEvent) *Position
 		return nil
 	}
 
+	for _, exchangeName := range []string{event.BuyOnExchange, event.SellOnExchange} {
+		if err := e.checkMargin(event.Symbol, exchangeName, qty, event.BuyPrice); err != nil {
+			e.logger.Warn().Err(err).Str("symbol", event.Symbol).Msg("execution: not enough margin, skipping")
+			return nil
+		}
+	}
+
+	tradeNotional := qty.Mul(decimal.NewFromFloat(event.BuyPrice))
+	if err := e.risk.checkExposure(event.Symbol, event.BuyOnExchange, event.SellOnExchange, tradeNotional, e.pm.Positions()); err != nil {
+		e.logger.Info().Err(err).Str("symbol", event.Symbol).Msg("execution: exposure limit, skipping")
+		return nil
+	}
+
 	buyVol, err := e.qtyForExchange(qty, event.Symbol, event.BuyOnExchange)
 	if err != nil {
 		e.logger.Error().Err(err).Str("symbol", event.Symbol).Msg("execution: failed to convert buy qty")
@@ -105,16 +141,23 @@ func (e *Engine) openPosition(ctx context.Context, event *SpreadEvent) *Position
 	}
 
 	pos := &Position{
+		ID:           uuid.New(),
 		Symbol:       event.Symbol,
 		BuyExchange:  event.BuyOnExchange,
 		SellExchange: event.SellOnExchange,
 		QtyCoins:     qty,
+		Notional:     tradeNotional,
 		OpenBuyLeg:   Leg{OrderID: buyOrder.ID},
 		OpenSellLeg:  Leg{OrderID: sellOrder.ID},
 		State:        PositionStateOpening,
+		OpenSignalAt: e.clock.Now(),
+		clock:        e.clock,
 	}
 
 	e.pm.Add(pos)
+	e.saveTrade(pos)
+	e.reserveMargin(event.Symbol, event.BuyOnExchange, buyOrder.ID, qty, event.BuyPrice)
+	e.reserveMargin(event.Symbol, event.SellOnExchange, sellOrder.ID, qty, event.BuyPrice)
 
 	e.logger.Info().
 		Str("symbol", event.Symbol).
@@ -153,66 +196,108 @@ func (e *Engine) handleExecution(ctx context.Context, event exchange.OrderExecut
 	}
 
 	go e.markOrderFilled(ctx, event)
+	if !event.LeavesQty.IsPositive() {
+		e.releaseMargin(event.OrderID)
+	}
+
+	if pos.OnRebalanceFilled(event.OrderID, event.ExecPrice, event.ExecQty, event.LeavesQty) {
+		e.logger.Info().
+			Str("symbol", pos.Symbol).
+			Str("order_id", event.OrderID.String()).
+			Stringer("exec_qty", event.ExecQty).
+			Msg("⚖️ execution: rebalance order filled")
+		e.saveTrade(pos)
+		if !event.LeavesQty.IsPositive() {
+			e.finishRebalance(ctx, pos)
+		}
+		return
+	}
 
 	state := pos.GetState()
 
 	switch state {
 	case PositionStateOpening, PositionStateOpeningPendingClose:
 		if event.LeavesQty.IsPositive() {
-			e.logger.Warn().
+			e.logger.Info().
 				Str("symbol", pos.Symbol).
 				Str("order_id", event.OrderID.String()).
 				Stringer("exec_qty", event.ExecQty).
 				Stringer("leaves_qty", event.LeavesQty).
 				Stringer("order_qty", event.OrderQty).
-				Msg("⚠️ execution: PARTIAL FILL on open leg — model treats as FULLY confirmed, real position size is smaller")
+				Msg("execution: open leg partially filled, waiting for the rest")
+		} else if event.Canceled {
+			e.logger.Warn().
+				Str("symbol", pos.Symbol).
+				Str("order_id", event.OrderID.String()).
+				Stringer("exec_qty", event.ExecQty).
+				Stringer("order_qty", event.OrderQty).
+				Msg("⚠️ execution: open leg canceled by the exchange, confirmed at its filled qty")
+		} else {
+			e.logger.Info().
+				Str("symbol", pos.Symbol).
+				Str("order_id", event.OrderID.String()).
+				Stringer("exec_qty", event.ExecQty).
+				Msg("✅ execution: open leg confirmed")
 		}
-		e.logger.Info().
-			Str("symbol", pos.Symbol).
-			Str("order_id", event.OrderID.String()).
-			Stringer("exec_qty", event.ExecQty).
-			Msg("✅ execution: open leg confirmed")
 
-		transition, err := pos.OnOpenLegFilled(event.OrderID, event.ExecPrice, event.ExecQty)
+		transition, err := pos.OnOpenLegFilled(event.OrderID, event.ExecPrice, event.ExecQty, event.LeavesQty)
 		if err != nil {
 			e.logger.Warn().Err(err).Msg("handleExecution: OnOpenLegFilled")
 			return
 		}
+		e.saveTrade(pos)
 
 		if transition == TransitionNone && pos.GetState() == PositionStateOpen {
 			e.logger.Info().Str("symbol", pos.Symbol).Msg("✅ execution: position open")
+			// marked before the transition is applied, so a close signal can't size the close legs
+			// before the rebalance fill
+			if e.cfg.Exchange.ArbitrageBot.RebalanceLegs && pos.BeginRebalance() {
+				go e.rebalanceLegs(ctx, pos)
+			}
 		}
 
 		e.applyTransition(ctx, pos, transition)
 
 	case PositionStateClosing:
 		if event.LeavesQty.IsPositive() {
-			e.logger.Warn().
+			e.logger.Info().
 				Str("symbol", pos.Symbol).
 				Str("order_id", event.OrderID.String()).
 				Stringer("exec_qty", event.ExecQty).
 				Stringer("leaves_qty", event.LeavesQty).
 				Stringer("order_qty", event.OrderQty).
-				Msg("⚠️ execution: PARTIAL FILL on close leg — exchange may still have open exposure")
+				Msg("execution: close leg partially filled, waiting for the rest")
+		} else if event.Canceled {
+			// the rest of the leg stays open on the exchange, the first terminal status wins
+			e.logger.Error().
+				Str("symbol", pos.Symbol).
+				Str("order_id", event.OrderID.String()).
+				Stringer("exec_qty", event.ExecQty).
+				Stringer("order_qty", event.OrderQty).
+				Msg("⚠️ execution: close leg canceled by the exchange — VERIFY EXCHANGE for residual position")
+			pos.Finish(models.ArbitrageTradeStatusRecoveryRequired, errors.New("close leg canceled by the exchange"))
+			e.sendRecoveryNotification(pos.Symbol, "A close leg was canceled by the exchange before it filled, check the exchanges")
+		} else {
+			e.logger.Info().
+				Str("symbol", pos.Symbol).
+				Str("order_id", event.OrderID.String()).
+				Stringer("exec_qty", event.ExecQty).
+				Msg("✅ execution: close leg confirmed")
 		}
-		e.logger.Info().
-			Str("symbol", pos.Symbol).
-			Str("order_id", event.OrderID.String()).
-			Stringer("exec_qty", event.ExecQty).
-			Msg("✅ execution: close leg confirmed")
 
-		transition, err := pos.OnCloseLegFilled(event.OrderID, event.ExecPrice, event.ExecQty)
+		transition, err := pos.OnCloseLegFilled(event.OrderID, event.ExecPrice, event.ExecQty, event.LeavesQty)
 		if err != nil {
 			e.logger.Warn().Err(err).Msg("handleExecution: OnCloseLegFilled")
 			return
 		}
+		e.saveTrade(pos)
 
 		e.applyTransition(ctx, pos, transition)
 
 	case PositionStateOpen:
 		// Position is fully open and waiting for the close signal. A fill event here usually means
-		// the exchange retransmitted an open-leg event (or, very rarely, a partial-fill straggler
-		// from a leg already marked Confirmed). markOrderFilled has already been called at the top
+		// the exchange retransmitted an open-leg event (both legs are confirmed only when fully filled,
+		// so there are no partial-fill stragglers). markOrderFilled has already been called at the top
 		// of this function — that update is idempotent on the same orderID, so there's nothing more
 		// to do here. Logged at Debug so the operator can spot any unusual repeats without noise.
 		e.logger.Debug().
@@ -223,14 +308,19 @@ func (e *Engine) handleExecution(ctx context.Context, event exchange.OrderExecut
 	case PositionStateTimedOut:
 		// Emergency cleanup fill (from watchFillTimeout or cleanupAfterPartnerFailed). markOrderFilled
 		// has already been called at the top, so the DB row is updated with actual exec price/qty.
-		// We just log and wait for the delayed pm.Delete to clean up.
-		if event.LeavesQty.IsPositive() {
+		// The fill is kept on the close leg for the PnL counted when the delayed pm.Delete finishes
+		// the position.
+		if pos.OnEmergencyCloseFilled(event.OrderID, event.ExecPrice, event.ExecQty, event.LeavesQty) {
+			e.saveTrade(pos)
+		}
+		if event.LeavesQty.IsPositive() || event.Canceled {
 			e.logger.Warn().
 				Str("symbol", pos.Symbol).
 				Str("order_id", event.OrderID.String()).
 				Stringer("exec_qty", event.ExecQty).
 				Stringer("leaves_qty", event.LeavesQty).
 				Stringer("order_qty", event.OrderQty).
+				Bool("canceled", event.Canceled).
 				Msg("⚠️ execution: PARTIAL FILL on emergency close leg — verify exchange for residual position")
 		}
 		e.logger.Info().
@@ -256,15 +346,31 @@ func (e *Engine) applyTransition(ctx context.Context, pos *Position, t PositionT
 	case TransitionFullyClosed:
 		e.logger.Info().Str("symbol", pos.Symbol).Msg("💰 execution: position fully closed")
 		e.pm.Delete(pos)
+		e.saveTrade(pos)
+		e.recordClosed(pos)
 	}
 }
 
-// submitOpenLegs concurrently sends both open orders to their exchanges. On error in either leg it
+// submitOpenLegs sets the leverage of both venues and concurrently sends both open orders to their exchanges.
+// If the leverage can't be set, nothing is sent and the symbol is blacklisted; it's not a trading failure,
+// so it doesn't count toward the consecutive failures limit. On error in either leg it
 // blacklists the symbol, marks the spread Failed, and cleans up the partner via cleanupAfterPartnerFailed.
-// On full success it spawns a fill-timeout watcher if the strategy requires one.
+// On full success it spawns a fill-timeout watcher.
 func (e *Engine) submitOpenLegs(ctx context.Context, pos *Position, buyOrder, sellOrder models.Order) {
-	buyClient := e.clients[pos.BuyExchange]
-	sellClient := e.clients[pos.SellExchange]
+	buyClient := e.clientFor(pos.BuyExchange)
+	sellClient := e.clientFor(pos.SellExchange)
+
+	// nothing is sent until both venues have the configured leverage
+	if err := e.prepareLeverage(ctx, pos); err != nil {
+		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("execution: failed to set leverage, position not opened")
+		e.releaseMargin(buyOrder.ID)
+		e.releaseMargin(sellOrder.ID)
+		e.blacklist(pos, "", err.Error())
+		pos.Finish(models.ArbitrageTradeStatusFailed, err)
+		e.saveTrade(pos)
+		go e.markSpreadFailed(ctx, pos)
+		return
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -281,6 +387,7 @@ func (e *Engine) submitOpenLegs(ctx context.Context, pos *Position, buyOrder, se
 		if buyErr != nil {
 			// order was saved before CreateOrder — mark it rejected so DB reflects reality
 			e.markOrderRejected(ctx, buyOrder.ID)
+			e.releaseMargin(buyOrder.ID)
 		} else {
 			// store exchange order ID for cancel support (needed by MEXC)
 			pos.SetOpenLegExchangeOrderID(buyOrder.ID, buyOrder.ExchangeOrderID)
@@ -292,6 +399,7 @@ func (e *Engine) submitOpenLegs(ctx context.Context, pos *Position, buyOrder, se
 		sellErr = sellClient.CreateOrder(ctx, &sellOrder)
 		if sellErr != nil {
 			e.markOrderRejected(ctx, sellOrder.ID)
+			e.releaseMargin(sellOrder.ID)
 		} else {
 			pos.SetOpenLegExchangeOrderID(sellOrder.ID, sellOrder.ExchangeOrderID)
 		}
@@ -300,10 +408,10 @@ func (e *Engine) submitOpenLegs(ctx context.Context, pos *Position, buyOrder, se
 	wg.Wait()
 
 	if buyErr == nil && sellErr == nil {
-		// both legs submitted — start fill timeout watcher for limit orders
-		if timeout := e.strategy.FillTimeout(); timeout > 0 {
-			go e.watchFillTimeout(ctx, pos, timeout)
-		}
+		// exchange order IDs are needed to cancel the legs after restart
+		e.saveTrade(pos)
+		// both legs submitted — start fill timeout watcher, market legs may be canceled or never reported too
+		go e.watchFillTimeout(ctx, pos, e.strategy.FillTimeout())
 		return
 	}
 
@@ -313,8 +421,21 @@ func (e *Engine) submitOpenLegs(ctx context.Context, pos *Position, buyOrder, se
 		Str("symbol", pos.Symbol).
 		Msg("execution: failed to submit open legs")
 
-	e.pm.Blacklist(pos)
-	e.logger.Warn().Str("symbol", pos.Symbol).Msg("execution: symbol blacklisted after failed open")
+	// the ban is limited to the exchange whose leg failed
+	switch {
+	case buyErr != nil && sellErr != nil:
+		e.blacklist(pos, "", errors.Join(buyErr, sellErr).Error())
+	case buyErr != nil:
+		e.blacklist(pos, pos.BuyExchange, buyErr.Error())
+	default:
+		e.blacklist(pos, pos.SellExchange, sellErr.Error())
+	}
+
+	if buyErr != nil && sellErr != nil {
+		// nothing reached the exchange, no cleanup
+		e.finishPosition(pos, models.ArbitrageTradeStatusFailed, errors.Join(buyErr, sellErr))
+	}
+	e.saveTrade(pos)
 
 	go e.markSpreadFailed(ctx, pos)
 
@@ -353,7 +474,7 @@ func (e *Engine) cleanupAfterPartnerFailed(ctx context.Context, client exchange.
 		}
 
 		// (1) still unfilled — try cancel.
-		if err := client.CancelOrder(ctx, order.ID, order.ExchangeOrderID, pos.Symbol); err != nil {
+		if err := client.CancelOrder(ctx, order.ID, order.ExchangeOrderID, pos.Symbol, categoryOf(order.Market)); err != nil {
 			// Race: limit may have filled between the IsOpenLegConfirmed check and the cancel
 			// call. Re-check confirmation; if now true, fall through to emergency close.
 			if pos.IsOpenLegConfirmed(side) {
@@ -372,9 +493,13 @@ func (e *Engine) cleanupAfterPartnerFailed(ctx context.Context, client exchange.
 				Str("exchange", exchangeName).
 				Str("side", string(side)).
 				Msg("⚠️ partner-failed: cancel of posted limit failed and leg is NOT confirmed — VERIFY EXCHANGE for stuck order or open position")
+			e.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, err)
+			e.saveTrade(pos)
 			return
 		}
 		e.markOrderCanceled(ctx, order.ID)
+		e.finishPosition(pos, models.ArbitrageTradeStatusFailed, nil)
+		e.saveTrade(pos)
 		e.logger.Info().
 			Str("symbol", pos.Symbol).
 			Str("exchange", exchangeName).
@@ -389,21 +514,24 @@ func (e *Engine) cleanupAfterPartnerFailed(ctx context.Context, client exchange.
 
 // submitCloseLegs concurrently sends close orders for both legs of an open position.
 func (e *Engine) submitCloseLegs(ctx context.Context, pos *Position) {
-	buyClient := e.clients[pos.BuyExchange]
-	sellClient := e.clients[pos.SellExchange]
+	buyClient := e.clientFor(pos.BuyExchange)
+	sellClient := e.clientFor(pos.SellExchange)
 
 	// helper: on any preparation error, delete the position so the slot is freed
 	fail := func(msg string, err error) {
 		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Msg(msg)
 		e.pm.Delete(pos)
+		// both legs are still open on the exchanges
+		e.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, err)
+		e.saveTrade(pos)
 	}
 
-	buyVol, err := e.qtyForExchange(pos.QtyCoins, pos.Symbol, pos.BuyExchange)
+	buyVol, err := e.closeQty(ctx, pos, models.OrderSideBuy)
 	if err != nil {
 		fail("execution: failed to convert close buy qty", err)
 		return
 	}
-	sellVol, err := e.qtyForExchange(pos.QtyCoins, pos.Symbol, pos.SellExchange)
+	sellVol, err := e.closeQty(ctx, pos, models.OrderSideSell)
 	if err != nil {
 		fail("execution: failed to convert close sell qty", err)
 		return
@@ -427,6 +555,7 @@ func (e *Engine) submitCloseLegs(ctx context.Context, pos *Position) {
 	closeSellLeg := Leg{OrderID: sellOrder.ID}
 	pos.SetCloseLegIDs(closeBuyLeg, closeSellLeg)
 	e.pm.IndexCloseLeg(pos, buyOrder.ID, sellOrder.ID)
+	e.saveTrade(pos)
 
 	// save orders before CloseOrder so execution events can always find them in DB
 	e.saveOrder(&buyOrder)
@@ -454,11 +583,24 @@ func (e *Engine) submitCloseLegs(ctx context.Context, pos *Position) {
 	e.logger.Info().Str("symbol", pos.Symbol).Msg("🔻 execution: close orders submitted, waiting for confirmations")
 
 	go e.saveCloseOrderIDsToSpread(ctx, pos, buyOrder.ID, sellOrder.ID)
+	go e.watchCloseFill(ctx, pos)
 }
 
-// emergencyClose is the action for TransitionEmergencyClose — same cleanup as fill timeout with no legs filled.
+// emergencyClose is the action for TransitionEmergencyClose — an open leg ended with nothing filled, so the
+// position is cleaned up the same way as on fill timeout without waiting for it.
 func (e *Engine) emergencyClose(ctx context.Context, pos *Position) {
-	e.logger.Warn().Str("symbol", pos.Symbol).Msg("execution: emergency close")
-	e.pm.Delete(pos)
-	go e.markSpreadFailed(ctx, pos)
+	shouldAct, info := pos.OnOpenTimeout()
+	if !shouldAct {
+		return // already cleaned up by an earlier event or the fill timeout watcher
+	}
+	e.saveTrade(pos)
+
+	e.logger.Warn().
+		Str("symbol", pos.Symbol).
+		Bool("buy_filled", info.BuyFilled).
+		Bool("sell_filled", info.SellFilled).
+		Msg("execution: emergency close, an open leg ended with nothing filled")
+
+	e.handleFillTimeout(ctx, pos, info)
+	e.scheduleDelayedDelete(ctx, pos)
 }
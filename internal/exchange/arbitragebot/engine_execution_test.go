@@ -0,0 +1,265 @@
+package arbitragebot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func TestEngine_HandleExecution_PartialFills(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().CloseOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Side == models.OrderSideBuy && order.Quantity.Equal(decimal.NewFromInt(2))
+	})).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().CloseOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Side == models.OrderSideSell && order.Quantity.Equal(decimal.NewFromInt(19))
+	})).Return(nil).Once()
+
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1")}},
+	}
+
+	pos := &Position{
+		ID:           uuid.New(),
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: uuid.New()},
+		OpenSellLeg:  Leg{OrderID: uuid.New()},
+		State:        PositionStateOpening,
+	}
+	engine.pm.Add(pos)
+
+	execution := func(orderID uuid.UUID, price, qty, leaves int64) exchange.OrderExecutionEvent {
+		return exchange.OrderExecutionEvent{
+			OrderID:   orderID,
+			ExecPrice: decimal.NewFromInt(price),
+			ExecQty:   decimal.NewFromInt(qty),
+			LeavesQty: decimal.NewFromInt(leaves),
+		}
+	}
+
+	engine.handleExecution(ctx, execution(pos.OpenBuyLeg.OrderID, 100, 1, 1))
+	assert.False(t, pos.IsOpenLegConfirmed(models.OrderSideBuy))
+	assert.Equal(t, "1", pos.OpenLegFilledQty(models.OrderSideBuy).String())
+
+	engine.handleExecution(ctx, execution(pos.OpenBuyLeg.OrderID, 101, 2, 0))
+	// retransmitted partial fill doesn't shrink the leg
+	engine.handleExecution(ctx, execution(pos.OpenBuyLeg.OrderID, 100, 1, 1))
+	assert.True(t, pos.IsOpenLegConfirmed(models.OrderSideBuy))
+	assert.Equal(t, "2", pos.OpenLegFilledQty(models.OrderSideBuy).String())
+	assert.Equal(t, "101", pos.OpenBuyLeg.AvgPrice.String())
+
+	// the sell leg order was cut by the exchange: 19 contracts of 20 filled
+	engine.handleExecution(ctx, execution(pos.OpenSellLeg.OrderID, 103, 19, 0))
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+
+	// each leg is closed by its own filled qty
+	require.Equal(t, TransitionSubmitClose, pos.RequestClose())
+	engine.submitCloseLegs(ctx, pos)
+}
+
+func TestEngine_HandleFillTimeout_ClosesPartialFill(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().CancelOrder(ctx, mock.Anything, "b1", "BTCUSDT", exchange.CategoryLinear).Return(nil).Once()
+
+	done := make(chan struct{})
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().CancelOrder(ctx, mock.Anything, "s1", "BTCUSDT", exchange.CategoryLinear).Return(nil).Once()
+	mexc.EXPECT().CloseOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Side == models.OrderSideSell && order.Quantity.Equal(decimal.NewFromInt(7))
+	})).Run(func(_ context.Context, _ *models.Order) { close(done) }).Return(nil).Once()
+
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"MEXC": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1")}},
+	}
+
+	pos := &Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: uuid.New(), ExchangeOrderID: "b1"},
+		OpenSellLeg:  Leg{OrderID: uuid.New(), ExchangeOrderID: "s1"},
+		State:        PositionStateOpening,
+	}
+	engine.pm.Add(pos)
+
+	_, err := pos.OnOpenLegFilled(pos.OpenSellLeg.OrderID, decimal.NewFromInt(103), decimal.NewFromInt(7), decimal.NewFromInt(13))
+	require.NoError(t, err)
+
+	shouldAct, info := pos.OnOpenTimeout()
+	require.True(t, shouldAct)
+	engine.handleFillTimeout(ctx, pos, info)
+
+	// the rest of the sell leg is cancelled, the filled 7 contracts are closed, nothing to close on the buy side
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("partial fill is not closed")
+	}
+}
+
+func TestEngine_HandleExecution_CanceledOpenLeg(t *testing.T) {
+	ctx := t.Context()
+
+	engine := NewEngine(getConfig(), nil, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	pos := &Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: uuid.New()},
+		OpenSellLeg:  Leg{OrderID: uuid.New()},
+		State:        PositionStateOpening,
+	}
+	engine.pm.Add(pos)
+
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.OpenBuyLeg.OrderID,
+		ExecPrice: decimal.NewFromInt(100),
+		ExecQty:   decimal.NewFromInt(2),
+		LeavesQty: decimal.Zero,
+	})
+	// the IOC sell leg ran out of liquidity: 15 contracts of 20 filled, the rest expired
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.OpenSellLeg.OrderID,
+		ExecPrice: decimal.NewFromInt(103),
+		ExecQty:   decimal.NewFromInt(15),
+		LeavesQty: decimal.Zero,
+		OrderQty:  decimal.NewFromInt(20),
+		Canceled:  true,
+	})
+
+	assert.True(t, pos.IsOpenLegConfirmed(models.OrderSideSell))
+	assert.Equal(t, "15", pos.OpenLegFilledQty(models.OrderSideSell).String())
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+}
+
+func TestEngine_HandleExecution_CanceledEmptyOpenLeg(t *testing.T) {
+	ctx := t.Context()
+
+	done := make(chan struct{})
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().CloseOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Side == models.OrderSideBuy && order.Quantity.Equal(decimal.NewFromInt(2))
+	})).Run(func(_ context.Context, _ *models.Order) { close(done) }).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}},
+	}
+
+	pos := &Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: uuid.New()},
+		OpenSellLeg:  Leg{OrderID: uuid.New()},
+		State:        PositionStateOpening,
+	}
+	engine.pm.Add(pos)
+
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.OpenBuyLeg.OrderID,
+		ExecPrice: decimal.NewFromInt(100),
+		ExecQty:   decimal.NewFromInt(2),
+		LeavesQty: decimal.Zero,
+	})
+	// the sell leg was canceled with nothing filled: the filled buy leg is flattened, nothing to do on MEXC
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.OpenSellLeg.OrderID,
+		ExecQty:   decimal.Zero,
+		LeavesQty: decimal.Zero,
+		OrderQty:  decimal.NewFromInt(20),
+		Canceled:  true,
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("filled leg is not closed")
+	}
+	assert.Equal(t, PositionStateTimedOut, pos.GetState())
+}
+
+func TestEngine_SubmitOpenLegs_MarketFillTimeout(t *testing.T) {
+	ctx := t.Context()
+
+	canceled := make(chan struct{}, 2)
+	cancel := func(_ context.Context, _ uuid.UUID, _ string, _ string, _ exchange.Category) {
+		canceled <- struct{}{}
+	}
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().CreateOrder(ctx, mock.Anything).Return(nil).Once()
+	bybit.EXPECT().CancelOrder(ctx, mock.Anything, mock.Anything, "BTCUSDT", exchange.CategoryLinear).Run(cancel).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().CreateOrder(ctx, mock.Anything).Return(nil).Once()
+	mexc.EXPECT().CancelOrder(ctx, mock.Anything, mock.Anything, "BTCUSDT", exchange.CategoryLinear).Run(cancel).Return(nil).Once()
+
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	clk := clock.NewManual(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	engine.SetClock(clk)
+
+	buyOrder := models.Order{ID: uuid.New(), Symbol: "BTCUSDT", Market: models.OrderMarketLinear, Type: models.OrderTypeMarket}
+	sellOrder := models.Order{ID: uuid.New(), Symbol: "BTCUSDT", Market: models.OrderMarketLinear, Type: models.OrderTypeMarket}
+	pos := &Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: buyOrder.ID},
+		OpenSellLeg:  Leg{OrderID: sellOrder.ID},
+		State:        PositionStateOpening,
+	}
+	engine.pm.Add(pos)
+
+	engine.submitOpenLegs(ctx, pos, buyOrder, sellOrder)
+
+	// no execution event arrives for the market legs: the watcher cancels whatever is left of them
+	require.Eventually(t, func() bool {
+		clk.Advance(marketFillTimeout)
+		return pos.GetState() == PositionStateTimedOut
+	}, time.Second, 10*time.Millisecond)
+	for range 2 {
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("pending leg is not canceled")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package arbitragebot
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,10 @@ import (
 // arrived. Long enough for ByBit/MEXC to send fills via WS in normal conditions.
 const emergencyCloseTrackingWindow = 10 * time.Second
 
+// closeFillTimeout is how long close legs wait for fills. They are market orders, so a position still
+// Closing after it lost its fills or a close order failed.
+const closeFillTimeout = 30 * time.Second
+
 // watchFillTimeout waits for the fill timeout and triggers cleanup if legs haven't filled.
 // Atomicity is guaranteed by Position.OnOpenTimeout — it transitions the position to TimedOut
 // under the position's mutex and returns false on subsequent calls, so this watcher won't race
@@ -27,13 +32,15 @@ func (e *Engine) watchFillTimeout(ctx context.Context, pos *Position, timeout ti
 	select {
 	case <-ctx.Done():
 		return
-	case <-time.After(timeout):
+	case <-e.clock.After(timeout):
 	}
 
 	shouldAct, info := pos.OnOpenTimeout()
 	if !shouldAct {
 		return // position already transitioned normally (e.g. both legs filled in time)
 	}
+	e.fillTimeouts.Add(1)
+	e.saveTrade(pos)
 
 	e.logger.Warn().
 		Str("symbol", pos.Symbol).
@@ -53,24 +60,20 @@ func (e *Engine) scheduleDelayedDelete(ctx context.Context, pos *Position) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(emergencyCloseTrackingWindow):
+		case <-e.clock.After(emergencyCloseTrackingWindow):
 		}
 		e.pm.Delete(pos)
+		// the open failed and the filled legs were flattened, unless the cleanup already asked for a manual check
+		e.finishPosition(pos, models.ArbitrageTradeStatusFailed, nil)
+		e.saveTrade(pos)
 	}()
 }
 
-// handleFillTimeout cancels still-pending legs and emergency-closes any filled legs.
+// handleFillTimeout cancels still-pending legs and emergency-closes any filled legs. A partially filled
+// leg is cancelled first and then its filled qty is emergency-closed.
 func (e *Engine) handleFillTimeout(ctx context.Context, pos *Position, info OpenTimeoutInfo) {
-	buyClient := e.clients[pos.BuyExchange]
-	sellClient := e.clients[pos.SellExchange]
-
-	// cancel whichever leg is still pending
-	if !info.BuyFilled {
-		go e.cancelPendingLeg(ctx, buyClient, pos.Symbol, pos.BuyExchange, models.OrderSideBuy, info.BuyOrderID, info.BuyExchangeOrderID)
-	}
-	if !info.SellFilled {
-		go e.cancelPendingLeg(ctx, sellClient, pos.Symbol, pos.SellExchange, models.OrderSideSell, info.SellOrderID, info.SellExchangeOrderID)
-	}
+	buyClient := e.clientFor(pos.BuyExchange)
+	sellClient := e.clientFor(pos.SellExchange)
 
 	// market-close whichever leg was already filled to neutralize the position
 	if info.BuyFilled {
@@ -80,6 +83,24 @@ func (e *Engine) handleFillTimeout(ctx context.Context, pos *Position, info Open
 		go e.emergencyCloseLeg(ctx, sellClient, pos, models.OrderSideSell, pos.SellExchange)
 	}
 
+	// cancel whichever leg is still pending
+	if !info.BuyFilled {
+		go func() {
+			e.cancelPendingLeg(ctx, buyClient, pos.Symbol, pos.BuyExchange, models.OrderSideBuy, info.BuyOrderID, info.BuyExchangeOrderID)
+			if pos.OpenLegFilledQty(models.OrderSideBuy).IsPositive() {
+				e.emergencyCloseLeg(ctx, buyClient, pos, models.OrderSideBuy, pos.BuyExchange)
+			}
+		}()
+	}
+	if !info.SellFilled {
+		go func() {
+			e.cancelPendingLeg(ctx, sellClient, pos.Symbol, pos.SellExchange, models.OrderSideSell, info.SellOrderID, info.SellExchangeOrderID)
+			if pos.OpenLegFilledQty(models.OrderSideSell).IsPositive() {
+				e.emergencyCloseLeg(ctx, sellClient, pos, models.OrderSideSell, pos.SellExchange)
+			}
+		}()
+	}
+
 	go e.markSpreadFailed(ctx, pos)
 }
 
@@ -87,13 +108,16 @@ func (e *Engine) handleFillTimeout(ctx context.Context, pos *Position, info Open
 // On failure (e.g. order already filled or already cancelled), logs a warning — the order's DB row
 // stays at its previous status and the operator may need to verify on the exchange manually.
 func (e *Engine) cancelPendingLeg(ctx context.Context, client exchange.Provider, symbol, exchangeName string, side models.OrderSide, orderID uuid.UUID, exchangeOrderID string) {
-	if err := client.CancelOrder(ctx, orderID, exchangeOrderID, symbol); err != nil {
+	if err := client.CancelOrder(ctx, orderID, exchangeOrderID, symbol, parseVenue(exchangeName).Category); err != nil {
 		e.logger.Error().
 			Err(err).
 			Str("symbol", symbol).
 			Str("exchange", exchangeName).
 			Str("side", string(side)).
 			Msg("⚠️ timeout: failed to cancel pending leg — check exchange for stuck order")
+		if pos := e.pm.FindByOrderID(orderID); pos != nil {
+			e.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, err)
+		}
 		return
 	}
 	e.logger.Info().
@@ -102,9 +126,10 @@ func (e *Engine) cancelPendingLeg(ctx context.Context, client exchange.Provider,
 		Str("side", string(side)).
 		Msg("✅ timeout: pending limit cancelled")
 	e.markOrderCanceled(ctx, orderID)
+	e.releaseMargin(orderID)
 }
 
-// emergencyCloseLeg market-closes a leg that already filled, after its partner timed out without filling.
+// emergencyCloseLeg market-closes a leg that already (partially) filled, after its partner timed out without filling.
 // Thin wrapper around submitEmergencyClose with timeout-specific log messages.
 func (e *Engine) emergencyCloseLeg(ctx context.Context, client exchange.Provider, pos *Position, side models.OrderSide, exchangeName string) {
 	e.submitEmergencyClose(ctx, client, pos, side, exchangeName, "timeout: filled leg emergency market-closed after partner failed to fill")
@@ -128,7 +153,14 @@ func (e *Engine) submitEmergencyClose(
 	exchangeName string,
 	successMsg string,
 ) {
-	vol, err := e.qtyForExchange(pos.QtyCoins, pos.Symbol, exchangeName)
+	if pos.IsOpenLegConfirmed(side) && !pos.OpenLegFilledQty(side).IsPositive() {
+		// the exchange canceled the leg with nothing filled, there is no position to flatten
+		pos.MarkCleanup()
+		e.scheduleDelayedDelete(ctx, pos)
+		return
+	}
+
+	vol, err := e.closeQty(ctx, pos, side)
 	if err != nil {
 		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Str("side", string(side)).Msg("⚠️ emergency close: failed to convert qty — leg may be unhedged on exchange")
 		return
@@ -161,6 +193,7 @@ func (e *Engine) submitEmergencyClose(
 			Str("side", string(side)).
 			Msg("⚠️ emergency close FAILED — VERIFY EXCHANGE for open position")
 		e.markOrderRejected(ctx, closeOrder.ID)
+		e.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, err)
 		// still schedule the delete — otherwise the close-leg orderID stays in pm.byOrderID forever
 		e.scheduleDelayedDelete(ctx, pos)
 		return
@@ -176,3 +209,23 @@ func (e *Engine) submitEmergencyClose(
 	// watchFillTimeout — pm.Delete is idempotent, double-call is harmless.
 	e.scheduleDelayedDelete(ctx, pos)
 }
+
+// watchCloseFill waits for the close legs of the position. If they are not confirmed in closeFillTimeout,
+// the position is removed and marked RECOVERY_REQUIRED, the reconciler reports its legs if they are still open.
+func (e *Engine) watchCloseFill(ctx context.Context, pos *Position) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-e.clock.After(closeFillTimeout):
+	}
+
+	if pos.GetState() != PositionStateClosing || e.pm.FindByKey(pos.Symbol, pos.BuyExchange, pos.SellExchange) != pos {
+		return
+	}
+
+	e.logger.Error().Str("symbol", pos.Symbol).Msg("⚠️ execution: close legs are not confirmed — VERIFY EXCHANGE")
+	e.pm.Delete(pos)
+	e.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, errors.New("close legs not confirmed in time"))
+	e.saveTrade(pos)
+	e.sendRecoveryNotification(pos.Symbol, "Close legs of the position are not confirmed, check the exchanges")
+}
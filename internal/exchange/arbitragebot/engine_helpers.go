@@ -1,6 +1,7 @@
 package arbitragebot
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/shopspring/decimal"
@@ -23,7 +24,9 @@ func (e *Engine) coinStep(symbol, exchange1, exchange2 string) (decimal.Decimal,
 }
 
 func (e *Engine) instrumentFor(symbol, exchangeName string) (exchange.Instrument, error) {
+	e.instrumentsMu.RLock()
 	instruments, ok := e.instruments[exchangeName]
+	e.instrumentsMu.RUnlock()
 	if !ok {
 		return exchange.Instrument{}, fmt.Errorf("no instrument data for exchange %s", exchangeName)
 	}
@@ -43,17 +46,30 @@ func (e *Engine) qtyForExchange(qty decimal.Decimal, symbol, exchangeName string
 	return qty.Div(inst.ContractSize), nil
 }
 
+// filledCoins converts the filled qty of the order (the ordered one if the fill was not recorded) from
+// exchange units to coins.
+func (e *Engine) filledCoins(order *models.Order) (decimal.Decimal, error) {
+	venueName := Venue{Exchange: order.ExchangeName, Category: categoryOf(order.Market)}.Name()
+	inst, err := e.instrumentFor(order.Symbol, venueName)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return filledQty(order).Mul(inst.ContractSize), nil
+}
+
 // buildOrder constructs a models.Order. price=nil → market order; price!=nil → limit order with
 // price aligned to the exchange's PriceStep (so the exchange doesn't reject for an invalid tick).
+// exchangeName is the venue name, the order market follows the venue category.
 func (e *Engine) buildOrder(symbol string, side models.OrderSide, qty decimal.Decimal, exchangeName string, price *decimal.Decimal) (models.Order, error) {
 	orderType := models.OrderTypeMarket
+	venue := parseVenue(exchangeName)
 	dto := exchange.CreateOrderDto{
 		Symbol:       symbol,
 		Side:         side,
 		Type:         orderType,
-		Market:       models.OrderMarketLinear,
+		Market:       venue.Category.OrderMarket(),
 		Quantity:     qty,
-		ExchangeName: exchangeName,
+		ExchangeName: venue.Exchange,
 	}
 	if price != nil {
 		aligned, err := e.alignPriceToInstrument(*price, symbol, exchangeName)
@@ -70,6 +86,60 @@ func (e *Engine) buildOrder(symbol string, side models.OrderSide, qty decimal.De
 	return exchange.MakeOrderStruct(dto)
 }
 
+// closeQty returns the vol closing the open leg of the side. The leg is closed by its own filled qty, so
+// the position stays delta-neutral even when the two legs filled different quantities. A leg without fills
+// (market order whose execution event hasn't arrived yet) is closed by QtyCoins. Spot buy fees are charged
+// in the base coin, so less than filled is held on a spot venue: the close sell is limited
+// by the free base coin balance fetched from the exchange and floored to the VolStep.
+func (e *Engine) closeQty(ctx context.Context, pos *Position, side models.OrderSide) (decimal.Decimal, error) {
+	exchangeName := pos.BuyExchange
+	if side == models.OrderSideSell {
+		exchangeName = pos.SellExchange
+	}
+
+	vol := pos.OpenLegFilledQty(side)
+	if !vol.IsPositive() {
+		var err error
+		vol, err = e.qtyForExchange(pos.QtyCoins, pos.Symbol, exchangeName)
+		if err != nil {
+			return decimal.Zero, err
+		}
+	}
+	if !parseVenue(exchangeName).IsSpot() {
+		return vol, nil
+	}
+
+	inst, err := e.instrumentFor(pos.Symbol, exchangeName)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	balances, err := e.clientFor(exchangeName).GetBalances(ctx, exchange.CategorySpot)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get spot balances on %s: %w", exchangeName, err)
+	}
+
+	free := decimal.Zero
+	for _, b := range balances {
+		if b.Asset == baseAsset(pos.Symbol) {
+			free = b.Free
+			break
+		}
+	}
+
+	if free.LessThan(vol) {
+		vol = free
+	}
+	if inst.VolStep.IsPositive() {
+		vol = vol.Div(inst.VolStep).Floor().Mul(inst.VolStep)
+	}
+	if !vol.IsPositive() {
+		return decimal.Zero, fmt.Errorf("no %s balance to close on %s", baseAsset(pos.Symbol), exchangeName)
+	}
+
+	return vol, nil
+}
+
 // alignPriceToInstrument rounds price to the nearest multiple of the instrument's PriceStep
 // using half-up rounding. Returns price unchanged if PriceStep is zero or non-positive.
 func (e *Engine) alignPriceToInstrument(price decimal.Decimal, symbol, exchangeName string) (decimal.Decimal, error) {
@@ -83,9 +153,9 @@ func (e *Engine) alignPriceToInstrument(price decimal.Decimal, symbol, exchangeN
 	return price.Div(inst.PriceStep).Round(0).Mul(inst.PriceStep), nil
 }
 
-// notional returns the trade size in USDT. Hardcoded for now, will come from config.
-func (e *Engine) notional() int64 {
-	return 10
+// notional returns the trade size in USDT.
+func (e *Engine) notional() decimal.Decimal {
+	return e.risk.notional()
 }
 
 func (e *Engine) isSilentMode() bool {
@@ -0,0 +1,126 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// SetAccountMarginModes sets the configured margin mode of the exchanges where it applies to the whole
+// account. Called once at startup, before any position is opened. The account mode is changed only when
+// account_margin_mode is on, never in silent mode.
+func (e *Engine) SetAccountMarginModes(ctx context.Context) error {
+	if !e.cfg.Exchange.ArbitrageBot.AccountMarginMode || e.isSilentMode() {
+		return nil
+	}
+	mode := e.marginMode()
+	if mode == "" {
+		return nil
+	}
+	for name, client := range e.clients {
+		// the account setting has no symbol, the alias wrapper is skipped
+		if aliased, ok := client.(*aliasedProvider); ok {
+			client = aliased.Provider
+		}
+		setter, ok := client.(exchange.AccountMarginModeSetter)
+		if !ok {
+			continue
+		}
+		if err := setter.SetAccountMarginMode(ctx, mode); err != nil {
+			return fmt.Errorf("failed to set %s margin mode on %s: %w", mode, name, err)
+		}
+		e.logger.Info().Str("exchange", name).Str("margin_mode", string(mode)).Msg("execution: account margin mode set")
+	}
+	return nil
+}
+
+// prepareLeverage sets the margin mode and leverage of the position symbol on both linear venues
+// before the open legs are submitted.
+func (e *Engine) prepareLeverage(ctx context.Context, pos *Position) error {
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+
+	for i, venueName := range []string{pos.BuyExchange, pos.SellExchange} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = e.ensureLeverage(ctx, pos.Symbol, venueName)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// ensureLeverage sets the configured margin mode and leverage of the symbol on the venue once, what has
+// been set is cached until restart. What is not configured is left as is on the exchange. Spot venues are
+// not leveraged.
+func (e *Engine) ensureLeverage(ctx context.Context, symbol, venueName string) error {
+	if parseVenue(venueName).IsSpot() {
+		return nil
+	}
+
+	mode := e.marginMode()
+	leverage := e.configuredLeverage(symbol)
+	if mode == "" && leverage == 0 {
+		return nil
+	}
+	key := venueName + "#" + symbol
+
+	e.leverageMu.Lock()
+	applied, ok := e.leverageSet[key]
+	e.leverageMu.Unlock()
+	if ok && applied == leverage {
+		return nil
+	}
+
+	client := e.clientFor(venueName)
+	if client == nil {
+		return fmt.Errorf("no client for venue %s", venueName)
+	}
+
+	if mode != "" {
+		if err := client.SetMarginMode(ctx, symbol, mode); err != nil {
+			return fmt.Errorf("failed to set %s margin mode of %s on %s: %w", mode, symbol, venueName, err)
+		}
+	}
+	if leverage > 0 {
+		if err := client.SetLeverage(ctx, symbol, leverage); err != nil {
+			return fmt.Errorf("failed to set %dx leverage of %s on %s: %w", leverage, symbol, venueName, err)
+		}
+	}
+
+	e.leverageMu.Lock()
+	e.leverageSet[key] = leverage
+	e.leverageMu.Unlock()
+
+	e.logger.Info().
+		Str("symbol", symbol).
+		Str("exchange", venueName).
+		Str("margin_mode", string(mode)).
+		Int64("leverage", leverage).
+		Msg("execution: leverage set")
+
+	return nil
+}
+
+// marginMode returns the configured margin mode, empty if the exchange setting is left as is.
+func (e *Engine) marginMode() exchange.MarginMode {
+	return exchange.MarginMode(e.cfg.Exchange.ArbitrageBot.MarginMode)
+}
+
+// configuredLeverage returns the configured leverage of the symbol, 0 if the exchange setting is left as is.
+func (e *Engine) configuredLeverage(symbol string) int64 {
+	if override, ok := e.cfg.Exchange.ArbitrageBot.SymbolLeverage[symbol]; ok {
+		return override
+	}
+	return e.cfg.Exchange.ArbitrageBot.Leverage
+}
+
+// symbolLeverage returns the leverage of the symbol trades are sized with, at least 1.
+func (e *Engine) symbolLeverage(symbol string) int64 {
+	return max(e.configuredLeverage(symbol), 1)
+}
@@ -0,0 +1,131 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func TestEngine_EnsureLeverage(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().SetMarginMode(ctx, "BTCUSDT", exchange.MarginModeIsolated).Return(nil).Once()
+	bybit.EXPECT().SetLeverage(ctx, "BTCUSDT", int64(3)).Return(nil).Once()
+	bybit.EXPECT().SetMarginMode(ctx, "ETHUSDT", exchange.MarginModeIsolated).Return(nil).Once()
+	bybit.EXPECT().SetLeverage(ctx, "ETHUSDT", int64(2)).Return(errors.New("leverage exceeds the limit")).Once()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Leverage = 2
+	cfg.Exchange.ArbitrageBot.SymbolLeverage = map[string]int64{"BTCUSDT": 3}
+	cfg.Exchange.ArbitrageBot.MarginMode = "isolated"
+	engine := NewEngine(cfg, []exchange.Provider{bybit}, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+
+	require.NoError(t, engine.ensureLeverage(ctx, "BTCUSDT", "ByBit"))
+	// cached, the exchange is not called again
+	require.NoError(t, engine.ensureLeverage(ctx, "BTCUSDT", "ByBit"))
+	// spot is not leveraged
+	require.NoError(t, engine.ensureLeverage(ctx, "BTCUSDT", "ByBit:spot"))
+
+	assert.ErrorContains(t, engine.ensureLeverage(ctx, "ETHUSDT", "ByBit"), "failed to set 2x leverage of ETHUSDT on ByBit")
+	assert.NotContains(t, engine.leverageSet, "ByBit#ETHUSDT")
+}
+
+func TestEngine_EnsureLeverage_NotConfigured(t *testing.T) {
+	ctx := t.Context()
+
+	// no SetMarginMode / SetLeverage expected: the mock fails the test if the exchange setting is changed
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit}, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+
+	require.NoError(t, engine.ensureLeverage(ctx, "BTCUSDT", "ByBit"))
+	// trades are sized without leverage
+	assert.Equal(t, "1", engine.leverage("BTCUSDT", "ByBit").String())
+}
+
+func TestEngine_SubmitOpenLegs_LeverageFailed(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().SetMarginMode(ctx, "BTCUSDT", exchange.MarginModeCross).Return(nil).Once()
+	bybit.EXPECT().SetLeverage(ctx, "BTCUSDT", int64(1)).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().SetMarginMode(ctx, "BTCUSDT", exchange.MarginModeCross).Return(errors.New("maintenance")).Once()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Risk.MaxConsecutiveFailures = 1
+	cfg.Exchange.ArbitrageBot.Leverage = 1
+	cfg.Exchange.ArbitrageBot.MarginMode = "cross"
+	engine := NewEngine(cfg, []exchange.Provider{bybit, mexc}, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+
+	pos := &Position{Symbol: "BTCUSDT", BuyExchange: "ByBit", SellExchange: "MEXC", State: PositionStateOpening}
+	engine.pm.Add(pos)
+
+	// no CreateOrder expected: the mocks fail the test if an order is sent
+	engine.submitOpenLegs(ctx, pos, models.Order{ID: uuid.New()}, models.Order{ID: uuid.New()})
+
+	assert.True(t, engine.pm.IsBlacklisted("BTCUSDT", "ByBit", "MEXC", time.Now()))
+	assert.Empty(t, engine.pm.Positions())
+	assert.Equal(t, models.ArbitrageTradeStatusFailed, pos.toTrade().Status)
+	// a setup error is not a trading failure, the kill-switch is not tripped
+	assert.NoError(t, engine.risk.canOpen(0))
+}
+
+func TestEngine_SetAccountMarginModes(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := &accountMarginProvider{MockProvider: exchangeMocks.NewMockProvider(t)}
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+
+	// per-symbol margin mode, not called at startup
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.MarginMode = "isolated"
+	engine := NewEngine(cfg, []exchange.Provider{bybit, mexc}, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+
+	// the account mode is not changed implicitly
+	require.NoError(t, engine.SetAccountMarginModes(ctx))
+	assert.Empty(t, bybit.modes)
+
+	cfg.Exchange.ArbitrageBot.AccountMarginMode = true
+	cfg.Exchange.ArbitrageBot.SilentMode = true
+	require.NoError(t, engine.SetAccountMarginModes(ctx))
+	assert.Empty(t, bybit.modes)
+
+	cfg.Exchange.ArbitrageBot.SilentMode = false
+	require.NoError(t, engine.SetAccountMarginModes(ctx))
+	assert.Equal(t, []exchange.MarginMode{exchange.MarginModeIsolated}, bybit.modes)
+
+	bybit.err = errors.New("positions are open")
+	assert.ErrorContains(t, engine.SetAccountMarginModes(ctx), "failed to set isolated margin mode on ByBit")
+}
+
+// accountMarginProvider is a provider with the account-wide margin mode.
+type accountMarginProvider struct {
+	*exchangeMocks.MockProvider
+	modes []exchange.MarginMode
+	err   error
+}
+
+func (p *accountMarginProvider) SetAccountMarginMode(_ context.Context, mode exchange.MarginMode) error {
+	p.modes = append(p.modes, mode)
+	return p.err
+}
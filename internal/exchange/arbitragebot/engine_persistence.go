@@ -2,7 +2,6 @@ package arbitragebot
 
 import (
 	"context"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -23,6 +22,11 @@ func (e *Engine) saveSpread(ctx context.Context, event *SpreadEvent, pos *Positi
 		SpreadPercent:    decimal.NewFromFloat(event.FromSpreadPercent),
 		MaxSpreadPercent: decimal.NewFromFloat(event.MaxSpreadPercent),
 		Status:           event.Status,
+
+		NetSpreadPercent:    decimal.NewFromFloat(event.FromNetSpreadPercent),
+		MaxNetSpreadPercent: decimal.NewFromFloat(event.MaxNetSpreadPercent),
+
+		Paper: e.paper,
 	}
 	if pos != nil {
 		spread.OpenBuyOrderID = pos.OpenBuyLeg.OrderID
@@ -54,18 +58,34 @@ func (e *Engine) saveCloseOrderIDsToSpread(ctx context.Context, pos *Position, c
 	err = e.spreadRepo.Update(ctx, &models.ArbitrageSpread{
 		CloseBuyOrderID:  closeBuyID,
 		CloseSellOrderID: closeSellID,
-		UpdatedAt:        time.Now(),
+		UpdatedAt:        e.clock.Now(),
 	}, FindFilter{ID: spread.ID})
 	if err != nil {
 		e.logger.Warn().Err(err).Msgf("failed to update spread with close order IDs symbol=%s", pos.Symbol)
 	}
 }
 
+// saveRebalanceOrderIDToSpread links the rebalance order to the open spread row, so its PnL is counted.
+func (e *Engine) saveRebalanceOrderIDToSpread(ctx context.Context, pos *Position, orderID uuid.UUID) {
+	err := e.spreadRepo.Update(ctx, &models.ArbitrageSpread{
+		RebalanceOrderID: orderID,
+		UpdatedAt:        e.clock.Now(),
+	}, FindFilter{
+		Symbol: pos.Symbol,
+		BuyEx:  pos.BuyExchange,
+		SellEx: pos.SellExchange,
+		Status: []models.ArbitrageSpreadStatus{models.ArbitrageSpreadOpened, models.ArbitrageSpreadUpdated},
+	})
+	if err != nil {
+		e.logger.Warn().Err(err).Str("symbol", pos.Symbol).Msg("failed to update spread with rebalance order ID")
+	}
+}
+
 // markSpreadFailed flips the spread row to ArbitrageSpreadFailed.
 func (e *Engine) markSpreadFailed(ctx context.Context, pos *Position) {
 	err := e.spreadRepo.Update(ctx, &models.ArbitrageSpread{
 		Status:    models.ArbitrageSpreadFailed,
-		UpdatedAt: time.Now(),
+		UpdatedAt: e.clock.Now(),
 	}, FindFilter{
 		Symbol: pos.Symbol,
 		BuyEx:  pos.BuyExchange,
@@ -83,6 +103,7 @@ func (e *Engine) saveOrder(order *models.Order) {
 	if e.orderRepo == nil {
 		return
 	}
+	order.Paper = e.paper
 	if err := e.orderRepo.Create(context.Background(), order); err != nil {
 		e.logger.Error().Err(err).Str("order_id", order.ID.String()).Msg("execution: failed to save order")
 	}
@@ -108,11 +129,48 @@ func (e *Engine) markOrderCanceled(ctx context.Context, orderID uuid.UUID) {
 	}
 }
 
+// markOrderFilled stores the cumulative fill of the order, an order with leaves qty stays PARTIALLY_FILLED,
+// an order canceled by the exchange is CANCELED with what it filled.
 func (e *Engine) markOrderFilled(ctx context.Context, event exchange.OrderExecutionEvent) {
 	if e.orderRepo == nil {
 		return
 	}
-	if err := e.orderRepo.UpdateFilled(ctx, event.OrderID, event.ExecPrice, event.ExecQty); err != nil {
+	var err error
+	switch {
+	case event.Canceled:
+		canceled := models.OrderStatusCanceled
+		err = e.orderRepo.UpdatePartialy(ctx, event.OrderID, OrderPatch{
+			Status:           &canceled,
+			AvgPrice:         &event.ExecPrice,
+			ExecutedQuantity: &event.ExecQty,
+		})
+	case event.LeavesQty.IsPositive():
+		partial := models.OrderStatusPartiallyFilled
+		err = e.orderRepo.UpdatePartialy(ctx, event.OrderID, OrderPatch{
+			Status:           &partial,
+			AvgPrice:         &event.ExecPrice,
+			ExecutedQuantity: &event.ExecQty,
+		})
+	default:
+		err = e.orderRepo.UpdateFilled(ctx, event.OrderID, event.ExecPrice, event.ExecQty)
+	}
+	if err != nil {
 		e.logger.Error().Err(err).Str("order_id", event.OrderID.String()).Msg("execution: failed to mark order filled")
 	}
 }
+
+// saveTrade persists the current state of the position. The write is synchronous, so the state of the
+// last transition is stored before Run returns; the snapshot is taken under tradeMu, so concurrent writes
+// can't store an older state last.
+func (e *Engine) saveTrade(pos *Position) {
+	if e.tradeRepo == nil {
+		return
+	}
+	e.tradeMu.Lock()
+	defer e.tradeMu.Unlock()
+	trade := pos.toTrade()
+	trade.Paper = e.paper
+	if err := e.tradeRepo.Save(context.Background(), trade); err != nil {
+		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Str("status", string(trade.Status)).Msg("failed to save trade")
+	}
+}
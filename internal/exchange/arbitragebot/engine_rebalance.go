@@ -0,0 +1,169 @@
+package arbitragebot
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// rebalanceFillTimeout is how long a close signal waits for the rebalance fill. After it the close is
+// submitted with the open legs as they are.
+const rebalanceFillTimeout = 10 * time.Second
+
+// rebalanceOrder is the order evening out the open legs of a position.
+type rebalanceOrder struct {
+	exchange string
+	side     models.OrderSide // side of the open leg the order applies to
+	reduce   bool             // reduce-only close of the heavier leg, otherwise top-up of the lighter one
+	vol      decimal.Decimal
+}
+
+// planRebalance computes the coin delta between the filled open legs and picks the order evening it out.
+// Reducing the heavier leg is preferred, it lowers the exposure; the lighter leg is topped up when the delta
+// is below MinVol of the heavier leg's venue. Returns false when the legs are even up to the VolStep.
+func (e *Engine) planRebalance(pos *Position) (rebalanceOrder, bool, error) {
+	buyInst, err := e.instrumentFor(pos.Symbol, pos.BuyExchange)
+	if err != nil {
+		return rebalanceOrder{}, false, err
+	}
+	sellInst, err := e.instrumentFor(pos.Symbol, pos.SellExchange)
+	if err != nil {
+		return rebalanceOrder{}, false, err
+	}
+
+	buyCoins := pos.OpenLegFilledQty(models.OrderSideBuy).Mul(buyInst.ContractSize)
+	sellCoins := pos.OpenLegFilledQty(models.OrderSideSell).Mul(sellInst.ContractSize)
+	delta := buyCoins.Sub(sellCoins)
+	if delta.IsZero() {
+		return rebalanceOrder{}, false, nil
+	}
+
+	heavy := rebalanceOrder{exchange: pos.BuyExchange, side: models.OrderSideBuy, reduce: true}
+	light := rebalanceOrder{exchange: pos.SellExchange, side: models.OrderSideSell}
+	heavyInst, lightInst := buyInst, sellInst
+	if delta.IsNegative() {
+		heavy = rebalanceOrder{exchange: pos.SellExchange, side: models.OrderSideSell, reduce: true}
+		light = rebalanceOrder{exchange: pos.BuyExchange, side: models.OrderSideBuy}
+		heavyInst, lightInst = sellInst, buyInst
+	}
+	delta = delta.Abs()
+
+	for _, candidate := range []struct {
+		order rebalanceOrder
+		vol   decimal.Decimal
+		min   decimal.Decimal
+	}{
+		{heavy, rebalanceVol(delta, heavyInst.ContractSize, heavyInst.VolStep), heavyInst.MinVol},
+		{light, rebalanceVol(delta, lightInst.ContractSize, lightInst.VolStep), lightInst.MinVol},
+	} {
+		if candidate.vol.IsPositive() && candidate.vol.GreaterThanOrEqual(candidate.min) {
+			candidate.order.vol = candidate.vol
+			return candidate.order, true, nil
+		}
+	}
+
+	return rebalanceOrder{}, false, nil
+}
+
+// rebalanceVol converts the coin delta to exchange units floored to the VolStep.
+func rebalanceVol(coins, contractSize, volStep decimal.Decimal) decimal.Decimal {
+	if !contractSize.IsPositive() {
+		return decimal.Zero
+	}
+	vol := coins.Div(contractSize)
+	if volStep.IsPositive() {
+		vol = vol.Div(volStep).Floor().Mul(volStep)
+	}
+	return vol
+}
+
+// rebalanceLegs sends a market top-up or reduce-only order when the open legs filled different coin qty,
+// so the position stays delta-neutral. The fill is added to the open leg, close legs are sized from it.
+// The position must be marked with BeginRebalance; a close requested meanwhile is submitted when the
+// order is filled, fails or isn't needed.
+func (e *Engine) rebalanceLegs(ctx context.Context, pos *Position) {
+	plan, ok, err := e.planRebalance(pos)
+	if err != nil {
+		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("rebalance: failed to plan — legs may be unhedged")
+		e.finishRebalance(ctx, pos)
+		return
+	}
+	if !ok {
+		e.finishRebalance(ctx, pos)
+		return
+	}
+
+	order, err := e.buildOrder(pos.Symbol, plan.side, plan.vol, plan.exchange, nil) // nil price = market
+	if err != nil {
+		e.logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("rebalance: failed to build order — legs may be unhedged")
+		e.finishRebalance(ctx, pos)
+		return
+	}
+	order.ID = uuid.New()
+
+	pos.SetRebalanceLeg(plan.side, plan.reduce, Leg{OrderID: order.ID})
+	e.pm.IndexRebalanceLeg(pos, order.ID)
+	e.saveOrder(&order)
+
+	client := e.clientFor(plan.exchange)
+	if plan.reduce {
+		err = client.CloseOrder(ctx, &order)
+	} else {
+		err = client.CreateOrder(ctx, &order)
+	}
+	if err != nil {
+		e.logger.Error().
+			Err(err).
+			Str("symbol", pos.Symbol).
+			Str("exchange", plan.exchange).
+			Str("side", string(plan.side)).
+			Bool("reduce", plan.reduce).
+			Msg("⚠️ rebalance: order FAILED — legs are unhedged by the delta")
+		e.markOrderRejected(ctx, order.ID)
+		e.finishRebalance(ctx, pos)
+		return
+	}
+	e.saveRebalanceOrderIDToSpread(ctx, pos, order.ID)
+
+	e.logger.Info().
+		Str("symbol", pos.Symbol).
+		Str("exchange", plan.exchange).
+		Str("side", string(plan.side)).
+		Bool("reduce", plan.reduce).
+		Stringer("vol", plan.vol).
+		Msg("⚖️ rebalance: order submitted")
+
+	go e.watchRebalanceFill(ctx, pos)
+}
+
+// watchRebalanceFill ends the rebalance if its fill doesn't arrive in rebalanceFillTimeout, so a deferred
+// close is not held forever.
+func (e *Engine) watchRebalanceFill(ctx context.Context, pos *Position) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-e.clock.After(rebalanceFillTimeout):
+	}
+
+	t := pos.FinishRebalance()
+	if t == TransitionNone {
+		return
+	}
+	e.logger.Warn().Str("symbol", pos.Symbol).Msg("⚠️ rebalance: no fill in time, closing with the legs as they are")
+	e.saveTrade(pos)
+	e.applyTransition(ctx, pos, t)
+}
+
+// finishRebalance ends the rebalance and submits the close requested while it ran.
+func (e *Engine) finishRebalance(ctx context.Context, pos *Position) {
+	t := pos.FinishRebalance()
+	if t == TransitionNone {
+		return
+	}
+	e.saveTrade(pos)
+	e.applyTransition(ctx, pos, t)
+}
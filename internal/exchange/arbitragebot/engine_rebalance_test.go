@@ -0,0 +1,161 @@
+package arbitragebot
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func TestEngine_PlanRebalance(t *testing.T) {
+	engine := NewEngine(getConfig(), nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {
+			Symbol:       "BTCUSDT",
+			ContractSize: decimal.NewFromInt(1),
+			VolStep:      decimal.RequireFromString("0.1"),
+			MinVol:       decimal.RequireFromString("0.5"),
+		}},
+		"MEXC": {"BTCUSDT": {
+			Symbol:       "BTCUSDT",
+			ContractSize: decimal.RequireFromString("0.1"),
+			VolStep:      decimal.NewFromInt(1),
+			MinVol:       decimal.NewFromInt(1),
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		buyFilled  string // ByBit, coins
+		sellFilled string // MEXC, contracts of 0.1 coin
+		ok         bool
+		expected   rebalanceOrder
+	}{
+		{name: "even", buyFilled: "2", sellFilled: "20"},
+		{
+			name: "long heavier, reduced", buyFilled: "2", sellFilled: "12",
+			ok: true, expected: rebalanceOrder{exchange: "ByBit", side: models.OrderSideBuy, reduce: true, vol: decimal.RequireFromString("0.8")},
+		},
+		{
+			name: "short heavier, reduced", buyFilled: "1", sellFilled: "17",
+			ok: true, expected: rebalanceOrder{exchange: "MEXC", side: models.OrderSideSell, reduce: true, vol: decimal.NewFromInt(7)},
+		},
+		{
+			name: "below MinVol of the heavier leg, lighter topped up", buyFilled: "2", sellFilled: "17",
+			ok: true, expected: rebalanceOrder{exchange: "MEXC", side: models.OrderSideSell, vol: decimal.NewFromInt(3)},
+		},
+		{name: "below VolStep", buyFilled: "2.05", sellFilled: "20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := &Position{
+				Symbol:       "BTCUSDT",
+				BuyExchange:  "ByBit",
+				SellExchange: "MEXC",
+				OpenBuyLeg:   Leg{FilledQty: decimal.RequireFromString(tt.buyFilled)},
+				OpenSellLeg:  Leg{FilledQty: decimal.RequireFromString(tt.sellFilled)},
+			}
+
+			plan, ok, err := engine.planRebalance(pos)
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.expected.exchange, plan.exchange)
+			assert.Equal(t, tt.expected.side, plan.side)
+			assert.Equal(t, tt.expected.reduce, plan.reduce)
+			assert.Equal(t, tt.expected.vol.String(), plan.vol.String())
+		})
+	}
+}
+
+func TestEngine_RebalanceLegs_TopUp(t *testing.T) {
+	ctx := t.Context()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().CreateOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Side == models.OrderSideSell && order.Type == models.OrderTypeMarket && order.Quantity.Equal(decimal.NewFromInt(2))
+	})).Return(nil).Once()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.RebalanceLegs = true
+	engine := NewEngine(cfg, []exchange.Provider{mexc}, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1), MinVol: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1"), VolStep: decimal.NewFromInt(1)}},
+	}
+
+	pos := &Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		OpenBuyLeg:   Leg{OrderID: uuid.New(), FilledQty: decimal.NewFromInt(2), Confirmed: true},
+		OpenSellLeg:  Leg{OrderID: uuid.New(), FilledQty: decimal.NewFromInt(18), AvgPrice: decimal.NewFromInt(100), Confirmed: true},
+		State:        PositionStateOpen,
+	}
+	engine.pm.Add(pos)
+
+	// 0.2 coin delta is below MinVol on ByBit, the short leg is topped up
+	engine.rebalanceLegs(ctx, pos)
+	require.Same(t, pos, engine.pm.FindByOrderID(pos.RebalanceLeg.OrderID))
+
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.RebalanceLeg.OrderID,
+		ExecPrice: decimal.NewFromInt(110),
+		ExecQty:   decimal.NewFromInt(2),
+	})
+	assert.Equal(t, "20", pos.OpenLegFilledQty(models.OrderSideSell).String())
+	assert.Equal(t, "101", pos.OpenSellLeg.AvgPrice.String())
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+
+	// retransmit is not applied twice
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{
+		OrderID:   pos.RebalanceLeg.OrderID,
+		ExecPrice: decimal.NewFromInt(110),
+		ExecQty:   decimal.NewFromInt(2),
+	})
+	assert.Equal(t, "20", pos.OpenLegFilledQty(models.OrderSideSell).String())
+
+	engine.pm.Delete(pos)
+	assert.Nil(t, engine.pm.FindByOrderID(pos.RebalanceLeg.OrderID))
+}
+
+func TestPosition_RequestClose_DeferredWhileRebalancing(t *testing.T) {
+	pos := &Position{Symbol: "BTCUSDT", State: PositionStateOpen}
+
+	require.True(t, pos.BeginRebalance())
+	assert.False(t, pos.BeginRebalance())
+
+	// close legs would be sized before the rebalance fill
+	assert.Equal(t, TransitionNone, pos.RequestClose())
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+	assert.False(t, pos.CloseSignalAt.IsZero())
+
+	assert.Equal(t, TransitionSubmitClose, pos.FinishRebalance())
+	assert.Equal(t, PositionStateClosing, pos.GetState())
+
+	// finishing twice doesn't submit the close again
+	assert.Equal(t, TransitionNone, pos.FinishRebalance())
+}
+
+func TestPosition_FinishRebalance_WithoutClose(t *testing.T) {
+	pos := &Position{Symbol: "BTCUSDT", State: PositionStateOpen}
+
+	require.True(t, pos.BeginRebalance())
+	assert.Equal(t, TransitionNone, pos.FinishRebalance())
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+
+	assert.Equal(t, TransitionSubmitClose, pos.RequestClose())
+	assert.Equal(t, PositionStateClosing, pos.GetState())
+}
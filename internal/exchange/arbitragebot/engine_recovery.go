@@ -0,0 +1,99 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// RestorePositions loads positions persisted in trades into PositionManager, so a restart doesn't orphan them.
+//   - Opening positions get a new fill timeout watcher: pending legs are cancelled and filled ones flattened.
+//   - Closing positions without close orders submit them, the rest wait closeFillTimeout for fills and
+//     are marked RECOVERY_REQUIRED otherwise. Fills that happened while the bot was stopped never arrive
+//     as execution events.
+//   - Trades that need a manual check are reported and left in the DB.
+//
+// Returns the active spreads of the restored positions, so the spread detector keeps tracking them.
+// Positions whose spread closed while the bot was stopped are closed.
+func (e *Engine) RestorePositions(ctx context.Context) []*models.ArbitrageSpread {
+	if e.tradeRepo == nil {
+		return nil
+	}
+
+	trades, err := e.tradeRepo.FindActive(ctx)
+	if err != nil {
+		e.logger.Warn().Err(err).Msg("recovery: failed to load trades")
+		return nil
+	}
+
+	var spreads []*models.ArbitrageSpread
+	for _, trade := range trades {
+		pos, err := positionFromTrade(trade)
+		if err != nil {
+			e.logger.Error().Err(err).Str("symbol", trade.Symbol).Msg("⚠️ recovery: trade is not restored — VERIFY EXCHANGE")
+			e.sendRecoveryNotification(trade.Symbol, fmt.Sprintf("Trade %s (%s / %s) needs a manual check: %s",
+				trade.Status, trade.BuyExchange, trade.SellExchange, trade.LastError))
+			continue
+		}
+
+		e.pm.Add(pos)
+		e.pm.IndexCloseLeg(pos, pos.CloseBuyLeg.OrderID, pos.CloseSellLeg.OrderID)
+
+		e.logger.Info().
+			Str("symbol", pos.Symbol).
+			Str("buy_on", pos.BuyExchange).
+			Str("sell_on", pos.SellExchange).
+			Str("status", string(trade.Status)).
+			Msg("♻️ recovery: position restored")
+
+		switch pos.GetState() {
+		case PositionStateOpening, PositionStateOpeningPendingClose:
+			go e.watchFillTimeout(ctx, pos, e.strategy.FillTimeout())
+		case PositionStateClosing:
+			if pos.CloseBuyLeg.OrderID == uuid.Nil && pos.CloseSellLeg.OrderID == uuid.Nil {
+				// stopped between the close signal and the close orders
+				go e.submitCloseLegs(ctx, pos)
+			} else {
+				go e.watchCloseFill(ctx, pos)
+			}
+			continue
+		}
+
+		if pos.GetState() == PositionStateOpeningPendingClose {
+			continue
+		}
+
+		spread, err := e.spreadRepo.FindOne(ctx, FindFilter{
+			Symbol: pos.Symbol,
+			BuyEx:  pos.BuyExchange,
+			SellEx: pos.SellExchange,
+			Status: []models.ArbitrageSpreadStatus{models.ArbitrageSpreadOpened, models.ArbitrageSpreadUpdated},
+		})
+		if err == nil && spread != nil {
+			spreads = append(spreads, spread)
+			continue
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			e.logger.Warn().Err(err).Str("symbol", pos.Symbol).Msg("recovery: failed to find spread")
+			continue
+		}
+
+		// the spread closed while the bot was stopped
+		transition := pos.RequestClose()
+		e.saveTrade(pos)
+		e.applyTransition(ctx, pos, transition)
+	}
+
+	return spreads
+}
+
+func (e *Engine) sendRecoveryNotification(symbol, msg string) {
+	if err := e.notif.Send(fmt.Sprintf("<b>⚠️ ARBITRAGE: Ticker - %s</b>\n\n%s", symbol, msg)); err != nil {
+		e.logger.Warn().Err(err).Msg("failed to send telegram notification")
+	}
+}
@@ -0,0 +1,225 @@
+package arbitragebot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+type tradeRepoStub struct {
+	mu     sync.Mutex
+	active []*models.ArbitrageTrade
+	saved  map[uuid.UUID]*models.ArbitrageTrade
+}
+
+func (r *tradeRepoStub) Save(_ context.Context, trade *models.ArbitrageTrade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.saved == nil {
+		r.saved = make(map[uuid.UUID]*models.ArbitrageTrade)
+	}
+	r.saved[trade.ID] = trade
+	return nil
+}
+
+func (r *tradeRepoStub) FindActive(_ context.Context) ([]*models.ArbitrageTrade, error) {
+	return r.active, nil
+}
+
+func (r *tradeRepoStub) status(id uuid.UUID) models.ArbitrageTradeStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if trade, ok := r.saved[id]; ok {
+		return trade.Status
+	}
+	return ""
+}
+
+func TestPosition_TradeRoundTrip(t *testing.T) {
+	pos := &Position{
+		ID:           uuid.New(),
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		QtyCoins:     decimal.NewFromInt(2),
+		OpenBuyLeg:   Leg{OrderID: uuid.New(), ExchangeOrderID: "b1"},
+		OpenSellLeg:  Leg{OrderID: uuid.New(), ExchangeOrderID: "s1"},
+		State:        PositionStateOpening,
+		OpenSignalAt: time.Now(),
+	}
+	assert.Equal(t, models.ArbitrageTradeStatusOpening, pos.toTrade().Status)
+
+	_, err := pos.OnOpenLegFilled(pos.OpenBuyLeg.OrderID, decimal.NewFromInt(100), decimal.NewFromInt(2), decimal.Zero)
+	require.NoError(t, err)
+	assert.Equal(t, models.ArbitrageTradeStatusOpenPartial, pos.toTrade().Status)
+
+	_, err = pos.OnOpenLegFilled(pos.OpenSellLeg.OrderID, decimal.NewFromInt(103), decimal.NewFromInt(20), decimal.Zero)
+	require.NoError(t, err)
+
+	trade := pos.toTrade()
+	assert.Equal(t, models.ArbitrageTradeStatusOpened, trade.Status)
+	assert.False(t, trade.OpenedAt.IsZero())
+
+	restored, err := positionFromTrade(trade)
+	require.NoError(t, err)
+	assert.Equal(t, PositionStateOpen, restored.State)
+	assert.Equal(t, pos.ID, restored.ID)
+	assert.Equal(t, "2", restored.QtyCoins.String())
+	assert.Equal(t, pos.OpenSellLeg, restored.OpenSellLeg)
+	assert.True(t, restored.OpenBuyLeg.Confirmed)
+
+	// a partly filled leg is restored unconfirmed
+	partial := &Position{
+		ID:          uuid.New(),
+		Symbol:      "SOLUSDT",
+		QtyCoins:    decimal.NewFromInt(2),
+		OpenBuyLeg:  Leg{OrderID: uuid.New()},
+		OpenSellLeg: Leg{OrderID: uuid.New()},
+		State:       PositionStateOpening,
+	}
+	_, err = partial.OnOpenLegFilled(partial.OpenBuyLeg.OrderID, decimal.NewFromInt(100), decimal.NewFromInt(1), decimal.NewFromInt(1))
+	require.NoError(t, err)
+	_, err = partial.OnOpenLegFilled(partial.OpenSellLeg.OrderID, decimal.NewFromInt(103), decimal.NewFromInt(2), decimal.Zero)
+	require.NoError(t, err)
+	restored, err = positionFromTrade(partial.toTrade())
+	require.NoError(t, err)
+	assert.Equal(t, PositionStateOpening, restored.State)
+	assert.Equal(t, "1", restored.OpenBuyLeg.FilledQty.String())
+	assert.False(t, restored.OpenBuyLeg.Confirmed)
+	assert.True(t, restored.OpenSellLeg.Confirmed)
+
+	// close signal before both legs filled is restored as pending close
+	pending := &Position{ID: uuid.New(), Symbol: "ETHUSDT", State: PositionStateOpening}
+	pending.RequestClose()
+	restored, err = positionFromTrade(pending.toTrade())
+	require.NoError(t, err)
+	assert.Equal(t, PositionStateOpeningPendingClose, restored.State)
+
+	// the first terminal status wins
+	pos.MarkCleanup()
+	assert.Equal(t, models.ArbitrageTradeStatusRecoveryRequired, pos.toTrade().Status)
+	pos.Finish(models.ArbitrageTradeStatusRecoveryRequired, assert.AnError)
+	pos.Finish(models.ArbitrageTradeStatusFailed, nil)
+	trade = pos.toTrade()
+	assert.Equal(t, models.ArbitrageTradeStatusRecoveryRequired, trade.Status)
+	assert.Equal(t, assert.AnError.Error(), trade.LastError)
+
+	_, err = positionFromTrade(trade)
+	assert.Error(t, err)
+}
+
+func TestEngine_SaveTrade_StoresBeforeReturn(t *testing.T) {
+	tradeRepo := &tradeRepoStub{}
+	engine := NewEngine(getConfig(), nil, nil, &spreadRepoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.SetTradeRepository(tradeRepo)
+
+	pos := &Position{ID: uuid.New(), Symbol: "BTCUSDT", State: PositionStateOpening}
+	engine.saveTrade(pos)
+	assert.Equal(t, models.ArbitrageTradeStatusOpening, tradeRepo.status(pos.ID))
+
+	// the last transition before shutdown is not lost
+	pos.Finish(models.ArbitrageTradeStatusClosed, nil)
+	engine.saveTrade(pos)
+	assert.Equal(t, models.ArbitrageTradeStatusClosed, tradeRepo.status(pos.ID))
+}
+
+func TestEngine_RestorePositions(t *testing.T) {
+	ctx := t.Context()
+
+	opened := &models.ArbitrageTrade{
+		ID:                uuid.New(),
+		Symbol:            "BTCUSDT",
+		BuyExchange:       "ByBit",
+		SellExchange:      "MEXC",
+		Status:            models.ArbitrageTradeStatusOpened,
+		QtyCoins:          decimal.NewFromInt(1),
+		OpenBuyOrderID:    uuid.New(),
+		OpenSellOrderID:   uuid.New(),
+		OpenBuyFilledQty:  decimal.NewFromInt(1),
+		OpenSellFilledQty: decimal.NewFromInt(10),
+		OpenBuyConfirmed:  true,
+		OpenSellConfirmed: true,
+	}
+	// the spread closed while the bot was stopped
+	closed := &models.ArbitrageTrade{
+		ID:                uuid.New(),
+		Symbol:            "ETHUSDT",
+		BuyExchange:       "ByBit",
+		SellExchange:      "MEXC",
+		Status:            models.ArbitrageTradeStatusOpened,
+		QtyCoins:          decimal.NewFromInt(3),
+		OpenBuyOrderID:    uuid.New(),
+		OpenSellOrderID:   uuid.New(),
+		OpenBuyFilledQty:  decimal.NewFromInt(3),
+		OpenSellFilledQty: decimal.NewFromInt(3),
+		OpenBuyConfirmed:  true,
+		OpenSellConfirmed: true,
+	}
+	broken := &models.ArbitrageTrade{
+		ID:           uuid.New(),
+		Symbol:       "SOLUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		Status:       models.ArbitrageTradeStatusRecoveryRequired,
+	}
+	tradeRepo := &tradeRepoStub{active: []*models.ArbitrageTrade{opened, closed, broken}}
+	spreadRepo := &spreadRepoStub{spreads: []*models.ArbitrageSpread{
+		{Symbol: "BTCUSDT", BuyOnExchange: "ByBit", SellOnExchange: "MEXC", MaxNetSpreadPercent: decimal.NewFromInt(4)},
+	}}
+
+	var closeOrders atomic.Int32
+	countClose := func(_ context.Context, _ *models.Order) { closeOrders.Add(1) }
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().CloseOrder(mock.Anything, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Symbol == "ETHUSDT" && order.Side == models.OrderSideBuy && order.Quantity.Equal(decimal.NewFromInt(3))
+	})).Run(countClose).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().CloseOrder(mock.Anything, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Symbol == "ETHUSDT" && order.Side == models.OrderSideSell && order.Quantity.Equal(decimal.NewFromInt(3))
+	})).Run(countClose).Return(nil).Once()
+
+	notif := &notifierStub{}
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, spreadRepo, notif, zerolog.Nop(), MarketStrategy{})
+	engine.SetTradeRepository(tradeRepo)
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"ETHUSDT": {Symbol: "ETHUSDT", ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"ETHUSDT": {Symbol: "ETHUSDT", ContractSize: decimal.NewFromInt(1)}},
+	}
+
+	spreads := engine.RestorePositions(ctx)
+	require.Len(t, spreads, 1)
+	assert.Equal(t, "BTCUSDT", spreads[0].Symbol)
+
+	pos := engine.pm.FindByKey("BTCUSDT", "ByBit", "MEXC")
+	require.NotNil(t, pos)
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+	assert.Same(t, pos, engine.pm.FindByOrderID(opened.OpenSellOrderID))
+
+	ethPos := engine.pm.FindByKey("ETHUSDT", "ByBit", "MEXC")
+	require.NotNil(t, ethPos)
+	assert.Equal(t, PositionStateClosing, ethPos.GetState())
+	require.Eventually(t, func() bool {
+		return tradeRepo.status(closed.ID) == models.ArbitrageTradeStatusClosing && closeOrders.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, engine.pm.FindByKey("SOLUSDT", "ByBit", "MEXC"))
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "needs a manual check")
+}
@@ -0,0 +1,124 @@
+package arbitragebot
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// finishPosition sets the terminal status of a position removed without being fully closed, adds the PnL
+// of its flattened legs to the daily loss limit and counts it as a failure for the consecutive failures
+// limit. A position is counted once, the first status wins.
+func (e *Engine) finishPosition(pos *Position, status models.ArbitrageTradeStatus, err error) {
+	if !pos.Finish(status, err) {
+		return
+	}
+	e.recordFlattened(pos)
+	if reason := e.risk.recordFailure(); reason != "" {
+		e.killSwitch(pos.Symbol, reason)
+	}
+}
+
+// recordClosed adds the realized PnL of the fully closed position to the daily loss limit.
+func (e *Engine) recordClosed(pos *Position) {
+	pnl, err := e.realizedPnL(pos)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("symbol", pos.Symbol).Msg("risk: failed to calculate realized PnL")
+		return
+	}
+
+	e.logger.Info().Str("symbol", pos.Symbol).Stringer("pnl", pnl).Msg("risk: position PnL")
+
+	if reason := e.risk.recordClosed(pnl, e.clock.Now()); reason != "" {
+		e.killSwitch(pos.Symbol, reason)
+	}
+}
+
+// recordFlattened adds the realized PnL of the legs flattened by the emergency closes of a failed position
+// to the daily loss limit.
+func (e *Engine) recordFlattened(pos *Position) {
+	pnl, err := e.flattenedPnL(pos)
+	if err != nil {
+		e.logger.Warn().Err(err).Str("symbol", pos.Symbol).Msg("risk: failed to calculate flattened legs PnL")
+		return
+	}
+	if pnl.IsZero() {
+		return
+	}
+
+	e.logger.Info().Str("symbol", pos.Symbol).Stringer("pnl", pnl).Msg("risk: flattened legs PnL")
+
+	if reason := e.risk.recordFlattened(pnl, e.clock.Now()); reason != "" {
+		e.killSwitch(pos.Symbol, reason)
+	}
+}
+
+// realizedPnL returns the PnL of the fully closed position in USDT: price difference of each leg at its
+// closed coin qty, less taker fees of the round trip. Funding is not included.
+func (e *Engine) realizedPnL(pos *Position) (decimal.Decimal, error) {
+	trade := pos.toTrade()
+	pnl, buyCoins, _, err := e.legsPnL(trade)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	feePercent := decimal.NewFromFloat(e.fees.roundTripFeePercent(pos.Symbol, pos.BuyExchange, pos.SellExchange))
+	fees := trade.OpenBuyAvgPrice.Mul(buyCoins).Mul(feePercent).Div(decimal.NewFromInt(100))
+
+	return pnl.Sub(fees), nil
+}
+
+// flattenedPnL returns the PnL of the closed qty of each leg of a position that was not fully closed in USDT,
+// less taker fees of the open and the close of that qty. Zero if no leg was closed.
+func (e *Engine) flattenedPnL(pos *Position) (decimal.Decimal, error) {
+	trade := pos.toTrade()
+	if !trade.CloseBuyFilledQty.IsPositive() && !trade.CloseSellFilledQty.IsPositive() {
+		return decimal.Zero, nil
+	}
+
+	pnl, buyCoins, sellCoins, err := e.legsPnL(trade)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	two := decimal.NewFromInt(2)
+	buyTaker := decimal.NewFromFloat(e.fees[parseVenue(pos.BuyExchange).Exchange].For(pos.Symbol).Taker)
+	sellTaker := decimal.NewFromFloat(e.fees[parseVenue(pos.SellExchange).Exchange].For(pos.Symbol).Taker)
+	fees := trade.OpenBuyAvgPrice.Mul(buyCoins).Mul(buyTaker).Mul(two).
+		Add(trade.OpenSellAvgPrice.Mul(sellCoins).Mul(sellTaker).Mul(two))
+
+	return pnl.Sub(fees), nil
+}
+
+// legsPnL returns the price difference of each leg of the trade at its closed coin qty in USDT, without fees,
+// and the closed coin qty of the buy and the sell leg.
+func (e *Engine) legsPnL(trade *models.ArbitrageTrade) (decimal.Decimal, decimal.Decimal, decimal.Decimal, error) {
+	buyInst, err := e.instrumentFor(trade.Symbol, trade.BuyExchange)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, decimal.Zero, err
+	}
+	sellInst, err := e.instrumentFor(trade.Symbol, trade.SellExchange)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, decimal.Zero, err
+	}
+
+	buyCoins := trade.CloseBuyFilledQty.Mul(buyInst.ContractSize)
+	sellCoins := trade.CloseSellFilledQty.Mul(sellInst.ContractSize)
+
+	buyPnL := trade.CloseBuyAvgPrice.Sub(trade.OpenBuyAvgPrice).Mul(buyCoins)
+	sellPnL := trade.OpenSellAvgPrice.Sub(trade.CloseSellAvgPrice).Mul(sellCoins)
+
+	return buyPnL.Add(sellPnL), buyCoins, sellCoins, nil
+}
+
+// killSwitch reports the tripped kill-switch, new opens are refused by riskLimits from now on.
+func (e *Engine) killSwitch(symbol, reason string) {
+	e.logger.Error().Str("symbol", symbol).Str("reason", reason).Msg("⛔ risk: KILL-SWITCH — new positions are not opened until restart")
+
+	msg := fmt.Sprintf("<b>⛔ ARBITRAGE: kill-switch</b>\n\n%s (last position: %s).\nNew positions are not opened until restart, open ones are still closed.", reason, symbol)
+	if err := e.notif.Send(msg); err != nil {
+		e.logger.Warn().Err(err).Msg("failed to send telegram notification")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -17,7 +16,7 @@ import (
 // but the log line for "SPREAD DETECTED" is emitted synchronously so it always appears before
 // the execution logs that follow.
 func (e *Engine) handleOpen(ctx context.Context, event *SpreadEvent) {
-	if e.pm.IsBlacklisted(event.Symbol) {
+	if e.pm.IsBlacklisted(event.Symbol, event.BuyOnExchange, event.SellOnExchange, e.clock.Now()) {
 		return
 	}
 
@@ -37,16 +36,18 @@ func (e *Engine) handleOpen(ctx context.Context, event *SpreadEvent) {
 // Telegram + DB update.
 func (e *Engine) handleUpdate(ctx context.Context, event *SpreadEvent) {
 	spreadStr := strconv.FormatFloat(event.MaxSpreadPercent, 'f', 2, 64)
+	netSpreadStr := strconv.FormatFloat(event.MaxNetSpreadPercent, 'f', 2, 64)
 	e.logger.Warn().
 		Str("pair", event.Symbol).
 		Str("spread", spreadStr).
+		Str("net spread", netSpreadStr).
 		Str("buy on", event.BuyOnExchange).
 		Str("sell on", event.SellOnExchange).
 		Msg("🔥 SPREAD GROWING")
 
 	go func() {
-		msg := fmt.Sprintf("<b>🔔 ARBITRAGE: Ticker - %s</b>\n\nSpread is growing, new spread: <code>%s%%</code>",
-			event.Symbol, spreadStr)
+		msg := fmt.Sprintf("<b>🔔 ARBITRAGE: Ticker - %s</b>\n\nSpread is growing, new spread: <code>%s%%</code> (net <code>%s%%</code>)",
+			event.Symbol, spreadStr, netSpreadStr)
 		if err := e.notif.Send(msg); err != nil {
 			e.logger.Warn().Err(err).Msg("failed to send telegram notification")
 		}
@@ -54,9 +55,10 @@ func (e *Engine) handleUpdate(ctx context.Context, event *SpreadEvent) {
 
 	go func() {
 		err := e.spreadRepo.Update(ctx, &models.ArbitrageSpread{
-			Status:           models.ArbitrageSpreadUpdated,
-			MaxSpreadPercent: decimal.NewFromFloat(event.MaxSpreadPercent),
-			UpdatedAt:        time.Now(),
+			Status:              models.ArbitrageSpreadUpdated,
+			MaxSpreadPercent:    decimal.NewFromFloat(event.MaxSpreadPercent),
+			MaxNetSpreadPercent: decimal.NewFromFloat(event.MaxNetSpreadPercent),
+			UpdatedAt:           e.clock.Now(),
 		}, FindFilter{
 			Symbol: event.Symbol,
 			BuyEx:  event.BuyOnExchange,
@@ -85,12 +87,13 @@ func (e *Engine) handleClose(ctx context.Context, event *SpreadEvent) {
 		pos := e.pm.FindByKey(event.Symbol, event.BuyOnExchange, event.SellOnExchange)
 		if pos != nil {
 			transition := pos.RequestClose()
+			e.saveTrade(pos)
 			e.applyTransition(ctx, pos, transition)
 		}
 	}
 
 	go func() {
-		now := time.Now()
+		now := e.clock.Now()
 		err := e.spreadRepo.Update(ctx, &models.ArbitrageSpread{
 			Status:    models.ArbitrageSpreadClosed,
 			ClosedAt:  &now,
@@ -113,6 +116,7 @@ func (e *Engine) logSpreadDetected(event *SpreadEvent) {
 	e.logger.Warn().
 		Str("pair", event.Symbol).
 		Str("spread", spreadStr).
+		Str("net spread", strconv.FormatFloat(event.FromNetSpreadPercent, 'f', 2, 64)).
 		Str("buy on", event.BuyOnExchange).
 		Str("sell on", event.SellOnExchange).
 		Msg("🔥 SPREAD DETECTED")
@@ -122,12 +126,13 @@ func (e *Engine) logSpreadDetected(event *SpreadEvent) {
 // goroutine so the slow HTTP call doesn't delay execution; logs are emitted by logSpreadDetected.
 func (e *Engine) sendOpenTelegram(event *SpreadEvent) {
 	spreadStr := strconv.FormatFloat(event.FromSpreadPercent, 'f', 2, 64)
+	netSpreadStr := strconv.FormatFloat(event.FromNetSpreadPercent, 'f', 2, 64)
 	msg := fmt.Sprintf(
 		"<b>🔔 ARBITRAGE: Ticker - %s</b>\n\n"+
-			"Spread: <code>%s%%</code>\n\n"+
+			"Spread: <code>%s%%</code> (net <code>%s%%</code>)\n\n"+
 			"🟢 Buy:  %s - <b>%s</b>\n"+
 			"🔴 Sell: %s - <b>%s</b>",
-		event.Symbol, spreadStr,
+		event.Symbol, spreadStr, netSpreadStr,
 		event.BuyOnExchange, utils.FormatPrice(event.BuyPrice),
 		event.SellOnExchange, utils.FormatPrice(event.SellPrice),
 	)
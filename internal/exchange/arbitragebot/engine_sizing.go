@@ -0,0 +1,146 @@
+package arbitragebot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// balanceDriftWarnInterval limits the balance drift warnings of an exchange pair.
+const balanceDriftWarnInterval = time.Hour
+
+// tradeNotional returns the notional of the next trade in USDT. With balance sizing it's the configured
+// fraction of the free USDT margin with leverage applied, the smaller of the two venues, capped by
+// the risk notional. Without it (or without balances) it's the fixed risk notional.
+func (e *Engine) tradeNotional(symbol, buyExchange, sellExchange string) (decimal.Decimal, error) {
+	fraction := e.cfg.Exchange.ArbitrageBot.Sizing.BalanceFraction
+	if fraction <= 0 || e.balances == nil {
+		return e.notional(), nil
+	}
+
+	buyFree, err := e.freeUSDT(buyExchange)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	sellFree, err := e.freeUSDT(sellExchange)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	e.warnBalanceDrift(buyExchange, buyFree, sellExchange, sellFree)
+
+	f := decimal.NewFromFloat(fraction)
+	notional := decimal.Min(
+		buyFree.Mul(f).Mul(e.leverage(symbol, buyExchange)),
+		sellFree.Mul(f).Mul(e.leverage(symbol, sellExchange)),
+	)
+	if !notional.IsPositive() {
+		return decimal.Zero, fmt.Errorf("no free USDT margin on %s / %s", buyExchange, sellExchange)
+	}
+
+	return decimal.Min(notional, e.notional()), nil
+}
+
+// checkMargin checks that the free USDT of the venue covers the margin of qty coins of the symbol at price:
+// the full cost on spot venues, the cost divided by leverage on linear ones. Skipped without balances.
+func (e *Engine) checkMargin(symbol, exchangeName string, qty decimal.Decimal, price float64) error {
+	if e.balances == nil {
+		return nil
+	}
+
+	margin := e.margin(symbol, exchangeName, qty, price)
+
+	free, err := e.freeUSDT(exchangeName)
+	if err != nil {
+		return err
+	}
+	if free.LessThan(margin) {
+		return fmt.Errorf("not enough USDT on %s: free %s, need %s", exchangeName, free, margin.StringFixed(2))
+	}
+
+	return nil
+}
+
+// reserveMargin reserves the margin of the open order in the balance store, so the opens sent before the
+// next balance refresh don't size from the same free USDT. Skipped without balances.
+func (e *Engine) reserveMargin(symbol, exchangeName string, orderID uuid.UUID, qty decimal.Decimal, price float64) {
+	if e.balances == nil {
+		return
+	}
+	e.balances.Reserve(exchangeName, orderID, e.margin(symbol, exchangeName, qty, price))
+}
+
+// releaseMargin releases the margin reserved for the order once it filled or failed.
+func (e *Engine) releaseMargin(orderID uuid.UUID) {
+	if e.balances == nil {
+		return
+	}
+	e.balances.Release(orderID)
+}
+
+// margin returns the USDT margin of qty coins of the symbol at price on the venue.
+func (e *Engine) margin(symbol, exchangeName string, qty decimal.Decimal, price float64) decimal.Decimal {
+	return qty.Mul(decimal.NewFromFloat(price)).Div(e.leverage(symbol, exchangeName))
+}
+
+// freeUSDT returns the free USDT of the venue from the balance store less the margin reserved for the
+// open orders sent since the last refresh.
+func (e *Engine) freeUSDT(exchangeName string) (decimal.Decimal, error) {
+	usdt, ok := e.balances.GetForAsset(exchangeName, "USDT")
+	if !ok {
+		return decimal.Zero, fmt.Errorf("no USDT balance on %s", exchangeName)
+	}
+	return usdt.Free.Sub(e.balances.Reserved(exchangeName)), nil
+}
+
+// leverage returns the leverage of the symbol on the venue, spot is not leveraged.
+func (e *Engine) leverage(symbol, exchangeName string) decimal.Decimal {
+	if parseVenue(exchangeName).IsSpot() {
+		return decimal.NewFromInt(1)
+	}
+	return decimal.NewFromInt(e.symbolLeverage(symbol))
+}
+
+// warnBalanceDrift logs and notifies when the free USDT of the two venues drifted apart by more than
+// the configured percent of the larger one, at most once per balanceDriftWarnInterval for a pair.
+func (e *Engine) warnBalanceDrift(exchange1 string, free1 decimal.Decimal, exchange2 string, free2 decimal.Decimal) {
+	threshold := e.cfg.Exchange.ArbitrageBot.Sizing.DriftWarnPercent
+	larger := decimal.Max(free1, free2)
+	if threshold <= 0 || !larger.IsPositive() {
+		return
+	}
+
+	drift := free1.Sub(free2).Abs().Div(larger).Mul(decimal.NewFromInt(100))
+	if drift.LessThanOrEqual(decimal.NewFromFloat(threshold)) {
+		return
+	}
+
+	pair := []string{exchange1, exchange2}
+	sort.Strings(pair)
+	key := pair[0] + "#" + pair[1]
+
+	e.driftMu.Lock()
+	if e.clock.Now().Sub(e.driftWarnedAt[key]) < balanceDriftWarnInterval {
+		e.driftMu.Unlock()
+		return
+	}
+	e.driftWarnedAt[key] = e.clock.Now()
+	e.driftMu.Unlock()
+
+	e.logger.Warn().
+		Str("exchange1", exchange1).
+		Stringer("free1", free1).
+		Str("exchange2", exchange2).
+		Stringer("free2", free2).
+		Stringer("drift_percent", drift.Round(1)).
+		Msg("⚠️ sizing: balances drifted apart, transfer USDT")
+
+	msg := fmt.Sprintf("<b>⚠️ ARBITRAGE: balance drift</b>\n\nFree USDT: %s %s, %s %s (%s%%).\nTransfer USDT to keep trade sizes.",
+		exchange1, free1.StringFixed(2), exchange2, free2.StringFixed(2), drift.StringFixed(1))
+	if err := e.notif.Send(msg); err != nil {
+		e.logger.Warn().Err(err).Msg("failed to send telegram notification")
+	}
+}
@@ -0,0 +1,111 @@
+package arbitragebot
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newSizingEngine(t *testing.T, notif *notifierStub, free map[string]int64) *Engine {
+	t.Helper()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Risk.NotionalUSDT = 100
+	cfg.Exchange.ArbitrageBot.Leverage = 5
+	cfg.Exchange.ArbitrageBot.Sizing.BalanceFraction = 0.1
+	cfg.Exchange.ArbitrageBot.Sizing.DriftWarnPercent = 50
+
+	balances := newBalanceStore(zerolog.Nop())
+	for venue, usdt := range free {
+		balances.SetVenue(venue, []models.Balance{{Asset: "USDT", Free: decimal.NewFromInt(usdt)}})
+	}
+
+	engine := NewEngine(cfg, nil, nil, &repoStub{}, notif, zerolog.Nop(), MarketStrategy{})
+	engine.SetBalanceStore(balances)
+	return engine
+}
+
+func TestEngine_TradeNotional(t *testing.T) {
+	engine := newSizingEngine(t, &notifierStub{}, map[string]int64{
+		"ByBit":      100,
+		"MEXC":       120,
+		"OKX":        1000,
+		"ByBit:spot": 200,
+	})
+
+	tests := []struct {
+		name     string
+		buy      string
+		sell     string
+		expected string
+		err      bool
+	}{
+		{name: "smaller side with leverage", buy: "ByBit", sell: "MEXC", expected: "50"},
+		{name: "capped by risk notional", buy: "OKX", sell: "OKX", expected: "100"},
+		{name: "spot is not leveraged", buy: "ByBit:spot", sell: "MEXC", expected: "20"},
+		{name: "unknown balance", buy: "Gate", sell: "MEXC", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notional, err := engine.tradeNotional("BTCUSDT", tt.buy, tt.sell)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, notional.String())
+		})
+	}
+
+	// without balance sizing the risk notional is used
+	engine.cfg.Exchange.ArbitrageBot.Sizing.BalanceFraction = 0
+	notional, err := engine.tradeNotional("BTCUSDT", "Gate", "MEXC")
+	require.NoError(t, err)
+	assert.Equal(t, "100", notional.String())
+}
+
+func TestEngine_CheckMargin(t *testing.T) {
+	engine := newSizingEngine(t, &notifierStub{}, map[string]int64{"ByBit": 10, "ByBit:spot": 10})
+
+	// 1 coin at 40: 8 USDT margin with 5x leverage, full cost on spot
+	assert.NoError(t, engine.checkMargin("BTCUSDT", "ByBit", decimal.NewFromInt(1), 40))
+	assert.ErrorContains(t, engine.checkMargin("BTCUSDT", "ByBit", decimal.NewFromInt(2), 40), "not enough USDT on ByBit")
+	assert.ErrorContains(t, engine.checkMargin("BTCUSDT", "ByBit:spot", decimal.NewFromInt(1), 40), "not enough USDT on ByBit:spot")
+	assert.ErrorContains(t, engine.checkMargin("BTCUSDT", "MEXC", decimal.NewFromInt(1), 40), "no USDT balance")
+}
+
+func TestEngine_CheckMargin_Reserved(t *testing.T) {
+	engine := newSizingEngine(t, &notifierStub{}, map[string]int64{"ByBit": 10})
+
+	// the first open reserves 8 USDT of margin, the second one doesn't fit until it's released
+	orderID := uuid.New()
+	require.NoError(t, engine.checkMargin("BTCUSDT", "ByBit", decimal.NewFromInt(1), 40))
+	engine.reserveMargin("BTCUSDT", "ByBit", orderID, decimal.NewFromInt(1), 40)
+	assert.ErrorContains(t, engine.checkMargin("BTCUSDT", "ByBit", decimal.NewFromInt(1), 40), "free 2, need 8.00")
+
+	engine.releaseMargin(orderID)
+	assert.NoError(t, engine.checkMargin("BTCUSDT", "ByBit", decimal.NewFromInt(1), 40))
+}
+
+func TestEngine_WarnBalanceDrift(t *testing.T) {
+	notif := &notifierStub{}
+	engine := newSizingEngine(t, notif, map[string]int64{"ByBit": 100, "MEXC": 40, "OKX": 80})
+
+	_, err := engine.tradeNotional("BTCUSDT", "ByBit", "OKX")
+	require.NoError(t, err)
+	assert.Empty(t, notif.msgs)
+
+	_, err = engine.tradeNotional("BTCUSDT", "ByBit", "MEXC")
+	require.NoError(t, err)
+	_, err = engine.tradeNotional("BTCUSDT", "MEXC", "ByBit")
+	require.NoError(t, err)
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "ByBit 100.00, MEXC 40.00 (60.0%)")
+}
@@ -0,0 +1,17 @@
+package arbitragebot
+
+import "github.com/lucrumx/bot/internal/exchange"
+
+// FeeSchedules holds fee schedules by exchange name.
+type FeeSchedules map[string]exchange.FeeSchedule
+
+// roundTripFeePercent returns taker fees (in percent of the notional) paid for the arbitrage round trip:
+// market open and market close on both the buy and the sell exchange.
+// Exchanges are venue names, spot venues use the schedule of their exchange.
+// Exchanges without a schedule are treated as zero fee.
+func (f FeeSchedules) roundTripFeePercent(symbol, buyExchange, sellExchange string) float64 {
+	buyTaker := f[parseVenue(buyExchange).Exchange].For(symbol).Taker
+	sellTaker := f[parseVenue(sellExchange).Exchange].For(symbol).Taker
+
+	return 2 * (buyTaker + sellTaker) * 100
+}
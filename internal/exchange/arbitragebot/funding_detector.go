@@ -0,0 +1,105 @@
+package arbitragebot
+
+import (
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const hoursPerYear = 24 * 365
+
+// FundingSpreadEvent represents a funding-arbitrage signal: long on LongExchange (lower funding),
+// short on ShortExchange (higher funding).
+type FundingSpreadEvent struct {
+	Status models.ArbitrageSpreadStatus
+
+	Symbol        string
+	LongExchange  string
+	ShortExchange string
+
+	LongRate  exchange.FundingRate
+	ShortRate exchange.FundingRate
+
+	AnnualizedPercent    float64
+	NetAnnualizedPercent float64
+}
+
+// FundingDetector detects cross-exchange funding differentials.
+// NOT safe for concurrent use. Caller must ensure single-goroutine access.
+type FundingDetector struct {
+	minAnnualizedPercent float64             // Minimal net annualized differential for signal
+	holdingDays          float64             // Holding period the round trip fees are amortized over
+	fees                 FeeSchedules        // Fee schedules by exchange
+	activeSpreads        map[string]struct{} // current active funding spreads
+}
+
+// NewFundingDetector creates a new FundingDetector.
+func NewFundingDetector(cfg *config.Config, fees FeeSchedules) *FundingDetector {
+	return &FundingDetector{
+		minAnnualizedPercent: cfg.Exchange.ArbitrageBot.Funding.MinAnnualizedPercent,
+		holdingDays:          cfg.Exchange.ArbitrageBot.Funding.HoldingDays,
+		fees:                 fees,
+		activeSpreads:        make(map[string]struct{}),
+	}
+}
+
+// Detect compares funding rates of one symbol across exchanges.
+// Differential is annualized per hour of funding interval (exchanges may settle every 1, 4 or 8 hours)
+// and reduced by round trip taker fees amortized over the holding period.
+// Emits ArbitrageSpreadOpened when the net differential reaches the threshold and ArbitrageSpreadClosed
+// when it falls below it.
+func (d *FundingDetector) Detect(symbol string, ratesByExchange map[string]exchange.FundingRate) []*FundingSpreadEvent {
+	var events []*FundingSpreadEvent
+
+	for longExchange, longRate := range ratesByExchange {
+		for shortExchange, shortRate := range ratesByExchange {
+			if longExchange == shortExchange {
+				continue
+			}
+
+			annualized := (hourlyRate(shortRate) - hourlyRate(longRate)) * hoursPerYear * 100
+			feesAnnualized := d.fees.roundTripFeePercent(symbol, longExchange, shortExchange) * 365 / d.holdingDays
+			net := annualized - feesAnnualized
+
+			key := getSpreadKey(symbol, longExchange, shortExchange)
+			_, active := d.activeSpreads[key]
+
+			switch {
+			case !active && net >= d.minAnnualizedPercent:
+				d.activeSpreads[key] = struct{}{}
+				events = append(events, &FundingSpreadEvent{
+					Status:               models.ArbitrageSpreadOpened,
+					Symbol:               symbol,
+					LongExchange:         longExchange,
+					ShortExchange:        shortExchange,
+					LongRate:             longRate,
+					ShortRate:            shortRate,
+					AnnualizedPercent:    annualized,
+					NetAnnualizedPercent: net,
+				})
+			case active && net < d.minAnnualizedPercent:
+				delete(d.activeSpreads, key)
+				events = append(events, &FundingSpreadEvent{
+					Status:               models.ArbitrageSpreadClosed,
+					Symbol:               symbol,
+					LongExchange:         longExchange,
+					ShortExchange:        shortExchange,
+					LongRate:             longRate,
+					ShortRate:            shortRate,
+					AnnualizedPercent:    annualized,
+					NetAnnualizedPercent: net,
+				})
+			}
+		}
+	}
+
+	return events
+}
+
+func hourlyRate(rate exchange.FundingRate) float64 {
+	interval := rate.IntervalHours
+	if interval <= 0 {
+		interval = exchange.DefaultFundingIntervalHours
+	}
+	return rate.Rate / float64(interval)
+}
@@ -0,0 +1,61 @@
+package arbitragebot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func getFundingConfig() *config.Config {
+	return &config.Config{
+		Exchange: config.ExchangeConfig{
+			ArbitrageBot: config.ArbitrageBotConfig{
+				Funding: config.FundingArbitrageConfig{
+					MinAnnualizedPercent: 20,
+					HoldingDays:          7,
+				},
+			},
+		},
+	}
+}
+
+func TestFundingDetector_OpenAndClose(t *testing.T) {
+	// round trip fees: 2 * (0.05% + 0.05%) = 0.2%, annualized over 7 days = 10.43%
+	fees := FeeSchedules{
+		"ByBit": {Default: exchange.FeeRate{Taker: 0.0005}},
+		"MEXC":  {Default: exchange.FeeRate{Taker: 0.0005}},
+	}
+	fd := NewFundingDetector(getFundingConfig(), fees)
+
+	// 0.01% / 8h vs 0.02% / 4h: (0.00005 - 0.0000125) * 8760 * 100 = 32.85%
+	events := fd.Detect("BTCUSDT", map[string]exchange.FundingRate{
+		"ByBit": {Symbol: "BTCUSDT", Rate: 0.0001, IntervalHours: 8},
+		"MEXC":  {Symbol: "BTCUSDT", Rate: 0.0002, IntervalHours: 4},
+	})
+	require.Len(t, events, 1)
+	assert.Equal(t, models.ArbitrageSpreadOpened, events[0].Status)
+	assert.Equal(t, "ByBit", events[0].LongExchange)
+	assert.Equal(t, "MEXC", events[0].ShortExchange)
+	assert.InDelta(t, 32.85, events[0].AnnualizedPercent, 1e-6)
+	assert.InDelta(t, 32.85-0.2*365/7, events[0].NetAnnualizedPercent, 1e-6)
+
+	// still above the threshold, no new events
+	events = fd.Detect("BTCUSDT", map[string]exchange.FundingRate{
+		"ByBit": {Symbol: "BTCUSDT", Rate: 0.0001, IntervalHours: 8},
+		"MEXC":  {Symbol: "BTCUSDT", Rate: 0.00025, IntervalHours: 4},
+	})
+	assert.Empty(t, events)
+
+	// gross 21.9% is above the threshold, but not net of fees
+	events = fd.Detect("BTCUSDT", map[string]exchange.FundingRate{
+		"ByBit": {Symbol: "BTCUSDT", Rate: 0.0001, IntervalHours: 8},
+		"MEXC":  {Symbol: "BTCUSDT", Rate: 0.0003, IntervalHours: 8},
+	})
+	require.Len(t, events, 1)
+	assert.Equal(t, models.ArbitrageSpreadClosed, events[0].Status)
+}
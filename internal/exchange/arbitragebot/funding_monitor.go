@@ -0,0 +1,199 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+// FundingMonitor polls funding rates of all exchanges, stores snapshots and reports funding-arbitrage signals.
+// When an exchange fails to return its rates, the spreads on it are evaluated on its last polled rates, so an
+// active spread is still closed when the rate of the other exchange moves.
+// NOT safe for concurrent use.
+type FundingMonitor struct {
+	clients   []exchange.Provider
+	repo      FundingRepository
+	detector  *FundingDetector
+	notif     notifier.Notifier
+	logger    zerolog.Logger
+	interval  time.Duration
+	symbols   map[string]struct{}
+	lastRates map[string]polledRates // exchange name -> last successfully polled rates
+}
+
+// polledRates are the funding rates of an exchange and the time they were polled at.
+type polledRates struct {
+	rates    map[string]exchange.FundingRate
+	polledAt time.Time
+}
+
+// NewFundingMonitor creates a new FundingMonitor for the given symbols.
+func NewFundingMonitor(
+	clients []exchange.Provider,
+	repo FundingRepository,
+	detector *FundingDetector,
+	notif notifier.Notifier,
+	logger zerolog.Logger,
+	interval time.Duration,
+	symbols map[string]struct{},
+) *FundingMonitor {
+	return &FundingMonitor{
+		clients:   clients,
+		repo:      repo,
+		detector:  detector,
+		notif:     notif,
+		logger:    logger,
+		interval:  interval,
+		symbols:   symbols,
+		lastRates: make(map[string]polledRates),
+	}
+}
+
+// Run polls funding rates every interval until ctx is done.
+func (m *FundingMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *FundingMonitor) poll(ctx context.Context) {
+	ratesBySymbol := make(map[string]map[string]exchange.FundingRate)
+	var snapshots []*models.FundingRate
+
+	for _, client := range m.clients {
+		exchangeName := client.GetExchangeName()
+
+		rates, err := client.GetFundingRates(ctx)
+		fresh := err == nil
+		if fresh {
+			m.lastRates[exchangeName] = polledRates{rates: rates, polledAt: time.Now()}
+		} else {
+			last, ok := m.lastRates[exchangeName]
+			if !ok {
+				m.logger.Warn().Err(err).Str("exchange", exchangeName).Msg("funding: failed to get funding rates")
+				continue
+			}
+			m.logger.Warn().
+				Err(err).
+				Str("exchange", exchangeName).
+				Time("polled_at", last.polledAt).
+				Msg("funding: failed to get funding rates, spreads are evaluated on the last rates")
+			rates = last.rates
+		}
+
+		for symbol, rate := range rates {
+			if _, ok := m.symbols[symbol]; !ok {
+				continue
+			}
+
+			if ratesBySymbol[symbol] == nil {
+				ratesBySymbol[symbol] = make(map[string]exchange.FundingRate)
+			}
+			ratesBySymbol[symbol][exchangeName] = rate
+			if fresh {
+				snapshots = append(snapshots, mapFundingRateSnapshot(exchangeName, rate))
+			}
+		}
+	}
+
+	if err := m.repo.SaveRates(ctx, snapshots); err != nil {
+		m.logger.Warn().Err(err).Msg("funding: failed to save funding rates")
+	}
+
+	for symbol, byExchange := range ratesBySymbol {
+		if len(byExchange) < 2 {
+			continue
+		}
+		for _, event := range m.detector.Detect(symbol, byExchange) {
+			m.handleEvent(ctx, event)
+		}
+	}
+}
+
+func (m *FundingMonitor) handleEvent(ctx context.Context, event *FundingSpreadEvent) {
+	netStr := strconv.FormatFloat(event.NetAnnualizedPercent, 'f', 2, 64)
+
+	switch event.Status {
+	case models.ArbitrageSpreadOpened:
+		m.logger.Warn().
+			Str("pair", event.Symbol).
+			Str("net annualized", netStr).
+			Str("long on", event.LongExchange).
+			Str("short on", event.ShortExchange).
+			Msg("💰 FUNDING SPREAD DETECTED")
+
+		msg := fmt.Sprintf(
+			"<b>💰 FUNDING: Ticker - %s</b>\n\n"+
+				"Net annualized: <code>%s%%</code> (gross <code>%s%%</code>)\n\n"+
+				"🟢 Long:  %s - <b>%s%%</b> / %dh\n"+
+				"🔴 Short: %s - <b>%s%%</b> / %dh",
+			event.Symbol, netStr, strconv.FormatFloat(event.AnnualizedPercent, 'f', 2, 64),
+			event.LongExchange, strconv.FormatFloat(event.LongRate.Rate*100, 'f', 4, 64), event.LongRate.IntervalHours,
+			event.ShortExchange, strconv.FormatFloat(event.ShortRate.Rate*100, 'f', 4, 64), event.ShortRate.IntervalHours,
+		)
+		if err := m.notif.Send(msg); err != nil {
+			m.logger.Warn().Err(err).Msg("failed to send telegram notification")
+		}
+
+		err := m.repo.CreateSpread(ctx, &models.FundingSpread{
+			Symbol:               event.Symbol,
+			LongExchange:         event.LongExchange,
+			ShortExchange:        event.ShortExchange,
+			LongRate:             decimal.NewFromFloat(event.LongRate.Rate),
+			ShortRate:            decimal.NewFromFloat(event.ShortRate.Rate),
+			AnnualizedPercent:    decimal.NewFromFloat(event.AnnualizedPercent),
+			NetAnnualizedPercent: decimal.NewFromFloat(event.NetAnnualizedPercent),
+		})
+		if err != nil {
+			m.logger.Warn().Err(err).Msgf("funding: failed to create funding spread symbol=%s", event.Symbol)
+		}
+	case models.ArbitrageSpreadClosed:
+		m.logger.Warn().
+			Str("pair", event.Symbol).
+			Str("net annualized", netStr).
+			Str("long on", event.LongExchange).
+			Str("short on", event.ShortExchange).
+			Msg("💰 FUNDING SPREAD CLOSED")
+
+		msg := fmt.Sprintf("<b>💰 FUNDING: Ticker - %s</b>\n\nFunding spread closed, net annualized: <code>%s%%</code>",
+			event.Symbol, netStr)
+		if err := m.notif.Send(msg); err != nil {
+			m.logger.Warn().Err(err).Msg("failed to send telegram notification")
+		}
+
+		if err := m.repo.CloseSpread(ctx, event.Symbol, event.LongExchange, event.ShortExchange, time.Now()); err != nil {
+			m.logger.Warn().Err(err).Msgf("funding: failed to close funding spread symbol=%s", event.Symbol)
+		}
+	}
+}
+
+func mapFundingRateSnapshot(exchangeName string, rate exchange.FundingRate) *models.FundingRate {
+	snapshot := &models.FundingRate{
+		ExchangeName:  exchangeName,
+		Symbol:        rate.Symbol,
+		Rate:          decimal.NewFromFloat(rate.Rate),
+		IntervalHours: rate.IntervalHours,
+	}
+	if rate.NextFundingTime > 0 {
+		next := time.UnixMilli(rate.NextFundingTime)
+		snapshot.NextFundingTime = &next
+	}
+	return snapshot
+}
@@ -0,0 +1,137 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+type fundingRepoStub struct {
+	rates   []*models.FundingRate
+	spreads []*models.FundingSpread
+	closed  []string
+}
+
+func (r *fundingRepoStub) SaveRates(_ context.Context, rates []*models.FundingRate) error {
+	r.rates = append(r.rates, rates...)
+	return nil
+}
+
+func (r *fundingRepoStub) FindRates(_ context.Context, exchangeName, symbol string, _, _ time.Time) ([]*models.FundingRate, error) {
+	var result []*models.FundingRate
+	for _, rate := range r.rates {
+		if rate.ExchangeName == exchangeName && rate.Symbol == symbol {
+			result = append(result, rate)
+		}
+	}
+	return result, nil
+}
+
+func (r *fundingRepoStub) CreateSpread(_ context.Context, spread *models.FundingSpread) error {
+	r.spreads = append(r.spreads, spread)
+	return nil
+}
+
+func (r *fundingRepoStub) CloseSpread(_ context.Context, symbol, longExchange, shortExchange string, _ time.Time) error {
+	r.closed = append(r.closed, getSpreadKey(symbol, longExchange, shortExchange))
+	return nil
+}
+
+func (r *fundingRepoStub) FindSpreads(_ context.Context, _ bool) ([]*models.FundingSpread, error) {
+	return r.spreads, nil
+}
+
+func TestFundingMonitor_Poll(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetFundingRates(ctx).Return(map[string]exchange.FundingRate{
+		"BTCUSDT": {Symbol: "BTCUSDT", Rate: -0.0001, IntervalHours: 8},
+		"ETHUSDT": {Symbol: "ETHUSDT", Rate: 0.0001, IntervalHours: 8},
+		"XYZUSDT": {Symbol: "XYZUSDT", Rate: 0.01, IntervalHours: 8},
+	}, nil)
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetFundingRates(ctx).Return(map[string]exchange.FundingRate{
+		"BTCUSDT": {Symbol: "BTCUSDT", Rate: 0.0003, IntervalHours: 8},
+		"ETHUSDT": {Symbol: "ETHUSDT", Rate: 0.0001, IntervalHours: 8},
+	}, nil)
+
+	bingx := exchangeMocks.NewMockProvider(t)
+	bingx.EXPECT().GetExchangeName().Return("BingX")
+	bingx.EXPECT().GetFundingRates(ctx).Return(nil, errors.New("boom"))
+
+	repo := &fundingRepoStub{}
+	notif := &notifierStub{}
+	monitor := NewFundingMonitor(
+		[]exchange.Provider{bybit, mexc, bingx},
+		repo,
+		NewFundingDetector(getFundingConfig(), FeeSchedules{}),
+		notif,
+		zerolog.New(io.Discard),
+		time.Minute,
+		map[string]struct{}{"BTCUSDT": {}, "ETHUSDT": {}},
+	)
+
+	monitor.poll(ctx)
+
+	// XYZUSDT is not a common symbol
+	assert.Len(t, repo.rates, 4)
+
+	require.Len(t, repo.spreads, 1)
+	assert.Equal(t, "BTCUSDT", repo.spreads[0].Symbol)
+	assert.Equal(t, "ByBit", repo.spreads[0].LongExchange)
+	assert.Equal(t, "MEXC", repo.spreads[0].ShortExchange)
+	assert.Len(t, notif.msgs, 1)
+}
+
+func TestFundingMonitor_Poll_LastRatesOnError(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetFundingRates(ctx).Return(map[string]exchange.FundingRate{
+		"BTCUSDT": {Symbol: "BTCUSDT", Rate: -0.0001, IntervalHours: 8},
+	}, nil).Once()
+	bybit.EXPECT().GetFundingRates(ctx).Return(nil, errors.New("boom")).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetFundingRates(ctx).Return(map[string]exchange.FundingRate{
+		"BTCUSDT": {Symbol: "BTCUSDT", Rate: 0.0003, IntervalHours: 8},
+	}, nil).Once()
+	mexc.EXPECT().GetFundingRates(ctx).Return(map[string]exchange.FundingRate{
+		"BTCUSDT": {Symbol: "BTCUSDT", Rate: -0.0001, IntervalHours: 8},
+	}, nil).Once()
+
+	repo := &fundingRepoStub{}
+	monitor := NewFundingMonitor(
+		[]exchange.Provider{bybit, mexc},
+		repo,
+		NewFundingDetector(getFundingConfig(), FeeSchedules{}),
+		&notifierStub{},
+		zerolog.New(io.Discard),
+		time.Minute,
+		map[string]struct{}{"BTCUSDT": {}},
+	)
+
+	monitor.poll(ctx)
+	require.Len(t, repo.spreads, 1)
+
+	// ByBit fails, the spread is closed on its last rate and the MEXC one
+	monitor.poll(ctx)
+	assert.Equal(t, []string{getSpreadKey("BTCUSDT", "ByBit", "MEXC")}, repo.closed)
+	assert.Len(t, repo.rates, 3, "the last rates are not stored again")
+}
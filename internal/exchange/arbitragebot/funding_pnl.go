@@ -0,0 +1,84 @@
+package arbitragebot
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// spreadFunding estimates funding of both legs of a closed spread from stored funding rate snapshots:
+// the buy leg is long on the buy exchange, the sell leg is short on the sell exchange, both held
+// from the open orders to the close orders. Returns false if funding was not measured: the funding rates
+// are not collected or there are no snapshots for the legs.
+func (a *ArbitrageBot) spreadFunding(ctx context.Context, spread *models.ArbitrageSpread, openBuy, openSell, closeBuy, closeSell *models.Order) (decimal.Decimal, bool, error) {
+	if a.fundingRepo == nil {
+		return decimal.Zero, false, nil
+	}
+
+	longFunding, longMeasured, err := a.legFunding(ctx, spread.BuyOnExchange, spread.Symbol, openBuy, closeSell)
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+
+	shortFunding, shortMeasured, err := a.legFunding(ctx, spread.SellOnExchange, spread.Symbol, openSell, closeBuy)
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+
+	return longFunding.Add(shortFunding), longMeasured || shortMeasured, nil
+}
+
+// legFunding estimates funding of the leg on the notional of its filled qty in coins, false if there are
+// no funding rate snapshots for the leg.
+func (a *ArbitrageBot) legFunding(ctx context.Context, exchangeName, symbol string, openOrder, closeOrder *models.Order) (decimal.Decimal, bool, error) {
+	from, to := openOrder.CreatedAt, closeOrder.CreatedAt
+
+	// the rate applied at the first settlement may have been published before the position was opened
+	lookBack := exchange.DefaultFundingIntervalHours * time.Hour
+	snapshots, err := a.fundingRepo.FindRates(ctx, exchangeName, symbol, from.Add(-lookBack), to)
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+	if len(snapshots) == 0 {
+		return decimal.Zero, false, nil
+	}
+
+	coins, err := a.engine.filledCoins(openOrder)
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+	notional := openOrder.AvgPrice.Mul(coins)
+
+	return estimateFunding(snapshots, from, to, notional, openOrder.Side), true, nil
+}
+
+// estimateFunding sums funding of the position for settlements in (from, to].
+// Settlement times are NextFundingTime of the snapshots (ordered oldest first), the rate of a settlement is
+// the latest snapshot taken before it. Longs pay rate * notional, shorts receive it (negative rate - vice versa).
+func estimateFunding(snapshots []*models.FundingRate, from, to time.Time, notional decimal.Decimal, side models.OrderSide) decimal.Decimal {
+	rates := make(map[time.Time]decimal.Decimal)
+	for _, s := range snapshots {
+		if s.NextFundingTime == nil {
+			continue
+		}
+		settlement := *s.NextFundingTime
+		if !settlement.After(from) || settlement.After(to) || !s.CreatedAt.Before(settlement) {
+			continue
+		}
+		rates[settlement] = s.Rate
+	}
+
+	total := decimal.Zero
+	for _, rate := range rates {
+		total = total.Add(rate.Mul(notional))
+	}
+
+	if side == models.OrderSideBuy {
+		return total.Neg()
+	}
+	return total
+}
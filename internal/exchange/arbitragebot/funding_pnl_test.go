@@ -0,0 +1,85 @@
+package arbitragebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestEstimateFunding(t *testing.T) {
+	base := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return base.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	snapshot := func(created time.Time, rate string, next time.Time) *models.FundingRate {
+		return &models.FundingRate{CreatedAt: created, Rate: decimal.RequireFromString(rate), NextFundingTime: &next}
+	}
+
+	snapshots := []*models.FundingRate{
+		snapshot(at(7, 0), "0.0001", at(8, 0)),
+		snapshot(at(7, 55), "0.0002", at(8, 0)), // latest before 08:00 settlement wins
+		snapshot(at(8, 5), "0.0003", at(16, 0)),
+		snapshot(at(15, 50), "-0.0001", at(16, 0)),
+		snapshot(at(16, 5), "0.0005", at(24, 0)), // settlement after close
+	}
+
+	from, to := at(6, 0), at(20, 0)
+	notional := decimal.NewFromInt(1000)
+
+	// (0.0002 - 0.0001) * 1000 = 0.1
+	long := estimateFunding(snapshots, from, to, notional, models.OrderSideBuy)
+	short := estimateFunding(snapshots, from, to, notional, models.OrderSideSell)
+
+	assert.Equal(t, "-0.1", long.String())
+	assert.Equal(t, "0.1", short.String())
+
+	// opened after the first settlement
+	long = estimateFunding(snapshots, at(9, 0), to, notional, models.OrderSideBuy)
+	assert.Equal(t, "0.1", long.String())
+}
+
+func TestArbitrageBot_LegFunding(t *testing.T) {
+	opened := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	settlement := opened.Add(2 * time.Hour)
+
+	engine := NewEngine(getConfig(), nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"MEXC": {"BTCUSDT": {ContractSize: decimal.RequireFromString("0.001")}},
+	}
+	repo := &fundingRepoStub{rates: []*models.FundingRate{{
+		ExchangeName:    "MEXC",
+		Symbol:          "BTCUSDT",
+		Rate:            decimal.RequireFromString("0.001"),
+		NextFundingTime: &settlement,
+		CreatedAt:       opened,
+	}}}
+	bot := &ArbitrageBot{engine: engine, fundingRepo: repo}
+
+	// 2000 contracts ordered, 1500 filled: 1.5 BTC at 100
+	openSell := &models.Order{
+		ExchangeName:     "MEXC",
+		Symbol:           "BTCUSDT",
+		Market:           models.OrderMarketLinear,
+		Side:             models.OrderSideSell,
+		Quantity:         decimal.NewFromInt(2000),
+		ExecutedQuantity: decimal.NewFromInt(1500),
+		AvgPrice:         decimal.NewFromInt(100),
+		CreatedAt:        opened,
+	}
+	closeBuy := &models.Order{CreatedAt: opened.Add(4 * time.Hour)}
+
+	funding, measured, err := bot.legFunding(t.Context(), "MEXC", "BTCUSDT", openSell, closeBuy)
+	require.NoError(t, err)
+	assert.True(t, measured)
+	assert.Equal(t, "0.15", funding.String())
+
+	// no snapshots on ByBit: funding is not measured
+	_, measured, err = bot.legFunding(t.Context(), "ByBit", "BTCUSDT", openSell, closeBuy)
+	require.NoError(t, err)
+	assert.False(t, measured)
+}
@@ -0,0 +1,86 @@
+package arbitragebot
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// FundingRepository represents a db repository for funding rate snapshots and funding spreads.
+type FundingRepository interface {
+	SaveRates(ctx context.Context, rates []*models.FundingRate) error
+	FindRates(ctx context.Context, exchangeName, symbol string, from, to time.Time) ([]*models.FundingRate, error)
+	CreateSpread(ctx context.Context, spread *models.FundingSpread) error
+	CloseSpread(ctx context.Context, symbol, longExchange, shortExchange string, closedAt time.Time) error
+	FindSpreads(ctx context.Context, activeOnly bool) ([]*models.FundingSpread, error)
+}
+
+// GormFundingRepository is a GORM implementation of FundingRepository interface.
+type GormFundingRepository struct {
+	db *gorm.DB
+}
+
+// NewFundingRepository creates a new GormFundingRepository.
+func NewFundingRepository(db *gorm.DB) *GormFundingRepository {
+	return &GormFundingRepository{db: db}
+}
+
+// SaveRates inserts funding rate snapshots.
+func (r *GormFundingRepository) SaveRates(ctx context.Context, rates []*models.FundingRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(rates, 500).Error
+}
+
+// FindRates finds funding rate snapshots of the symbol on the exchange taken in [from, to], oldest first.
+func (r *GormFundingRepository) FindRates(
+	ctx context.Context,
+	exchangeName, symbol string,
+	from, to time.Time,
+) ([]*models.FundingRate, error) {
+	var rates []*models.FundingRate
+
+	err := r.db.WithContext(ctx).
+		Model(&models.FundingRate{}).
+		Where("exchange_name = ? AND symbol = ? AND created_at BETWEEN ? AND ?", exchangeName, symbol, from, to).
+		Order("created_at ASC").
+		Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// CreateSpread creates a new funding spread.
+func (r *GormFundingRepository) CreateSpread(ctx context.Context, spread *models.FundingSpread) error {
+	return r.db.WithContext(ctx).Create(spread).Error
+}
+
+// CloseSpread sets closed_at of the active funding spread of the symbol and exchanges.
+func (r *GormFundingRepository) CloseSpread(ctx context.Context, symbol, longExchange, shortExchange string, closedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.FundingSpread{}).
+		Where("symbol = ? AND long_exchange = ? AND short_exchange = ? AND closed_at IS NULL", symbol, longExchange, shortExchange).
+		Updates(&models.FundingSpread{ClosedAt: &closedAt, UpdatedAt: closedAt}).Error
+}
+
+// FindSpreads finds funding spreads, newest first.
+func (r *GormFundingRepository) FindSpreads(ctx context.Context, activeOnly bool) ([]*models.FundingSpread, error) {
+	var spreads []*models.FundingSpread
+
+	tx := r.db.WithContext(ctx).Model(&models.FundingSpread{})
+	if activeOnly {
+		tx = tx.Where("closed_at IS NULL")
+	}
+
+	if err := tx.Order("created_at DESC").Find(&spreads).Error; err != nil {
+		return nil, err
+	}
+
+	return spreads, nil
+}
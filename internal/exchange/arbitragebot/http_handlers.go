@@ -1,28 +1,50 @@
 package arbitragebot
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
 )
 
 // HTTPHandlers contains HTTP handlers for the arbitrage bot.
 type HTTPHandlers struct {
-	repo ArbitrageSpreadRepository
+	repo          ArbitrageSpreadRepository
+	fundingRepo   FundingRepository
+	blacklistRepo BlacklistRepository
 }
 
-// NewHTTPHandlers creates a new instance of HttpHandlers with the provided repository.
-func NewHTTPHandlers(repo ArbitrageSpreadRepository) *HTTPHandlers {
+// NewHTTPHandlers creates a new instance of HttpHandlers with the provided repositories.
+func NewHTTPHandlers(repo ArbitrageSpreadRepository, fundingRepo FundingRepository, blacklistRepo BlacklistRepository) *HTTPHandlers {
 	return &HTTPHandlers{
-		repo: repo,
+		repo:          repo,
+		fundingRepo:   fundingRepo,
+		blacklistRepo: blacklistRepo,
 	}
 }
 
-// GetSpreadsHandler handles the HTTP request to get all arbitrage spreads.
+// GetSpreadsHandler handles the HTTP request to get arbitrage spreads (?paper=true - only paper ones,
+// ?paper=false - only live ones, all by default).
 func (h *HTTPHandlers) GetSpreadsHandler(c *gin.Context) {
-	spreads, err := h.repo.FindAll(c.Request.Context(), FindFilter{})
+	var filter FindFilter
+	if value := c.Query("paper"); value != "" {
+		paper, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "paper must be true or false"})
+			return
+		}
+		filter.Paper = &paper
+	}
+
+	spreads, err := h.repo.FindAll(c.Request.Context(), filter)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -30,35 +52,181 @@ func (h *HTTPHandlers) GetSpreadsHandler(c *gin.Context) {
 	}
 
 	type spreadResponse struct {
-		ID               string          `json:"id"`
-		CreatedAt        time.Time       `json:"created_at"`
-		UpdatedAt        time.Time       `json:"updated_at"`
-		Symbol           string          `json:"symbol"`
-		BuyOnExchange    string          `json:"buy_on_exchange"`
-		SellOnExchange   string          `json:"sell_on_exchange"`
-		BuyPrice         decimal.Decimal `json:"buy_price"`
-		SellPrice        decimal.Decimal `json:"sell_price"`
-		SpreadPercent    decimal.Decimal `json:"spread_percent"`
-		MaxSpreadPercent decimal.Decimal `json:"max_spread_percent"`
-		Status           string          `json:"status"`
+		ID                  string          `json:"id"`
+		CreatedAt           time.Time       `json:"created_at"`
+		UpdatedAt           time.Time       `json:"updated_at"`
+		Symbol              string          `json:"symbol"`
+		BuyOnExchange       string          `json:"buy_on_exchange"`
+		SellOnExchange      string          `json:"sell_on_exchange"`
+		BuyPrice            decimal.Decimal `json:"buy_price"`
+		SellPrice           decimal.Decimal `json:"sell_price"`
+		SpreadPercent       decimal.Decimal `json:"spread_percent"`
+		MaxSpreadPercent    decimal.Decimal `json:"max_spread_percent"`
+		NetSpreadPercent    decimal.Decimal `json:"net_spread_percent"`
+		MaxNetSpreadPercent decimal.Decimal `json:"max_net_spread_percent"`
+		Status              string          `json:"status"`
+		Paper               bool            `json:"paper"`
 	}
 
 	var response []spreadResponse
 	for _, spread := range spreads {
 		response = append(response, spreadResponse{
-			ID:               spread.ID.String(),
-			CreatedAt:        spread.CreatedAt,
-			UpdatedAt:        spread.UpdatedAt,
-			Symbol:           spread.Symbol,
-			BuyOnExchange:    spread.BuyOnExchange,
-			SellOnExchange:   spread.SellOnExchange,
-			BuyPrice:         spread.BuyPrice,
-			SellPrice:        spread.SellPrice,
-			SpreadPercent:    spread.SpreadPercent,
-			MaxSpreadPercent: spread.MaxSpreadPercent,
-			Status:           string(spread.Status),
+			ID:                  spread.ID.String(),
+			CreatedAt:           spread.CreatedAt,
+			UpdatedAt:           spread.UpdatedAt,
+			Symbol:              spread.Symbol,
+			BuyOnExchange:       spread.BuyOnExchange,
+			SellOnExchange:      spread.SellOnExchange,
+			BuyPrice:            spread.BuyPrice,
+			SellPrice:           spread.SellPrice,
+			SpreadPercent:       spread.SpreadPercent,
+			MaxSpreadPercent:    spread.MaxSpreadPercent,
+			NetSpreadPercent:    spread.NetSpreadPercent,
+			MaxNetSpreadPercent: spread.MaxNetSpreadPercent,
+			Status:              string(spread.Status),
+			Paper:               spread.Paper,
 		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// GetFundingSpreadsHandler handles the HTTP request to get funding spreads (?active=true - only not closed ones).
+func (h *HTTPHandlers) GetFundingSpreadsHandler(c *gin.Context) {
+	activeOnly := c.Query("active") == "true"
+
+	spreads, err := h.fundingRepo.FindSpreads(c.Request.Context(), activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	type fundingSpreadResponse struct {
+		ID                   string          `json:"id"`
+		CreatedAt            time.Time       `json:"created_at"`
+		ClosedAt             *time.Time      `json:"closed_at"`
+		Symbol               string          `json:"symbol"`
+		LongExchange         string          `json:"long_exchange"`
+		ShortExchange        string          `json:"short_exchange"`
+		LongRate             decimal.Decimal `json:"long_rate"`
+		ShortRate            decimal.Decimal `json:"short_rate"`
+		AnnualizedPercent    decimal.Decimal `json:"annualized_percent"`
+		NetAnnualizedPercent decimal.Decimal `json:"net_annualized_percent"`
+	}
+
+	response := make([]fundingSpreadResponse, 0, len(spreads))
+	for _, spread := range spreads {
+		response = append(response, fundingSpreadResponse{
+			ID:                   spread.ID.String(),
+			CreatedAt:            spread.CreatedAt,
+			ClosedAt:             spread.ClosedAt,
+			Symbol:               spread.Symbol,
+			LongExchange:         spread.LongExchange,
+			ShortExchange:        spread.ShortExchange,
+			LongRate:             spread.LongRate,
+			ShortRate:            spread.ShortRate,
+			AnnualizedPercent:    spread.AnnualizedPercent,
+			NetAnnualizedPercent: spread.NetAnnualizedPercent,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type blacklistEntryResponse struct {
+	ID        string     `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Symbol    string     `json:"symbol"`
+	Exchange  string     `json:"exchange"`
+	Reason    string     `json:"reason"`
+}
+
+func toBlacklistEntryResponse(entry *models.BlacklistEntry) blacklistEntryResponse {
+	return blacklistEntryResponse{
+		ID:        entry.ID.String(),
+		CreatedAt: entry.CreatedAt,
+		ExpiresAt: entry.ExpiresAt,
+		Symbol:    entry.Symbol,
+		Exchange:  entry.ExchangeName,
+		Reason:    entry.Reason,
+	}
+}
+
+// GetBlacklistHandler handles the HTTP request to get the active symbol blacklist entries.
+func (h *HTTPHandlers) GetBlacklistHandler(c *gin.Context) {
+	entries, err := h.blacklistRepo.FindActive(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]blacklistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, toBlacklistEntryResponse(entry))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateBlacklistEntryRequest is DTO for adding a symbol to the blacklist.
+// An empty exchange bans the symbol on all exchanges, an empty ttl (e.g. "30m", "24h") never expires.
+type CreateBlacklistEntryRequest struct {
+	Symbol   string `json:"symbol" binding:"required"`
+	Exchange string `json:"exchange"`
+	Reason   string `json:"reason" binding:"required"`
+	TTL      string `json:"ttl"`
+}
+
+// CreateBlacklistEntryHandler handles the HTTP request to add a symbol to the blacklist.
+// The bot applies it on the next blacklist refresh.
+func (h *HTTPHandlers) CreateBlacklistEntryHandler(c *gin.Context) {
+	var request CreateBlacklistEntryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry := &models.BlacklistEntry{
+		Symbol:       strings.ToUpper(request.Symbol),
+		ExchangeName: request.Exchange,
+		Reason:       request.Reason,
+	}
+	if request.TTL != "" {
+		ttl, err := time.ParseDuration(request.TTL)
+		if err != nil || ttl <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ttl must be a positive duration, e.g. 30m or 24h"})
+			return
+		}
+		expiresAt := time.Now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	if err := h.blacklistRepo.Create(c.Request.Context(), entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBlacklistEntryResponse(entry))
+}
+
+// DeleteBlacklistEntryHandler handles the HTTP request to remove an entry from the blacklist.
+// The bot applies it on the next blacklist refresh.
+func (h *HTTPHandlers) DeleteBlacklistEntryHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.blacklistRepo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "blacklist entry not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
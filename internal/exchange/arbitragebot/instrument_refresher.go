@@ -0,0 +1,243 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+// SymbolsChangeFunc is called by InstrumentRefresher when tradable symbols are listed or delisted,
+// to subscribe the added symbols and unsubscribe the removed ones.
+type SymbolsChangeFunc func(ctx context.Context, added, removed []string)
+
+// InstrumentRefresher periodically reloads contract specs of all venues into the engine, reports listings,
+// delistings and parameter changes, and keeps the market data subscriptions on the tradable symbols.
+// A symbol with an open position stays subscribed until the position is gone. NOT safe for concurrent use.
+type InstrumentRefresher struct {
+	engine   *Engine
+	venues   []Venue
+	notif    notifier.Notifier
+	logger   zerolog.Logger
+	interval time.Duration
+	onChange SymbolsChangeFunc
+
+	subscribed map[string]struct{} // tradable symbols with market data subscriptions
+}
+
+// NewInstrumentRefresher creates a new InstrumentRefresher of the given venues, subscribed are the tradable
+// symbols the market data is subscribed to on startup.
+func NewInstrumentRefresher(
+	engine *Engine,
+	venues []Venue,
+	notif notifier.Notifier,
+	logger zerolog.Logger,
+	interval time.Duration,
+	subscribed map[string]struct{},
+	onChange SymbolsChangeFunc,
+) *InstrumentRefresher {
+	symbols := make(map[string]struct{}, len(subscribed))
+	for symbol := range subscribed {
+		symbols[symbol] = struct{}{}
+	}
+
+	return &InstrumentRefresher{
+		engine:     engine,
+		venues:     venues,
+		notif:      notif,
+		logger:     logger,
+		interval:   interval,
+		onChange:   onChange,
+		subscribed: symbols,
+	}
+}
+
+// Run refreshes the instruments every interval. Blocks until ctx is cancelled.
+func (r *InstrumentRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh reloads the instruments of all venues once. A venue that fails to load or returns no instruments
+// keeps its cached ones.
+func (r *InstrumentRefresher) Refresh(ctx context.Context) {
+	current := r.engine.Instruments()
+	var report []string
+
+	for _, venue := range r.venues {
+		venueName := venue.Name()
+		client := r.engine.clientFor(venueName)
+		if client == nil {
+			continue
+		}
+
+		instruments, err := client.GetInstruments(ctx, venue.Category)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("exchange", venueName).Msg("instruments: refresh failed")
+			continue
+		}
+		if len(instruments) == 0 {
+			r.logger.Warn().Str("exchange", venueName).Msg("instruments: empty response, cache kept")
+			continue
+		}
+
+		diff := diffInstruments(current[venueName], instruments)
+		// open positions still have to be closed with the last known specs
+		for _, symbol := range diff.delisted {
+			if r.engine.pm.HasSymbol(symbol) {
+				instruments[symbol] = current[venueName][symbol]
+			}
+		}
+		r.engine.SetInstruments(venueName, instruments)
+		if diff.empty() {
+			continue
+		}
+
+		r.logger.Info().
+			Str("exchange", venueName).
+			Strs("listed", diff.listed).
+			Strs("delisted", diff.delisted).
+			Strs("changed", diff.changed).
+			Msg("instruments: updated")
+		report = append(report, diff.describe(venueName)...)
+	}
+
+	added, removed, kept := r.updateSubscriptions()
+	if len(added) > 0 || len(removed) > 0 {
+		r.logger.Info().Strs("added", added).Strs("removed", removed).Msg("instruments: tradable symbols changed")
+		r.onChange(ctx, added, removed)
+	}
+	if len(added) > 0 {
+		report = append(report, "Subscribed: "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		report = append(report, "Unsubscribed: "+strings.Join(removed, ", "))
+	}
+	if len(kept) > 0 {
+		report = append(report, "⚠️ Not tradable anymore, kept for open positions: "+strings.Join(kept, ", "))
+	}
+
+	if len(report) == 0 {
+		return
+	}
+	msg := "<b>🔄 ARBITRAGE: instruments changed</b>\n\n" + strings.Join(report, "\n")
+	if err := r.notif.Send(msg); err != nil {
+		r.logger.Warn().Err(err).Msg("failed to send telegram notification")
+	}
+}
+
+// updateSubscriptions returns tradable symbols that are not subscribed yet and subscribed ones that are not
+// tradable anymore, the latter split by whether they can be removed or still have an open position.
+func (r *InstrumentRefresher) updateSubscriptions() (added, removed, kept []string) {
+	tradable := tradableSymbols(r.engine.Instruments())
+
+	for symbol := range tradable {
+		if _, ok := r.subscribed[symbol]; !ok {
+			added = append(added, symbol)
+			r.subscribed[symbol] = struct{}{}
+		}
+	}
+	for symbol := range r.subscribed {
+		if _, ok := tradable[symbol]; ok {
+			continue
+		}
+		if r.engine.pm.HasSymbol(symbol) {
+			kept = append(kept, symbol)
+			continue
+		}
+		removed = append(removed, symbol)
+		delete(r.subscribed, symbol)
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(kept)
+	return added, removed, kept
+}
+
+// instrumentsDiff is the difference between the cached and the reloaded instruments of a venue.
+type instrumentsDiff struct {
+	listed   []string
+	delisted []string
+	changed  []string // "BTCUSDT vol step 0.001 → 0.01"
+}
+
+func (d instrumentsDiff) empty() bool {
+	return len(d.listed) == 0 && len(d.delisted) == 0 && len(d.changed) == 0
+}
+
+// describe returns the report lines of the venue.
+func (d instrumentsDiff) describe(venueName string) []string {
+	var lines []string
+	if len(d.listed) > 0 {
+		lines = append(lines, fmt.Sprintf("%s listed: %s", venueName, strings.Join(d.listed, ", ")))
+	}
+	if len(d.delisted) > 0 {
+		lines = append(lines, fmt.Sprintf("%s delisted: %s", venueName, strings.Join(d.delisted, ", ")))
+	}
+	for _, change := range d.changed {
+		lines = append(lines, fmt.Sprintf("%s: %s", venueName, change))
+	}
+	return lines
+}
+
+// diffInstruments compares the cached instruments of a venue with the reloaded ones.
+func diffInstruments(old, updated map[string]exchange.Instrument) instrumentsDiff {
+	var diff instrumentsDiff
+
+	for symbol, inst := range updated {
+		prev, ok := old[symbol]
+		if !ok {
+			diff.listed = append(diff.listed, symbol)
+			continue
+		}
+		diff.changed = append(diff.changed, instrumentChanges(prev, inst)...)
+	}
+	for symbol := range old {
+		if _, ok := updated[symbol]; !ok {
+			diff.delisted = append(diff.delisted, symbol)
+		}
+	}
+
+	sort.Strings(diff.listed)
+	sort.Strings(diff.delisted)
+	sort.Strings(diff.changed)
+	return diff
+}
+
+// instrumentChanges describes the changed contract parameters of the symbol.
+func instrumentChanges(prev, updated exchange.Instrument) []string {
+	fields := []struct {
+		name      string
+		prev, cur decimal.Decimal
+	}{
+		{name: "vol step", prev: prev.VolStep, cur: updated.VolStep},
+		{name: "min vol", prev: prev.MinVol, cur: updated.MinVol},
+		{name: "price step", prev: prev.PriceStep, cur: updated.PriceStep},
+		{name: "contract size", prev: prev.ContractSize, cur: updated.ContractSize},
+	}
+
+	var changes []string
+	for _, field := range fields {
+		if !field.prev.Equal(field.cur) {
+			changes = append(changes, fmt.Sprintf("%s %s %s → %s", updated.Symbol, field.name, field.prev, field.cur))
+		}
+	}
+	return changes
+}
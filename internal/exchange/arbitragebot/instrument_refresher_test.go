@@ -0,0 +1,112 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func testInstrument(symbol, volStep string) exchange.Instrument {
+	return exchange.Instrument{
+		Symbol:       symbol,
+		VolStep:      decimal.RequireFromString(volStep),
+		MinVol:       decimal.RequireFromString(volStep),
+		PriceStep:    decimal.RequireFromString("0.1"),
+		ContractSize: decimal.NewFromInt(1),
+	}
+}
+
+func testInstruments(instruments ...exchange.Instrument) map[string]exchange.Instrument {
+	bySymbol := make(map[string]exchange.Instrument, len(instruments))
+	for _, inst := range instruments {
+		bySymbol[inst.Symbol] = inst
+	}
+	return bySymbol
+}
+
+func TestDiffInstruments(t *testing.T) {
+	old := testInstruments(testInstrument("BTCUSDT", "0.001"), testInstrument("ETHUSDT", "0.01"), testInstrument("XRPUSDT", "1"))
+	updated := testInstruments(testInstrument("BTCUSDT", "0.001"), testInstrument("ETHUSDT", "0.1"), testInstrument("SOLUSDT", "0.1"))
+
+	diff := diffInstruments(old, updated)
+
+	assert.Equal(t, []string{"SOLUSDT"}, diff.listed)
+	assert.Equal(t, []string{"XRPUSDT"}, diff.delisted)
+	assert.Equal(t, []string{"ETHUSDT min vol 0.01 → 0.1", "ETHUSDT vol step 0.01 → 0.1"}, diff.changed)
+
+	assert.True(t, diffInstruments(old, old).empty())
+}
+
+func TestInstrumentRefresher_Refresh(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetInstruments(ctx, exchange.CategoryLinear).Return(testInstruments(
+		testInstrument("BTCUSDT", "0.01"),
+		testInstrument("SOLUSDT", "0.1"),
+	), nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetInstruments(ctx, exchange.CategoryLinear).Return(nil, errors.New("maintenance")).Once()
+
+	notif := &notifierStub{}
+	engine := NewEngine(getConfig(), []exchange.Provider{bybit, mexc}, nil, &repoStub{}, notif, zerolog.Nop(), MarketStrategy{})
+	engine.SetInstruments("ByBit", testInstruments(
+		testInstrument("BTCUSDT", "0.001"),
+		testInstrument("ETHUSDT", "0.01"),
+		testInstrument("XRPUSDT", "1"),
+	))
+	engine.SetInstruments("MEXC", testInstruments(
+		testInstrument("BTCUSDT", "0.001"),
+		testInstrument("ETHUSDT", "0.01"),
+		testInstrument("XRPUSDT", "1"),
+		testInstrument("SOLUSDT", "0.1"),
+	))
+	// the open position keeps XRPUSDT subscribed and its specs cached
+	engine.pm.Add(&Position{Symbol: "XRPUSDT", BuyExchange: "ByBit", SellExchange: "MEXC", State: PositionStateOpen})
+
+	venues := []Venue{
+		{Exchange: "ByBit", Category: exchange.CategoryLinear},
+		{Exchange: "MEXC", Category: exchange.CategoryLinear},
+	}
+	subscribed := map[string]struct{}{"BTCUSDT": {}, "ETHUSDT": {}, "XRPUSDT": {}}
+
+	var added, removed []string
+	refresher := NewInstrumentRefresher(engine, venues, notif, zerolog.Nop(), 0, subscribed,
+		func(_ context.Context, a, r []string) {
+			added, removed = a, r
+		})
+
+	refresher.Refresh(ctx)
+
+	assert.Equal(t, []string{"SOLUSDT"}, added)
+	assert.Equal(t, []string{"ETHUSDT"}, removed)
+
+	// the failed venue keeps its cache
+	assert.Len(t, engine.Instruments()["MEXC"], 4)
+
+	inst, err := engine.instrumentFor("BTCUSDT", "ByBit")
+	require.NoError(t, err)
+	assert.Equal(t, "0.01", inst.VolStep.String())
+	_, err = engine.instrumentFor("ETHUSDT", "ByBit")
+	assert.Error(t, err)
+	_, err = engine.instrumentFor("XRPUSDT", "ByBit")
+	assert.NoError(t, err)
+
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "ByBit listed: SOLUSDT")
+	assert.Contains(t, notif.msgs[0], "ByBit delisted: ETHUSDT, XRPUSDT")
+	assert.Contains(t, notif.msgs[0], "ByBit: BTCUSDT vol step 0.001 → 0.01")
+	assert.Contains(t, notif.msgs[0], "Subscribed: SOLUSDT")
+	assert.Contains(t, notif.msgs[0], "Unsubscribed: ETHUSDT")
+}
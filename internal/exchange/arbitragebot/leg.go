@@ -10,8 +10,21 @@ type Leg struct {
 	OrderID         uuid.UUID
 	ExchangeOrderID string // populated after CreateOrder, needed for CancelOrder on exchanges that require it (e.g. MEXC)
 
-	// Populated on fill — used for partial fill tracking (limit orders)
+	// Populated on fill: cumulative filled qty (exchange units) and its average price.
+	// Confirmed is set once the order is fully filled or the exchange canceled its rest.
 	FilledQty decimal.Decimal
 	AvgPrice  decimal.Decimal
 	Confirmed bool
 }
+
+// applyFill stores the cumulative fill of the leg order. Execution events may be retransmitted or arrive
+// out of order, so the filled qty never shrinks.
+func (l *Leg) applyFill(execPrice, execQty, leavesQty decimal.Decimal) {
+	if execQty.GreaterThan(l.FilledQty) {
+		l.FilledQty = execQty
+		l.AvgPrice = execPrice
+	}
+	if !leavesQty.IsPositive() {
+		l.Confirmed = true
+	}
+}
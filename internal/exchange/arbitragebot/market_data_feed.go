@@ -0,0 +1,119 @@
+package arbitragebot
+
+import (
+	"context"
+	"slices"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+// symbolsChange is a change of the tradable symbols reported by InstrumentRefresher.
+type symbolsChange struct {
+	added   []string
+	removed []string
+}
+
+// feedBatchSize is the max number of symbols subscribed together. Removing a symbol re-subscribes the rest of
+// its batch, small batches keep it from reconnecting the whole universe subscribed at startup.
+const feedBatchSize = 50
+
+// feedBatch is a set of symbols subscribed together, cancelling it closes all of its subscriptions.
+type feedBatch struct {
+	symbols map[string]struct{}
+	cancel  context.CancelFunc
+}
+
+// marketDataFeed keeps the WebSocket subscriptions of the bot on the tradable symbols. Symbols are subscribed
+// in batches of up to feedBatchSize: added symbols get new batches, removing a symbol re-subscribes the rest
+// of its batch before the old one is cancelled, so the other symbols don't lose prices. NOT safe for
+// concurrent use.
+type marketDataFeed struct {
+	bot           *ArbitrageBot
+	tradeEventsCh chan<- PriceChangeEvent
+	errCh         chan<- error
+	batches       []*feedBatch
+	symbols       map[string]struct{}
+}
+
+func newMarketDataFeed(bot *ArbitrageBot, tradeEventsCh chan<- PriceChangeEvent, errCh chan<- error) *marketDataFeed {
+	return &marketDataFeed{
+		bot:           bot,
+		tradeEventsCh: tradeEventsCh,
+		errCh:         errCh,
+		symbols:       make(map[string]struct{}),
+	}
+}
+
+// Has reports whether the symbol is subscribed, events of removed symbols still in flight are dropped by it.
+func (f *marketDataFeed) Has(symbol string) bool {
+	_, ok := f.symbols[symbol]
+	return ok
+}
+
+// Add subscribes the symbols in new batches.
+func (f *marketDataFeed) Add(ctx context.Context, symbols []string) {
+	for chunk := range slices.Chunk(symbols, feedBatchSize) {
+		f.subscribe(ctx, chunk)
+	}
+}
+
+// subscribe subscribes the symbols in a new batch.
+func (f *marketDataFeed) subscribe(ctx context.Context, symbols []string) {
+	batchCtx, cancel := context.WithCancel(ctx)
+	batch := &feedBatch{symbols: make(map[string]struct{}, len(symbols)), cancel: cancel}
+	for _, s := range symbols {
+		batch.symbols[s] = struct{}{}
+		f.symbols[s] = struct{}{}
+	}
+	f.batches = append(f.batches, batch)
+
+	cfg := f.bot.cfg.Exchange.ArbitrageBot
+	if cfg.PriceSource == config.PriceSourceTrade {
+		go f.bot.grabTrade(batchCtx, symbols, f.tradeEventsCh, f.errCh)
+	} else {
+		go f.bot.grabBookTicker(batchCtx, symbols, f.tradeEventsCh, f.errCh)
+	}
+	if cfg.DepthSizing {
+		go f.bot.grabOrderBooks(batchCtx, symbols, f.errCh)
+	}
+}
+
+// Remove unsubscribes the symbols.
+func (f *marketDataFeed) Remove(ctx context.Context, symbols []string) {
+	removed := make(map[string]struct{}, len(symbols))
+	for _, s := range symbols {
+		removed[s] = struct{}{}
+		delete(f.symbols, s)
+	}
+
+	batches := f.batches[:0]
+	var stale []*feedBatch
+	var resubscribe []string
+	for _, batch := range f.batches {
+		affected := false
+		for s := range batch.symbols {
+			if _, ok := removed[s]; ok {
+				affected = true
+				break
+			}
+		}
+		if !affected {
+			batches = append(batches, batch)
+			continue
+		}
+
+		stale = append(stale, batch)
+		for s := range batch.symbols {
+			if _, ok := removed[s]; !ok {
+				resubscribe = append(resubscribe, s)
+			}
+		}
+	}
+	f.batches = batches
+
+	// make before break: the rest of the symbols are subscribed again before the old batches are closed
+	f.Add(ctx, resubscribe)
+	for _, batch := range stale {
+		batch.cancel()
+	}
+}
@@ -0,0 +1,42 @@
+package arbitragebot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketDataFeed_RemoveResubscribesOnlyItsBatch(t *testing.T) {
+	cfg := getConfig()
+	// no venues, the batches subscribe nothing
+	bot := &ArbitrageBot{
+		cfg:    cfg,
+		logger: zerolog.Nop(),
+		engine: NewEngine(cfg, nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{}),
+	}
+	feed := newMarketDataFeed(bot, make(chan PriceChangeEvent), make(chan error, 1))
+
+	symbols := make([]string, 2*feedBatchSize+10)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%dUSDT", i)
+	}
+	feed.Add(t.Context(), symbols)
+	require.Len(t, feed.batches, 3)
+	first, second, last := feed.batches[0], feed.batches[1], feed.batches[2]
+	assert.Len(t, last.symbols, 10)
+
+	feed.Remove(t.Context(), []string{symbols[0], symbols[1]})
+
+	assert.False(t, feed.Has(symbols[0]))
+	assert.True(t, feed.Has(symbols[2]))
+	// the other batches keep their subscriptions, the rest of the first one is subscribed again
+	require.Len(t, feed.batches, 3)
+	assert.Same(t, second, feed.batches[0])
+	assert.Same(t, last, feed.batches[1])
+	assert.NotSame(t, first, feed.batches[2])
+	assert.Len(t, feed.batches[2].symbols, feedBatchSize-2)
+	assert.NotContains(t, feed.batches[2].symbols, symbols[0])
+}
@@ -8,7 +8,11 @@ import (
 	"github.com/lucrumx/bot/internal/models"
 )
 
-// MarketStrategy executes both open and close legs as market orders with no fill timeout.
+// marketFillTimeout is how long market open legs wait for fills. They fill at once unless the exchange
+// cancels them or the execution event is lost, the watcher cleans up the position then.
+const marketFillTimeout = 30 * time.Second
+
+// MarketStrategy executes both open and close legs as market orders.
 // Returns nil prices everywhere so Engine.buildOrder constructs market-type orders.
 type MarketStrategy struct{}
 
@@ -22,9 +26,9 @@ func (MarketStrategy) ClosePrice(_ *Position, _ models.OrderSide) *decimal.Decim
 	return nil
 }
 
-// FillTimeout returns 0 because market orders fill instantly; no watcher is needed.
+// FillTimeout returns marketFillTimeout.
 func (MarketStrategy) FillTimeout() time.Duration {
-	return 0
+	return marketFillTimeout
 }
 
 // Validate is a no-op for MarketStrategy — there is nothing to misconfigure.
@@ -0,0 +1,44 @@
+package arbitragebot
+
+import (
+	"sync"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// orderBookStore keeps L2 order books per exchange and symbol, fed from websocket order book streams.
+type orderBookStore struct {
+	mu    sync.RWMutex
+	books map[string]map[string]*exchange.OrderBook // exchange -> symbol -> book
+}
+
+func newOrderBookStore() *orderBookStore {
+	return &orderBookStore{
+		books: make(map[string]map[string]*exchange.OrderBook),
+	}
+}
+
+// Apply applies order book update of the exchange, creating the book on the first update.
+func (s *orderBookStore) Apply(exchangeName string, update exchange.OrderBookUpdate) {
+	s.mu.Lock()
+	if s.books[exchangeName] == nil {
+		s.books[exchangeName] = make(map[string]*exchange.OrderBook)
+	}
+	book, ok := s.books[exchangeName][update.Symbol]
+	if !ok {
+		book = exchange.NewOrderBook(update.Symbol)
+		s.books[exchangeName][update.Symbol] = book
+	}
+	s.mu.Unlock()
+
+	book.Apply(update)
+}
+
+// Get returns the order book of the symbol on the exchange.
+func (s *orderBookStore) Get(exchangeName, symbol string) (*exchange.OrderBook, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	book, ok := s.books[exchangeName][symbol]
+	return book, ok
+}
@@ -0,0 +1,40 @@
+package arbitragebot
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/simulator"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+// WithPaperTrading wraps the clients into simulated exchanges: market data comes from the clients, orders
+// are filled by the simulators against it with the fee schedules of the clients, nothing is sent to the
+// exchanges.
+func WithPaperTrading(clients []exchange.Provider, cfg config.PaperConfig, logger zerolog.Logger) []exchange.Provider {
+	simCfg := simulator.Config{
+		Latency:         cfg.Latency,
+		SlippagePercent: cfg.SlippagePercent,
+		FillRule:        simulator.FillRule(cfg.FillRule),
+		VolumeLimited:   cfg.VolumeLimited,
+		Balance:         decimal.NewFromFloat(cfg.BalanceUSDT),
+	}
+
+	wrapped := make([]exchange.Provider, 0, len(clients))
+	for _, client := range clients {
+		wrapped = append(wrapped, simulator.New(client, simCfg, clock.Real(), logger))
+	}
+	return wrapped
+}
+
+// paperNotifier marks the notifications of a paper trading bot.
+type paperNotifier struct {
+	notifier.Notifier
+}
+
+func (n paperNotifier) Send(message string) error {
+	return n.Notifier.Send("📝 <b>PAPER</b>\n" + message)
+}
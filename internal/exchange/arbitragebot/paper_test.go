@@ -0,0 +1,131 @@
+package arbitragebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/simulator"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func TestEngine_Paper_TagsRecords(t *testing.T) {
+	ctx := t.Context()
+
+	orders := newMemoryOrderRepository()
+	spreads := &memorySpreadRepository{}
+	trades := newMemoryTradeRepository()
+
+	engine := NewEngine(getConfig(), nil, orders, spreads, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.SetTradeRepository(trades)
+	engine.SetPaper(true)
+
+	pos := &Position{
+		ID:           uuid.New(),
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "BingX",
+		QtyCoins:     decimal.NewFromInt(1),
+		OpenBuyLeg:   Leg{OrderID: uuid.New()},
+		OpenSellLeg:  Leg{OrderID: uuid.New()},
+		State:        PositionStateOpening,
+	}
+	engine.saveSpread(ctx, &SpreadEvent{
+		Symbol:         "BTCUSDT",
+		BuyOnExchange:  "ByBit",
+		SellOnExchange: "BingX",
+		Status:         models.ArbitrageSpreadOpened,
+	}, pos)
+	engine.saveOrder(&models.Order{ID: pos.OpenBuyLeg.OrderID, Symbol: "BTCUSDT", ExchangeName: "ByBit"})
+	engine.saveTrade(pos)
+
+	found, err := spreads.FindAll(ctx, FindFilter{})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.True(t, found[0].Paper)
+
+	order, err := orders.GetByID(ctx, pos.OpenBuyLeg.OrderID)
+	require.NoError(t, err)
+	assert.True(t, order.Paper)
+
+	require.Eventually(t, func() bool { return len(trades.all()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.True(t, trades.all()[0].Paper)
+}
+
+func TestWithPaperTrading(t *testing.T) {
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bingx := exchangeMocks.NewMockProvider(t)
+	bingx.EXPECT().GetExchangeName().Return("BingX")
+
+	clients := WithPaperTrading([]exchange.Provider{bybit, bingx}, config.PaperConfig{
+		Latency:     50 * time.Millisecond,
+		FillRule:    "through",
+		BalanceUSDT: 1000,
+	}, zerolog.Nop())
+
+	require.Len(t, clients, 2)
+	for i, name := range []string{"ByBit", "BingX"} {
+		assert.IsType(t, &simulator.Exchange{}, clients[i])
+		assert.Equal(t, name, clients[i].GetExchangeName())
+	}
+
+	balances, err := clients[0].GetBalances(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.True(t, balances[0].Free.Equal(decimal.NewFromInt(1000)))
+}
+
+func TestPaperNotifier(t *testing.T) {
+	notif := &notifierStub{}
+	require.NoError(t, paperNotifier{Notifier: notif}.Send("position open"))
+
+	require.Len(t, notif.msgs, 1)
+	assert.Equal(t, "📝 <b>PAPER</b>\nposition open", notif.msgs[0])
+}
+
+func TestHTTPHandlers_Spreads_PaperFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &memorySpreadRepository{}
+	require.NoError(t, repo.Create(t.Context(), &models.ArbitrageSpread{Symbol: "LIVEUSDT"}))
+	require.NoError(t, repo.Create(t.Context(), &models.ArbitrageSpread{Symbol: "PAPERUSDT", Paper: true}))
+
+	r := gin.New()
+	r.GET("/arbitrage-spreads", NewHTTPHandlers(repo, nil, nil).GetSpreadsHandler)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/arbitrage-spreads"+query, nil))
+		return w
+	}
+
+	w := get("")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "LIVEUSDT")
+	assert.Contains(t, w.Body.String(), "PAPERUSDT")
+
+	w = get("?paper=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "LIVEUSDT")
+	assert.Contains(t, w.Body.String(), `"symbol":"PAPERUSDT"`)
+	assert.Contains(t, w.Body.String(), `"paper":true`)
+
+	w = get("?paper=false")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "LIVEUSDT")
+	assert.NotContains(t, w.Body.String(), "PAPERUSDT")
+
+	assert.Equal(t, http.StatusBadRequest, get("?paper=maybe").Code)
+}
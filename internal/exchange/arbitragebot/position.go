@@ -3,10 +3,12 @@ package arbitragebot
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/models"
 )
 
@@ -35,7 +37,7 @@ const (
 	TransitionNone PositionTransition = iota
 	// TransitionSubmitClose both open legs confirmed, spread already closed → submit close now
 	TransitionSubmitClose
-	// TransitionEmergencyClose one open leg failed → emergency close the other
+	// TransitionEmergencyClose one open leg ended with nothing filled → cancel or flatten the other
 	TransitionEmergencyClose
 	// TransitionFullyClosed both close legs confirmed → delete position
 	TransitionFullyClosed
@@ -46,10 +48,12 @@ const (
 type Position struct {
 	mu sync.Mutex
 
+	ID           uuid.UUID // id of the arbitrage_trades row the position is persisted to
 	Symbol       string
 	BuyExchange  string
 	SellExchange string
 	QtyCoins     decimal.Decimal // qty in coins (base currency), used for close leg sizing
+	Notional     decimal.Decimal // USDT at the signal price, counted in risk exposure limits
 
 	OpenBuyLeg  Leg
 	OpenSellLeg Leg
@@ -57,7 +61,28 @@ type Position struct {
 	CloseBuyLeg  Leg
 	CloseSellLeg Leg
 
+	// RebalanceLeg is the order evening out the open legs after asymmetric fills, its fills are
+	// added to (top-up) or subtracted from (reduce-only) the open leg of rebalanceSide.
+	RebalanceLeg    Leg
+	rebalanceSide   models.OrderSide
+	rebalanceReduce bool
+	// rebalancing holds the close off until the rebalance order is done, closeDeferred is a close
+	// requested meanwhile.
+	rebalancing   bool
+	closeDeferred bool
+
 	State PositionState
+
+	OpenSignalAt  time.Time
+	OpenedAt      time.Time // both open legs confirmed
+	CloseSignalAt time.Time
+	ClosedAt      time.Time // both close legs confirmed
+
+	// result is the terminal trade status set by Finish, empty while the position is alive
+	result    models.ArbitrageTradeStatus
+	lastError string
+
+	clock clock.Clock // engine clock, nil - wall clock (restored positions)
 }
 
 // Key returns a unique string key for the position.
@@ -65,9 +90,18 @@ func (p *Position) Key() string {
 	return positionKey(p.Symbol, p.BuyExchange, p.SellExchange)
 }
 
-// OnOpenLegFilled processes a fill event for an open leg.
-// Returns the transition Engine should apply.
-func (p *Position) OnOpenLegFilled(orderID uuid.UUID, execPrice, execQty decimal.Decimal) (PositionTransition, error) {
+// now returns the time of the engine clock. Caller must hold the mutex.
+func (p *Position) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+	return p.clock.Now()
+}
+
+// OnOpenLegFilled processes a fill event for an open leg. execQty is the cumulative filled qty of the order,
+// the leg is confirmed when leavesQty is zero, also when the exchange canceled the rest of the order.
+// Returns the transition Engine should apply, TransitionEmergencyClose if a leg ended with nothing filled.
+func (p *Position) OnOpenLegFilled(orderID uuid.UUID, execPrice, execQty, leavesQty decimal.Decimal) (PositionTransition, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -77,21 +111,23 @@ func (p *Position) OnOpenLegFilled(orderID uuid.UUID, execPrice, execQty decimal
 
 	switch orderID {
 	case p.OpenBuyLeg.OrderID:
-		p.OpenBuyLeg.Confirmed = true
-		p.OpenBuyLeg.AvgPrice = execPrice
-		p.OpenBuyLeg.FilledQty = execQty
+		p.OpenBuyLeg.applyFill(execPrice, execQty, leavesQty)
 	case p.OpenSellLeg.OrderID:
-		p.OpenSellLeg.Confirmed = true
-		p.OpenSellLeg.AvgPrice = execPrice
-		p.OpenSellLeg.FilledQty = execQty
+		p.OpenSellLeg.applyFill(execPrice, execQty, leavesQty)
 	default:
 		return TransitionNone, fmt.Errorf("position %s: unknown order ID %s in OnOpenLegFilled", p.Symbol, orderID)
 	}
 
+	if p.openLegEmpty() {
+		return TransitionEmergencyClose, nil
+	}
+
 	if !p.bothOpenConfirmed() {
 		return TransitionNone, nil
 	}
 
+	p.OpenedAt = p.now()
+
 	if p.State == PositionStateOpeningPendingClose {
 		p.State = PositionStateClosing
 		return TransitionSubmitClose, nil
@@ -101,9 +137,10 @@ func (p *Position) OnOpenLegFilled(orderID uuid.UUID, execPrice, execQty decimal
 	return TransitionNone, nil
 }
 
-// OnCloseLegFilled processes a fill event for a close leg.
+// OnCloseLegFilled processes a fill event for a close leg. execQty is the cumulative filled qty of the order,
+// the leg is confirmed when leavesQty is zero.
 // Returns the transition Engine should apply.
-func (p *Position) OnCloseLegFilled(orderID uuid.UUID, execPrice, execQty decimal.Decimal) (PositionTransition, error) {
+func (p *Position) OnCloseLegFilled(orderID uuid.UUID, execPrice, execQty, leavesQty decimal.Decimal) (PositionTransition, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -113,13 +150,9 @@ func (p *Position) OnCloseLegFilled(orderID uuid.UUID, execPrice, execQty decima
 
 	switch orderID {
 	case p.CloseBuyLeg.OrderID:
-		p.CloseBuyLeg.Confirmed = true
-		p.CloseBuyLeg.AvgPrice = execPrice
-		p.CloseBuyLeg.FilledQty = execQty
+		p.CloseBuyLeg.applyFill(execPrice, execQty, leavesQty)
 	case p.CloseSellLeg.OrderID:
-		p.CloseSellLeg.Confirmed = true
-		p.CloseSellLeg.AvgPrice = execPrice
-		p.CloseSellLeg.FilledQty = execQty
+		p.CloseSellLeg.applyFill(execPrice, execQty, leavesQty)
 	default:
 		return TransitionNone, fmt.Errorf("position %s: unknown order ID %s in OnCloseLegFilled", p.Symbol, orderID)
 	}
@@ -128,6 +161,8 @@ func (p *Position) OnCloseLegFilled(orderID uuid.UUID, execPrice, execQty decima
 		return TransitionNone, nil
 	}
 
+	p.ClosedAt = p.now()
+
 	return TransitionFullyClosed, nil
 }
 
@@ -140,15 +175,56 @@ func (p *Position) RequestClose() PositionTransition {
 	switch p.State {
 	case PositionStateOpening:
 		p.State = PositionStateOpeningPendingClose
+		p.CloseSignalAt = p.now()
 		return TransitionNone
 	case PositionStateOpen:
+		if p.rebalancing {
+			// close legs are sized from the open legs, the rebalance fill has to be in them first
+			if !p.closeDeferred {
+				p.closeDeferred = true
+				p.CloseSignalAt = p.now()
+			}
+			return TransitionNone
+		}
 		p.State = PositionStateClosing
+		p.CloseSignalAt = p.now()
 		return TransitionSubmitClose
 	default:
 		return TransitionNone
 	}
 }
 
+// BeginRebalance marks the open position as rebalancing, a close requested until FinishRebalance is
+// deferred. Returns false if the position is not open or is already rebalancing.
+func (p *Position) BeginRebalance() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.State != PositionStateOpen || p.rebalancing {
+		return false
+	}
+	p.rebalancing = true
+	return true
+}
+
+// FinishRebalance ends the rebalance. Returns TransitionSubmitClose if a close was requested meanwhile.
+func (p *Position) FinishRebalance() PositionTransition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.rebalancing {
+		return TransitionNone
+	}
+	p.rebalancing = false
+
+	if !p.closeDeferred || p.State != PositionStateOpen {
+		return TransitionNone
+	}
+	p.closeDeferred = false
+	p.State = PositionStateClosing
+	return TransitionSubmitClose
+}
+
 // SetOpenLegExchangeOrderID stores the exchange-assigned order ID on the open leg.
 // Called after CreateOrder returns to enable cancel by exchange ID (e.g. MEXC).
 func (p *Position) SetOpenLegExchangeOrderID(orderID uuid.UUID, exchangeOrderID string) {
@@ -171,6 +247,75 @@ func (p *Position) SetCloseLegIDs(buyLeg, sellLeg Leg) {
 	p.CloseSellLeg = sellLeg
 }
 
+// SetRebalanceLeg registers the rebalance order of the open leg of the side, so its fills can be matched.
+func (p *Position) SetRebalanceLeg(side models.OrderSide, reduce bool, leg Leg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.RebalanceLeg = leg
+	p.rebalanceSide = side
+	p.rebalanceReduce = reduce
+}
+
+// OnRebalanceFilled applies a fill of the rebalance order to its open leg. Returns false if orderID is not
+// the rebalance order.
+func (p *Position) OnRebalanceFilled(orderID uuid.UUID, execPrice, execQty, leavesQty decimal.Decimal) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if orderID == uuid.Nil || orderID != p.RebalanceLeg.OrderID {
+		return false
+	}
+
+	prevQty, prevPrice := p.RebalanceLeg.FilledQty, p.RebalanceLeg.AvgPrice
+	p.RebalanceLeg.applyFill(execPrice, execQty, leavesQty)
+
+	inc := p.RebalanceLeg.FilledQty.Sub(prevQty)
+	if !inc.IsPositive() {
+		return true
+	}
+
+	leg := &p.OpenBuyLeg
+	if p.rebalanceSide == models.OrderSideSell {
+		leg = &p.OpenSellLeg
+	}
+
+	if p.rebalanceReduce {
+		leg.FilledQty = leg.FilledQty.Sub(inc)
+		return true
+	}
+
+	// top-up: the leg average price includes the new fills
+	incValue := p.RebalanceLeg.AvgPrice.Mul(p.RebalanceLeg.FilledQty).Sub(prevPrice.Mul(prevQty))
+	filled := leg.FilledQty.Add(inc)
+	leg.AvgPrice = leg.AvgPrice.Mul(leg.FilledQty).Add(incValue).Div(filled)
+	leg.FilledQty = filled
+
+	return true
+}
+
+// OnEmergencyCloseFilled applies a fill of an emergency close leg, or a late fill of an open leg that the
+// cleanup closes by its filled qty. Returns false if orderID is neither.
+func (p *Position) OnEmergencyCloseFilled(orderID uuid.UUID, execPrice, execQty, leavesQty decimal.Decimal) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case orderID == uuid.Nil:
+		return false
+	case orderID == p.CloseBuyLeg.OrderID:
+		p.CloseBuyLeg.applyFill(execPrice, execQty, leavesQty)
+	case orderID == p.CloseSellLeg.OrderID:
+		p.CloseSellLeg.applyFill(execPrice, execQty, leavesQty)
+	case orderID == p.OpenBuyLeg.OrderID:
+		p.OpenBuyLeg.applyFill(execPrice, execQty, leavesQty)
+	case orderID == p.OpenSellLeg.OrderID:
+		p.OpenSellLeg.applyFill(execPrice, execQty, leavesQty)
+	default:
+		return false
+	}
+	return true
+}
+
 // SetEmergencyCloseLeg stores one emergency close leg (only one side at a time).
 // Called from the partner-failed / fill-timeout cleanup paths so that the matching
 // execution event can be routed back via pm.byOrderID and the DB row marked filled.
@@ -207,6 +352,43 @@ func (p *Position) IsOpenLegConfirmed(side models.OrderSide) bool {
 	return p.OpenSellLeg.Confirmed
 }
 
+// Finish sets the terminal trade status of a position removed from PositionManager without being
+// fully closed. The first call wins: a cleanup that needs a manual check stays RECOVERY_REQUIRED.
+// Returns true if the status was set by this call.
+func (p *Position) Finish(status models.ArbitrageTradeStatus, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.result != "" {
+		return false
+	}
+	p.result = status
+	if err != nil {
+		p.lastError = err.Error()
+	}
+	return true
+}
+
+// exposure returns the USDT exposure of the position: the notional of the open signal, or the filled
+// qty at the open price for positions restored after restart.
+func (p *Position) exposure() decimal.Decimal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Notional.IsPositive() {
+		return p.Notional
+	}
+	return p.QtyCoins.Mul(p.OpenBuyLeg.AvgPrice)
+}
+
+// OpenLegFilledQty returns the filled qty (exchange units) of the given side's open leg.
+func (p *Position) OpenLegFilledQty(side models.OrderSide) decimal.Decimal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if side == models.OrderSideBuy {
+		return p.OpenBuyLeg.FilledQty
+	}
+	return p.OpenSellLeg.FilledQty
+}
+
 // GetState returns the current state under the mutex.
 func (p *Position) GetState() PositionState {
 	p.mu.Lock()
@@ -218,6 +400,12 @@ func (p *Position) bothOpenConfirmed() bool {
 	return p.OpenBuyLeg.Confirmed && p.OpenSellLeg.Confirmed
 }
 
+// openLegEmpty reports whether an open leg ended (e.g. canceled by the exchange) with nothing filled.
+func (p *Position) openLegEmpty() bool {
+	return p.OpenBuyLeg.Confirmed && !p.OpenBuyLeg.FilledQty.IsPositive() ||
+		p.OpenSellLeg.Confirmed && !p.OpenSellLeg.FilledQty.IsPositive()
+}
+
 func (p *Position) bothCloseConfirmed() bool {
 	return p.CloseBuyLeg.Confirmed && p.CloseSellLeg.Confirmed
 }
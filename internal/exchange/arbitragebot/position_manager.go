@@ -2,24 +2,27 @@ package arbitragebot
 
 import (
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/models"
 )
 
 // PositionManager manages the collection of active positions and the runtime blacklist.
 // All methods are safe for concurrent use.
 type PositionManager struct {
 	mu        sync.Mutex
-	positions map[string]*Position    // key → position
-	byOrderID map[uuid.UUID]*Position // orderID → position (for fast lookup)
-	blacklist map[string]struct{}     // symbol → blacklisted
+	positions map[string]*Position                // key → position
+	byOrderID map[uuid.UUID]*Position             // orderID → position (for fast lookup)
+	blacklist map[string][]*models.BlacklistEntry // symbol → bans
 }
 
 func newPositionManager() *PositionManager {
 	return &PositionManager{
 		positions: make(map[string]*Position),
 		byOrderID: make(map[uuid.UUID]*Position),
-		blacklist: make(map[string]struct{}),
+		blacklist: make(map[string][]*models.BlacklistEntry),
 	}
 }
 
@@ -44,6 +47,13 @@ func (m *PositionManager) IndexCloseLeg(pos *Position, buyOrderID, sellOrderID u
 	}
 }
 
+// IndexRebalanceLeg adds the rebalance order ID to the lookup index.
+func (m *PositionManager) IndexRebalanceLeg(pos *Position, orderID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byOrderID[orderID] = pos
+}
+
 // Delete removes the position and all its order ID index entries.
 func (m *PositionManager) Delete(pos *Position) {
 	m.mu.Lock()
@@ -53,6 +63,7 @@ func (m *PositionManager) Delete(pos *Position) {
 	delete(m.byOrderID, pos.OpenSellLeg.OrderID)
 	delete(m.byOrderID, pos.CloseBuyLeg.OrderID)
 	delete(m.byOrderID, pos.CloseSellLeg.OrderID)
+	delete(m.byOrderID, pos.RebalanceLeg.OrderID)
 }
 
 // FindByOrderID returns the position associated with the given order ID, or nil.
@@ -86,6 +97,18 @@ func (m *PositionManager) HasOverlap(symbol, buyExchange, sellExchange string) b
 	return false
 }
 
+// HasSymbol returns true if any active position trades the symbol.
+func (m *PositionManager) HasSymbol(symbol string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, pos := range m.positions {
+		if pos.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
 // Count returns the number of active positions.
 func (m *PositionManager) Count() int {
 	m.mu.Lock()
@@ -93,20 +116,60 @@ func (m *PositionManager) Count() int {
 	return len(m.positions)
 }
 
-// Blacklist adds the symbol to the blacklist and removes its position.
-func (m *PositionManager) Blacklist(pos *Position) {
+// Positions returns a snapshot of the active positions.
+func (m *PositionManager) Positions() []*Position {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	positions := make([]*Position, 0, len(m.positions))
+	for _, pos := range m.positions {
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// Blacklist adds the ban of the position symbol to the blacklist and removes the position.
+func (m *PositionManager) Blacklist(pos *Position, entry *models.BlacklistEntry) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.blacklist[pos.Symbol] = struct{}{}
+	m.blacklist[entry.Symbol] = append(m.blacklist[entry.Symbol], entry)
 	delete(m.positions, pos.Key())
 	delete(m.byOrderID, pos.OpenBuyLeg.OrderID)
 	delete(m.byOrderID, pos.OpenSellLeg.OrderID)
 }
 
-// IsBlacklisted returns true if the symbol is blacklisted.
-func (m *PositionManager) IsBlacklisted(symbol string) bool {
+// SetBlacklist replaces the persisted bans with the given ones, e.g. the active entries loaded from DB.
+// Bans that were not persisted (ID is not set) are kept.
+func (m *PositionManager) SetBlacklist(entries []*models.BlacklistEntry) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	_, ok := m.blacklist[symbol]
-	return ok
+
+	blacklist := make(map[string][]*models.BlacklistEntry, len(entries))
+	for _, entry := range entries {
+		blacklist[entry.Symbol] = append(blacklist[entry.Symbol], entry)
+	}
+	for symbol, bans := range m.blacklist {
+		for _, entry := range bans {
+			if entry.ID == uuid.Nil {
+				blacklist[symbol] = append(blacklist[symbol], entry)
+			}
+		}
+	}
+	m.blacklist = blacklist
+}
+
+// IsBlacklisted returns true if the symbol has an active ban at now for all exchanges or for one of the
+// venues. A ban by exchange name covers all its venues, a ban by venue name - only the venue.
+func (m *PositionManager) IsBlacklisted(symbol, buyExchange, sellExchange string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range m.blacklist[symbol] {
+		if !entry.IsActive(now) {
+			continue
+		}
+		switch entry.ExchangeName {
+		case "", buyExchange, sellExchange, parseVenue(buyExchange).Exchange, parseVenue(sellExchange).Exchange:
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,139 @@
+package arbitragebot
+
+import (
+	"fmt"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// toTrade returns the arbitrage_trades row of the position, taken under the mutex.
+func (p *Position) toTrade() *models.ArbitrageTrade {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return &models.ArbitrageTrade{
+		ID:            p.ID,
+		SpreadKey:     p.Key(),
+		Symbol:        p.Symbol,
+		BuyExchange:   p.BuyExchange,
+		SellExchange:  p.SellExchange,
+		Status:        p.tradeStatus(),
+		OpenSignalAt:  p.OpenSignalAt,
+		CloseSignalAt: p.CloseSignalAt,
+		OpenedAt:      p.OpenedAt,
+		ClosedAt:      p.ClosedAt,
+		QtyCoins:      p.QtyCoins,
+
+		OpenBuyOrderID:          p.OpenBuyLeg.OrderID,
+		OpenSellOrderID:         p.OpenSellLeg.OrderID,
+		CloseBuyOrderID:         p.CloseBuyLeg.OrderID,
+		CloseSellOrderID:        p.CloseSellLeg.OrderID,
+		OpenBuyExchangeOrderID:  p.OpenBuyLeg.ExchangeOrderID,
+		OpenSellExchangeOrderID: p.OpenSellLeg.ExchangeOrderID,
+
+		OpenBuyFilledQty:   p.OpenBuyLeg.FilledQty,
+		OpenBuyAvgPrice:    p.OpenBuyLeg.AvgPrice,
+		OpenSellFilledQty:  p.OpenSellLeg.FilledQty,
+		OpenSellAvgPrice:   p.OpenSellLeg.AvgPrice,
+		CloseBuyFilledQty:  p.CloseBuyLeg.FilledQty,
+		CloseBuyAvgPrice:   p.CloseBuyLeg.AvgPrice,
+		CloseSellFilledQty: p.CloseSellLeg.FilledQty,
+		CloseSellAvgPrice:  p.CloseSellLeg.AvgPrice,
+
+		OpenBuyConfirmed:   p.OpenBuyLeg.Confirmed,
+		OpenSellConfirmed:  p.OpenSellLeg.Confirmed,
+		CloseBuyConfirmed:  p.CloseBuyLeg.Confirmed,
+		CloseSellConfirmed: p.CloseSellLeg.Confirmed,
+
+		LastError: p.lastError,
+	}
+}
+
+// tradeStatus maps the position state to the trade status. Caller must hold the mutex.
+func (p *Position) tradeStatus() models.ArbitrageTradeStatus {
+	if p.result != "" {
+		return p.result
+	}
+
+	switch p.State {
+	case PositionStateOpening, PositionStateOpeningPendingClose:
+		if p.OpenBuyLeg.Confirmed || p.OpenSellLeg.Confirmed {
+			return models.ArbitrageTradeStatusOpenPartial
+		}
+		return models.ArbitrageTradeStatusOpening
+	case PositionStateOpen:
+		return models.ArbitrageTradeStatusOpened
+	case PositionStateClosing:
+		switch {
+		case p.bothCloseConfirmed():
+			return models.ArbitrageTradeStatusClosed
+		case p.CloseBuyLeg.Confirmed || p.CloseSellLeg.Confirmed:
+			return models.ArbitrageTradeStatusClosePartial
+		default:
+			return models.ArbitrageTradeStatusClosing
+		}
+	default:
+		// fill timeout / partner-failed cleanup is in progress, if the bot stops now the legs need a manual check
+		return models.ArbitrageTradeStatusRecoveryRequired
+	}
+}
+
+// positionFromTrade rebuilds the position persisted in the trade. A partly filled leg is restored unconfirmed,
+// so the open or close is not treated as complete.
+// Trades that need a manual check (RECOVERY_REQUIRED) and terminal ones are not restorable.
+func positionFromTrade(trade *models.ArbitrageTrade) (*Position, error) {
+	var state PositionState
+
+	switch trade.Status {
+	case models.ArbitrageTradeStatusNew, models.ArbitrageTradeStatusOpening, models.ArbitrageTradeStatusOpenPartial:
+		state = PositionStateOpening
+		if !trade.CloseSignalAt.IsZero() {
+			state = PositionStateOpeningPendingClose
+		}
+	case models.ArbitrageTradeStatusOpened:
+		state = PositionStateOpen
+	case models.ArbitrageTradeStatusClosing, models.ArbitrageTradeStatusClosePartial:
+		state = PositionStateClosing
+	default:
+		return nil, fmt.Errorf("trade %s in status %s is not restorable", trade.ID, trade.Status)
+	}
+
+	return &Position{
+		ID:           trade.ID,
+		Symbol:       trade.Symbol,
+		BuyExchange:  trade.BuyExchange,
+		SellExchange: trade.SellExchange,
+		QtyCoins:     trade.QtyCoins,
+		OpenBuyLeg: Leg{
+			OrderID:         trade.OpenBuyOrderID,
+			ExchangeOrderID: trade.OpenBuyExchangeOrderID,
+			FilledQty:       trade.OpenBuyFilledQty,
+			AvgPrice:        trade.OpenBuyAvgPrice,
+			Confirmed:       trade.OpenBuyConfirmed,
+		},
+		OpenSellLeg: Leg{
+			OrderID:         trade.OpenSellOrderID,
+			ExchangeOrderID: trade.OpenSellExchangeOrderID,
+			FilledQty:       trade.OpenSellFilledQty,
+			AvgPrice:        trade.OpenSellAvgPrice,
+			Confirmed:       trade.OpenSellConfirmed,
+		},
+		CloseBuyLeg: Leg{
+			OrderID:   trade.CloseBuyOrderID,
+			FilledQty: trade.CloseBuyFilledQty,
+			AvgPrice:  trade.CloseBuyAvgPrice,
+			Confirmed: trade.CloseBuyConfirmed,
+		},
+		CloseSellLeg: Leg{
+			OrderID:   trade.CloseSellOrderID,
+			FilledQty: trade.CloseSellFilledQty,
+			AvgPrice:  trade.CloseSellAvgPrice,
+			Confirmed: trade.CloseSellConfirmed,
+		},
+		State:         state,
+		OpenSignalAt:  trade.OpenSignalAt,
+		OpenedAt:      trade.OpenedAt,
+		CloseSignalAt: trade.CloseSignalAt,
+		ClosedAt:      trade.ClosedAt,
+	}, nil
+}
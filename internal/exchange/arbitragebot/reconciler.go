@@ -0,0 +1,454 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+// reconcileConfirmations is how many consecutive periodic checks must see a naked leg or an orphan order
+// before it is reported. Exchange state is fetched before the positions snapshot, so a position closed
+// in between looks naked for one check.
+const reconcileConfirmations = 2
+
+// Reconciler compares exchange positions and open orders with the bot state.
+// On startup it rebuilds hedged positions from DB spreads and orders, then periodically reports naked legs
+// (perpetual positions not owned by any active position) and orphan orders placed by the bot,
+// optionally flattening them. NOT safe for concurrent use.
+type Reconciler struct {
+	engine      *Engine
+	venues      []Venue
+	spreadRepo  ArbitrageSpreadRepository
+	orderRepo   OrderRepository
+	notif       notifier.Notifier
+	logger      zerolog.Logger
+	interval    time.Duration
+	autoFlatten bool
+
+	observations map[string]int // naked leg / orphan order key → consecutive checks it was seen in
+}
+
+// NewReconciler creates a new Reconciler of the engine positions on the given venues.
+func NewReconciler(
+	engine *Engine,
+	venues []Venue,
+	spreadRepo ArbitrageSpreadRepository,
+	orderRepo OrderRepository,
+	notif notifier.Notifier,
+	logger zerolog.Logger,
+	interval time.Duration,
+	autoFlatten bool,
+) *Reconciler {
+	return &Reconciler{
+		engine:       engine,
+		venues:       venues,
+		spreadRepo:   spreadRepo,
+		orderRepo:    orderRepo,
+		notif:        notif,
+		logger:       logger,
+		interval:     interval,
+		autoFlatten:  autoFlatten,
+		observations: make(map[string]int),
+	}
+}
+
+// Restore rebuilds positions of DB spreads whose both legs are still held on the exchanges (positions
+// restored from trades by Engine.RestorePositions are skipped) and runs the first check, naked legs found
+// by it are reported right away. Restored positions are persisted as trades.
+// Positions of spreads closed before the restart are closed immediately, the rest are returned,
+// so the spread detector keeps tracking them.
+func (r *Reconciler) Restore(ctx context.Context) []*models.ArbitrageSpread {
+	defer r.check(ctx, 1)
+
+	spreads, err := r.spreadRepo.FindAll(ctx, FindFilter{
+		NotInStatus:  []models.ArbitrageSpreadStatus{models.ArbitrageSpreadFailed},
+		OpenPosition: true,
+	})
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("reconcile: failed to load spreads")
+		return nil
+	}
+	if len(spreads) == 0 {
+		return nil
+	}
+
+	// newest first: an older spread sharing the symbol and a venue with a restored one is stale
+	sort.Slice(spreads, func(i, j int) bool {
+		return spreads[i].CreatedAt.After(spreads[j].CreatedAt)
+	})
+
+	holdings := r.loadHoldings(ctx)
+
+	var restored []*models.ArbitrageSpread
+	for _, spread := range spreads {
+		if r.engine.pm.HasOverlap(spread.Symbol, spread.BuyOnExchange, spread.SellOnExchange) {
+			continue
+		}
+
+		pos, err := r.restorePosition(ctx, spread, holdings)
+		if err != nil {
+			r.logger.Warn().
+				Err(err).
+				Str("symbol", spread.Symbol).
+				Str("buy_on", spread.BuyOnExchange).
+				Str("sell_on", spread.SellOnExchange).
+				Msg("reconcile: position not restored")
+			continue
+		}
+		if pos == nil {
+			continue
+		}
+
+		r.engine.pm.Add(pos)
+		r.logger.Info().
+			Str("symbol", pos.Symbol).
+			Str("buy_on", pos.BuyExchange).
+			Str("sell_on", pos.SellExchange).
+			Stringer("qty_coins", pos.QtyCoins).
+			Msg("♻️ reconcile: position restored")
+		r.sendNotification(fmt.Sprintf("<b>♻️ ARBITRAGE: Ticker - %s</b>\n\nPosition restored after restart\n\n🟢 Buy:  %s\n🔴 Sell: %s",
+			pos.Symbol, pos.BuyExchange, pos.SellExchange))
+
+		if spread.Status == models.ArbitrageSpreadClosed {
+			// the spread closed before the restart, but close legs were never submitted
+			transition := pos.RequestClose()
+			r.engine.saveTrade(pos)
+			r.engine.applyTransition(ctx, pos, transition)
+			continue
+		}
+		r.engine.saveTrade(pos)
+		restored = append(restored, spread)
+	}
+
+	return restored
+}
+
+// Run checks the exchange state every interval until ctx is done.
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx, reconcileConfirmations)
+		}
+	}
+}
+
+// holdings are the exchange positions (linear venues, by symbol and side) and base coin balances
+// (spot venues, by asset) in coins. A venue is missing if its state could not be fetched.
+type holdings map[string]map[string]decimal.Decimal
+
+func (r *Reconciler) loadHoldings(ctx context.Context) holdings {
+	result := make(holdings, len(r.venues))
+
+	for _, venue := range r.venues {
+		client := r.engine.clientFor(venue.Name())
+		held := make(map[string]decimal.Decimal)
+
+		if venue.IsSpot() {
+			balances, err := client.GetBalances(ctx, exchange.CategorySpot)
+			if err != nil {
+				r.logger.Warn().Err(err).Str("exchange", venue.Name()).Msg("reconcile: failed to get balances")
+				continue
+			}
+			for _, b := range balances {
+				held[b.Asset] = b.Total
+			}
+		} else {
+			positions, err := client.GetPositions(ctx)
+			if err != nil {
+				r.logger.Warn().Err(err).Str("exchange", venue.Name()).Msg("reconcile: failed to get positions")
+				continue
+			}
+			for _, p := range positions {
+				inst, err := r.engine.instrumentFor(p.Symbol, venue.Name())
+				if err != nil {
+					continue
+				}
+				key := p.Symbol + "#" + string(p.Side)
+				held[key] = held[key].Add(p.Qty.Mul(inst.ContractSize))
+			}
+		}
+
+		result[venue.Name()] = held
+	}
+
+	return result
+}
+
+// heldCoins returns coins held by the leg of the symbol on the venue, false if the venue state is unknown.
+func (h holdings) heldCoins(venueName, symbol string, side models.OrderSide) (decimal.Decimal, bool) {
+	held, ok := h[venueName]
+	if !ok {
+		return decimal.Zero, false
+	}
+	if parseVenue(venueName).IsSpot() {
+		return held[baseAsset(symbol)], true
+	}
+	return held[symbol+"#"+string(side)], true
+}
+
+// restorePosition rebuilds the open position of the spread from its open orders.
+// Returns nil position if the legs are not held on the exchanges anymore.
+// A leg counts as held when the exchange holds at least half of its qty (spot buy fees are charged
+// in the base coin, the coin can also be partially sold by hand).
+func (r *Reconciler) restorePosition(ctx context.Context, spread *models.ArbitrageSpread, h holdings) (*Position, error) {
+	buyOrder, err := r.orderRepo.GetByID(ctx, spread.OpenBuyOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("get open buy order: %w", err)
+	}
+	sellOrder, err := r.orderRepo.GetByID(ctx, spread.OpenSellOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("get open sell order: %w", err)
+	}
+
+	buyInst, err := r.engine.instrumentFor(spread.Symbol, spread.BuyOnExchange)
+	if err != nil {
+		return nil, err
+	}
+	sellInst, err := r.engine.instrumentFor(spread.Symbol, spread.SellOnExchange)
+	if err != nil {
+		return nil, err
+	}
+
+	buyCoins := filledQty(buyOrder).Mul(buyInst.ContractSize)
+	sellCoins := filledQty(sellOrder).Mul(sellInst.ContractSize)
+
+	buyHeld, ok := h.heldCoins(spread.BuyOnExchange, spread.Symbol, models.OrderSideBuy)
+	if !ok {
+		return nil, fmt.Errorf("unknown state of %s", spread.BuyOnExchange)
+	}
+	sellHeld, ok := h.heldCoins(spread.SellOnExchange, spread.Symbol, models.OrderSideSell)
+	if !ok {
+		return nil, fmt.Errorf("unknown state of %s", spread.SellOnExchange)
+	}
+
+	half := decimal.NewFromFloat(0.5)
+	isBuyHeld := buyCoins.IsPositive() && buyHeld.GreaterThanOrEqual(buyCoins.Mul(half))
+	isSellHeld := sellCoins.IsPositive() && sellHeld.GreaterThanOrEqual(sellCoins.Mul(half))
+
+	switch {
+	case isBuyHeld && isSellHeld:
+	case isBuyHeld && parseVenue(spread.BuyOnExchange).IsSpot():
+		// naked perpetual legs are reported by the check, spot coins are never flattened automatically
+		r.report(spread.Symbol, fmt.Sprintf("spot leg on %s is held, perpetual short on %s is not",
+			spread.BuyOnExchange, spread.SellOnExchange))
+		return nil, nil
+	default:
+		r.logger.Debug().
+			Str("symbol", spread.Symbol).
+			Str("buy_on", spread.BuyOnExchange).
+			Str("sell_on", spread.SellOnExchange).
+			Msg("reconcile: position is not held on the exchanges")
+		return nil, nil
+	}
+
+	if !buyCoins.Equal(sellCoins) {
+		r.logger.Warn().
+			Str("symbol", spread.Symbol).
+			Stringer("buy_coins", buyCoins).
+			Stringer("sell_coins", sellCoins).
+			Msg("reconcile: legs of the restored position differ in qty")
+	}
+
+	return &Position{
+		ID:           uuid.New(),
+		Symbol:       spread.Symbol,
+		BuyExchange:  spread.BuyOnExchange,
+		SellExchange: spread.SellOnExchange,
+		QtyCoins:     decimal.Min(buyCoins, sellCoins),
+		OpenBuyLeg:   legOf(buyOrder),
+		OpenSellLeg:  legOf(sellOrder),
+		State:        PositionStateOpen,
+		OpenSignalAt: spread.CreatedAt,
+		OpenedAt:     spread.CreatedAt,
+	}, nil
+}
+
+// filledQty returns the executed qty of the order, or the ordered one if the fill was not recorded.
+func filledQty(order *models.Order) decimal.Decimal {
+	if order.ExecutedQuantity.IsPositive() {
+		return order.ExecutedQuantity
+	}
+	return order.Quantity
+}
+
+func legOf(order *models.Order) Leg {
+	return Leg{
+		OrderID:         order.ID,
+		ExchangeOrderID: order.ExchangeOrderID,
+		FilledQty:       filledQty(order),
+		AvgPrice:        order.AvgPrice,
+		Confirmed:       true,
+	}
+}
+
+// check reports naked legs and orphan orders seen in confirmations consecutive checks.
+func (r *Reconciler) check(ctx context.Context, confirmations int) {
+	seen := make(map[string]struct{})
+
+	for _, venue := range r.venues {
+		client := r.engine.clientFor(venue.Name())
+		if !venue.IsSpot() {
+			r.checkPositions(ctx, client, venue, seen, confirmations)
+		}
+		r.checkOpenOrders(ctx, client, venue, seen, confirmations)
+	}
+
+	for key := range r.observations {
+		if _, ok := seen[key]; !ok {
+			delete(r.observations, key)
+		}
+	}
+}
+
+// checkPositions finds perpetual positions of the venue not owned by any active position.
+func (r *Reconciler) checkPositions(ctx context.Context, client exchange.Provider, venue Venue, seen map[string]struct{}, confirmations int) {
+	positions, err := client.GetPositions(ctx)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("exchange", venue.Name()).Msg("reconcile: failed to get positions")
+		return
+	}
+
+	// snapshot after the exchange state: a position opened in between is owned already
+	owned := make(map[string]struct{})
+	for _, pos := range r.engine.pm.Positions() {
+		owned[pos.BuyExchange+"#"+pos.Symbol] = struct{}{}
+		owned[pos.SellExchange+"#"+pos.Symbol] = struct{}{}
+	}
+
+	for _, p := range positions {
+		if _, ok := owned[venue.Name()+"#"+p.Symbol]; ok {
+			continue
+		}
+
+		n := r.observe("position#"+venue.Name()+"#"+p.Symbol+"#"+string(p.Side), seen)
+		if n < confirmations {
+			continue
+		}
+		if n == confirmations {
+			r.report(p.Symbol, fmt.Sprintf("naked %s leg on %s: qty %s, entry price %s",
+				p.Side, venue.Name(), p.Qty, p.EntryPrice))
+		}
+		if r.autoFlatten {
+			r.flatten(ctx, client, venue, p)
+		}
+	}
+}
+
+// checkOpenOrders finds orders placed by the bot that don't belong to any active position.
+func (r *Reconciler) checkOpenOrders(ctx context.Context, client exchange.Provider, venue Venue, seen map[string]struct{}, confirmations int) {
+	orders, err := client.GetOpenOrders(ctx, venue.Category)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("exchange", venue.Name()).Msg("reconcile: failed to get open orders")
+		return
+	}
+
+	for _, order := range orders {
+		if order.OrderID == uuid.Nil || r.engine.pm.FindByOrderID(order.OrderID) != nil {
+			continue
+		}
+
+		n := r.observe("order#"+order.OrderID.String(), seen)
+		if n < confirmations {
+			continue
+		}
+		if n == confirmations {
+			r.report(order.Symbol, fmt.Sprintf("orphan %s order %s on %s: qty %s, price %s",
+				order.Side, order.OrderID, venue.Name(), order.Qty, order.Price))
+		}
+		if r.autoFlatten {
+			r.cancel(ctx, client, venue, order)
+		}
+	}
+}
+
+// observe counts the key as seen in the current check and returns the number of consecutive checks it was seen in.
+func (r *Reconciler) observe(key string, seen map[string]struct{}) int {
+	seen[key] = struct{}{}
+	r.observations[key]++
+	return r.observations[key]
+}
+
+// flatten closes the naked position by a reduce-only market order.
+func (r *Reconciler) flatten(ctx context.Context, client exchange.Provider, venue Venue, p exchange.Position) {
+	order, err := exchange.MakeOrderStruct(exchange.CreateOrderDto{
+		Symbol:       p.Symbol,
+		Side:         p.Side,
+		Type:         models.OrderTypeMarket,
+		Market:       venue.Category.OrderMarket(),
+		Quantity:     p.Qty,
+		ExchangeName: venue.Exchange,
+	})
+	if err != nil {
+		r.logger.Error().Err(err).Str("symbol", p.Symbol).Msg("reconcile: failed to build flatten order")
+		return
+	}
+
+	r.engine.saveOrder(&order)
+
+	if err := client.CloseOrder(ctx, &order); err != nil {
+		r.engine.markOrderRejected(ctx, order.ID)
+		r.logger.Error().
+			Err(err).
+			Str("symbol", p.Symbol).
+			Str("exchange", venue.Name()).
+			Str("side", string(p.Side)).
+			Msg("⚠️ reconcile: failed to flatten naked leg — VERIFY EXCHANGE for open position")
+		return
+	}
+
+	r.logger.Warn().
+		Str("symbol", p.Symbol).
+		Str("exchange", venue.Name()).
+		Str("side", string(p.Side)).
+		Stringer("qty", p.Qty).
+		Msg("🛡 reconcile: naked leg flattened")
+	r.sendNotification(fmt.Sprintf("<b>🛡 ARBITRAGE: Ticker - %s</b>\n\nNaked %s leg on %s flattened",
+		p.Symbol, p.Side, venue.Name()))
+}
+
+// cancel cancels the orphan order.
+func (r *Reconciler) cancel(ctx context.Context, client exchange.Provider, venue Venue, order exchange.OpenOrder) {
+	if err := client.CancelOrder(ctx, order.OrderID, order.ExchangeOrderID, order.Symbol, venue.Category); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("symbol", order.Symbol).
+			Str("exchange", venue.Name()).
+			Str("order_id", order.OrderID.String()).
+			Msg("reconcile: failed to cancel orphan order")
+		return
+	}
+
+	r.engine.markOrderCanceled(ctx, order.OrderID)
+	r.logger.Warn().
+		Str("symbol", order.Symbol).
+		Str("exchange", venue.Name()).
+		Str("order_id", order.OrderID.String()).
+		Msg("reconcile: orphan order canceled")
+}
+
+// report logs the mismatch with the exchange state and sends it to the notifier.
+func (r *Reconciler) report(symbol, msg string) {
+	r.logger.Error().Str("symbol", symbol).Msg("⚠️ reconcile: " + msg)
+	r.sendNotification(fmt.Sprintf("<b>⚠️ ARBITRAGE: Ticker - %s</b>\n\nReconcile: %s", symbol, msg))
+}
+
+func (r *Reconciler) sendNotification(msg string) {
+	if err := r.notif.Send(msg); err != nil {
+		r.logger.Warn().Err(err).Msg("failed to send telegram notification")
+	}
+}
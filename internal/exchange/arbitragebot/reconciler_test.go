@@ -0,0 +1,248 @@
+package arbitragebot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+type spreadRepoStub struct {
+	repoStub
+	spreads []*models.ArbitrageSpread
+}
+
+func (r *spreadRepoStub) FindAll(_ context.Context, _ FindFilter) ([]*models.ArbitrageSpread, error) {
+	return r.spreads, nil
+}
+
+func (r *spreadRepoStub) FindOne(_ context.Context, f FindFilter) (*models.ArbitrageSpread, error) {
+	for _, spread := range r.spreads {
+		if spread.Symbol == f.Symbol && spread.BuyOnExchange == f.BuyEx && spread.SellOnExchange == f.SellEx {
+			return spread, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type orderRepoStub struct {
+	orders map[uuid.UUID]*models.Order
+}
+
+func (r *orderRepoStub) GetByID(_ context.Context, id uuid.UUID) (*models.Order, error) {
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return order, nil
+}
+
+func (r *orderRepoStub) Create(_ context.Context, order *models.Order) error {
+	r.orders[order.ID] = order
+	return nil
+}
+
+func (r *orderRepoStub) UpdateFilled(_ context.Context, _ uuid.UUID, _ decimal.Decimal, _ decimal.Decimal) error {
+	return nil
+}
+
+func (r *orderRepoStub) UpdatePartialy(_ context.Context, _ uuid.UUID, _ OrderPatch) error {
+	return nil
+}
+
+func newReconcilerEngine(clients []exchange.Provider, orderRepo OrderRepository, notif *notifierStub) *Engine {
+	engine := NewEngine(getConfig(), clients, orderRepo, &repoStub{}, notif, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {
+			"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)},
+			"ETHUSDT": {Symbol: "ETHUSDT", ContractSize: decimal.NewFromInt(1)},
+		},
+		"MEXC": {
+			"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1")},
+		},
+	}
+	return engine
+}
+
+func TestReconciler_Restore(t *testing.T) {
+	ctx := t.Context()
+
+	buyOrder := &models.Order{
+		ID:               uuid.New(),
+		ExchangeOrderID:  "buy-1",
+		Quantity:         decimal.NewFromInt(1),
+		ExecutedQuantity: decimal.NewFromInt(1),
+		AvgPrice:         decimal.NewFromInt(100),
+	}
+	sellOrder := &models.Order{
+		ID:              uuid.New(),
+		ExchangeOrderID: "sell-1",
+		Quantity:        decimal.NewFromInt(10),
+	}
+	orderRepo := &orderRepoStub{orders: map[uuid.UUID]*models.Order{buyOrder.ID: buyOrder, sellOrder.ID: sellOrder}}
+	spreadRepo := &spreadRepoStub{spreads: []*models.ArbitrageSpread{
+		{
+			Symbol:              "BTCUSDT",
+			BuyOnExchange:       "ByBit",
+			SellOnExchange:      "MEXC",
+			Status:              models.ArbitrageSpreadUpdated,
+			MaxNetSpreadPercent: decimal.NewFromInt(3),
+			OpenBuyOrderID:      buyOrder.ID,
+			OpenSellOrderID:     sellOrder.ID,
+			CreatedAt:           time.Now(),
+		},
+	}}
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetPositions(ctx).Return([]exchange.Position{
+		{Symbol: "BTCUSDT", Side: models.OrderSideBuy, Qty: decimal.NewFromInt(1)},
+		{Symbol: "ETHUSDT", Side: models.OrderSideSell, Qty: decimal.NewFromInt(2)},
+	}, nil)
+	bybit.EXPECT().GetOpenOrders(ctx, exchange.CategoryLinear).Return(nil, nil)
+	bybit.EXPECT().CloseOrder(ctx, mock.MatchedBy(func(order *models.Order) bool {
+		return order.Symbol == "ETHUSDT" && order.Side == models.OrderSideSell &&
+			order.Type == models.OrderTypeMarket && order.Quantity.Equal(decimal.NewFromInt(2))
+	})).Return(nil).Once()
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetPositions(ctx).Return([]exchange.Position{
+		{Symbol: "BTCUSDT", Side: models.OrderSideSell, Qty: decimal.NewFromInt(10)},
+	}, nil)
+	mexc.EXPECT().GetOpenOrders(ctx, exchange.CategoryLinear).Return(nil, nil)
+
+	notif := &notifierStub{}
+	engine := newReconcilerEngine([]exchange.Provider{bybit, mexc}, orderRepo, notif)
+	reconciler := NewReconciler(
+		engine,
+		[]Venue{{Exchange: "ByBit", Category: exchange.CategoryLinear}, {Exchange: "MEXC", Category: exchange.CategoryLinear}},
+		spreadRepo,
+		orderRepo,
+		notif,
+		zerolog.Nop(),
+		time.Minute,
+		true,
+	)
+
+	restored := reconciler.Restore(ctx)
+	require.Len(t, restored, 1)
+
+	pos := engine.pm.FindByKey("BTCUSDT", "ByBit", "MEXC")
+	require.NotNil(t, pos)
+	assert.Equal(t, PositionStateOpen, pos.GetState())
+	assert.Equal(t, "1", pos.QtyCoins.String())
+	assert.Equal(t, "sell-1", pos.OpenSellLeg.ExchangeOrderID)
+	assert.Same(t, pos, engine.pm.FindByOrderID(buyOrder.ID))
+
+	// ETHUSDT short is not owned by the bot: reported on startup without grace period and flattened
+	require.Len(t, notif.msgs, 3)
+	assert.Contains(t, notif.msgs[1], "naked SELL leg on ByBit")
+	assert.Contains(t, notif.msgs[2], "flattened")
+}
+
+func TestReconciler_Restore_SkipsFlatLegs(t *testing.T) {
+	ctx := t.Context()
+
+	buyOrder := &models.Order{ID: uuid.New(), Quantity: decimal.NewFromInt(1)}
+	sellOrder := &models.Order{ID: uuid.New(), Quantity: decimal.NewFromInt(10)}
+	orderRepo := &orderRepoStub{orders: map[uuid.UUID]*models.Order{buyOrder.ID: buyOrder, sellOrder.ID: sellOrder}}
+	spreadRepo := &spreadRepoStub{spreads: []*models.ArbitrageSpread{
+		{
+			Symbol:          "BTCUSDT",
+			BuyOnExchange:   "ByBit",
+			SellOnExchange:  "MEXC",
+			Status:          models.ArbitrageSpreadOpened,
+			OpenBuyOrderID:  buyOrder.ID,
+			OpenSellOrderID: sellOrder.ID,
+		},
+	}}
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetPositions(ctx).Return(nil, nil)
+	bybit.EXPECT().GetOpenOrders(ctx, exchange.CategoryLinear).Return(nil, nil)
+
+	// the short leg is naked, it's reported, but auto flatten is off
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetPositions(ctx).Return([]exchange.Position{
+		{Symbol: "BTCUSDT", Side: models.OrderSideSell, Qty: decimal.NewFromInt(10)},
+	}, nil)
+	mexc.EXPECT().GetOpenOrders(ctx, exchange.CategoryLinear).Return(nil, nil)
+
+	notif := &notifierStub{}
+	engine := newReconcilerEngine([]exchange.Provider{bybit, mexc}, orderRepo, notif)
+	reconciler := NewReconciler(
+		engine,
+		[]Venue{{Exchange: "ByBit", Category: exchange.CategoryLinear}, {Exchange: "MEXC", Category: exchange.CategoryLinear}},
+		spreadRepo,
+		orderRepo,
+		notif,
+		zerolog.Nop(),
+		time.Minute,
+		false,
+	)
+
+	assert.Empty(t, reconciler.Restore(ctx))
+	assert.Zero(t, engine.pm.Count())
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "naked SELL leg on MEXC")
+}
+
+func TestReconciler_Check_GracePeriod(t *testing.T) {
+	ctx := t.Context()
+	orphanID := uuid.New()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetPositions(ctx).Return([]exchange.Position{
+		{Symbol: "BTCUSDT", Side: models.OrderSideBuy, Qty: decimal.NewFromInt(1)},
+	}, nil)
+	bybit.EXPECT().GetOpenOrders(ctx, exchange.CategoryLinear).Return([]exchange.OpenOrder{
+		{OrderID: orphanID, ExchangeOrderID: "1", Symbol: "ETHUSDT", Side: models.OrderSideSell},
+		{OrderID: uuid.Nil, ExchangeOrderID: "2", Symbol: "ETHUSDT", Side: models.OrderSideSell},
+	}, nil)
+
+	notif := &notifierStub{}
+	engine := newReconcilerEngine([]exchange.Provider{bybit}, &orderRepoStub{orders: map[uuid.UUID]*models.Order{}}, notif)
+	reconciler := NewReconciler(
+		engine,
+		[]Venue{{Exchange: "ByBit", Category: exchange.CategoryLinear}},
+		&spreadRepoStub{},
+		&orderRepoStub{},
+		notif,
+		zerolog.Nop(),
+		time.Minute,
+		false,
+	)
+
+	reconciler.check(ctx, reconcileConfirmations)
+	assert.Empty(t, notif.msgs)
+
+	reconciler.check(ctx, reconcileConfirmations)
+	require.Len(t, notif.msgs, 2)
+	assert.Contains(t, notif.msgs[0], "naked BUY leg on ByBit")
+	assert.Contains(t, notif.msgs[1], "orphan SELL order "+orphanID.String())
+
+	// reported once
+	reconciler.check(ctx, reconcileConfirmations)
+	assert.Len(t, notif.msgs, 2)
+
+	// owned by the bot now
+	engine.pm.Add(&Position{Symbol: "BTCUSDT", BuyExchange: "ByBit", SellExchange: "MEXC", OpenBuyLeg: Leg{OrderID: orphanID}})
+	reconciler.check(ctx, reconcileConfirmations)
+	assert.Empty(t, reconciler.observations)
+}
@@ -0,0 +1,144 @@
+package arbitragebot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+// riskLimits checks the configured limits before every open and holds the kill-switch.
+// Position count and exposure limits skip a single trade; daily loss and consecutive failures trip
+// the kill-switch, which stops new opens until restart. All methods are safe for concurrent use.
+type riskLimits struct {
+	mu  sync.Mutex
+	cfg config.RiskConfig
+
+	day       string          // UTC date the daily PnL belongs to
+	dailyPnL  decimal.Decimal // realized PnL of positions closed or flattened on day
+	failures  int             // consecutive failed positions
+	killedFor string          // kill-switch reason, empty - opens are allowed
+}
+
+func newRiskLimits(cfg config.RiskConfig) *riskLimits {
+	return &riskLimits{cfg: cfg}
+}
+
+// notional returns the trade size in USDT.
+func (r *riskLimits) notional() decimal.Decimal {
+	return decimal.NewFromFloat(r.cfg.NotionalUSDT)
+}
+
+// canOpen checks the kill-switch and the number of open positions.
+func (r *riskLimits) canOpen(openPositions int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.killedFor != "" {
+		return fmt.Errorf("kill-switch is on: %s", r.killedFor)
+	}
+	if r.cfg.MaxPositions > 0 && openPositions >= r.cfg.MaxPositions {
+		return fmt.Errorf("already have %d open positions", openPositions)
+	}
+	return nil
+}
+
+// checkExposure checks that a new position of notional USDT keeps the symbol, exchange and total exposure
+// of the open positions within limits. Both legs count against the exposure of their exchange.
+func (r *riskLimits) checkExposure(symbol, buyExchange, sellExchange string, notional decimal.Decimal, positions []*Position) error {
+	symbolExposure := notional
+	totalExposure := notional
+	exchangeExposure := map[string]decimal.Decimal{}
+	buyEx, sellEx := parseVenue(buyExchange).Exchange, parseVenue(sellExchange).Exchange
+	exchangeExposure[buyEx] = exchangeExposure[buyEx].Add(notional)
+	exchangeExposure[sellEx] = exchangeExposure[sellEx].Add(notional)
+
+	for _, pos := range positions {
+		exposure := pos.exposure()
+		totalExposure = totalExposure.Add(exposure)
+		if pos.Symbol == symbol {
+			symbolExposure = symbolExposure.Add(exposure)
+		}
+		for _, ex := range []string{parseVenue(pos.BuyExchange).Exchange, parseVenue(pos.SellExchange).Exchange} {
+			if _, ok := exchangeExposure[ex]; ok {
+				exchangeExposure[ex] = exchangeExposure[ex].Add(exposure)
+			}
+		}
+	}
+
+	if exceeds(symbolExposure, r.cfg.MaxSymbolExposureUSDT) {
+		return fmt.Errorf("%s exposure %s exceeds %v USDT", symbol, symbolExposure.StringFixed(2), r.cfg.MaxSymbolExposureUSDT)
+	}
+	for ex, exposure := range exchangeExposure {
+		if exceeds(exposure, r.cfg.MaxExchangeExposureUSDT) {
+			return fmt.Errorf("%s exposure %s exceeds %v USDT", ex, exposure.StringFixed(2), r.cfg.MaxExchangeExposureUSDT)
+		}
+	}
+	if exceeds(totalExposure, r.cfg.MaxTotalExposureUSDT) {
+		return fmt.Errorf("total exposure %s exceeds %v USDT", totalExposure.StringFixed(2), r.cfg.MaxTotalExposureUSDT)
+	}
+	return nil
+}
+
+// recordClosed adds the realized PnL of a fully closed position and resets the consecutive failures.
+// Returns the kill-switch reason if the daily loss limit tripped it now.
+func (r *riskLimits) recordClosed(pnl decimal.Decimal, now time.Time) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = 0
+	return r.addPnL(pnl, now)
+}
+
+// recordFlattened adds the PnL of the legs flattened by a failed position to the daily PnL, the failure is
+// counted by recordFailure. Returns the kill-switch reason if the daily loss limit tripped it now.
+func (r *riskLimits) recordFlattened(pnl decimal.Decimal, now time.Time) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addPnL(pnl, now)
+}
+
+// addPnL adds the PnL to the daily PnL and checks the daily loss limit. Caller must hold the mutex.
+func (r *riskLimits) addPnL(pnl decimal.Decimal, now time.Time) string {
+	day := now.UTC().Format(time.DateOnly)
+	if day != r.day {
+		r.day = day
+		r.dailyPnL = decimal.Zero
+	}
+	r.dailyPnL = r.dailyPnL.Add(pnl)
+
+	if r.cfg.MaxDailyLossUSDT > 0 && r.dailyPnL.Neg().GreaterThanOrEqual(decimal.NewFromFloat(r.cfg.MaxDailyLossUSDT)) {
+		return r.kill(fmt.Sprintf("daily loss %s USDT reached the limit of %v USDT", r.dailyPnL.Neg().StringFixed(2), r.cfg.MaxDailyLossUSDT))
+	}
+	return ""
+}
+
+// recordFailure counts a position that failed to open or needs a manual check.
+// Returns the kill-switch reason if the consecutive failures limit tripped it now.
+func (r *riskLimits) recordFailure() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures++
+	if r.cfg.MaxConsecutiveFailures > 0 && r.failures >= r.cfg.MaxConsecutiveFailures {
+		return r.kill(fmt.Sprintf("%d consecutive failed positions", r.failures))
+	}
+	return ""
+}
+
+// kill turns the kill-switch on. Returns the reason the first time only. Caller must hold the mutex.
+func (r *riskLimits) kill(reason string) string {
+	if r.killedFor != "" {
+		return ""
+	}
+	r.killedFor = reason
+	return reason
+}
+
+func exceeds(exposure decimal.Decimal, limit float64) bool {
+	return limit > 0 && exposure.GreaterThan(decimal.NewFromFloat(limit))
+}
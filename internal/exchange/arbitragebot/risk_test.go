@@ -0,0 +1,157 @@
+package arbitragebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestRiskLimits_CheckExposure(t *testing.T) {
+	risk := newRiskLimits(config.RiskConfig{
+		MaxSymbolExposureUSDT:   25,
+		MaxExchangeExposureUSDT: 35,
+		MaxTotalExposureUSDT:    45,
+	})
+
+	positions := []*Position{
+		{Symbol: "BTCUSDT", BuyExchange: "ByBit", SellExchange: "MEXC", Notional: decimal.NewFromInt(10)},
+		// restored position, exposure from the filled qty
+		{Symbol: "ETHUSDT", BuyExchange: "ByBit:spot", SellExchange: "OKX", QtyCoins: decimal.NewFromInt(2), OpenBuyLeg: Leg{AvgPrice: decimal.NewFromInt(10)}},
+	}
+
+	ten := decimal.NewFromInt(10)
+	assert.NoError(t, risk.checkExposure("BTCUSDT", "Gate", "BingX", ten, positions))
+	assert.ErrorContains(t, risk.checkExposure("BTCUSDT", "Gate", "BingX", decimal.NewFromInt(16), positions), "BTCUSDT exposure")
+	// ByBit carries both positions: 10 + 20 + 10
+	assert.ErrorContains(t, risk.checkExposure("SOLUSDT", "ByBit", "BingX", ten, positions), "ByBit exposure")
+	assert.NoError(t, risk.checkExposure("SOLUSDT", "MEXC", "BingX", ten, positions))
+	assert.ErrorContains(t, risk.checkExposure("SOLUSDT", "Gate", "BingX", decimal.NewFromInt(16), positions), "total exposure")
+}
+
+func TestRiskLimits_KillSwitch(t *testing.T) {
+	risk := newRiskLimits(config.RiskConfig{MaxPositions: 2, MaxDailyLossUSDT: 5, MaxConsecutiveFailures: 2})
+
+	assert.NoError(t, risk.canOpen(1))
+	assert.Error(t, risk.canOpen(2))
+
+	// a closed position resets consecutive failures
+	assert.Empty(t, risk.recordFailure())
+	assert.Empty(t, risk.recordClosed(decimal.NewFromInt(1), time.Now()))
+	assert.Empty(t, risk.recordFailure())
+
+	// the daily loss is reset on a new UTC day
+	yesterday := time.Now().Add(-24 * time.Hour)
+	assert.Empty(t, risk.recordClosed(decimal.NewFromInt(-4), yesterday))
+	assert.Empty(t, risk.recordClosed(decimal.NewFromInt(-4), time.Now()))
+	assert.Contains(t, risk.recordClosed(decimal.NewFromInt(-1), time.Now()), "daily loss 5.00 USDT")
+	assert.ErrorContains(t, risk.canOpen(0), "kill-switch is on")
+
+	// reported once
+	assert.Empty(t, risk.recordFailure())
+	assert.Empty(t, risk.recordFailure())
+}
+
+func TestEngine_RecordClosed_TripsKillSwitch(t *testing.T) {
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Risk.MaxDailyLossUSDT = 1
+	notif := &notifierStub{}
+	engine := NewEngine(cfg, nil, nil, &repoStub{}, notif, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1")}},
+	}
+	engine.fees = FeeSchedules{"ByBit": {Default: exchange.FeeRate{Taker: 0.001}}}
+
+	pos := &Position{
+		ID:           uuid.New(),
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		OpenBuyLeg:   Leg{AvgPrice: decimal.NewFromInt(100), FilledQty: decimal.NewFromInt(2)},
+		OpenSellLeg:  Leg{AvgPrice: decimal.NewFromInt(101), FilledQty: decimal.NewFromInt(20)},
+		CloseBuyLeg:  Leg{AvgPrice: decimal.NewFromInt(99), FilledQty: decimal.NewFromInt(2)},
+		CloseSellLeg: Leg{AvgPrice: decimal.NewFromInt(101), FilledQty: decimal.NewFromInt(20)},
+		State:        PositionStateClosing,
+	}
+
+	// -2 on the long leg, 0 on the short one, fees 200 * 0.2%
+	pnl, err := engine.realizedPnL(pos)
+	require.NoError(t, err)
+	assert.Equal(t, "-2.4", pnl.String())
+
+	engine.recordClosed(pos)
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "kill-switch")
+	assert.False(t, engine.canBeOpened(&SpreadEvent{Symbol: "ETHUSDT", BuyPrice: 1}))
+
+	// failures of positions are counted once
+	engine.finishPosition(pos, models.ArbitrageTradeStatusFailed, nil)
+	engine.finishPosition(pos, models.ArbitrageTradeStatusRecoveryRequired, nil)
+	assert.Equal(t, 1, engine.risk.failures)
+}
+
+func TestEngine_FinishPosition_RecordsFlattenedLegs(t *testing.T) {
+	ctx := t.Context()
+
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.Risk.MaxDailyLossUSDT = 10
+	notif := &notifierStub{}
+	engine := NewEngine(cfg, nil, nil, &repoStub{}, notif, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.1")}},
+	}
+	engine.fees = FeeSchedules{"ByBit": {Default: exchange.FeeRate{Taker: 0.001}}}
+
+	// the short leg failed, the filled long leg is emergency-closed
+	closeID := uuid.New()
+	pos := &Position{
+		ID:           uuid.New(),
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		OpenBuyLeg:   Leg{OrderID: uuid.New(), AvgPrice: decimal.NewFromInt(100), FilledQty: decimal.NewFromInt(2), Confirmed: true},
+		OpenSellLeg:  Leg{OrderID: uuid.New()},
+		CloseBuyLeg:  Leg{OrderID: closeID},
+		State:        PositionStateTimedOut,
+	}
+	engine.pm.Add(pos)
+	engine.pm.IndexCloseLeg(pos, closeID, uuid.Nil)
+
+	engine.handleExecution(ctx, exchange.OrderExecutionEvent{OrderID: closeID, ExecPrice: decimal.NewFromInt(96), ExecQty: decimal.NewFromInt(2)})
+	assert.True(t, pos.CloseBuyLeg.Confirmed)
+
+	// -8 on the long leg, fees 200 * 0.2%
+	pnl, err := engine.flattenedPnL(pos)
+	require.NoError(t, err)
+	assert.Equal(t, "-8.4", pnl.String())
+
+	engine.finishPosition(pos, models.ArbitrageTradeStatusFailed, nil)
+	assert.Equal(t, "-8.4", engine.risk.dailyPnL.String())
+	assert.Equal(t, 1, engine.risk.failures)
+	assert.Empty(t, notif.msgs)
+
+	// a failure without fills adds nothing
+	engine.finishPosition(&Position{Symbol: "ETHUSDT", BuyExchange: "ByBit", SellExchange: "MEXC"}, models.ArbitrageTradeStatusFailed, nil)
+	assert.Equal(t, "-8.4", engine.risk.dailyPnL.String())
+
+	// the next flattened loss trips the daily loss limit
+	engine.finishPosition(&Position{
+		Symbol:       "BTCUSDT",
+		BuyExchange:  "ByBit",
+		SellExchange: "MEXC",
+		OpenSellLeg:  Leg{AvgPrice: decimal.NewFromInt(100), FilledQty: decimal.NewFromInt(20)},
+		CloseSellLeg: Leg{AvgPrice: decimal.NewFromInt(102), FilledQty: decimal.NewFromInt(20)},
+	}, models.ArbitrageTradeStatusFailed, nil)
+	require.Len(t, notif.msgs, 1)
+	assert.Contains(t, notif.msgs[0], "daily loss 12.40 USDT")
+}
@@ -3,20 +3,40 @@ package arbitragebot
 import (
 	"time"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/models"
 )
 
 // PricePoint represents a price point with timestamp.
+// Bid/Ask are the best bid/ask from the book ticker stream, Price is the last trade price.
 type PricePoint struct {
 	Price float64
+	Bid   float64
+	Ask   float64
 	TsMs  int64
 }
 
+// buyPrice returns the price a market buy is executed at: best ask, or last trade price if there is no book data.
+func (p PricePoint) buyPrice() float64 {
+	if p.Ask > 0 {
+		return p.Ask
+	}
+	return p.Price
+}
+
+// sellPrice returns the price a market sell is executed at: best bid, or last trade price if there is no book data.
+func (p PricePoint) sellPrice() float64 {
+	if p.Bid > 0 {
+		return p.Bid
+	}
+	return p.Price
+}
+
 const minStepChangeToUpdate = 0.5
 
 type activeSpreadState struct {
-	maxSpreadPercent float64
+	maxNetSpreadPercent float64
 }
 
 // SpreadEvent represents an arbitrage spread event.
@@ -30,8 +50,13 @@ type SpreadEvent struct {
 	BuyPrice  float64
 	SellPrice float64
 
+	// Gross spread: (SellPrice - BuyPrice) / BuyPrice * 100
 	FromSpreadPercent float64
 	MaxSpreadPercent  float64
+
+	// Net spread: gross minus taker fees of both legs for open and close and slippage
+	FromNetSpreadPercent float64
+	MaxNetSpreadPercent  float64
 }
 
 // SpreadDetector detects arbitrage opportunities by comparing prices across exchanges for a given symbol.
@@ -39,9 +64,11 @@ type SpreadEvent struct {
 type SpreadDetector struct {
 	minSpreadPercent      float64                       // Minimal spread percent
 	maxAgeMs              int64                         // Max age of price in milliseconds
-	nowFn                 func() time.Time              // Function to get current time (for testing)
+	clock                 clock.Clock                   // Time source, price ages are measured against it
 	activeSpreads         map[string]*activeSpreadState // current active spreads
 	percentForCloseSpread float64                       // Spread percent for close signal
+	slippagePercent       float64                       // Round trip slippage percent
+	fees                  FeeSchedules                  // Fee schedules by exchange
 }
 
 // NewSpreadDetector creates a new SpreadDetector.
@@ -49,13 +76,37 @@ func NewSpreadDetector(cfg *config.Config) *SpreadDetector {
 	return &SpreadDetector{
 		minSpreadPercent:      cfg.Exchange.ArbitrageBot.MinSpreadPercent,
 		maxAgeMs:              cfg.Exchange.ArbitrageBot.MaxAgeMs,
-		nowFn:                 time.Now,
+		clock:                 clock.Real(),
 		activeSpreads:         make(map[string]*activeSpreadState),
 		percentForCloseSpread: cfg.Exchange.ArbitrageBot.PercentForCloseSpread,
+		slippagePercent:       cfg.Exchange.ArbitrageBot.SlippagePercent,
+		fees:                  make(FeeSchedules),
+	}
+}
+
+// SetFees sets fee schedules used for net spread calculation.
+func (d *SpreadDetector) SetFees(fees FeeSchedules) {
+	d.fees = fees
+}
+
+// SetClock sets the time source, the replayed one in replays and backtests.
+func (d *SpreadDetector) SetClock(c clock.Clock) {
+	d.clock = c
+}
+
+// Restore marks the spread as active, so the detector emits update and close events for a position
+// restored after restart instead of opening it again.
+func (d *SpreadDetector) Restore(symbol, buyExchange, sellExchange string, maxNetSpreadPercent float64) {
+	d.activeSpreads[getSpreadKey(symbol, buyExchange, sellExchange)] = &activeSpreadState{
+		maxNetSpreadPercent: maxNetSpreadPercent,
 	}
 }
 
 // Detect identifies arbitrage opportunities by comparing prices across exchanges for a given symbol.
+// Spread is executable one: sell bid on the sell exchange vs buy ask on the buy exchange
+// (falls back to last trade prices if there is no book data).
+// Thresholds are compared with net spread: gross spread minus taker fees of both legs
+// for open and close and configured slippage. Keys are venue names, spot venues are never the sell leg.
 // pricesByExchange map[string]PricePoint - prices by exchanges for one symbol
 // example pricesByExchange map[string]PricePoint:
 //
@@ -68,7 +119,7 @@ func NewSpreadDetector(cfg *config.Config) *SpreadDetector {
 //		 },
 //	}
 func (d *SpreadDetector) Detect(symbol string, pricesByExchange map[string]PricePoint) []*SpreadEvent {
-	now := d.nowFn()
+	now := d.clock.Now()
 
 	freshestPrice := d.filterFreshestPrices(pricesByExchange, now)
 	if freshestPrice == nil {
@@ -78,56 +129,60 @@ func (d *SpreadDetector) Detect(symbol string, pricesByExchange map[string]Price
 	var spreadEvents []*SpreadEvent
 	var spreadKey string
 
-	for buyExchange, buyPrice := range freshestPrice {
-		for sellExchange, sellPrice := range freshestPrice {
+	for buyExchange, buyPoint := range freshestPrice {
+		for sellExchange, sellPoint := range freshestPrice {
 
 			if buyExchange == sellExchange {
 				continue
 			}
-
-			if sellPrice.Price <= buyPrice.Price {
-				// here spread will be negative
+			// spot can't be sold short, spot venue is the buy leg only
+			if parseVenue(sellExchange).IsSpot() {
 				continue
 			}
 
+			buyPx := buyPoint.buyPrice()
+			sellPx := sellPoint.sellPrice()
+
 			spreadKey = getSpreadKey(symbol, buyExchange, sellExchange)
 
-			// TODO сейчас порог это gross. Добавить расчет net порога с учетом sell fee, buy fee,
-			// TODO какое-нибудь проскальзываение ...
-			spreadPercent := (sellPrice.Price - buyPrice.Price) / buyPrice.Price * 100
+			spreadPercent := (sellPx - buyPx) / buyPx * 100
+			netSpreadPercent := spreadPercent - d.fees.roundTripFeePercent(symbol, buyExchange, sellExchange) - d.slippagePercent
 
-			//
+			// Open/update/close decisions are made on net spread
 			_, ok := d.activeSpreads[spreadKey]
-			if !ok && spreadPercent < d.minSpreadPercent {
+			if !ok && netSpreadPercent < d.minSpreadPercent {
 				continue
-			} else if !ok && spreadPercent >= d.minSpreadPercent {
+			} else if !ok && netSpreadPercent >= d.minSpreadPercent {
 				// New spread
 				d.activeSpreads[spreadKey] = &activeSpreadState{
-					maxSpreadPercent: spreadPercent,
+					maxNetSpreadPercent: netSpreadPercent,
 				}
 				spreadEvents = append(spreadEvents, &SpreadEvent{
-					Status:            models.ArbitrageSpreadOpened,
-					Symbol:            symbol,
-					BuyOnExchange:     buyExchange,
-					SellOnExchange:    sellExchange,
-					BuyPrice:          buyPrice.Price,
-					SellPrice:         sellPrice.Price,
-					FromSpreadPercent: spreadPercent,
-					MaxSpreadPercent:  spreadPercent,
+					Status:               models.ArbitrageSpreadOpened,
+					Symbol:               symbol,
+					BuyOnExchange:        buyExchange,
+					SellOnExchange:       sellExchange,
+					BuyPrice:             buyPx,
+					SellPrice:            sellPx,
+					FromSpreadPercent:    spreadPercent,
+					MaxSpreadPercent:     spreadPercent,
+					FromNetSpreadPercent: netSpreadPercent,
+					MaxNetSpreadPercent:  netSpreadPercent,
 				})
-			} else if ok && spreadPercent > d.activeSpreads[spreadKey].maxSpreadPercent+minStepChangeToUpdate {
+			} else if ok && netSpreadPercent > d.activeSpreads[spreadKey].maxNetSpreadPercent+minStepChangeToUpdate {
 				// Update
-				d.activeSpreads[spreadKey].maxSpreadPercent = spreadPercent
+				d.activeSpreads[spreadKey].maxNetSpreadPercent = netSpreadPercent
 				spreadEvents = append(spreadEvents, &SpreadEvent{
-					Status:           models.ArbitrageSpreadUpdated,
-					Symbol:           symbol,
-					BuyOnExchange:    buyExchange,
-					SellOnExchange:   sellExchange,
-					BuyPrice:         buyPrice.Price,
-					SellPrice:        sellPrice.Price,
-					MaxSpreadPercent: spreadPercent,
+					Status:              models.ArbitrageSpreadUpdated,
+					Symbol:              symbol,
+					BuyOnExchange:       buyExchange,
+					SellOnExchange:      sellExchange,
+					BuyPrice:            buyPx,
+					SellPrice:           sellPx,
+					MaxSpreadPercent:    spreadPercent,
+					MaxNetSpreadPercent: netSpreadPercent,
 				})
-			} else if ok && spreadPercent <= d.percentForCloseSpread {
+			} else if ok && netSpreadPercent <= d.percentForCloseSpread {
 				// Close
 				spreadEvents = append(spreadEvents, &SpreadEvent{
 					Status:         models.ArbitrageSpreadClosed,
@@ -148,7 +203,7 @@ func (d *SpreadDetector) filterFreshestPrices(pricesByExchange map[string]PriceP
 	freshestPrice := make(map[string]PricePoint, len(pricesByExchange))
 
 	for exchangeName, price := range pricesByExchange {
-		if price.Price <= 0 {
+		if price.buyPrice() <= 0 || price.sellPrice() <= 0 {
 			continue
 		}
 		if now.UnixMilli()-price.TsMs > d.maxAgeMs {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/models"
 )
 
@@ -17,6 +18,10 @@ func getConfig() *config.Config {
 				MaxAgeMs:              60_000,
 				MinSpreadPercent:      1,
 				PercentForCloseSpread: 0.1,
+				Risk: config.RiskConfig{
+					MaxPositions: 3,
+					NotionalUSDT: 10,
+				},
 			},
 		},
 	}
@@ -34,14 +39,16 @@ func TestSpreadDetector_TestOpenSpreadEvent(t *testing.T) {
 
 	expectedSpreadEvent := []*SpreadEvent{
 		{
-			Status:            models.ArbitrageSpreadOpened,
-			Symbol:            "BTCUSDT",
-			BuyOnExchange:     "ByBit",
-			SellOnExchange:    "BingX",
-			BuyPrice:          100,
-			SellPrice:         103,
-			FromSpreadPercent: 3.0,
-			MaxSpreadPercent:  3.0,
+			Status:               models.ArbitrageSpreadOpened,
+			Symbol:               "BTCUSDT",
+			BuyOnExchange:        "ByBit",
+			SellOnExchange:       "BingX",
+			BuyPrice:             100,
+			SellPrice:            103,
+			FromSpreadPercent:    3.0,
+			MaxSpreadPercent:     3.0,
+			FromNetSpreadPercent: 3.0,
+			MaxNetSpreadPercent:  3.0,
 		},
 	}
 
@@ -64,13 +71,14 @@ func TestSpreadDetector_TestUpdateSpreadEvent(t *testing.T) {
 	})
 	expectedSpreadEvent := []*SpreadEvent{
 		{
-			Status:           models.ArbitrageSpreadUpdated,
-			Symbol:           "BTCUSDT",
-			BuyOnExchange:    "ByBit",
-			SellOnExchange:   "BingX",
-			BuyPrice:         100,
-			SellPrice:        105,
-			MaxSpreadPercent: 5.0,
+			Status:              models.ArbitrageSpreadUpdated,
+			Symbol:              "BTCUSDT",
+			BuyOnExchange:       "ByBit",
+			SellOnExchange:      "BingX",
+			BuyPrice:            100,
+			SellPrice:           105,
+			MaxSpreadPercent:    5.0,
+			MaxNetSpreadPercent: 5.0,
 		},
 	}
 
@@ -102,3 +110,77 @@ func TestSpreadDetector_TestCloseSpreadEvent(t *testing.T) {
 
 	assert.Equal(t, expectedSpreadEvent, updatedEvent)
 }
+
+func TestSpreadDetector_UsesBidAsk(t *testing.T) {
+	cfg := getConfig()
+
+	sd := NewSpreadDetector(cfg)
+
+	// last trade prices look like 3% spread, but the book is not executable: sell bid 101.5 vs buy ask 101
+	spreadEvent := sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit": {Price: 100, Bid: 100.9, Ask: 101, TsMs: time.Now().UnixMilli()},
+		"BingX": {Price: 103, Bid: 101.5, Ask: 103.1, TsMs: time.Now().UnixMilli()},
+	})
+	assert.Nil(t, spreadEvent)
+
+	spreadEvent = sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit": {Bid: 99.9, Ask: 100, TsMs: time.Now().UnixMilli()},
+		"BingX": {Bid: 102, Ask: 102.1, TsMs: time.Now().UnixMilli()},
+	})
+
+	expectedSpreadEvent := []*SpreadEvent{
+		{
+			Status:               models.ArbitrageSpreadOpened,
+			Symbol:               "BTCUSDT",
+			BuyOnExchange:        "ByBit",
+			SellOnExchange:       "BingX",
+			BuyPrice:             100,
+			SellPrice:            102,
+			FromSpreadPercent:    2.0,
+			MaxSpreadPercent:     2.0,
+			FromNetSpreadPercent: 2.0,
+			MaxNetSpreadPercent:  2.0,
+		},
+	}
+
+	assert.Equal(t, expectedSpreadEvent, spreadEvent)
+}
+
+func TestSpreadDetector_UsesNetSpread(t *testing.T) {
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.SlippagePercent = 0.2
+
+	sd := NewSpreadDetector(cfg)
+	// round trip fees: 2 * (0.05% + 0.1%) = 0.3%, costs with slippage = 0.5%
+	sd.SetFees(FeeSchedules{
+		"ByBit": {Default: exchange.FeeRate{Taker: 0.0005}},
+		"BingX": {
+			Default: exchange.FeeRate{Taker: 0.0002},
+			Symbols: map[string]exchange.FeeRate{"BTCUSDT": {Taker: 0.001}},
+		},
+	})
+
+	// gross 1.4% is above min spread, but net 0.9% is not
+	spreadEvent := sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit": {Price: 100, TsMs: time.Now().UnixMilli()},
+		"BingX": {Price: 101.4, TsMs: time.Now().UnixMilli()},
+	})
+	assert.Nil(t, spreadEvent)
+
+	spreadEvent = sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit": {Price: 100, TsMs: time.Now().UnixMilli()},
+		"BingX": {Price: 102, TsMs: time.Now().UnixMilli()},
+	})
+	assert.Len(t, spreadEvent, 1)
+	assert.Equal(t, models.ArbitrageSpreadOpened, spreadEvent[0].Status)
+	assert.InDelta(t, 2.0, spreadEvent[0].FromSpreadPercent, 1e-9)
+	assert.InDelta(t, 1.5, spreadEvent[0].FromNetSpreadPercent, 1e-9)
+
+	// gross 0.55% is still positive, but net 0.05% is below the close threshold
+	spreadEvent = sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit": {Price: 100, TsMs: time.Now().UnixMilli()},
+		"BingX": {Price: 100.55, TsMs: time.Now().UnixMilli()},
+	})
+	assert.Len(t, spreadEvent, 1)
+	assert.Equal(t, models.ArbitrageSpreadClosed, spreadEvent[0].Status)
+}
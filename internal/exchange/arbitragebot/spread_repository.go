@@ -27,11 +27,16 @@ type FindFilter struct {
 	Status      []models.ArbitrageSpreadStatus
 	NotInStatus []models.ArbitrageSpreadStatus
 	ID          uuid.UUID
+	// OpenPosition selects spreads with placed open orders and no close orders yet.
+	OpenPosition bool
+	// Paper selects the paper (true) or live (false) spreads, nil - both.
+	Paper *bool
 }
 
 // Repository is a GORM implementation of ArbitrageSpreadRepository interface.
 type Repository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	paper *bool // nil - live and paper spreads
 }
 
 // NewArbitrageSpreadRepository creates a new GormArbitrageSpreadRepository.
@@ -39,6 +44,19 @@ func NewArbitrageSpreadRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// WithPaper returns the repository limited to the paper (true) or live (false) spreads, so a paper bot and
+// a live one sharing the DB don't see each other's spreads.
+func (r *Repository) WithPaper(paper bool) *Repository {
+	return &Repository{db: r.db, paper: &paper}
+}
+
+func (r *Repository) scope(db *gorm.DB) *gorm.DB {
+	if r.paper != nil {
+		db = db.Where("paper = ?", *r.paper)
+	}
+	return db
+}
+
 // Create creates a new arbitrage spread in the repository.
 func (r *Repository) Create(ctx context.Context, spread *models.ArbitrageSpread) error {
 	return r.db.WithContext(ctx).Create(spread).Error
@@ -46,7 +64,7 @@ func (r *Repository) Create(ctx context.Context, spread *models.ArbitrageSpread)
 
 // Update updates an existing arbitrage spread in the repository based on the provided filter.
 func (r *Repository) Update(ctx context.Context, spread *models.ArbitrageSpread, f FindFilter) error {
-	tx := r.db.WithContext(ctx).Model(&models.ArbitrageSpread{})
+	tx := r.scope(r.db.WithContext(ctx).Model(&models.ArbitrageSpread{}))
 
 	if f.Symbol != "" {
 		tx = tx.Where("symbol = ?", f.Symbol)
@@ -86,6 +104,13 @@ func combineFilters(db *gorm.DB, f FindFilter) *gorm.DB {
 	if len(f.NotInStatus) > 0 {
 		db = db.Where("status NOT IN ?", f.NotInStatus)
 	}
+	if f.OpenPosition {
+		db = db.Where("open_buy_order_id <> ? AND open_sell_order_id <> ?", uuid.Nil, uuid.Nil).
+			Where("close_buy_order_id = ? AND close_sell_order_id = ?", uuid.Nil, uuid.Nil)
+	}
+	if f.Paper != nil {
+		db = db.Where("paper = ?", *f.Paper)
+	}
 
 	return db
 }
@@ -98,7 +123,7 @@ func (r *Repository) FindAll(
 
 	var spreads []*models.ArbitrageSpread
 
-	tx := r.db.WithContext(ctx).Model(&models.ArbitrageSpread{})
+	tx := r.scope(r.db.WithContext(ctx).Model(&models.ArbitrageSpread{}))
 	tx = combineFilters(tx, f)
 
 	result := tx.Find(&spreads)
@@ -116,7 +141,7 @@ func (r *Repository) FindOne(
 ) (*models.ArbitrageSpread, error) {
 
 	var spread *models.ArbitrageSpread
-	tx := r.db.WithContext(ctx).Model(&models.ArbitrageSpread{})
+	tx := r.scope(r.db.WithContext(ctx).Model(&models.ArbitrageSpread{}))
 	tx = combineFilters(tx, f)
 
 	result := tx.First(&spread)
@@ -135,6 +160,7 @@ func (r *Repository) FindOneByOrderID(ctx context.Context, orderID uuid.UUID) (*
 		Or("open_sell_order_id = ?", orderID).
 		Or("close_buy_order_id = ?", orderID).
 		Or("close_sell_order_id = ?", orderID).
+		Or("rebalance_order_id = ?", orderID).
 		First(&spread)
 
 	if result.Error != nil {
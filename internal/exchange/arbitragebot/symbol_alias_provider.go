@@ -0,0 +1,367 @@
+package arbitragebot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// WithSymbolAliases wraps the clients so that symbols, prices and contract sizes they take and return are
+// canonical (see SymbolAliases). Clients of exchanges without aliases are returned as is.
+func WithSymbolAliases(clients []exchange.Provider, aliases *SymbolAliases) []exchange.Provider {
+	wrapped := make([]exchange.Provider, 0, len(clients))
+	for _, client := range clients {
+		name := client.GetExchangeName()
+		spot := Venue{Exchange: name, Category: exchange.CategorySpot}.Name()
+		if !aliases.hasVenue(name) && !aliases.hasVenue(spot) {
+			wrapped = append(wrapped, client)
+			continue
+		}
+		wrapped = append(wrapped, &aliasedProvider{
+			Provider: client,
+			aliases:  aliases,
+			orders:   make(map[uuid.UUID]symbolAlias),
+		})
+	}
+	return wrapped
+}
+
+// aliasedProvider translates canonical symbols and prices to the ones of the exchange and back.
+// Quantities are in exchange units and are not translated: the multiplier is applied to
+// Instrument.ContractSize instead. Execution events carry no symbol, so aliases of placed orders are kept
+// until the order is fully filled.
+type aliasedProvider struct {
+	exchange.Provider
+	aliases *SymbolAliases
+
+	ordersMu sync.Mutex
+	orders   map[uuid.UUID]symbolAlias // non-identity aliases of orders by client order id
+}
+
+func (p *aliasedProvider) venueName(category exchange.Category) string {
+	return Venue{Exchange: p.GetExchangeName(), Category: category}.Name()
+}
+
+func (p *aliasedProvider) rememberOrder(orderID uuid.UUID, alias symbolAlias) {
+	if alias.identity() {
+		return
+	}
+	p.ordersMu.Lock()
+	p.orders[orderID] = alias
+	p.ordersMu.Unlock()
+}
+
+// GetTickers returns tickers of the canonical symbols.
+func (p *aliasedProvider) GetTickers(ctx context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	venueName := p.venueName(category)
+
+	tickers, err := p.Provider.GetTickers(ctx, p.aliases.toNativeSymbols(venueName, symbols), category)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]exchange.Ticker, 0, len(tickers))
+	for _, t := range tickers {
+		alias, ok := p.aliases.toCanonical(venueName, t.Symbol)
+		if !ok {
+			continue
+		}
+		t.Symbol = alias.canonical
+		t.LastPrice = alias.toCanonicalPrice(t.LastPrice)
+		t.IndexPrice = alias.toCanonicalPrice(t.IndexPrice)
+		t.MarkPrice = alias.toCanonicalPrice(t.MarkPrice)
+		t.PrevPrice24h = alias.toCanonicalPrice(t.PrevPrice24h)
+		t.HighPrice24h = alias.toCanonicalPrice(t.HighPrice24h)
+		t.LowPrice24h = alias.toCanonicalPrice(t.LowPrice24h)
+		t.PrevPrice1h = alias.toCanonicalPrice(t.PrevPrice1h)
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// GetInstruments returns instruments by canonical symbol, price step and contract size in canonical coins.
+func (p *aliasedProvider) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	venueName := p.venueName(category)
+
+	instruments, err := p.Provider.GetInstruments(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]exchange.Instrument, len(instruments))
+	for symbol, inst := range instruments {
+		alias, ok := p.aliases.toCanonical(venueName, symbol)
+		if !ok {
+			continue
+		}
+		inst.Symbol = alias.canonical
+		inst.PriceStep = alias.toCanonicalPrice(inst.PriceStep)
+		inst.ContractSize = inst.ContractSize.Mul(alias.multiplier)
+		result[alias.canonical] = inst
+	}
+
+	return result, nil
+}
+
+// GetFeeSchedule returns the fee schedule with per-symbol rates by canonical symbol.
+func (p *aliasedProvider) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	venueName := p.venueName(exchange.CategoryLinear)
+
+	schedule, err := p.Provider.GetFeeSchedule(ctx)
+	if err != nil || schedule.Symbols == nil {
+		return schedule, err
+	}
+
+	symbols := make(map[string]exchange.FeeRate, len(schedule.Symbols))
+	for symbol, rate := range schedule.Symbols {
+		if alias, ok := p.aliases.toCanonical(venueName, symbol); ok {
+			symbols[alias.canonical] = rate
+		}
+	}
+	schedule.Symbols = symbols
+
+	return schedule, nil
+}
+
+// GetFundingRates returns funding rates by canonical symbol.
+func (p *aliasedProvider) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	venueName := p.venueName(exchange.CategoryLinear)
+
+	rates, err := p.Provider.GetFundingRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]exchange.FundingRate, len(rates))
+	for symbol, rate := range rates {
+		alias, ok := p.aliases.toCanonical(venueName, symbol)
+		if !ok {
+			continue
+		}
+		rate.Symbol = alias.canonical
+		result[alias.canonical] = rate
+	}
+
+	return result, nil
+}
+
+// SubscribeTrades subscribes to trades of the canonical symbols.
+func (p *aliasedProvider) SubscribeTrades(ctx context.Context, symbols []string, category exchange.Category) (<-chan exchange.Trade, error) {
+	venueName := p.venueName(category)
+
+	ch, err := p.Provider.SubscribeTrades(ctx, p.aliases.toNativeSymbols(venueName, symbols), category)
+	if err != nil || !p.aliases.hasVenue(venueName) {
+		return ch, err
+	}
+
+	return translateStream(ctx, ch, func(t exchange.Trade) (exchange.Trade, bool) {
+		alias, ok := p.aliases.toCanonical(venueName, t.Symbol)
+		t.Symbol = alias.canonical
+		t.Price = alias.toCanonicalPriceFloat(t.Price)
+		return t, ok
+	}), nil
+}
+
+// SubscribeBookTicker subscribes to the book ticker of the canonical symbols.
+func (p *aliasedProvider) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	venueName := p.venueName(exchange.CategoryLinear)
+
+	ch, err := p.Provider.SubscribeBookTicker(ctx, p.aliases.toNativeSymbols(venueName, symbols))
+	if err != nil || !p.aliases.hasVenue(venueName) {
+		return ch, err
+	}
+
+	return translateStream(ctx, ch, func(top exchange.BookTop) (exchange.BookTop, bool) {
+		alias, ok := p.aliases.toCanonical(venueName, top.Symbol)
+		top.Symbol = alias.canonical
+		top.BidPrice = alias.toCanonicalPriceFloat(top.BidPrice)
+		top.AskPrice = alias.toCanonicalPriceFloat(top.AskPrice)
+		return top, ok
+	}), nil
+}
+
+// SubscribeOrderBook subscribes to the order book of the canonical symbols.
+func (p *aliasedProvider) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	venueName := p.venueName(exchange.CategoryLinear)
+
+	ch, err := p.Provider.SubscribeOrderBook(ctx, p.aliases.toNativeSymbols(venueName, symbols))
+	if err != nil || !p.aliases.hasVenue(venueName) {
+		return ch, err
+	}
+
+	return translateStream(ctx, ch, func(update exchange.OrderBookUpdate) (exchange.OrderBookUpdate, bool) {
+		alias, ok := p.aliases.toCanonical(venueName, update.Symbol)
+		update.Symbol = alias.canonical
+		for i := range update.Bids {
+			update.Bids[i].Price = alias.toCanonicalPriceFloat(update.Bids[i].Price)
+		}
+		for i := range update.Asks {
+			update.Asks[i].Price = alias.toCanonicalPriceFloat(update.Asks[i].Price)
+		}
+		return update, ok
+	}), nil
+}
+
+// CreateOrder places the order with the exchange symbol and price.
+func (p *aliasedProvider) CreateOrder(ctx context.Context, order *models.Order) error {
+	return p.placeOrder(ctx, order, p.Provider.CreateOrder)
+}
+
+// CloseOrder places the closing order with the exchange symbol and price.
+func (p *aliasedProvider) CloseOrder(ctx context.Context, order *models.Order) error {
+	return p.placeOrder(ctx, order, p.Provider.CloseOrder)
+}
+
+// placeOrder passes a copy of the order with the exchange symbol and price to place, then copies back
+// the fields set by the exchange with the canonical symbol and prices.
+func (p *aliasedProvider) placeOrder(ctx context.Context, order *models.Order, place func(context.Context, *models.Order) error) error {
+	alias := p.aliases.toNative(p.venueName(categoryOf(order.Market)), order.Symbol)
+	if alias.identity() {
+		return place(ctx, order)
+	}
+	p.rememberOrder(order.ID, alias)
+
+	native := *order
+	native.Symbol = alias.native
+	native.Price = alias.toNativePrice(order.Price)
+
+	err := place(ctx, &native)
+
+	symbol, price := order.Symbol, order.Price
+	*order = native
+	order.Symbol = symbol
+	order.Price = price
+	order.AvgPrice = alias.toCanonicalPrice(native.AvgPrice)
+
+	return err
+}
+
+// CancelOrder cancels the order of the canonical symbol.
+func (p *aliasedProvider) CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) error {
+	alias := p.aliases.toNative(p.venueName(category), symbol)
+	return p.Provider.CancelOrder(ctx, orderID, exchangeOrderID, alias.native, category)
+}
+
+// SetLeverage sets the leverage of the canonical symbol.
+func (p *aliasedProvider) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	alias := p.aliases.toNative(p.venueName(exchange.CategoryLinear), symbol)
+	return p.Provider.SetLeverage(ctx, alias.native, leverage)
+}
+
+// SetMarginMode sets the margin mode of the canonical symbol.
+func (p *aliasedProvider) SetMarginMode(ctx context.Context, symbol string, mode exchange.MarginMode) error {
+	alias := p.aliases.toNative(p.venueName(exchange.CategoryLinear), symbol)
+	return p.Provider.SetMarginMode(ctx, alias.native, mode)
+}
+
+// GetOrder returns the order of the canonical symbol with the canonical average price.
+func (p *aliasedProvider) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	alias := p.aliases.toNative(p.venueName(category), symbol)
+	// orders restored after restart are looked up before their executions arrive
+	p.rememberOrder(orderID, alias)
+
+	order, err := p.Provider.GetOrder(ctx, orderID, exchangeOrderID, alias.native, category)
+	if err != nil {
+		return order, err
+	}
+	order.AvgPrice = alias.toCanonicalPrice(order.AvgPrice)
+
+	return order, nil
+}
+
+// GetPositions returns positions by canonical symbol.
+func (p *aliasedProvider) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	venueName := p.venueName(exchange.CategoryLinear)
+
+	positions, err := p.Provider.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]exchange.Position, 0, len(positions))
+	for _, pos := range positions {
+		alias, ok := p.aliases.toCanonical(venueName, pos.Symbol)
+		if !ok {
+			continue
+		}
+		pos.Symbol = alias.canonical
+		pos.EntryPrice = alias.toCanonicalPrice(pos.EntryPrice)
+		result = append(result, pos)
+	}
+
+	return result, nil
+}
+
+// GetOpenOrders returns open orders by canonical symbol.
+func (p *aliasedProvider) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	venueName := p.venueName(category)
+
+	orders, err := p.Provider.GetOpenOrders(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]exchange.OpenOrder, 0, len(orders))
+	for _, order := range orders {
+		alias, ok := p.aliases.toCanonical(venueName, order.Symbol)
+		if !ok {
+			continue
+		}
+		order.Symbol = alias.canonical
+		order.Price = alias.toCanonicalPrice(order.Price)
+		result = append(result, order)
+	}
+
+	return result, nil
+}
+
+// SubscribeExecutions returns executions with canonical prices of the orders placed through the provider.
+func (p *aliasedProvider) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
+	ch, err := p.Provider.SubscribeExecutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return translateStream(ctx, ch, func(event exchange.OrderExecutionEvent) (exchange.OrderExecutionEvent, bool) {
+		p.ordersMu.Lock()
+		alias, ok := p.orders[event.OrderID]
+		if ok && event.LeavesQty.IsZero() {
+			delete(p.orders, event.OrderID)
+		}
+		p.ordersMu.Unlock()
+
+		if ok {
+			event.ExecPrice = alias.toCanonicalPrice(event.ExecPrice)
+			event.OrderPrice = alias.toCanonicalPrice(event.OrderPrice)
+		}
+		return event, true
+	}), nil
+}
+
+// translateStream forwards messages of in translated by fn to the returned channel, messages fn returns
+// false for are dropped. The returned channel is closed when in is closed.
+func translateStream[T any](ctx context.Context, in <-chan T, fn func(T) (T, bool)) <-chan T {
+	out := make(chan T, cap(in))
+
+	go func() {
+		defer close(out)
+		for msg := range in {
+			msg, ok := fn(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,39 @@
+package arbitragebot
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// SymbolAliasRepository represents a db repository for symbol aliases.
+type SymbolAliasRepository interface {
+	FindAll(ctx context.Context) ([]models.SymbolAlias, error)
+}
+
+// GormSymbolAliasRepository is a GORM implementation of SymbolAliasRepository interface.
+type GormSymbolAliasRepository struct {
+	db *gorm.DB
+}
+
+// NewSymbolAliasRepository creates a new GormSymbolAliasRepository.
+func NewSymbolAliasRepository(db *gorm.DB) *GormSymbolAliasRepository {
+	return &GormSymbolAliasRepository{db: db}
+}
+
+// FindAll finds all aliases, oldest first.
+func (r *GormSymbolAliasRepository) FindAll(ctx context.Context) ([]models.SymbolAlias, error) {
+	var aliases []models.SymbolAlias
+
+	err := r.db.WithContext(ctx).
+		Model(&models.SymbolAlias{}).
+		Order("created_at ASC").
+		Find(&aliases).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
@@ -0,0 +1,153 @@
+package arbitragebot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// symbolAlias maps the symbol of a venue to its canonical symbol. One coin of native is multiplier canonical
+// coins, so native prices are multiplier times the canonical ones.
+type symbolAlias struct {
+	canonical  string
+	native     string
+	multiplier decimal.Decimal
+}
+
+// identity returns true if the alias changes neither the symbol nor prices.
+func (a symbolAlias) identity() bool {
+	return a.canonical == a.native && a.multiplier.Equal(decimal.NewFromInt(1))
+}
+
+func (a symbolAlias) toCanonicalPrice(price decimal.Decimal) decimal.Decimal {
+	return price.Div(a.multiplier)
+}
+
+func (a symbolAlias) toNativePrice(price decimal.Decimal) decimal.Decimal {
+	return price.Mul(a.multiplier)
+}
+
+func (a symbolAlias) toCanonicalPriceFloat(price float64) float64 {
+	return price / a.multiplier.InexactFloat64()
+}
+
+// SymbolAliases maps symbols of each venue to canonical symbols, so tradableSymbols, SpreadDetector and order
+// sizing match the same asset listed under different tickers (1000PEPEUSDT and PEPEUSDT, renamed tokens).
+// A symbol without an alias is canonical with multiplier 1, unless it's the canonical symbol of another alias
+// on the same venue: then it's a different asset under the same ticker and is hidden.
+// NOT modified after creation, safe for concurrent use.
+type SymbolAliases struct {
+	byNative    map[string]map[string]symbolAlias // [venue][exchange symbol]
+	byCanonical map[string]map[string]symbolAlias // [venue][canonical symbol]
+}
+
+// NewSymbolAliases creates SymbolAliases, a later alias of the same venue and exchange symbol overrides
+// an earlier one.
+func NewSymbolAliases(aliases []models.SymbolAlias) (*SymbolAliases, error) {
+	s := &SymbolAliases{
+		byNative:    make(map[string]map[string]symbolAlias),
+		byCanonical: make(map[string]map[string]symbolAlias),
+	}
+
+	for _, a := range aliases {
+		if a.Symbol == "" || a.ExchangeName == "" || a.ExchangeSymbol == "" {
+			return nil, fmt.Errorf("symbol alias %q on %q: empty field", a.ExchangeSymbol, a.ExchangeName)
+		}
+		if !a.Multiplier.IsPositive() {
+			return nil, fmt.Errorf("symbol alias %s on %s: multiplier must be positive", a.ExchangeSymbol, a.ExchangeName)
+		}
+
+		venueName := parseVenue(a.ExchangeName).Name()
+		if s.byNative[venueName] == nil {
+			s.byNative[venueName] = make(map[string]symbolAlias)
+		}
+		s.byNative[venueName][a.ExchangeSymbol] = symbolAlias{
+			canonical:  a.Symbol,
+			native:     a.ExchangeSymbol,
+			multiplier: a.Multiplier,
+		}
+	}
+
+	for venueName, byNative := range s.byNative {
+		s.byCanonical[venueName] = make(map[string]symbolAlias, len(byNative))
+		for _, alias := range byNative {
+			if prev, ok := s.byCanonical[venueName][alias.canonical]; ok {
+				return nil, fmt.Errorf("symbol aliases %s and %s on %s map to the same symbol %s",
+					prev.native, alias.native, venueName, alias.canonical)
+			}
+			s.byCanonical[venueName][alias.canonical] = alias
+		}
+	}
+
+	return s, nil
+}
+
+// LoadSymbolAliases creates SymbolAliases from the config and the DB, DB aliases override config ones
+// of the same venue and exchange symbol.
+func LoadSymbolAliases(ctx context.Context, cfg *config.Config, repo SymbolAliasRepository) (*SymbolAliases, error) {
+	var aliases []models.SymbolAlias
+	for _, a := range cfg.Exchange.ArbitrageBot.SymbolAliases {
+		aliases = append(aliases, models.SymbolAlias{
+			Symbol:         a.Symbol,
+			ExchangeName:   a.Exchange,
+			ExchangeSymbol: a.ExchangeSymbol,
+			Multiplier:     decimal.NewFromFloat(a.Multiplier),
+		})
+	}
+
+	stored, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load symbol aliases: %w", err)
+	}
+
+	return NewSymbolAliases(append(aliases, stored...))
+}
+
+// Len returns the number of aliases.
+func (s *SymbolAliases) Len() int {
+	n := 0
+	for _, byNative := range s.byNative {
+		n += len(byNative)
+	}
+	return n
+}
+
+// hasVenue returns true if the venue has aliases.
+func (s *SymbolAliases) hasVenue(venueName string) bool {
+	return len(s.byNative[venueName]) > 0
+}
+
+// toCanonical returns the alias of the exchange symbol of the venue, false if the symbol is hidden.
+func (s *SymbolAliases) toCanonical(venueName, symbol string) (symbolAlias, bool) {
+	if alias, ok := s.byNative[venueName][symbol]; ok {
+		return alias, true
+	}
+	if _, ok := s.byCanonical[venueName][symbol]; ok {
+		return symbolAlias{}, false
+	}
+	return symbolAlias{canonical: symbol, native: symbol, multiplier: decimal.NewFromInt(1)}, true
+}
+
+// toNative returns the alias of the canonical symbol on the venue.
+func (s *SymbolAliases) toNative(venueName, symbol string) symbolAlias {
+	if alias, ok := s.byCanonical[venueName][symbol]; ok {
+		return alias
+	}
+	return symbolAlias{canonical: symbol, native: symbol, multiplier: decimal.NewFromInt(1)}
+}
+
+// toNativeSymbols returns the exchange symbols of the canonical ones on the venue.
+func (s *SymbolAliases) toNativeSymbols(venueName string, symbols []string) []string {
+	if symbols == nil {
+		return nil
+	}
+	natives := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		natives = append(natives, s.toNative(venueName, symbol).native)
+	}
+	return natives
+}
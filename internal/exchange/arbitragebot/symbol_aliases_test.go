@@ -0,0 +1,210 @@
+package arbitragebot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+type symbolAliasRepoStub struct {
+	aliases []models.SymbolAlias
+}
+
+func (r *symbolAliasRepoStub) FindAll(_ context.Context) ([]models.SymbolAlias, error) {
+	return r.aliases, nil
+}
+
+func pepeAliases(t *testing.T) *SymbolAliases {
+	t.Helper()
+
+	aliases, err := NewSymbolAliases([]models.SymbolAlias{
+		{Symbol: "PEPEUSDT", ExchangeName: "ByBit", ExchangeSymbol: "1000PEPEUSDT", Multiplier: decimal.NewFromInt(1000)},
+	})
+	require.NoError(t, err)
+	return aliases
+}
+
+func TestNewSymbolAliases(t *testing.T) {
+	aliases := pepeAliases(t)
+
+	alias, ok := aliases.toCanonical("ByBit", "1000PEPEUSDT")
+	require.True(t, ok)
+	assert.Equal(t, "PEPEUSDT", alias.canonical)
+	assert.Equal(t, "1000PEPEUSDT", aliases.toNative("ByBit", "PEPEUSDT").native)
+
+	// a different asset under the canonical ticker is hidden
+	_, ok = aliases.toCanonical("ByBit", "PEPEUSDT")
+	assert.False(t, ok)
+
+	// other venues are not aliased
+	alias, ok = aliases.toCanonical("ByBit:spot", "PEPEUSDT")
+	require.True(t, ok)
+	assert.True(t, alias.identity())
+	assert.True(t, aliases.toNative("MEXC", "PEPEUSDT").identity())
+
+	_, err := NewSymbolAliases([]models.SymbolAlias{
+		{Symbol: "PEPEUSDT", ExchangeName: "ByBit", ExchangeSymbol: "1000PEPEUSDT", Multiplier: decimal.NewFromInt(1000)},
+		{Symbol: "PEPEUSDT", ExchangeName: "ByBit", ExchangeSymbol: "PEPE1000USDT", Multiplier: decimal.NewFromInt(1000)},
+	})
+	assert.ErrorContains(t, err, "map to the same symbol PEPEUSDT")
+
+	_, err = NewSymbolAliases([]models.SymbolAlias{
+		{Symbol: "PEPEUSDT", ExchangeName: "ByBit", ExchangeSymbol: "1000PEPEUSDT"},
+	})
+	assert.ErrorContains(t, err, "multiplier must be positive")
+}
+
+func TestLoadSymbolAliases(t *testing.T) {
+	cfg := getConfig()
+	cfg.Exchange.ArbitrageBot.SymbolAliases = []config.SymbolAliasConfig{
+		{Symbol: "PEPEUSDT", Exchange: "ByBit", ExchangeSymbol: "1000PEPEUSDT", Multiplier: 100},
+		{Symbol: "BONKUSDT", Exchange: "MEXC", ExchangeSymbol: "1000BONKUSDT", Multiplier: 1000},
+	}
+	repo := &symbolAliasRepoStub{aliases: []models.SymbolAlias{
+		{Symbol: "PEPEUSDT", ExchangeName: "ByBit", ExchangeSymbol: "1000PEPEUSDT", Multiplier: decimal.NewFromInt(1000)},
+	}}
+
+	aliases, err := LoadSymbolAliases(t.Context(), cfg, repo)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, aliases.Len())
+	// the DB overrides the config
+	assert.Equal(t, "1000", aliases.toNative("ByBit", "PEPEUSDT").multiplier.String())
+	assert.Equal(t, "1000BONKUSDT", aliases.toNative("MEXC", "BONKUSDT").native)
+}
+
+func TestWithSymbolAliases_Instruments(t *testing.T) {
+	ctx := t.Context()
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetInstruments(ctx, exchange.CategoryLinear).Return(map[string]exchange.Instrument{
+		"1000PEPEUSDT": {
+			Symbol:       "1000PEPEUSDT",
+			VolStep:      decimal.NewFromInt(100),
+			MinVol:       decimal.NewFromInt(100),
+			PriceStep:    decimal.RequireFromString("0.0000001"),
+			ContractSize: decimal.NewFromInt(1),
+		},
+		"PEPEUSDT": {Symbol: "PEPEUSDT", ContractSize: decimal.NewFromInt(1)},
+		"BTCUSDT":  {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)},
+	}, nil)
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().GetInstruments(ctx, exchange.CategoryLinear).Return(map[string]exchange.Instrument{
+		"PEPEUSDT": {Symbol: "PEPEUSDT", ContractSize: decimal.NewFromInt(100000)},
+		"BTCUSDT":  {Symbol: "BTCUSDT", ContractSize: decimal.RequireFromString("0.0001")},
+	}, nil)
+
+	clients := WithSymbolAliases([]exchange.Provider{bybit, mexc}, pepeAliases(t))
+	// MEXC has no aliases and is not wrapped
+	assert.Same(t, mexc, clients[1])
+
+	instruments := map[string]map[string]exchange.Instrument{}
+	for _, client := range clients {
+		bySymbol, err := client.GetInstruments(ctx, exchange.CategoryLinear)
+		require.NoError(t, err)
+		instruments[client.GetExchangeName()] = bySymbol
+	}
+
+	pepe := instruments["ByBit"]["PEPEUSDT"]
+	assert.Equal(t, "PEPEUSDT", pepe.Symbol)
+	assert.Equal(t, "100", pepe.VolStep.String())
+	assert.Equal(t, "0.0000000001", pepe.PriceStep.String())
+	assert.Equal(t, "1000", pepe.ContractSize.String())
+	assert.Len(t, instruments["ByBit"], 2)
+
+	assert.Equal(t, map[string]struct{}{"PEPEUSDT": {}, "BTCUSDT": {}}, tradableSymbols(instruments))
+}
+
+func TestWithSymbolAliases_Orders(t *testing.T) {
+	ctx := t.Context()
+	orderID := uuid.New()
+
+	executions := make(chan exchange.OrderExecutionEvent, 1)
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().SubscribeExecutions(mock.Anything).Return(executions, nil)
+	bybit.EXPECT().CreateOrder(ctx, mock.Anything).RunAndReturn(func(_ context.Context, order *models.Order) error {
+		assert.Equal(t, "1000PEPEUSDT", order.Symbol)
+		assert.Equal(t, "0.0123", order.Price.String())
+		order.ExchangeOrderID = "ex-1"
+		order.AvgPrice = decimal.RequireFromString("0.0124")
+		return nil
+	})
+	bybit.EXPECT().CancelOrder(ctx, orderID, "ex-1", "1000PEPEUSDT", exchange.CategoryLinear).Return(nil)
+
+	client := WithSymbolAliases([]exchange.Provider{bybit}, pepeAliases(t))[0]
+
+	execCh, err := client.SubscribeExecutions(ctx)
+	require.NoError(t, err)
+
+	order := &models.Order{
+		ID:       orderID,
+		Symbol:   "PEPEUSDT",
+		Market:   models.OrderMarketLinear,
+		Price:    decimal.RequireFromString("0.0000123"),
+		Quantity: decimal.NewFromInt(100),
+	}
+	require.NoError(t, client.CreateOrder(ctx, order))
+
+	assert.Equal(t, "PEPEUSDT", order.Symbol)
+	assert.Equal(t, "0.0000123", order.Price.String())
+	assert.Equal(t, "0.0000124", order.AvgPrice.String())
+	assert.Equal(t, "ex-1", order.ExchangeOrderID)
+
+	executions <- exchange.OrderExecutionEvent{
+		OrderID:   orderID,
+		ExecPrice: decimal.RequireFromString("0.0124"),
+		ExecQty:   decimal.NewFromInt(100),
+		LeavesQty: decimal.Zero,
+	}
+	event := <-execCh
+	assert.Equal(t, "0.0000124", event.ExecPrice.String())
+	assert.Equal(t, "100", event.ExecQty.String())
+
+	require.NoError(t, client.CancelOrder(ctx, orderID, "ex-1", "PEPEUSDT", exchange.CategoryLinear))
+}
+
+func TestWithSymbolAliases_BookTicker(t *testing.T) {
+	ctx := t.Context()
+
+	tops := make(chan exchange.BookTop, 3)
+	tops <- exchange.BookTop{Symbol: "1000PEPEUSDT", BidPrice: 0.012, AskPrice: 0.013}
+	tops <- exchange.BookTop{Symbol: "PEPEUSDT", BidPrice: 5, AskPrice: 6}
+	tops <- exchange.BookTop{Symbol: "BTCUSDT", BidPrice: 100, AskPrice: 101}
+	close(tops)
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().SubscribeBookTicker(ctx, []string{"1000PEPEUSDT", "BTCUSDT"}).Return(tops, nil)
+
+	client := WithSymbolAliases([]exchange.Provider{bybit}, pepeAliases(t))[0]
+
+	ch, err := client.SubscribeBookTicker(ctx, []string{"PEPEUSDT", "BTCUSDT"})
+	require.NoError(t, err)
+
+	var got []exchange.BookTop
+	for top := range ch {
+		got = append(got, top)
+	}
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "PEPEUSDT", got[0].Symbol)
+	assert.InDelta(t, 0.000012, got[0].BidPrice, 1e-12)
+	assert.InDelta(t, 0.000013, got[0].AskPrice, 1e-12)
+	assert.Equal(t, "BTCUSDT", got[1].Symbol)
+	assert.InDelta(t, 100, got[1].BidPrice, 1e-12)
+}
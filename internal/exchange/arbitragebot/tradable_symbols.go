@@ -0,0 +1,45 @@
+package arbitragebot
+
+import "github.com/lucrumx/bot/internal/exchange"
+
+// tradableSymbols returns the symbols listed on at least one pair of venues the bot can trade: two venues,
+// at least one of them linear (spot venues are the buy leg only). Used at startup to figure out which symbols
+// can actually be arbitraged; a symbol listed on two exchanges only is traded between those two, the venues
+// subscribe to the tradable symbols they list (see venueSymbols). Symbols are canonical (see SymbolAliases),
+// so an asset listed under different tickers is matched by its canonical symbol.
+func tradableSymbols(instruments map[string]map[string]exchange.Instrument) map[string]struct{} {
+	type listing struct {
+		venues int
+		linear bool
+	}
+	listings := make(map[string]listing)
+
+	for venueName, bySymbol := range instruments {
+		linear := !parseVenue(venueName).IsSpot()
+		for symbol := range bySymbol {
+			l := listings[symbol]
+			l.venues++
+			l.linear = l.linear || linear
+			listings[symbol] = l
+		}
+	}
+
+	result := make(map[string]struct{})
+	for symbol, l := range listings {
+		if l.venues >= 2 && l.linear {
+			result[symbol] = struct{}{}
+		}
+	}
+	return result
+}
+
+// venueSymbols returns the symbols listed on the venue, in the order of symbols.
+func venueSymbols(instruments map[string]map[string]exchange.Instrument, venueName string, symbols []string) []string {
+	result := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if _, ok := instruments[venueName][s]; ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
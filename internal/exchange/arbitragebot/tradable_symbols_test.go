@@ -0,0 +1,31 @@
+package arbitragebot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestTradableSymbols(t *testing.T) {
+	instruments := map[string]map[string]exchange.Instrument{
+		"ByBit":      {"BTCUSDT": {}, "ETHUSDT": {}, "SOLUSDT": {}},
+		"BingX":      {"BTCUSDT": {}, "ETHUSDT": {}},
+		"Gate":       {"BTCUSDT": {}, "GTUSDT": {}},
+		"OKX":        {"GTUSDT": {}, "OKBUSDT": {}},
+		"ByBit:spot": {"SOLUSDT": {}, "DOGEUSDT": {}},
+		"MEXC:spot":  {"DOGEUSDT": {}},
+	}
+
+	// GTUSDT is listed on Gate and OKX only, SOLUSDT on the perp and the spot of ByBit,
+	// OKBUSDT on one venue and DOGEUSDT on spot venues only
+	assert.Equal(t, map[string]struct{}{
+		"BTCUSDT": {},
+		"ETHUSDT": {},
+		"GTUSDT":  {},
+		"SOLUSDT": {},
+	}, tradableSymbols(instruments))
+
+	assert.Equal(t, []string{"BTCUSDT", "GTUSDT"}, venueSymbols(instruments, "Gate", []string{"BTCUSDT", "ETHUSDT", "GTUSDT", "SOLUSDT"}))
+}
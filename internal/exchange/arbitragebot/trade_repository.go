@@ -0,0 +1,59 @@
+package arbitragebot
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// TradeRepository represents a db repository for arbitrage trades — persisted Position state.
+type TradeRepository interface {
+	Save(ctx context.Context, trade *models.ArbitrageTrade) error
+	FindActive(ctx context.Context) ([]*models.ArbitrageTrade, error)
+}
+
+// GormTradeRepository is a GORM implementation of TradeRepository interface.
+type GormTradeRepository struct {
+	db    *gorm.DB
+	paper *bool // nil - live and paper trades
+}
+
+// NewTradeRepository creates a new GormTradeRepository.
+func NewTradeRepository(db *gorm.DB) *GormTradeRepository {
+	return &GormTradeRepository{db: db}
+}
+
+// WithPaper returns the repository limited to the paper (true) or live (false) trades, so a paper bot
+// doesn't restore live positions and vice versa.
+func (r *GormTradeRepository) WithPaper(paper bool) *GormTradeRepository {
+	return &GormTradeRepository{db: r.db, paper: &paper}
+}
+
+// Save inserts the trade or updates all its fields (except created_at) if it already exists.
+func (r *GormTradeRepository) Save(ctx context.Context, trade *models.ArbitrageTrade) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).
+		Create(trade).Error
+}
+
+// FindActive finds trades that are not closed or failed, oldest first.
+func (r *GormTradeRepository) FindActive(ctx context.Context) ([]*models.ArbitrageTrade, error) {
+	var trades []*models.ArbitrageTrade
+
+	tx := r.db.WithContext(ctx).
+		Model(&models.ArbitrageTrade{}).
+		Where("status NOT IN ?", []models.ArbitrageTradeStatus{models.ArbitrageTradeStatusClosed, models.ArbitrageTradeStatusFailed})
+	if r.paper != nil {
+		tx = tx.Where("paper = ?", *r.paper)
+	}
+
+	err := tx.Order("created_at ASC").Find(&trades).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
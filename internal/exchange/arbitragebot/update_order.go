@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/models"
@@ -78,8 +79,11 @@ func (a *ArbitrageBot) processSpread(ctx context.Context, spread *models.Arbitra
 		spread.OpenBuyOrderID, spread.OpenSellOrderID,
 		spread.CloseBuyOrderID, spread.CloseSellOrderID,
 	}
+	if spread.RebalanceOrderID != uuid.Nil {
+		orderIDs = append(orderIDs, spread.RebalanceOrderID)
+	}
 
-	orders := make(map[uuid.UUID]*models.Order, 4)
+	orders := make(map[uuid.UUID]*models.Order, len(orderIDs))
 	for _, id := range orderIDs {
 		order, err := a.orderRepo.GetByID(ctx, id)
 		if err != nil {
@@ -101,27 +105,63 @@ func (a *ArbitrageBot) processSpread(ctx context.Context, spread *models.Arbitra
 	closeBuy := orders[spread.CloseBuyOrderID]
 	closeSell := orders[spread.CloseSellOrderID]
 
-	qty := openBuy.Quantity
-	profitA := closeSell.AvgPrice.Sub(openBuy.AvgPrice).Mul(qty) // exchange A: buy open → sell close
-	profitB := openSell.AvgPrice.Sub(closeBuy.AvgPrice).Mul(qty) // exchange B: sell open → buy close
-	fees := openBuy.Fees.Add(openSell.Fees).Add(closeBuy.Fees).Add(closeSell.Fees)
-	profit := profitA.Add(profitB).Add(fees)
+	// each leg is closed by its own filled qty (with the rebalance fill), so the cash flow of all orders
+	// is the trading PnL of both legs
+	tradeProfit := decimal.Zero
+	fees := decimal.Zero
+	for _, id := range orderIDs {
+		order := orders[id]
+		cashFlow, err := a.orderCashFlow(order)
+		if err != nil {
+			return fmt.Errorf("failed to calc order %s PnL: %w", id, err)
+		}
+		tradeProfit = tradeProfit.Add(cashFlow)
+		fees = fees.Add(order.Fees)
+	}
+
+	funding, measured, err := a.spreadFunding(ctx, spread, openBuy, openSell, closeBuy, closeSell)
+	if err != nil {
+		return fmt.Errorf("failed to estimate funding: %w", err)
+	}
+	// without funding rate snapshots the funding is unknown, not zero
+	var fundingProfit *decimal.Decimal
+	if measured {
+		fundingProfit = &funding
+	}
+
+	profit := tradeProfit.Add(fees).Add(funding)
 
 	a.logger.Info().
 		Str("spread_id", spread.ID.String()).
 		Str("symbol", spread.Symbol).
 		Stringer("profit", profit).
 		Stringer("fees", fees).
+		Stringer("funding", funding).
 		Msg("profit-calc: spread profit calculated")
 
 	return a.arbitrageSpreadRepo.Update(ctx, &models.ArbitrageSpread{
-		Profit:    &profit,
-		UpdatedAt: time.Now(),
+		Profit:        &profit,
+		FundingProfit: fundingProfit,
+		UpdatedAt:     time.Now(),
 	}, FindFilter{
 		ID: spread.ID,
 	})
 }
 
+// orderCashFlow returns the USDT received by a sell (positive) or paid for a buy (negative) on the filled
+// coin qty of the order.
+func (a *ArbitrageBot) orderCashFlow(order *models.Order) (decimal.Decimal, error) {
+	coins, err := a.engine.filledCoins(order)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	amount := order.AvgPrice.Mul(coins)
+	if order.Side == models.OrderSideBuy {
+		return amount.Neg(), nil
+	}
+	return amount, nil
+}
+
 func (a *ArbitrageBot) enrichOrderFromExchange(ctx context.Context, order *models.Order) error {
 	var client exchange.Provider
 	for _, cl := range a.clients {
@@ -134,7 +174,7 @@ func (a *ArbitrageBot) enrichOrderFromExchange(ctx context.Context, order *model
 		return fmt.Errorf("no client found for exchange %s", order.ExchangeName)
 	}
 
-	orderInfo, err := client.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol)
+	orderInfo, err := client.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol, categoryOf(order.Market))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,79 @@
+package arbitragebot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+type spreadUpdateStub struct {
+	repoStub
+	updated *models.ArbitrageSpread
+}
+
+func (r *spreadUpdateStub) Update(_ context.Context, spread *models.ArbitrageSpread, _ FindFilter) error {
+	r.updated = spread
+	return nil
+}
+
+func TestArbitrageBot_ProcessSpread_Rebalanced(t *testing.T) {
+	engine := NewEngine(getConfig(), nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit": {"BTCUSDT": {ContractSize: decimal.NewFromInt(1)}},
+		"MEXC":  {"BTCUSDT": {ContractSize: decimal.RequireFromString("0.1")}},
+	}
+
+	order := func(exchangeName string, side models.OrderSide, qty, executed, price string) *models.Order {
+		return &models.Order{
+			ID:               uuid.New(),
+			ExchangeName:     exchangeName,
+			Symbol:           "BTCUSDT",
+			Market:           models.OrderMarketLinear,
+			Side:             side,
+			Quantity:         decimal.RequireFromString(qty),
+			ExecutedQuantity: decimal.RequireFromString(executed),
+			AvgPrice:         decimal.RequireFromString(price),
+			Fees:             decimal.RequireFromString("-0.1"),
+		}
+	}
+	// 2 BTC bought on ByBit, 1.5 BTC (15 contracts of 20) sold on MEXC, the buy leg reduced by 0.5 BTC
+	openBuy := order("ByBit", models.OrderSideBuy, "2", "2", "100")
+	openSell := order("MEXC", models.OrderSideSell, "20", "15", "103")
+	rebalance := order("ByBit", models.OrderSideSell, "0.5", "0.5", "101")
+	closeSell := order("ByBit", models.OrderSideSell, "1.5", "1.5", "102")
+	closeBuy := order("MEXC", models.OrderSideBuy, "15", "15", "101")
+
+	orderRepo := &orderRepoStub{orders: map[uuid.UUID]*models.Order{}}
+	for _, o := range []*models.Order{openBuy, openSell, rebalance, closeSell, closeBuy} {
+		orderRepo.orders[o.ID] = o
+	}
+	spreadRepo := &spreadUpdateStub{}
+	bot := &ArbitrageBot{engine: engine, orderRepo: orderRepo, arbitrageSpreadRepo: spreadRepo, logger: zerolog.Nop()}
+
+	err := bot.processSpread(t.Context(), &models.ArbitrageSpread{
+		ID:               uuid.New(),
+		Symbol:           "BTCUSDT",
+		BuyOnExchange:    "ByBit",
+		SellOnExchange:   "MEXC",
+		OpenBuyOrderID:   openBuy.ID,
+		OpenSellOrderID:  openSell.ID,
+		CloseBuyOrderID:  closeBuy.ID,
+		CloseSellOrderID: closeSell.ID,
+		RebalanceOrderID: rebalance.ID,
+	})
+	require.NoError(t, err)
+
+	// ByBit: -200 + 50.5 + 153 = 3.5, MEXC: 154.5 - 151.5 = 3, fees of 5 orders -0.5
+	require.NotNil(t, spreadRepo.updated.Profit)
+	assert.Equal(t, "6", spreadRepo.updated.Profit.String())
+	// funding rates are not collected
+	assert.Nil(t, spreadRepo.updated.FundingProfit)
+}
@@ -0,0 +1,73 @@
+package arbitragebot
+
+import (
+	"strings"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const spotVenueSuffix = ":spot"
+
+// Venue is a market of the exchange the engine trades on: perpetual futures (linear) or spot.
+// Spot venues allow spot buy / perp short basis positions.
+type Venue struct {
+	Exchange string
+	Category exchange.Category
+}
+
+// Name returns the key of the venue used for prices, spreads, positions, instruments and balances.
+// Linear venue is named by the exchange (the same keys as before spot support), spot one gets the ":spot" suffix.
+func (v Venue) Name() string {
+	if v.Category == exchange.CategorySpot {
+		return v.Exchange + spotVenueSuffix
+	}
+	return v.Exchange
+}
+
+// IsSpot reports whether the venue is a spot market.
+func (v Venue) IsSpot() bool {
+	return v.Category == exchange.CategorySpot
+}
+
+// parseVenue is the reverse of Venue.Name.
+func parseVenue(name string) Venue {
+	if exchangeName, ok := strings.CutSuffix(name, spotVenueSuffix); ok {
+		return Venue{Exchange: exchangeName, Category: exchange.CategorySpot}
+	}
+	return Venue{Exchange: name, Category: exchange.CategoryLinear}
+}
+
+// categoryOf returns the category of the order market.
+func categoryOf(market models.OrderMarket) exchange.Category {
+	if market == models.OrderMarketSpot {
+		return exchange.CategorySpot
+	}
+	return exchange.CategoryLinear
+}
+
+// buildVenues returns linear venues of all clients plus spot venues of the exchanges listed in spotExchanges
+// (case-insensitive).
+func buildVenues(clients []exchange.Provider, spotExchanges []string) []Venue {
+	spotSet := make(map[string]struct{}, len(spotExchanges))
+	for _, name := range spotExchanges {
+		spotSet[strings.ToLower(name)] = struct{}{}
+	}
+
+	venues := make([]Venue, 0, len(clients)+len(spotExchanges))
+	for _, client := range clients {
+		venues = append(venues, Venue{Exchange: client.GetExchangeName(), Category: exchange.CategoryLinear})
+	}
+	for _, client := range clients {
+		if _, ok := spotSet[strings.ToLower(client.GetExchangeName())]; ok {
+			venues = append(venues, Venue{Exchange: client.GetExchangeName(), Category: exchange.CategorySpot})
+		}
+	}
+
+	return venues
+}
+
+// baseAsset returns the base coin of the USDT symbol: BTCUSDT -> BTC.
+func baseAsset(symbol string) string {
+	return strings.TrimSuffix(symbol, "USDT")
+}
@@ -0,0 +1,95 @@
+package arbitragebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func TestVenue_NameAndParse(t *testing.T) {
+	linear := Venue{Exchange: "ByBit", Category: exchange.CategoryLinear}
+	spot := Venue{Exchange: "ByBit", Category: exchange.CategorySpot}
+
+	assert.Equal(t, "ByBit", linear.Name())
+	assert.Equal(t, "ByBit:spot", spot.Name())
+	assert.False(t, linear.IsSpot())
+	assert.True(t, spot.IsSpot())
+
+	assert.Equal(t, linear, parseVenue(linear.Name()))
+	assert.Equal(t, spot, parseVenue(spot.Name()))
+}
+
+func TestBuildVenues(t *testing.T) {
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	okx := exchangeMocks.NewMockProvider(t)
+	okx.EXPECT().GetExchangeName().Return("OKX")
+
+	venues := buildVenues([]exchange.Provider{bybit, okx}, []string{"bybit", "gate"})
+
+	assert.Equal(t, []Venue{
+		{Exchange: "ByBit", Category: exchange.CategoryLinear},
+		{Exchange: "OKX", Category: exchange.CategoryLinear},
+		{Exchange: "ByBit", Category: exchange.CategorySpot},
+	}, venues)
+}
+
+func TestSpreadDetector_SkipsSpotSell(t *testing.T) {
+	sd := NewSpreadDetector(getConfig())
+	now := time.Now().UnixMilli()
+
+	events := sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit:spot": {Price: 103, TsMs: now},
+		"BingX":      {Price: 100, TsMs: now},
+	})
+	assert.Empty(t, events, "spot can't be sold short")
+
+	events = sd.Detect("BTCUSDT", map[string]PricePoint{
+		"ByBit:spot": {Price: 100, TsMs: now},
+		"BingX":      {Price: 103, TsMs: now},
+	})
+	require.Len(t, events, 1)
+	assert.Equal(t, "ByBit:spot", events[0].BuyOnExchange)
+	assert.Equal(t, "BingX", events[0].SellOnExchange)
+}
+
+func TestEngine_BuildOrder_SpotVenue(t *testing.T) {
+	engine := NewEngine(getConfig(), nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+
+	order, err := engine.buildOrder("BTCUSDT", models.OrderSideBuy, decimal.NewFromInt(1), "ByBit:spot", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderMarketSpot, order.Market)
+	assert.Equal(t, "ByBit", order.ExchangeName)
+
+	order, err = engine.buildOrder("BTCUSDT", models.OrderSideSell, decimal.NewFromInt(1), "BingX", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderMarketLinear, order.Market)
+	assert.Equal(t, "BingX", order.ExchangeName)
+}
+
+func TestEngine_CloseQty_SpotLimitedByBalance(t *testing.T) {
+	client := exchangeMocks.NewMockProvider(t)
+	client.EXPECT().GetBalances(t.Context(), exchange.CategorySpot).Return([]models.Balance{
+		{Asset: "USDT", Free: decimal.NewFromInt(100)},
+		{Asset: "BTC", Free: decimal.RequireFromString("0.00999")},
+	}, nil)
+
+	engine := NewEngine(getConfig(), nil, nil, &repoStub{}, &notifierStub{}, zerolog.Nop(), MarketStrategy{})
+	engine.clients["ByBit"] = client
+	engine.instruments = map[string]map[string]exchange.Instrument{
+		"ByBit:spot": {"BTCUSDT": {Symbol: "BTCUSDT", VolStep: decimal.RequireFromString("0.0001"), ContractSize: decimal.NewFromInt(1)}},
+	}
+
+	// buy fee was charged in BTC, sell what is left
+	vol, err := engine.closeQty(t.Context(), &Position{Symbol: "BTCUSDT", BuyExchange: "ByBit:spot", QtyCoins: decimal.RequireFromString("0.01")}, models.OrderSideBuy)
+	require.NoError(t, err)
+	assert.Equal(t, "0.0099", vol.String())
+}
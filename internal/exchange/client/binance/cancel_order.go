@@ -0,0 +1,37 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+// API: DELETE /fapi/v1/order
+// Docs: https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Cancel-Order
+// Spot: DELETE /api/v3/order
+
+// CancelOrder cancels a pending limit order by client order id.
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string, category exchange.Category) error {
+	query := url.Values{
+		"symbol":            {symbol},
+		"origClientOrderId": {orderID.String()},
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodDelete, orderPath(category.OrderMarket()), query)
+	if err != nil {
+		return fmt.Errorf("Binance CancelOrder: failed to create request: %w", err)
+	}
+
+	var raw dtos.OrderDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("Binance CancelOrder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Package binance provides a client for the Binance USDT-M futures exchange (and spot REST for spot venues).
+package binance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Client represents a Binance USDT-M futures client.
+type Client struct {
+	exchangeName string
+	baseURL      string
+	spotBaseURL  string
+	httpClient   *http.Client
+	logger       zerolog.Logger
+	cfg          *config.Config
+	wsManager    *exchange.WSManager
+
+	bookManager      *exchange.StreamManager[exchange.BookTop]
+	orderBookManager *exchange.StreamManager[exchange.OrderBookUpdate]
+
+	wsPrivate        *WsPrivateClient
+	wsPrivateStarted bool
+}
+
+// NewClient constructor.
+func NewClient(cfg *config.Config, logger zerolog.Logger) *Client {
+	return &Client{
+		exchangeName: "Binance",
+		baseURL:      cfg.Exchange.Binance.APIBaseURL,
+		spotBaseURL:  cfg.Exchange.Binance.SpotAPIBaseURL,
+		httpClient:   &http.Client{},
+		cfg:          cfg,
+		logger:       logger,
+		wsManager: exchange.NewWSManager(cfg, func(c *config.Config) exchange.WsClient {
+			return newWsClient(c, logger)
+		}),
+		bookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.BookTop] {
+			return newWsBookClient(c, logger)
+		}),
+		orderBookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.OrderBookUpdate] {
+			return newWsOrderBookClient(c, logger)
+		}),
+	}
+}
+
+// GetExchangeName returns the exchange name.
+func (c *Client) GetExchangeName() string {
+	return c.exchangeName
+}
+
+// SubscribeTrades initiates WebSocket trade subscriptions (aggTrade) for the given symbols and streams trades to the returned channel.
+func (c *Client) SubscribeTrades(ctx context.Context, symbols []string, category exchange.Category) (<-chan exchange.Trade, error) {
+	return c.wsManager.SubscribeTrades(ctx, symbols, category)
+}
+
+// SubscribeBookTicker subscribes to best bid/ask (bookTicker) of the given symbols and streams them to the returned channel.
+func (c *Client) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	return c.bookManager.Subscribe(ctx, symbols)
+}
+
+// SubscribeOrderBook subscribes to L2 orderbook (depth20 snapshots) of the given symbols.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	return c.orderBookManager.Subscribe(ctx, symbols)
+}
+
+// SubscribeExecutions subscribes to order execution events and streams them to the returned channel. Implements the interface Provider
+func (c *Client) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
+	if !c.wsPrivateStarted {
+		c.wsPrivate = NewWsPrivateClient(c.cfg, c.logger)
+		if err := c.wsPrivate.Start(ctx); err != nil {
+			return nil, err
+		}
+		c.wsPrivateStarted = true
+	}
+
+	return c.wsPrivate.SubscribeToExecutions()
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// BalanceDTO represents a futures account balance of an asset.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Futures-Account-Balance-V2
+type BalanceDTO struct {
+	Asset              string        `json:"asset"`
+	Balance            utils.Decimal `json:"balance"` // wallet balance
+	CrossUnPnl         utils.Decimal `json:"crossUnPnl"`
+	AvailableBalance   utils.Decimal `json:"availableBalance"`
+	MaxWithdrawAmount  utils.Decimal `json:"maxWithdrawAmount"`
+	CrossWalletBalance utils.Decimal `json:"crossWalletBalance"`
+}
+
+// SpotAccountDTO represents a spot account information response.
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#account-information-user_data
+type SpotAccountDTO struct {
+	Balances []SpotBalanceDTO `json:"balances"`
+}
+
+// SpotBalanceDTO represents a spot balance of an asset.
+type SpotBalanceDTO struct {
+	Asset  string        `json:"asset"`
+	Free   utils.Decimal `json:"free"`
+	Locked utils.Decimal `json:"locked"`
+}
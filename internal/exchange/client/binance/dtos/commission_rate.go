@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// CommissionRateDTO represents a response to a user commission rate request.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/User-Commission-Rate
+type CommissionRateDTO struct {
+	Symbol              string            `json:"symbol"`
+	MakerCommissionRate utils.JSONFloat64 `json:"makerCommissionRate"`
+	TakerCommissionRate utils.JSONFloat64 `json:"takerCommissionRate"`
+}
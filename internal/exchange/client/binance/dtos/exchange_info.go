@@ -0,0 +1,31 @@
+package dtos
+
+// Symbol filter types used for instrument specification.
+const (
+	FilterPriceFilter = "PRICE_FILTER"
+	FilterLotSize     = "LOT_SIZE"
+)
+
+// SymbolFilterDTO represents a trading rule of a symbol. Fields are filled depending on FilterType.
+type SymbolFilterDTO struct {
+	FilterType string `json:"filterType"`
+	TickSize   string `json:"tickSize"` // PRICE_FILTER
+	StepSize   string `json:"stepSize"` // LOT_SIZE
+	MinQty     string `json:"minQty"`   // LOT_SIZE
+}
+
+// SymbolDTO represents a symbol of the exchange info.
+type SymbolDTO struct {
+	Symbol       string            `json:"symbol"`
+	Status       string            `json:"status"`
+	ContractType string            `json:"contractType"` // futures only
+	QuoteAsset   string            `json:"quoteAsset"`
+	Filters      []SymbolFilterDTO `json:"filters"`
+}
+
+// ExchangeInfoDTO represents a response to an exchange info request (futures and spot have the same shape).
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Exchange-Information
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/general-endpoints#exchange-information
+type ExchangeInfoDTO struct {
+	Symbols []SymbolDTO `json:"symbols"`
+}
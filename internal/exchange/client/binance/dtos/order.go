@@ -0,0 +1,76 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// OrderSide represents the side of an order.
+type OrderSide string
+
+// OrderType represents the type of order.
+type OrderType string
+
+// TimeInForce represents the time in force of an order.
+type TimeInForce string
+
+const (
+	// OrderSideBuy represents the buy side of an order.
+	OrderSideBuy OrderSide = "BUY"
+	// OrderSideSell represents the sell side of an order.
+	OrderSideSell OrderSide = "SELL"
+
+	// OrderTypeMarket represents a market order.
+	OrderTypeMarket OrderType = "MARKET"
+	// OrderTypeLimit represents a limit order.
+	OrderTypeLimit OrderType = "LIMIT"
+	// OrderTypeLimitMaker represents a post-only spot limit order (spot has no GTX time in force).
+	OrderTypeLimitMaker OrderType = "LIMIT_MAKER"
+
+	// TimeInForceGTC - Good Till Cancel.
+	TimeInForceGTC TimeInForce = "GTC"
+	// TimeInForceIOC - Immediate Or Cancel.
+	TimeInForceIOC TimeInForce = "IOC"
+	// TimeInForceFOK - Fill Or Kill.
+	TimeInForceFOK TimeInForce = "FOK"
+	// TimeInForcePostOnly - Good Till Crossing (post only).
+	TimeInForcePostOnly TimeInForce = "GTX"
+)
+
+// OrderDTO represents an order of the new order / query order responses.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/New-Order
+type OrderDTO struct {
+	OrderID       int64         `json:"orderId"`
+	ClientOrderID string        `json:"clientOrderId"`
+	Symbol        string        `json:"symbol"`
+	Status        string        `json:"status"` // NEW, PARTIALLY_FILLED, FILLED, CANCELED, EXPIRED
+	Side          string        `json:"side"`
+	Type          string        `json:"type"`
+	OrigQty       utils.Decimal `json:"origQty"`
+	Price         utils.Decimal `json:"price"`
+	AvgPrice      utils.Decimal `json:"avgPrice"`
+	ExecutedQty   utils.Decimal `json:"executedQty"`
+	CumQuote      utils.Decimal `json:"cumQuote"`
+	// CummulativeQuoteQty is the filled quote qty of spot orders, spot orders have no avgPrice.
+	CummulativeQuoteQty utils.Decimal `json:"cummulativeQuoteQty"`
+	ReduceOnly          bool          `json:"reduceOnly"`
+	UpdateTime          int64         `json:"updateTime"`
+}
+
+// UserTradeDTO represents a fill of an order (account trade list).
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Account-Trade-List
+type UserTradeDTO struct {
+	OrderID         int64         `json:"orderId"`
+	Symbol          string        `json:"symbol"`
+	Price           utils.Decimal `json:"price"`
+	Qty             utils.Decimal `json:"qty"`
+	QuoteQty        utils.Decimal `json:"quoteQty"`
+	Commission      utils.Decimal `json:"commission"` // positive, paid fee
+	CommissionAsset string        `json:"commissionAsset"`
+	RealizedPnl     utils.Decimal `json:"realizedPnl"` // futures only
+	Time            int64         `json:"time"`
+}
+
+// LeverageDTO represents a response to a change leverage request.
+type LeverageDTO struct {
+	Symbol           string `json:"symbol"`
+	Leverage         int64  `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+}
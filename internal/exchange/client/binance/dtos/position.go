@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// PositionRiskDTO represents a position of the position information response.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Position-Information-V2
+type PositionRiskDTO struct {
+	Symbol       string        `json:"symbol"`
+	PositionAmt  utils.Decimal `json:"positionAmt"` // negative for short in one-way mode
+	EntryPrice   utils.Decimal `json:"entryPrice"`
+	PositionSide string        `json:"positionSide"` // BOTH, LONG, SHORT
+}
@@ -0,0 +1,20 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// PremiumIndexDTO represents mark price and funding rate of a perpetual contract.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Mark-Price
+type PremiumIndexDTO struct {
+	Symbol          string            `json:"symbol"`
+	MarkPrice       utils.JSONFloat64 `json:"markPrice"`
+	IndexPrice      utils.JSONFloat64 `json:"indexPrice"`
+	LastFundingRate utils.JSONFloat64 `json:"lastFundingRate"`
+	NextFundingTime int64             `json:"nextFundingTime"`
+}
+
+// FundingInfoDTO represents funding settings of a symbol. Binance returns only symbols with adjusted settings.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Get-Funding-Rate-Info
+type FundingInfoDTO struct {
+	Symbol               string `json:"symbol"`
+	FundingIntervalHours int64  `json:"fundingIntervalHours"`
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// TickerDTO represents 24hr rolling window price change statistics of a symbol.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/24hr-Ticker-Price-Change-Statistics
+type TickerDTO struct {
+	Symbol             string        `json:"symbol"`
+	PriceChangePercent utils.Decimal `json:"priceChangePercent"` // percent, 1.5 = 1.5%
+	LastPrice          utils.Decimal `json:"lastPrice"`
+	OpenPrice          utils.Decimal `json:"openPrice"`
+	HighPrice          utils.Decimal `json:"highPrice"`
+	LowPrice           utils.Decimal `json:"lowPrice"`
+	Volume             utils.Decimal `json:"volume"`
+	QuoteVolume        utils.Decimal `json:"quoteVolume"`
+	CloseTime          int64         `json:"closeTime"`
+}
@@ -0,0 +1,89 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+// Binance uses keys differing only in case ("e" - event type, "E" - event time), encoding/json matches keys
+// case-insensitively, so every such key must have its own field, otherwise it overwrites the other one.
+
+// WsBaseMessage is used to find out the event type of websocket message. Subscription responses have no event type.
+type WsBaseMessage struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+}
+
+// WsAggTradeDTO represents a message of the aggTrade stream.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/websocket-market-streams/Aggregate-Trade-Streams
+type WsAggTradeDTO struct {
+	EventType    string            `json:"e"`
+	EventTime    int64             `json:"E"`
+	Symbol       string            `json:"s"`
+	Price        utils.JSONFloat64 `json:"p"`
+	Qty          utils.JSONFloat64 `json:"q"`
+	TradeTime    int64             `json:"T"`
+	IsBuyerMaker bool              `json:"m"` // true - the taker is seller
+}
+
+// WsBookTickerDTO represents a message of the bookTicker stream.
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/websocket-market-streams/Individual-Symbol-Book-Ticker-Streams
+type WsBookTickerDTO struct {
+	EventType       string            `json:"e"`
+	EventTime       int64             `json:"E"`
+	Symbol          string            `json:"s"`
+	TransactionTime int64             `json:"T"`
+	BidPrice        utils.JSONFloat64 `json:"b"`
+	BidQty          utils.JSONFloat64 `json:"B"`
+	AskPrice        utils.JSONFloat64 `json:"a"`
+	AskQty          utils.JSONFloat64 `json:"A"`
+}
+
+// WsDepthDTO represents a message of the partial depth stream (top N levels snapshot).
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/websocket-market-streams/Partial-Book-Depth-Streams
+type WsDepthDTO struct {
+	EventType       string                 `json:"e"`
+	EventTime       int64                  `json:"E"`
+	Symbol          string                 `json:"s"`
+	TransactionTime int64                  `json:"T"`
+	Bids            [][2]utils.JSONFloat64 `json:"b"` // [price, quantity]
+	Asks            [][2]utils.JSONFloat64 `json:"a"` // [price, quantity]
+}
+
+// PrivateMessageDTO represents a message of the user data stream.
+type PrivateMessageDTO struct {
+	EventType string          `json:"e"`
+	EventTS   int64           `json:"E"`
+	TradeTS   int64           `json:"T"`
+	O         json.RawMessage `json:"o"` // order update https://developers.binance.com/docs/derivatives/usds-margined-futures/user-data-streams/Event-Order-Update
+}
+
+// ExecutionDTO represents an order update of the user data stream.
+type ExecutionDTO struct {
+	Symbol          string        `json:"s"`
+	ClientOrderID   string        `json:"c"`
+	Side            string        `json:"S"`  // BUY/SELL
+	OrderType       string        `json:"o"`  // LIMIT/MARKET, etc.
+	Qty             utils.Decimal `json:"q"`  // order quantity
+	Price           utils.Decimal `json:"p"`  // order price
+	AvgPrice        utils.Decimal `json:"ap"` // average filled price
+	ActivationPrice utils.Decimal `json:"AP"` // trailing stop activation price
+	ExecutionType   string        `json:"x"`  // NEW/TRADE/CANCELED/EXPIRED, etc.
+	OrderStatus     string        `json:"X"`  // NEW/PARTIALLY_FILLED/FILLED/CANCELED, etc.
+	OrderID         int64         `json:"i"`
+	LastFilledQty   utils.Decimal `json:"l"`
+	FilledQty       utils.Decimal `json:"z"` // cumulative filled quantity
+	LastFilledPrice utils.Decimal `json:"L"`
+	CommissionAsset string        `json:"N"`
+	Commission      utils.Decimal `json:"n"`
+	TradeTS         int64         `json:"T"`
+	TradeID         int64         `json:"t"`
+	ReduceOnly      bool          `json:"R"`
+	RealizedPnl     utils.Decimal `json:"rp"`
+}
+
+// ListenKeyDTO represents a response to a start user data stream request.
+type ListenKeyDTO struct {
+	ListenKey string `json:"listenKey"`
+}
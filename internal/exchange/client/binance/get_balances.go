@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const (
+	balanceURL     = "/fapi/v2/balance"
+	spotAccountURL = "/api/v3/account"
+)
+
+// GetBalances returns the balances of the user's futures (linear) or spot account.
+func (c *Client) GetBalances(ctx context.Context, category exchange.Category) ([]models.Balance, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotBalances(ctx)
+	default:
+		return nil, fmt.Errorf("Binance GetBalances: unsupported category %s", category)
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, balanceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetBalances: failed to create request: %w", err)
+	}
+
+	var raw []dtos.BalanceDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetBalances: %w", err)
+	}
+
+	result := make([]models.Balance, 0, len(raw))
+	for _, b := range raw {
+		total := b.Balance.Add(b.CrossUnPnl.Decimal)
+		result = append(result, models.Balance{
+			ExchangeName: c.GetExchangeName(),
+			Asset:        b.Asset,
+			Free:         b.AvailableBalance.Decimal,
+			Locked:       total.Sub(b.AvailableBalance.Decimal),
+			Total:        total,
+		})
+	}
+
+	return result, nil
+}
+
+func (c *Client) getSpotBalances(ctx context.Context) ([]models.Balance, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, spotAccountURL, url.Values{"omitZeroBalances": {"true"}})
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetBalances: failed to create spot request: %w", err)
+	}
+
+	var raw dtos.SpotAccountDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetBalances: spot: %w", err)
+	}
+
+	result := make([]models.Balance, 0, len(raw.Balances))
+	for _, b := range raw.Balances {
+		result = append(result, models.Balance{
+			ExchangeName: c.GetExchangeName(),
+			Asset:        b.Asset,
+			Free:         b.Free.Decimal,
+			Locked:       b.Locked.Decimal,
+			Total:        b.Free.Add(b.Locked.Decimal),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetBalances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, balanceURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MBX-APIKEY"))
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`[
+			{"accountAlias":"SgsR","asset":"USDT","balance":"1000.50","crossWalletBalance":"1000.50","crossUnPnl":"-0.50","availableBalance":"800.00","maxWithdrawAmount":"800.00","marginAvailable":true,"updateTime":1617939110373}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	balances, err := c.GetBalances(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+
+	assert.Equal(t, "Binance", balances[0].ExchangeName)
+	assert.Equal(t, "USDT", balances[0].Asset)
+	assert.Equal(t, "800", balances[0].Free.String())
+	assert.Equal(t, "200", balances[0].Locked.String())
+	assert.Equal(t, "1000", balances[0].Total.String())
+}
+
+func TestClient_GetBalances_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotAccountURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"balances":[{"asset":"USDT","free":"100.5","locked":"10"},{"asset":"BTC","free":"0.01","locked":"0"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	balances, err := c.GetBalances(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	assert.Equal(t, "USDT", balances[0].Asset)
+	assert.Equal(t, "100.5", balances[0].Free.String())
+	assert.Equal(t, "110.5", balances[0].Total.String())
+}
@@ -0,0 +1,38 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+const (
+	commissionRateURL = "/fapi/v1/commissionRate"
+	// feeReferenceSymbol is used to get the account rate, Binance returns commission rate of one symbol per request.
+	feeReferenceSymbol = "BTCUSDT"
+)
+
+// GetFeeSchedule retrieves the account fee rates from Binance.
+// The rate depends on VIP level, not on the symbol, so the rate of feeReferenceSymbol is used as Default.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, commissionRateURL, url.Values{"symbol": {feeReferenceSymbol}})
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("Binance GetFeeSchedule: failed to create request: %w", err)
+	}
+
+	var raw dtos.CommissionRateDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("Binance GetFeeSchedule: %w", err)
+	}
+
+	return exchange.FeeSchedule{
+		Default: exchange.FeeRate{
+			Maker: float64(raw.MakerCommissionRate),
+			Taker: float64(raw.TakerCommissionRate),
+		},
+	}, nil
+}
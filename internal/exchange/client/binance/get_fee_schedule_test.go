@@ -0,0 +1,32 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, commissionRateURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MBX-APIKEY"))
+		assert.Equal(t, feeReferenceSymbol, r.URL.Query().Get("symbol"))
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","makerCommissionRate":"0.0002","takerCommissionRate":"0.0004"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	fees, err := c.GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FeeRate{Maker: 0.0002, Taker: 0.0004}, fees.For("ETHUSDT"))
+}
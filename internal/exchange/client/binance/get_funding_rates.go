@@ -0,0 +1,64 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+const (
+	premiumIndexURL = "/fapi/v1/premiumIndex"
+	fundingInfoURL  = "/fapi/v1/fundingInfo"
+)
+
+// GetFundingRates retrieves current funding rates of all perpetual symbols from Binance.
+// Premium index has no funding interval, it's taken from funding info which lists only symbols with adjusted interval,
+// the rest use exchange.DefaultFundingIntervalHours.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, premiumIndexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetFundingRates: failed to create request: %w", err)
+	}
+
+	var raw []dtos.PremiumIndexDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetFundingRates: premium index: %w", err)
+	}
+
+	req, err = c.newRequest(ctx, http.MethodGet, fundingInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetFundingRates: failed to create request: %w", err)
+	}
+
+	var info []dtos.FundingInfoDTO
+	if _, err := c.do(req, &info); err != nil {
+		return nil, fmt.Errorf("Binance GetFundingRates: funding info: %w", err)
+	}
+
+	intervals := make(map[string]int64, len(info))
+	for _, dto := range info {
+		if dto.FundingIntervalHours > 0 {
+			intervals[dto.Symbol] = dto.FundingIntervalHours
+		}
+	}
+
+	result := make(map[string]exchange.FundingRate, len(raw))
+	for _, dto := range raw {
+		interval, ok := intervals[dto.Symbol]
+		if !ok {
+			interval = exchange.DefaultFundingIntervalHours
+		}
+
+		result[dto.Symbol] = exchange.FundingRate{
+			Symbol:          dto.Symbol,
+			Rate:            float64(dto.LastFundingRate),
+			IntervalHours:   interval,
+			NextFundingTime: dto.NextFundingTime,
+		}
+	}
+
+	return result, nil
+}
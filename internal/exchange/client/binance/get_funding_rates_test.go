@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case premiumIndexURL:
+			_, _ = w.Write([]byte(`[
+				{"symbol":"BTCUSDT","markPrice":"65000.5","indexPrice":"65001","lastFundingRate":"0.00010000","nextFundingTime":1760000000000},
+				{"symbol":"SOLUSDT","markPrice":"150.2","indexPrice":"150.1","lastFundingRate":"-0.00030000","nextFundingTime":1760014400000}
+			]`))
+		case fundingInfoURL:
+			_, _ = w.Write([]byte(`[
+				{"symbol":"SOLUSDT","adjustedFundingRateCap":"0.02","adjustedFundingRateFloor":"-0.02","fundingIntervalHours":4}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	rates, err := c.GetFundingRates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.0001,
+		IntervalHours:   exchange.DefaultFundingIntervalHours,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, -0.0003, rates["SOLUSDT"].Rate)
+	assert.Equal(t, int64(4), rates["SOLUSDT"].IntervalHours)
+}
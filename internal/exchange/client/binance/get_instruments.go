@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+const (
+	exchangeInfoURL     = "/fapi/v1/exchangeInfo"
+	spotExchangeInfoURL = "/api/v3/exchangeInfo"
+)
+
+// GetInstruments retrieves specifications of USDT perpetual (linear) or USDT spot symbols from Binance.
+// Qty of USDT-M futures and spot is in coins, so ContractSize is 1.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	path := exchangeInfoURL
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		path = spotExchangeInfoURL
+	default:
+		return nil, fmt.Errorf("Binance GetInstruments: unsupported category %s", category)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetInstruments: failed to create request: %w", err)
+	}
+
+	var raw dtos.ExchangeInfoDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetInstruments: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(raw.Symbols))
+	for _, dto := range raw.Symbols {
+		if dto.Status != "TRADING" || dto.QuoteAsset != "USDT" {
+			continue
+		}
+		if category == exchange.CategoryLinear && dto.ContractType != "PERPETUAL" {
+			continue
+		}
+
+		instrument, err := mapInstrument(dto)
+		if err != nil {
+			return nil, fmt.Errorf("Binance GetInstruments: %w", err)
+		}
+
+		result[dto.Symbol] = instrument
+	}
+
+	return result, nil
+}
+
+func mapInstrument(dto dtos.SymbolDTO) (exchange.Instrument, error) {
+	instrument := exchange.Instrument{
+		Symbol:       dto.Symbol,
+		ContractSize: decimal.NewFromInt(1),
+	}
+
+	var err error
+	for _, f := range dto.Filters {
+		switch f.FilterType {
+		case dtos.FilterPriceFilter:
+			if instrument.PriceStep, err = decimal.NewFromString(f.TickSize); err != nil {
+				return exchange.Instrument{}, fmt.Errorf("invalid tickSize for %s: %w", dto.Symbol, err)
+			}
+		case dtos.FilterLotSize:
+			if instrument.VolStep, err = decimal.NewFromString(f.StepSize); err != nil {
+				return exchange.Instrument{}, fmt.Errorf("invalid stepSize for %s: %w", dto.Symbol, err)
+			}
+			if instrument.MinVol, err = decimal.NewFromString(f.MinQty); err != nil {
+				return exchange.Instrument{}, fmt.Errorf("invalid minQty for %s: %w", dto.Symbol, err)
+			}
+		}
+	}
+
+	return instrument, nil
+}
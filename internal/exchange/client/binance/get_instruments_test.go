@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetInstruments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, exchangeInfoURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"timezone":"UTC","symbols":[
+			{"symbol":"BTCUSDT","status":"TRADING","contractType":"PERPETUAL","quoteAsset":"USDT","filters":[
+				{"filterType":"PRICE_FILTER","minPrice":"556.80","maxPrice":"4529764","tickSize":"0.10"},
+				{"filterType":"LOT_SIZE","minQty":"0.001","maxQty":"1000","stepSize":"0.001"},
+				{"filterType":"MARKET_LOT_SIZE","minQty":"0.001","maxQty":"120","stepSize":"0.001"},
+				{"filterType":"MIN_NOTIONAL","notional":"100"}
+			]},
+			{"symbol":"BTCUSDT_251226","status":"TRADING","contractType":"CURRENT_QUARTER","quoteAsset":"USDT","filters":[]},
+			{"symbol":"ETHBTC","status":"TRADING","contractType":"PERPETUAL","quoteAsset":"BTC","filters":[]},
+			{"symbol":"LUNAUSDT","status":"SETTLING","contractType":"PERPETUAL","quoteAsset":"USDT","filters":[]}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	instruments, err := c.GetInstruments(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, instruments, 1)
+
+	btc := instruments["BTCUSDT"]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, "0.001", btc.VolStep.String())
+	assert.Equal(t, "0.001", btc.MinVol.String())
+	assert.Equal(t, "0.1", btc.PriceStep.String())
+	assert.Equal(t, "1", btc.ContractSize.String())
+}
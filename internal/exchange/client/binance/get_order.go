@@ -0,0 +1,95 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const (
+	userTradesURL     = "/fapi/v1/userTrades"
+	spotUserTradesURL = "/api/v3/myTrades"
+)
+
+// GetOrder retrieves the order from the exchange by client order id. Order response has no fees,
+// so fees and realized pnl are summed up from the trades (fills) of the order.
+// Fees are returned negative (paid), the same way they are added to the spread profit.
+// Spot fees are charged in the received asset: base coin fees are converted to USDT by the fill price,
+// fees paid in other assets (BNB discount) are not counted.
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	market := category.OrderMarket()
+
+	query := url.Values{
+		"symbol":            {symbol},
+		"origClientOrderId": {orderID.String()},
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, orderPath(market), query)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Binance GetOrder: failed to create request: %w", err)
+	}
+
+	var order dtos.OrderDTO
+	if _, err := c.do(req, &order); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Binance GetOrder: %w", err)
+	}
+
+	if exchangeOrderID == "" {
+		exchangeOrderID = strconv.FormatInt(order.OrderID, 10)
+	}
+
+	tradesQuery := url.Values{
+		"symbol":  {symbol},
+		"orderId": {exchangeOrderID},
+	}
+
+	tradesPath := userTradesURL
+	if market == models.OrderMarketSpot {
+		tradesPath = spotUserTradesURL
+	}
+
+	req, err = c.newSignedRequest(ctx, http.MethodGet, tradesPath, tradesQuery)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Binance GetOrder: failed to create trades request: %w", err)
+	}
+
+	var trades []dtos.UserTradeDTO
+	if _, err := c.do(req, &trades); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Binance GetOrder: trades: %w", err)
+	}
+
+	fees := decimal.Zero
+	profit := decimal.Zero
+	for _, t := range trades {
+		switch {
+		case market != models.OrderMarketSpot || t.CommissionAsset == "USDT":
+			fees = fees.Sub(t.Commission.Decimal)
+		case t.CommissionAsset+"USDT" == symbol:
+			fees = fees.Sub(t.Commission.Mul(t.Price.Decimal))
+		}
+		profit = profit.Add(t.RealizedPnl.Decimal)
+	}
+
+	avgPrice := order.AvgPrice.Decimal
+	if market == models.OrderMarketSpot && order.ExecutedQty.IsPositive() {
+		avgPrice = order.CummulativeQuoteQty.Div(order.ExecutedQty.Decimal)
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: exchangeOrderID,
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        avgPrice,
+		Fees:            fees,
+		Profit:          profit,
+	}, nil
+}
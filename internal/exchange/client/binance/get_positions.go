@@ -0,0 +1,101 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /fapi/v2/positionRisk
+// Docs: https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Position-Information-V2
+// Open orders: GET /fapi/v1/openOrders, spot: GET /api/v3/openOrders
+
+const (
+	positionRiskURL   = "/fapi/v2/positionRisk"
+	openOrdersURL     = "/fapi/v1/openOrders"
+	spotOpenOrdersURL = "/api/v3/openOrders"
+)
+
+// GetPositions returns open USDT-M futures positions.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, positionRiskURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetPositions: failed to create request: %w", err)
+	}
+
+	var raw []dtos.PositionRiskDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw))
+	for _, p := range raw {
+		if p.PositionAmt.IsZero() {
+			continue
+		}
+
+		side := models.OrderSideBuy
+		if p.PositionAmt.IsNegative() || p.PositionSide == "SHORT" {
+			side = models.OrderSideSell
+		}
+
+		positions = append(positions, exchange.Position{
+			Symbol:     p.Symbol,
+			Side:       side,
+			Qty:        p.PositionAmt.Abs(),
+			EntryPrice: p.EntryPrice.Decimal,
+		})
+	}
+
+	return positions, nil
+}
+
+// GetOpenOrders returns orders resting on the book of the category.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	path := openOrdersURL
+	if category == exchange.CategorySpot {
+		path = spotOpenOrdersURL
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetOpenOrders: failed to create request: %w", err)
+	}
+
+	var raw []dtos.OrderDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw))
+	for _, o := range raw {
+		// orders placed not by the bot have a foreign clientOrderId
+		orderID, err := uuid.Parse(o.ClientOrderID)
+		if err != nil {
+			orderID = uuid.Nil
+		}
+
+		side := models.OrderSideBuy
+		if o.Side == string(dtos.OrderSideSell) {
+			side = models.OrderSideSell
+		}
+
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         orderID,
+			ExchangeOrderID: strconv.FormatInt(o.OrderID, 10),
+			Symbol:          o.Symbol,
+			Side:            side,
+			Qty:             o.OrigQty.Decimal,
+			Price:           o.Price.Decimal,
+		})
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestClient_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, positionRiskURL, r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`[
+			{"symbol":"BTCUSDT","positionAmt":"-0.010","entryPrice":"65000.5","positionSide":"BOTH"},
+			{"symbol":"ETHUSDT","positionAmt":"0.000","entryPrice":"0.0","positionSide":"BOTH"},
+			{"symbol":"SOLUSDT","positionAmt":"2","entryPrice":"150","positionSide":"BOTH"}
+		]`))
+	}))
+	defer server.Close()
+
+	positions, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 2)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "0.01", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+	assert.Equal(t, models.OrderSideBuy, positions[1].Side)
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotOpenOrdersURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`[
+			{"orderId":28,"clientOrderId":"550e8400-e29b-41d4-a716-446655440000","symbol":"BTCUSDT","side":"BUY","origQty":"0.01","price":"64000"},
+			{"orderId":29,"clientOrderId":"web_abc","symbol":"ETHUSDT","side":"SELL","origQty":"1","price":"3000"}
+		]`))
+	}))
+	defer server.Close()
+
+	orders, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetOpenOrders(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), orders[0].OrderID)
+	assert.Equal(t, "28", orders[0].ExchangeOrderID)
+	assert.Equal(t, models.OrderSideBuy, orders[0].Side)
+	assert.Equal(t, "0.01", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+	assert.Equal(t, models.OrderSideSell, orders[1].Side)
+}
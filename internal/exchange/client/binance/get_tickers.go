@@ -0,0 +1,60 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+const tickersURL = "/fapi/v1/ticker/24hr"
+
+// GetTickers returns 24h tickers of USDT-M perpetual symbols. All symbols are returned when symbols is empty.
+func (c *Client) GetTickers(ctx context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	if category != exchange.CategoryLinear {
+		return nil, fmt.Errorf("Binance GetTickers: unsupported category %s", category)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, tickersURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Binance GetTickers: failed to create request: %w", err)
+	}
+
+	// without symbol param Binance returns tickers of all symbols, filter them here
+	var raw []dtos.TickerDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Binance GetTickers: %w", err)
+	}
+
+	filter := make(map[string]struct{}, len(symbols))
+	for _, s := range symbols {
+		filter[s] = struct{}{}
+	}
+
+	result := make([]exchange.Ticker, 0, len(raw))
+	for _, dto := range raw {
+		if _, ok := filter[dto.Symbol]; len(filter) > 0 && !ok {
+			continue
+		}
+
+		result = append(result, mapTicker(dto))
+	}
+
+	return result, nil
+}
+
+func mapTicker(d dtos.TickerDTO) exchange.Ticker {
+	return exchange.Ticker{
+		Symbol:       d.Symbol,
+		LastPrice:    d.LastPrice.Decimal,
+		PrevPrice24h: d.OpenPrice.Decimal,
+		Price24hPcnt: d.PriceChangePercent.Div(decimal.NewFromInt(100)),
+		HighPrice24h: d.HighPrice.Decimal,
+		LowPrice24h:  d.LowPrice.Decimal,
+		Turnover24h:  d.QuoteVolume.Decimal,
+	}
+}
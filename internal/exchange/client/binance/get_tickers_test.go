@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetTickers_Integration(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "1" {
+		t.Skip("Skip integration test")
+	}
+
+	client := NewClient(getTestConfig("https://fapi.binance.com"), zerolog.Nop())
+
+	tickers, err := client.GetTickers(t.Context(), []string{"BTCUSDT"}, exchange.CategoryLinear)
+
+	assert.NoError(t, err)
+	assert.Len(t, tickers, 1)
+}
+
+func TestClient_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, tickersURL, r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"symbol":"BTCUSDT","priceChangePercent":"1.500","lastPrice":"65000.10","openPrice":"64039.50","highPrice":"65500","lowPrice":"63800","volume":"150000","quoteVolume":"9750000000","closeTime":1760000000000},
+			{"symbol":"ETHUSDT","priceChangePercent":"-2.000","lastPrice":"2450","openPrice":"2500","highPrice":"2510","lowPrice":"2400","volume":"900000","quoteVolume":"2205000000","closeTime":1760000000000}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	tickers, err := c.GetTickers(t.Context(), []string{"BTCUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+
+	assert.Equal(t, "BTCUSDT", tickers[0].Symbol)
+	assert.Equal(t, "65000.1", tickers[0].LastPrice.String())
+	assert.Equal(t, "0.015", tickers[0].Price24hPcnt.String())
+	assert.Equal(t, "9750000000", tickers[0].Turnover24h.String())
+
+	all, err := c.GetTickers(t.Context(), nil, exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestClient_GetTickers_UnsupportedCategory(t *testing.T) {
+	c := NewClient(getTestConfig(""), zerolog.Nop())
+
+	_, err := c.GetTickers(t.Context(), nil, exchange.CategorySpot)
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// Api description: https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/New-Order
+// Orders are placed in one-way position mode (positionSide BOTH), positions are closed with reduceOnly orders.
+// Spot: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-trade
+
+const (
+	orderURL     = "/fapi/v1/order"
+	spotOrderURL = "/api/v3/order"
+)
+
+// CreateOrder sends an order to the exchange.
+// On success, mutates order: sets ExchangeOrderID, ExchangeName, Status, RawResponse.
+func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	return c.submitOrder(ctx, order, mapRequestDataToOrderDTO(order))
+}
+
+// CloseOrder closes an existing position by placing a reduce-only market order in the opposite direction.
+// Spot has no positions, so a spot leg is closed by a plain market order of the opposite side.
+func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	// flip side to close the position
+	side := dtos.OrderSideSell
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideBuy
+	}
+
+	query := url.Values{
+		"symbol":           {order.Symbol},
+		"side":             {string(side)},
+		"type":             {string(dtos.OrderTypeMarket)},
+		"quantity":         {order.Quantity.String()},
+		"newClientOrderId": {order.ID.String()},
+		"reduceOnly":       {"true"},
+		"newOrderRespType": {"RESULT"},
+	}
+
+	if order.Market == models.OrderMarketSpot {
+		query.Del("reduceOnly")
+	}
+
+	return c.submitOrder(ctx, order, query)
+}
+
+func (c *Client) submitOrder(ctx context.Context, order *models.Order, query url.Values) error {
+	req, err := c.newSignedRequest(ctx, http.MethodPost, orderPath(order.Market), query)
+	if err != nil {
+		return fmt.Errorf("Binance client failed to create order request: %w", err)
+	}
+
+	var raw dtos.OrderDTO
+	body, err := c.do(req, &raw)
+	if err != nil {
+		return fmt.Errorf("Binance client order failed: %w", err)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	confirmed.ExchangeOrderID = strconv.FormatInt(raw.OrderID, 10)
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusNew
+
+	return nil
+}
+
+func validateBeforeCreateOrder(order *models.Order) error {
+	if order.Market != models.OrderMarketLinear && order.Market != models.OrderMarketSpot {
+		return fmt.Errorf("Binance client supports only linear and spot markets")
+	}
+
+	if order.Quantity.LessThanOrEqual(decimal.NewFromInt(0)) {
+		return fmt.Errorf("Binance client order quantity must be greater than 0")
+	}
+
+	if len(order.Symbol) <= 0 {
+		return fmt.Errorf("Binance client order symbol must be specified")
+	}
+
+	if order.ID == uuid.Nil {
+		return fmt.Errorf("Binance client order id must be valid uuid")
+	}
+
+	return nil
+}
+
+func mapRequestDataToOrderDTO(order *models.Order) url.Values {
+	side := dtos.OrderSideBuy
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideSell
+	}
+
+	orderType := dtos.OrderTypeMarket
+	if order.Type == models.OrderTypeLimit {
+		orderType = dtos.OrderTypeLimit
+	}
+
+	query := url.Values{
+		"symbol":           {order.Symbol},
+		"side":             {string(side)},
+		"type":             {string(orderType)},
+		"quantity":         {order.Quantity.String()},
+		"newClientOrderId": {order.ID.String()},
+		"newOrderRespType": {"RESULT"},
+	}
+
+	if order.Type == models.OrderTypeLimit {
+		query.Set("price", order.Price.String())
+		query.Set("timeInForce", string(mapTimeInForce(order.TimeInForce)))
+	}
+
+	// spot has no GTX time in force, post-only is a separate order type
+	if order.Market == models.OrderMarketSpot && order.Type == models.OrderTypeLimit && order.TimeInForce == models.TimeInForcePostOnly {
+		query.Set("type", string(dtos.OrderTypeLimitMaker))
+		query.Del("timeInForce")
+	}
+
+	return query
+}
+
+// orderPath returns the order endpoint of the market.
+func orderPath(market models.OrderMarket) string {
+	if market == models.OrderMarketSpot {
+		return spotOrderURL
+	}
+	return orderURL
+}
+
+// mapTimeInForce maps the generic models.TimeInForce to Binance-specific values.
+// Defaults to GTC when unspecified.
+func mapTimeInForce(tif models.TimeInForce) dtos.TimeInForce {
+	switch tif {
+	case models.TimeInForceIOC:
+		return dtos.TimeInForceIOC
+	case models.TimeInForceFOK:
+		return dtos.TimeInForceFOK
+	case models.TimeInForcePostOnly:
+		return dtos.TimeInForcePostOnly
+	default:
+		return dtos.TimeInForceGTC
+	}
+}
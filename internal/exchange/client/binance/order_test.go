@@ -0,0 +1,252 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newTestOrder() *models.Order {
+	return &models.Order{
+		ID:       uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketLinear,
+		Quantity: decimal.RequireFromString("0.01"),
+	}
+}
+
+func TestClient_CreateOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, orderURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MBX-APIKEY"))
+
+		q := r.URL.Query()
+		assert.Equal(t, "BTCUSDT", q.Get("symbol"))
+		assert.Equal(t, "BUY", q.Get("side"))
+		assert.Equal(t, "MARKET", q.Get("type"))
+		assert.Equal(t, "0.01", q.Get("quantity"))
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", q.Get("newClientOrderId"))
+		assert.Empty(t, q.Get("reduceOnly"))
+		assert.Empty(t, q.Get("price"))
+		assert.NotEmpty(t, q.Get("signature"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542179,"clientOrderId":"550e8400-e29b-41d4-a716-446655440000","symbol":"BTCUSDT","status":"FILLED","side":"BUY","type":"MARKET","origQty":"0.01","price":"0","avgPrice":"65000.10","executedQty":"0.01","cumQuote":"650.001"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+
+	assert.Equal(t, "Binance", order.ExchangeName)
+	assert.Equal(t, "22542179", order.ExchangeOrderID)
+	assert.Equal(t, models.OrderStatusNew, order.Status)
+	assert.NotEmpty(t, order.RawResponse)
+}
+
+func TestClient_CreateOrder_Limit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "SELL", q.Get("side"))
+		assert.Equal(t, "LIMIT", q.Get("type"))
+		assert.Equal(t, "65100.5", q.Get("price"))
+		assert.Equal(t, "GTX", q.Get("timeInForce"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542180,"status":"NEW"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Side = models.OrderSideSell
+	order.Type = models.OrderTypeLimit
+	order.Price = decimal.RequireFromString("65100.5")
+	order.TimeInForce = models.TimeInForcePostOnly
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "22542180", order.ExchangeOrderID)
+}
+
+func TestClient_CreateOrder_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-2019,"msg":"Margin is insufficient."}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	err := c.CreateOrder(t.Context(), order)
+
+	var apiErr *apiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, -2019, apiErr.Code)
+	assert.Empty(t, order.ExchangeOrderID)
+}
+
+func TestClient_CreateOrder_Validation(t *testing.T) {
+	c := NewClient(getTestConfig(""), zerolog.Nop())
+
+	order := newTestOrder()
+	order.Quantity = decimal.Zero
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+
+	order = newTestOrder()
+	order.Market = models.OrderMarket("INVERSE")
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+}
+
+func TestClient_CloseOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// open order was BUY, close flips the side
+		assert.Equal(t, "SELL", q.Get("side"))
+		assert.Equal(t, "MARKET", q.Get("type"))
+		assert.Equal(t, "true", q.Get("reduceOnly"))
+		assert.Equal(t, "0.01", q.Get("quantity"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542181,"status":"FILLED"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+	assert.Equal(t, "22542181", order.ExchangeOrderID)
+}
+
+func TestClient_CancelOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, orderURL, r.URL.Path)
+		assert.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", r.URL.Query().Get("origClientOrderId"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542179,"status":"CANCELED"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	err := c.CancelOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "22542179", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+}
+
+func TestClient_GetOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case orderURL:
+			assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", r.URL.Query().Get("origClientOrderId"))
+			_, _ = w.Write([]byte(`{"orderId":22542179,"status":"FILLED","avgPrice":"65000.10","executedQty":"0.02"}`))
+		case userTradesURL:
+			assert.Equal(t, "22542179", r.URL.Query().Get("orderId"))
+			_, _ = w.Write([]byte(`[
+				{"orderId":22542179,"symbol":"BTCUSDT","price":"65000","qty":"0.01","commission":"0.26","commissionAsset":"USDT","realizedPnl":"1.5"},
+				{"orderId":22542179,"symbol":"BTCUSDT","price":"65000.2","qty":"0.01","commission":"0.26","commissionAsset":"USDT","realizedPnl":"1.5"}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	orderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	order, err := c.GetOrder(t.Context(), orderID, "", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	assert.Equal(t, orderID, order.OrderID)
+	assert.Equal(t, "22542179", order.ExchangeOrderID)
+	assert.Equal(t, "Binance", order.ExchangeName)
+	assert.Equal(t, "65000.1", order.AvgPrice.String())
+	assert.Equal(t, "-0.52", order.Fees.String())
+	assert.Equal(t, "3", order.Profit.String())
+}
+
+func TestClient_CreateOrder_SpotPostOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotOrderURL, r.URL.Path)
+
+		q := r.URL.Query()
+		assert.Equal(t, "LIMIT_MAKER", q.Get("type"))
+		assert.Equal(t, "65000", q.Get("price"))
+		assert.Empty(t, q.Get("timeInForce"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542180,"status":"NEW"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+	order.Type = models.OrderTypeLimit
+	order.Price = decimal.RequireFromString("65000")
+	order.TimeInForce = models.TimeInForcePostOnly
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "22542180", order.ExchangeOrderID)
+}
+
+func TestClient_CloseOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotOrderURL, r.URL.Path)
+
+		q := r.URL.Query()
+		assert.Equal(t, "SELL", q.Get("side"))
+		assert.Equal(t, "MARKET", q.Get("type"))
+		assert.Empty(t, q.Get("reduceOnly"))
+
+		_, _ = w.Write([]byte(`{"orderId":22542181,"status":"FILLED"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+}
+
+func TestClient_GetOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case spotOrderURL:
+			_, _ = w.Write([]byte(`{"orderId":22542180,"status":"FILLED","executedQty":"0.02","cummulativeQuoteQty":"1300.002"}`))
+		case spotUserTradesURL:
+			_, _ = w.Write([]byte(`[
+				{"orderId":22542180,"symbol":"BTCUSDT","price":"65000","qty":"0.01","commission":"0.00001","commissionAsset":"BTC"},
+				{"orderId":22542180,"symbol":"BTCUSDT","price":"65000.2","qty":"0.01","commission":"0.0005","commissionAsset":"BNB"}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	order, err := c.GetOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "", "BTCUSDT", exchange.CategorySpot)
+	require.NoError(t, err)
+
+	assert.Equal(t, "65000.1", order.AvgPrice.String())
+	// base coin fee converted by fill price, BNB fee is not counted
+	assert.Equal(t, "-0.65", order.Fees.String())
+}
@@ -0,0 +1,109 @@
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// spotPathPrefix is the path prefix of spot REST endpoints, they are served by the spot host.
+const spotPathPrefix = "/api/"
+
+// apiError is an error response of Binance API, returned with non 2xx http status.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("Binance api error, %d, %s", e.Code, e.Message)
+}
+
+func sign(secret, message string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// urlFor returns the full url of the endpoint: spot endpoints (/api/...) go to the spot host, futures (/fapi/...) to the futures host.
+func (c *Client) urlFor(path string) string {
+	if strings.HasPrefix(path, spotPathPrefix) {
+		return c.spotBaseURL + path
+	}
+	return c.baseURL + path
+}
+
+// newRequest creates a public (unsigned) request.
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.urlFor(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return req, nil
+}
+
+// newSignedRequest creates a SIGNED request: timestamp and recvWindow are added to the query,
+// the query is signed with HMAC SHA256 and the signature goes last.
+// Docs: https://developers.binance.com/docs/derivatives/usds-margined-futures/general-info#signed-trade-and-user_data-endpoint-security
+func (c *Client) newSignedRequest(ctx context.Context, method string, path string, query url.Values) (*http.Request, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	query.Set("recvWindow", strconv.FormatInt(c.cfg.Exchange.Binance.RecvWindow, 10))
+
+	req, err := http.NewRequestWithContext(ctx, method, c.urlFor(path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr := query.Encode()
+	req.URL.RawQuery = fmt.Sprintf("%s&signature=%s", queryStr, sign(c.cfg.Exchange.Binance.APISecret, queryStr))
+	req.Header.Set("X-MBX-APIKEY", c.cfg.Exchange.Binance.APIKey)
+
+	return req, nil
+}
+
+// do sends the request and unmarshals the response body into out.
+// Binance returns errors as {"code": -1121, "msg": "Invalid symbol."} with non 2xx http status.
+func (c *Client) do(req *http.Request, out interface{}) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Code != 0 {
+			return nil, &apiErr
+		}
+		return nil, fmt.Errorf("unexpected http status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if out == nil {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+func getTestConfig(baseURL string) *config.Config {
+	return &config.Config{
+		Exchange: config.ExchangeConfig{
+			Binance: config.BinanceConfig{
+				APIBaseURL:     baseURL,
+				SpotAPIBaseURL: baseURL,
+				APIKey:         "test-api-key",
+				APISecret:      "test-api-secret",
+				RecvWindow:     5000,
+			},
+		},
+	}
+}
+
+func TestSign(t *testing.T) {
+	// example from Binance API docs (SIGNED endpoint examples)
+	secret := "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+	query := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+
+	assert.Equal(t, "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71", sign(secret, query))
+}
+
+func TestClient_NewSignedRequest(t *testing.T) {
+	c := NewClient(getTestConfig("https://fapi.binance.com"), zerolog.Nop())
+
+	req, err := c.newSignedRequest(t.Context(), http.MethodGet, "/fapi/v1/order", url.Values{"symbol": {"BTCUSDT"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-api-key", req.Header.Get("X-MBX-APIKEY"))
+
+	// signature is the last param and signs everything before it
+	rawQuery := req.URL.RawQuery
+	idx := strings.LastIndex(rawQuery, "&signature=")
+	require.Positive(t, idx)
+	assert.Equal(t, sign("test-api-secret", rawQuery[:idx]), rawQuery[idx+len("&signature="):])
+
+	query := req.URL.Query()
+	assert.Equal(t, "BTCUSDT", query.Get("symbol"))
+	assert.Equal(t, "5000", query.Get("recvWindow"))
+	assert.NotEmpty(t, query.Get("timestamp"))
+}
+
+func TestClient_Do_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	req, err := c.newRequest(t.Context(), http.MethodGet, "/fapi/v1/ticker/24hr", nil)
+	require.NoError(t, err)
+
+	_, err = c.do(req, nil)
+
+	var apiErr *apiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, -1121, apiErr.Code)
+	assert.Equal(t, "Invalid symbol.", apiErr.Message)
+}
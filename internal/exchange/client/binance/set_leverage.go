@@ -0,0 +1,73 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+// Docs: https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Change-Initial-Leverage
+// Margin type: https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Change-Margin-Type
+
+const (
+	leverageURL   = "/fapi/v1/leverage"
+	marginTypeURL = "/fapi/v1/marginType"
+
+	// codeNoNeedToChangeMarginType is returned when the symbol already has the margin type.
+	codeNoNeedToChangeMarginType = -4046
+)
+
+// SetLeverage sets the initial leverage for a symbol.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	query := url.Values{
+		"symbol":   {symbol},
+		"leverage": {strconv.FormatInt(leverage, 10)},
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodPost, leverageURL, query)
+	if err != nil {
+		return fmt.Errorf("Binance SetLeverage: failed to create request: %w", err)
+	}
+
+	var raw dtos.LeverageDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("Binance SetLeverage: %w", err)
+	}
+
+	return nil
+}
+
+// SetMarginMode sets the margin type of a symbol.
+func (c *Client) SetMarginMode(ctx context.Context, symbol string, mode exchange.MarginMode) error {
+	marginType := "CROSSED"
+	if mode == exchange.MarginModeIsolated {
+		marginType = "ISOLATED"
+	}
+
+	query := url.Values{
+		"symbol":     {symbol},
+		"marginType": {marginType},
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodPost, marginTypeURL, query)
+	if err != nil {
+		return fmt.Errorf("Binance SetMarginMode: failed to create request: %w", err)
+	}
+
+	_, err = c.do(req, nil)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Code == codeNoNeedToChangeMarginType {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Binance SetMarginMode: %w", err)
+	}
+
+	return nil
+}
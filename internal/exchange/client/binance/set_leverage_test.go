@@ -0,0 +1,54 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_SetLeverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, leverageURL, r.URL.Path)
+		assert.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "5", r.URL.Query().Get("leverage"))
+
+		_, _ = w.Write([]byte(`{"leverage":5,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+}
+
+func TestClient_SetMarginMode(t *testing.T) {
+	changed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, marginTypeURL, r.URL.Path)
+		assert.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "ISOLATED", r.URL.Query().Get("marginType"))
+
+		if changed {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":-4046,"msg":"No need to change margin type."}`))
+			return
+		}
+		changed = true
+		_, _ = w.Write([]byte(`{"code":200,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated))
+	// already isolated
+	require.NoError(t, c.SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated))
+}
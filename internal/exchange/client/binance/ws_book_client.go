@@ -0,0 +1,244 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+const (
+	bookTickerTopic = "bookTicker"
+	// orderBookTopic is full snapshot of 20 levels every 100ms.
+	orderBookTopic = "depth20@100ms"
+
+	bookTickerEvent = "bookTicker"
+	depthEvent      = "depthUpdate"
+)
+
+// wsBookClient represents a WebSocket client streaming orderbook of Binance USDT-M futures symbols.
+// T is exchange.BookTop for bookTicker or exchange.OrderBookUpdate for depth.
+type wsBookClient[T any] struct {
+	Metrics    *Metrics
+	cfg        *config.Config
+	wsMu       sync.Mutex
+	backoff    *exchange.Backoff
+	logger     zerolog.Logger
+	topic      string
+	eventType  string
+	mapMessage func(message []byte) (T, error)
+}
+
+func newWsBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		topic:      bookTickerTopic,
+		eventType:  bookTickerEvent,
+		mapMessage: mapBookTicker,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		topic:      orderBookTopic,
+		eventType:  depthEvent,
+		mapMessage: mapDepth,
+	}
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the topic of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.Binance.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("binance failed to dial websocket: %w", err)
+	}
+
+	if err = c.writeJSON(wsConn, subscribePayload(symbols, c.topic)); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("binance failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads book messages from the connection and reconnects with backoff until ctx is done.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("binance failed to reconnect to Binance book websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("binance reconnected to Binance book websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) readMessage(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("binance failed to read message from Binance book websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		var base dtos.WsBaseMessage
+		if err = json.Unmarshal(messageByte, &base); err != nil {
+			c.logger.Warn().Err(err).Msgf("binance failed to unmarshal message from Binance %s websocket: %s", c.topic, messageByte)
+			continue
+		}
+
+		// subscription responses have no event type
+		if base.EventType != c.eventType {
+			continue
+		}
+
+		update, err := c.mapMessage(messageByte)
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("binance failed to map message from Binance %s websocket: %s", c.topic, messageByte)
+			continue
+		}
+
+		select {
+		case outChan <- update:
+		default:
+			c.Metrics.droppedBookUpdates.Add(1)
+		}
+	}
+}
+
+func (c *wsBookClient[T]) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCnt := c.Metrics.droppedBookUpdates.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("Binance %s metrics: dropped book updates=%d, reconnects=%d", c.topic, droppedCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+func mapBookTicker(message []byte) (exchange.BookTop, error) {
+	var dto dtos.WsBookTickerDTO
+	if err := json.Unmarshal(message, &dto); err != nil {
+		return exchange.BookTop{}, err
+	}
+
+	return exchange.BookTop{
+		Symbol:   dto.Symbol,
+		Category: exchange.CategoryLinear,
+		Ts:       dto.TransactionTime,
+		BidPrice: float64(dto.BidPrice),
+		BidQty:   float64(dto.BidQty),
+		AskPrice: float64(dto.AskPrice),
+		AskQty:   float64(dto.AskQty),
+	}, nil
+}
+
+// mapDepth maps partial depth message to exchange.OrderBookUpdate. Partial depth stream pushes top N levels,
+// so every message is a snapshot.
+func mapDepth(message []byte) (exchange.OrderBookUpdate, error) {
+	var dto dtos.WsDepthDTO
+	if err := json.Unmarshal(message, &dto); err != nil {
+		return exchange.OrderBookUpdate{}, err
+	}
+
+	return exchange.OrderBookUpdate{
+		Symbol:   dto.Symbol,
+		Category: exchange.CategoryLinear,
+		Ts:       dto.TransactionTime,
+		Snapshot: true,
+		Bids:     mapPriceLevels(dto.Bids),
+		Asks:     mapPriceLevels(dto.Asks),
+	}, nil
+}
+
+func mapPriceLevels(levels [][2]utils.JSONFloat64) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, len(levels))
+	for i, l := range levels {
+		res[i] = exchange.PriceLevel{Price: float64(l[0]), Qty: float64(l[1])}
+	}
+	return res
+}
@@ -0,0 +1,223 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+// Binance sends ping frames every 3 minutes and drops the connection without pong,
+// gorilla/websocket answers them by default while the connection is being read, so there is no ping routine here.
+
+const aggTradeTopic = "aggTrade"
+
+// Metrics holds metrics related to websocket client operations.
+type Metrics struct {
+	droppedTrades      atomic.Uint64
+	droppedBookUpdates atomic.Uint64
+	reconnects         atomic.Uint64
+}
+
+// wsClient represents a WebSocket client streaming trades of Binance USDT-M futures.
+type wsClient struct {
+	Metrics *Metrics
+	cfg     *config.Config
+	wsMu    sync.Mutex
+	backoff *exchange.Backoff
+	logger  zerolog.Logger
+}
+
+func newWsClient(cfg *config.Config, logger zerolog.Logger) *wsClient {
+	return &wsClient{
+		Metrics: &Metrics{},
+		cfg:     cfg,
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:  logger,
+	}
+}
+
+func (c *wsClient) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsClient) Start(ctx context.Context, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) error {
+	if category != exchange.CategoryLinear {
+		return fmt.Errorf("binance websocket supports only %s trades", exchange.CategoryLinear)
+	}
+
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, category, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the trades of the given symbols.
+func (c *wsClient) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.Binance.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("binance failed to dial websocket: %w", err)
+	}
+
+	if err = c.writeJSON(wsConn, subscribePayload(symbols, aggTradeTopic)); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("binance failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads trades from the connection and reconnects with backoff (replaying subscriptions) until ctx is done.
+func (c *wsClient) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, category, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsClient) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("binance failed to reconnect to Binance websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("binance reconnected to Binance websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsClient) readMessage(ctx context.Context, wsConn *websocket.Conn, category exchange.Category, outChan chan<- exchange.Trade) {
+	defer func() {
+		err := wsConn.Close()
+		if err != nil && ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("binance failed to close websocket connection")
+		}
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("binance failed to read message from Binance websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		trade, ok := c.mapTrade(messageByte, category)
+		if !ok {
+			continue
+		}
+
+		select {
+		case outChan <- trade:
+		default:
+			c.Metrics.droppedTrades.Add(1)
+		}
+	}
+}
+
+// mapTrade maps aggTrade message to exchange.Trade. Subscription responses ({"result":null,"id":1}) are skipped.
+func (c *wsClient) mapTrade(message []byte, category exchange.Category) (exchange.Trade, bool) {
+	var tradeMessage dtos.WsAggTradeDTO
+	if err := json.Unmarshal(message, &tradeMessage); err != nil {
+		c.logger.Warn().Err(err).Msg("binance failed to unmarshal trade message from Binance websocket")
+		return exchange.Trade{}, false
+	}
+
+	if tradeMessage.EventType != aggTradeTopic {
+		return exchange.Trade{}, false
+	}
+
+	// buyer is maker - the taker sold
+	side := exchange.Buy
+	if tradeMessage.IsBuyerMaker {
+		side = exchange.Sell
+	}
+
+	return exchange.Trade{
+		Symbol:   tradeMessage.Symbol,
+		Category: category,
+		Ts:       tradeMessage.TradeTime,
+		Price:    float64(tradeMessage.Price),
+		Volume:   float64(tradeMessage.Qty),
+		Side:     side,
+	}, true
+}
+
+func (c *wsClient) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedTradesCnt := c.Metrics.droppedTrades.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedTradesCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("Binance metrics: dropped trades=%d, reconnects=%d", droppedTradesCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+// subscribePayload builds a SUBSCRIBE request for the topic of all given symbols, stream names are lowercase (btcusdt@aggTrade).
+func subscribePayload(symbols []string, topic string) map[string]interface{} {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, fmt.Sprintf("%s@%s", strings.ToLower(symbol), topic))
+	}
+
+	return map[string]interface{}{
+		"method": "SUBSCRIBE",
+		"params": params,
+		"id":     time.Now().UnixMilli(),
+	}
+}
@@ -0,0 +1,139 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+var testUpgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// newTestWsServer starts a websocket stand-in, which checks the subscription request and pushes messages.
+func newTestWsServer(t *testing.T, wantParams []string, messages ...string) (*httptest.Server, *config.Config) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		var sub struct {
+			Method string   `json:"method"`
+			Params []string `json:"params"`
+			ID     int64    `json:"id"`
+		}
+		require.NoError(t, conn.ReadJSON(&sub))
+		assert.Equal(t, "SUBSCRIBE", sub.Method)
+		assert.Equal(t, wantParams, sub.Params)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`)))
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			Binance: config.BinanceConfig{
+				WSUrl: "ws" + strings.TrimPrefix(server.URL, "http"),
+			},
+		},
+	}
+
+	return server, cfg
+}
+
+func TestWsClient_Trades(t *testing.T) {
+	server, cfg := newTestWsServer(t, []string{"btcusdt@aggTrade", "ethusdt@aggTrade"},
+		`{"e":"aggTrade","E":123456789,"s":"BTCUSDT","a":5933014,"p":"65000.10","q":"0.5","f":100,"l":105,"T":123456785,"m":true}`,
+		`{"e":"aggTrade","E":123456790,"s":"ETHUSDT","a":5933015,"p":"2450","q":"2","f":106,"l":106,"T":123456786,"m":false}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	trades := make(chan exchange.Trade, 10)
+	client := newWsClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT", "ETHUSDT"}, exchange.CategoryLinear, trades))
+
+	assert.Equal(t, exchange.Trade{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       123456785,
+		Price:    65000.1,
+		Volume:   0.5,
+		Side:     exchange.Sell,
+	}, <-trades)
+
+	second := <-trades
+	assert.Equal(t, "ETHUSDT", second.Symbol)
+	assert.Equal(t, exchange.Buy, second.Side)
+}
+
+func TestWsBookClient_BookTicker(t *testing.T) {
+	server, cfg := newTestWsServer(t, []string{"btcusdt@bookTicker"},
+		`{"e":"bookTicker","u":400900217,"E":1568014460893,"T":1568014460891,"s":"BTCUSDT","b":"65000.10","B":"31.21","a":"65000.20","A":"40.66"}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.BookTop, 10)
+	client := newWsBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, out))
+
+	assert.Equal(t, exchange.BookTop{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1568014460891,
+		BidPrice: 65000.1,
+		BidQty:   31.21,
+		AskPrice: 65000.2,
+		AskQty:   40.66,
+	}, <-out)
+}
+
+func TestWsBookClient_OrderBook(t *testing.T) {
+	server, cfg := newTestWsServer(t, []string{"btcusdt@depth20@100ms"},
+		`{"e":"depthUpdate","E":1571889248277,"T":1571889248276,"s":"BTCUSDT","U":390497796,"u":390497878,"pu":390497794,
+			"b":[["65000.10","1.5"],["65000.00","2"]],"a":[["65000.20","0.7"]]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.OrderBookUpdate, 10)
+	client := newWsOrderBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, out))
+
+	assert.Equal(t, exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1571889248276,
+		Snapshot: true,
+		Bids:     []exchange.PriceLevel{{Price: 65000.1, Qty: 1.5}, {Price: 65000, Qty: 2}},
+		Asks:     []exchange.PriceLevel{{Price: 65000.2, Qty: 0.7}},
+	}, <-out)
+}
@@ -0,0 +1,250 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+// Docs: https://developers.binance.com/docs/derivatives/usds-margined-futures/user-data-streams/Connect
+
+const (
+	listenKeyURL = "/fapi/v1/listenKey"
+	// listenKey expires in 60 minutes without keepalive.
+	listenKeyKeepAliveInterval = 30 * time.Minute
+
+	privateOrderTradeUpdateEvent = "ORDER_TRADE_UPDATE"
+	privateListenKeyExpiredEvent = "listenKeyExpired"
+	executionTypeTrade           = "TRADE"
+	executionTypeCanceled        = "CANCELED"
+	executionTypeExpired         = "EXPIRED"
+	executionTypeExpiredInMatch  = "EXPIRED_IN_MATCH" // expired by self-trade prevention
+)
+
+// WsPrivateClient handles the user data stream of Binance USDT-M futures (listenKey based websocket).
+type WsPrivateClient struct {
+	baseURL    string
+	wsURL      string
+	cfg        *config.Config
+	httpClient *http.Client
+	logger     zerolog.Logger
+	wsConn     *websocket.Conn
+
+	executionChannel    chan exchange.OrderExecutionEvent
+	executionSubscribed bool
+}
+
+// NewWsPrivateClient initializes a WsPrivateClient with the given configuration and logger for private WebSocket connections.
+func NewWsPrivateClient(cfg *config.Config, logger zerolog.Logger) *WsPrivateClient {
+	return &WsPrivateClient{
+		baseURL:    cfg.Exchange.Binance.APIBaseURL,
+		wsURL:      cfg.Exchange.Binance.WSUrl,
+		cfg:        cfg,
+		httpClient: &http.Client{},
+		logger:     logger,
+
+		executionChannel:    make(chan exchange.OrderExecutionEvent, 100),
+		executionSubscribed: false,
+	}
+}
+
+// Start creates a listenKey, connects to the user data stream and starts message handling and listenKey keepalive routines.
+func (c *WsPrivateClient) Start(ctx context.Context) error {
+	if c.executionSubscribed {
+		return nil
+	}
+
+	listenKey, err := c.listenKey(ctx, http.MethodPost)
+	if err != nil {
+		return err
+	}
+
+	wsConn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/%s", c.wsURL, listenKey), nil)
+	if err != nil {
+		return fmt.Errorf("Binance ws private: failed to connect to websocket: %w", err)
+	}
+
+	c.wsConn = wsConn
+
+	go func() {
+		<-ctx.Done()
+		_ = wsConn.Close()
+	}()
+
+	go c.keepAlive(ctx)
+
+	go func() {
+		defer c.closeChannels()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := c.handleMessage()
+				if err != nil {
+					c.logger.Error().Err(err).Str("exchange", "Binance").Msg("error processing message")
+					_ = wsConn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	c.executionSubscribed = true
+
+	return nil
+}
+
+// SubscribeToExecutions returns the execution events channel.
+// Binance user data stream automatically receives all account events.
+func (c *WsPrivateClient) SubscribeToExecutions() (<-chan exchange.OrderExecutionEvent, error) {
+	return c.executionChannel, nil
+}
+
+// keepAlive extends the listenKey validity while the stream is in use.
+func (c *WsPrivateClient) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(listenKeyKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.listenKey(ctx, http.MethodPut); err != nil {
+				c.logger.Warn().Err(err).Str("exchange", "Binance").Msg("Failed to keep alive listen key")
+			}
+		}
+	}
+}
+
+func (c *WsPrivateClient) handleMessage() error {
+	mt, raw, err := c.wsConn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("Binance ws private: failed to read message from. Network issue? %v", err)
+	}
+
+	if mt != websocket.TextMessage {
+		c.logger.Warn().Str("exchange", "Binance").Msg("unexpected message type")
+		return nil
+	}
+
+	var r dtos.PrivateMessageDTO
+	if err := json.Unmarshal(raw, &r); err != nil {
+		c.logger.Warn().Err(err).Str("exchange", "Binance").Msg("Binance ws private: failed to unmarshal response message")
+		return nil
+	}
+
+	switch r.EventType {
+	case privateOrderTradeUpdateEvent:
+		order, ok := c.handleExecutionEvent(&r)
+		if !ok {
+			return nil
+		}
+		// Blocking, but channel has buffer
+		c.executionChannel <- order
+	case privateListenKeyExpiredEvent:
+		return fmt.Errorf("Binance ws private: listen key expired")
+	default:
+		c.logger.Debug().Str("event_type", r.EventType).Msg("Binance ws private: skip event")
+	}
+
+	return nil
+}
+
+// handleExecutionEvent maps order update to exchange.OrderExecutionEvent. Only fills (execution type TRADE) and
+// orders ended by the exchange (CANCELED, EXPIRED) are mapped, the latter with Canceled set and no leaves qty.
+// Orders which were not placed by the bot (client order id is not uuid) are skipped.
+func (c *WsPrivateClient) handleExecutionEvent(message *dtos.PrivateMessageDTO) (exchange.OrderExecutionEvent, bool) {
+	var execution dtos.ExecutionDTO
+	if err := json.Unmarshal(message.O, &execution); err != nil {
+		c.logger.Warn().Err(err).Msg("Binance ws private: failed to unmarshal execution message from Binance private websocket")
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	var canceled bool
+	switch execution.ExecutionType {
+	case executionTypeTrade:
+	case executionTypeCanceled, executionTypeExpired, executionTypeExpiredInMatch:
+		canceled = true
+	default:
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	orderID, err := uuid.Parse(execution.ClientOrderID)
+	if err != nil {
+		c.logger.Debug().Str("client_order_id", execution.ClientOrderID).Msg("Binance ws private: skip execution of foreign order")
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	leavesQty := execution.Qty.Sub(execution.FilledQty.Decimal)
+	if canceled {
+		leavesQty = decimal.Zero
+	}
+
+	return exchange.OrderExecutionEvent{
+		OrderID:         orderID,
+		ExchangeOrderID: strconv.FormatInt(execution.OrderID, 10),
+		ExecPrice:       execution.AvgPrice.Decimal,
+		ExecQty:         execution.FilledQty.Decimal,
+		ExecValue:       execution.AvgPrice.Mul(execution.FilledQty.Decimal),
+		LeavesQty:       leavesQty,
+		OrderPrice:      execution.Price.Decimal,
+		OrderQty:        execution.Qty.Decimal,
+		Canceled:        canceled,
+	}, true
+}
+
+func (c *WsPrivateClient) closeChannels() {
+	close(c.executionChannel)
+}
+
+// listenKey creates (POST) or keeps alive (PUT) the listenKey of the user data stream. Requires only API key, no signature.
+func (c *WsPrivateClient) listenKey(ctx context.Context, method string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+listenKeyURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("Binance client failed to create request (ws private client): %w", err)
+	}
+
+	req.Header.Set("X-MBX-APIKEY", c.cfg.Exchange.Binance.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Binance client http listen key request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Binance client failed to read listen key response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Binance client unexpected http while getting listen key status code: %d, %s", resp.StatusCode, body)
+	}
+
+	var r dtos.ListenKeyDTO
+	if err := json.Unmarshal(body, &r); err != nil {
+		return "", fmt.Errorf("Binance client failed to unmarshal listen key response: %w", err)
+	}
+
+	if r.ListenKey == "" {
+		return "", fmt.Errorf("Binance client got empty listen key: %s", body)
+	}
+
+	return r.ListenKey, nil
+}
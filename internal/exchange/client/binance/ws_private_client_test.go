@@ -0,0 +1,102 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange/client/binance/dtos"
+)
+
+func TestWsPrivateClient_Happy(t *testing.T) {
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, listenKeyURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MBX-APIKEY"))
+
+		_, _ = w.Write([]byte(`{"listenKey":"some-listen-key-123"}`))
+	}))
+	defer httpSrv.Close()
+
+	wsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/some-listen-key-123", r.URL.Path)
+
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		messages := []string{
+			// new order, no fill yet - skipped
+			`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT","c":"550e8400-e29b-41d4-a716-446655440000","S":"BUY","o":"MARKET","q":"0.01","p":"0","ap":"0","x":"NEW","X":"NEW","i":8886774,"l":"0","z":"0","L":"0","T":1568879465650}}`,
+			// order placed manually - skipped
+			`{"e":"ORDER_TRADE_UPDATE","E":1568879465652,"T":1568879465651,"o":{"s":"BTCUSDT","c":"web_AsDf1234","S":"SELL","o":"MARKET","q":"1","p":"0","ap":"65000","x":"TRADE","X":"FILLED","i":8886775,"l":"1","z":"1","L":"65000","T":1568879465651}}`,
+			`{"e":"ACCOUNT_UPDATE","E":1568879465653,"T":1568879465652,"a":{}}`,
+			`{"e":"ORDER_TRADE_UPDATE","E":1568879465654,"T":1568879465653,"o":{"s":"BTCUSDT","c":"550e8400-e29b-41d4-a716-446655440000","S":"BUY","o":"MARKET","q":"0.01","p":"0","ap":"50000.5","x":"TRADE","X":"FILLED","i":8886774,"l":"0.01","z":"0.01","L":"50000.5","N":"USDT","n":"0.2","T":1568879465653}}`,
+		}
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer wsSrv.Close()
+
+	cfg := getTestConfig(httpSrv.URL)
+	cfg.Exchange.Binance.WSUrl = "ws" + strings.TrimPrefix(wsSrv.URL, "http")
+
+	client := NewWsPrivateClient(cfg, zerolog.Nop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.Start(ctx))
+
+	execCh, err := client.SubscribeToExecutions()
+	require.NoError(t, err)
+
+	select {
+	case event := <-execCh:
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", event.OrderID.String())
+		assert.Equal(t, "8886774", event.ExchangeOrderID)
+		assert.Equal(t, "50000.5", event.ExecPrice.String())
+		assert.Equal(t, "0.01", event.ExecQty.String())
+		assert.Equal(t, "500.005", event.ExecValue.String())
+		assert.True(t, event.LeavesQty.IsZero())
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for execution event")
+	}
+}
+
+// A market order partly filled and expired by the exchange ends at its filled qty
+func TestWsPrivateClient_HandleExecutionEvent_Expired(t *testing.T) {
+	client := NewWsPrivateClient(getTestConfig(""), zerolog.Nop())
+
+	event, ok := client.handleExecutionEvent(&dtos.PrivateMessageDTO{
+		EventType: privateOrderTradeUpdateEvent,
+		O:         []byte(`{"s":"BTCUSDT","c":"550e8400-e29b-41d4-a716-446655440000","S":"BUY","o":"MARKET","q":"0.03","p":"0","ap":"50000","x":"EXPIRED","X":"EXPIRED","i":8886774,"l":"0","z":"0.01","L":"0","T":1568879465653}`),
+	})
+	require.True(t, ok)
+	assert.True(t, event.Canceled)
+	assert.Equal(t, "0.01", event.ExecQty.String())
+	assert.Equal(t, "50000", event.ExecPrice.String())
+	assert.True(t, event.LeavesQty.IsZero())
+
+	// new order is still skipped
+	_, ok = client.handleExecutionEvent(&dtos.PrivateMessageDTO{
+		EventType: privateOrderTradeUpdateEvent,
+		O:         []byte(`{"s":"BTCUSDT","c":"550e8400-e29b-41d4-a716-446655440000","q":"0.03","x":"NEW","X":"NEW","i":8886774,"z":"0"}`),
+	})
+	assert.False(t, ok)
+}
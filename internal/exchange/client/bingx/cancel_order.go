@@ -9,15 +9,32 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
 )
 
 // API: DELETE /openApi/swap/v2/trade/order
 // Docs: https://bingx-api.github.io/docs-v3/#/en/Swap/Trades%20Endpoints/Cancel%20Order
+// Spot: POST /openApi/spot/v1/trade/cancel
 
-const cancelOrderURL = "/openApi/swap/v2/trade/order"
+const (
+	cancelOrderURL     = "/openApi/swap/v2/trade/order"
+	cancelSpotOrderURL = "/openApi/spot/v1/trade/cancel"
+)
 
 // CancelOrder cancels a pending limit order by clientOrderId.
-func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string) error {
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string, category exchange.Category) error {
+	if category == exchange.CategorySpot {
+		query := map[string]string{
+			"symbol":        denormalizeTickerName(symbol),
+			"clientOrderID": orderID.String(),
+		}
+		if _, err := c.doSignedRequest(ctx, http.MethodPost, cancelSpotOrderURL, query, nil); err != nil {
+			return fmt.Errorf("BingX | CancelOrder: spot: %w", err)
+		}
+		return nil
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+cancelOrderURL, nil)
 	if err != nil {
 		return fmt.Errorf("BingX | CancelOrder: failed to create request: %w", err)
@@ -20,6 +20,9 @@ type Client struct {
 	cfg          *config.Config
 	wsManager    *exchange.WSManager
 
+	bookManager      *exchange.StreamManager[exchange.BookTop]
+	orderBookManager *exchange.StreamManager[exchange.OrderBookUpdate]
+
 	wsPrivate        *WsPrivateClient
 	wsPrivateStarted bool
 }
@@ -35,6 +38,12 @@ func NewClient(cfg *config.Config, logger zerolog.Logger) *Client {
 		wsManager: exchange.NewWSManager(cfg, func(c *config.Config) exchange.WsClient {
 			return newWsClient(c)
 		}),
+		bookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.BookTop] {
+			return newWsBookClient(c)
+		}),
+		orderBookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.OrderBookUpdate] {
+			return newWsOrderBookClient(c)
+		}),
 	}
 }
 
@@ -48,6 +57,16 @@ func (c *Client) SubscribeTrades(ctx context.Context, symbols []string, category
 	return c.wsManager.SubscribeTrades(ctx, symbols, category)
 }
 
+// SubscribeBookTicker subscribes to best bid/ask (bookTicker) of the given symbols and streams them to the returned channel.
+func (c *Client) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	return c.bookManager.Subscribe(ctx, symbols)
+}
+
+// SubscribeOrderBook subscribes to L2 orderbook (depth20 snapshots) of the given symbols.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	return c.orderBookManager.Subscribe(ctx, symbols)
+}
+
 // SubscribeExecutions subscribes to order execution events and streams them to the returned channel. Implements the interface Provider
 func (c *Client) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
 	if !c.wsPrivateStarted {
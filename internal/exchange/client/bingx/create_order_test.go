@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/models"
 )
 
@@ -163,3 +164,75 @@ func Test_CreateOrder_MapOrderToQuery(t *testing.T) {
 		"timestamp":     strconv.FormatInt(timestamp, 10),
 	}, res)
 }
+
+func Test_CreateOrder_Spot(t *testing.T) {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			BingX: config.BingXConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, createSpotOrderURL, r.URL.Path)
+
+		query := r.URL.Query()
+		assert.Equal(t, "BTC-USDT", query.Get("symbol"))
+		assert.Equal(t, "BUY", query.Get("side"))
+		assert.Equal(t, "MARKET", query.Get("type"))
+		assert.Equal(t, "0.01", query.Get("quantity"))
+		assert.Empty(t, query.Get("positionSide"))
+		assert.NotEmpty(t, query.Get("newClientOrderId"))
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"symbol":"BTC-USDT","orderId":1735950085,"status":"FILLED","executedQty":"0.01","cummulativeQuoteQty":"650.001"}}`))
+	}))
+	defer server.Close()
+
+	bingx := NewClient(cfg, zerolog.Nop())
+	bingx.baseURL = server.URL
+	bingx.httpClient = server.Client()
+
+	order := models.Order{
+		ID:       uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketSpot,
+		Quantity: decimal.RequireFromString("0.01"),
+	}
+
+	assert.NoError(t, bingx.CreateOrder(t.Context(), &order))
+	assert.Equal(t, "1735950085", order.ExchangeOrderID)
+}
+
+func Test_GetOrder_Spot(t *testing.T) {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			BingX: config.BingXConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, getSpotOrderURL, r.URL.Path)
+		assert.Equal(t, "1735950085", r.URL.Query().Get("orderId"))
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"symbol":"BTC-USDT","orderId":1735950085,"side":"BUY","status":"FILLED","executedQty":"0.01","cummulativeQuoteQty":"650","fee":-0.00001}}`))
+	}))
+	defer server.Close()
+
+	bingx := NewClient(cfg, zerolog.Nop())
+	bingx.baseURL = server.URL
+	bingx.httpClient = server.Client()
+
+	order, err := bingx.GetOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "1735950085", "BTCUSDT", exchange.CategorySpot)
+	assert.NoError(t, err)
+	assert.Equal(t, "65000", order.AvgPrice.String())
+	// buy fee is in base coin
+	assert.Equal(t, "-0.65", order.Fees.String())
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// WsBookTickerDataDTO represents best bid/ask data of the bookTicker stream.
+type WsBookTickerDataDTO struct {
+	Symbol   string            `json:"s"` // Trading pair
+	T        int64             `json:"T"` // Transaction time
+	BidPrice utils.JSONFloat64 `json:"b"` // Best bid price
+	BidQty   utils.JSONFloat64 `json:"B"` // Best bid quantity
+	AskPrice utils.JSONFloat64 `json:"a"` // Best ask price
+	AskQty   utils.JSONFloat64 `json:"A"` // Best ask quantity
+}
+
+// WsBookTickerMessageDTO represents a bookTicker message transfer object.
+type WsBookTickerMessageDTO struct {
+	Code     int                 `json:"code"`     // Error code, 0 for normal, 1 for error
+	DataType string              `json:"dataType"` // Subscribed data type, e.g., BTC-USDT@bookTicker
+	Data     WsBookTickerDataDTO `json:"data"`
+}
+
+// WsDepthDataDTO represents order book levels of the depth stream (full snapshot of N levels).
+type WsDepthDataDTO struct {
+	Bids [][2]utils.JSONFloat64 `json:"bids"` // [price, quantity]
+	Asks [][2]utils.JSONFloat64 `json:"asks"` // [price, quantity]
+}
+
+// WsDepthMessageDTO represents a depth message transfer object.
+type WsDepthMessageDTO struct {
+	Code     int            `json:"code"`     // Error code, 0 for normal, 1 for error
+	DataType string         `json:"dataType"` // Subscribed data type, e.g., BTC-USDT@depth20@100ms
+	Ts       int64          `json:"ts"`
+	Data     WsDepthDataDTO `json:"data"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// ResponseCommissionRateDTO represents a response to a user commission rate request.
+type ResponseCommissionRateDTO struct {
+	Code int64  `json:"code"`
+	Msg  string `json:"msg"`
+	Data struct {
+		Commission struct {
+			TakerCommissionRate utils.JSONFloat64 `json:"takerCommissionRate"`
+			MakerCommissionRate utils.JSONFloat64 `json:"makerCommissionRate"`
+		} `json:"commission"`
+	} `json:"data"`
+}
@@ -0,0 +1,36 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// PositionDTO represents a perpetual futures position.
+type PositionDTO struct {
+	Symbol       string        `json:"symbol"`       // BTC-USDT
+	PositionSide string        `json:"positionSide"` // LONG, SHORT, BOTH (one-way mode)
+	PositionAmt  utils.Decimal `json:"positionAmt"`
+	AvgPrice     utils.Decimal `json:"avgPrice"`
+}
+
+// ResponsePositionsDTO represents a response to a positions request.
+// https://bingx-api.github.io/docs-v3/#/en/Swap/Account%20Endpoints/Query%20position%20data
+type ResponsePositionsDTO struct {
+	Data []PositionDTO `json:"data"`
+}
+
+// OpenOrderDTO represents an order resting on the book. Spot orders have clientOrderID key, swap ones - clientOrderId.
+type OpenOrderDTO struct {
+	Symbol            string        `json:"symbol"`
+	OrderID           int64         `json:"orderId"`
+	Side              string        `json:"side"`
+	OrigQty           utils.Decimal `json:"origQty"`
+	Price             utils.Decimal `json:"price"`
+	ClientOrderID     string        `json:"clientOrderId"`
+	SpotClientOrderID string        `json:"clientOrderID"`
+}
+
+// ResponseOpenOrdersDTO represents a response to swap and spot open orders requests.
+// https://bingx-api.github.io/docs-v3/#/en/Swap/Trades%20Endpoints/Current%20All%20Open%20Orders
+type ResponseOpenOrdersDTO struct {
+	Data struct {
+		Orders []OpenOrderDTO `json:"orders"`
+	} `json:"data"`
+}
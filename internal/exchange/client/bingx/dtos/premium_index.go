@@ -0,0 +1,19 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// PremiumIndexDTO represents mark price and funding rate of a perpetual contract.
+type PremiumIndexDTO struct {
+	Symbol          string            `json:"symbol"`
+	MarkPrice       utils.JSONFloat64 `json:"markPrice"`
+	IndexPrice      utils.JSONFloat64 `json:"indexPrice"`
+	LastFundingRate utils.JSONFloat64 `json:"lastFundingRate"`
+	NextFundingTime int64             `json:"nextFundingTime"`
+}
+
+// ResponsePremiumIndexDTO represents a response to a premium index request.
+type ResponsePremiumIndexDTO struct {
+	Code int64             `json:"code"`
+	Msg  string            `json:"msg"`
+	Data []PremiumIndexDTO `json:"data"`
+}
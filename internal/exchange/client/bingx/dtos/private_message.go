@@ -17,6 +17,12 @@ type PrivateMessageDTO struct {
 	AC        json.RawMessage `json:"ac"` // account configuration update such as leverage https://bingx-api.github.io/docs-v3/#/en/Swap/Websocket%20Account%20Data/Configuration%20updates%20such%20as%20leverage%20and%20margin%20mode
 }
 
+// Order statuses that end the order before it's fully filled.
+const (
+	OrderStatusCanceled = "CANCELED"
+	OrderStatusExpired  = "EXPIRED"
+)
+
 // ExecutionDTO represents a single execution data transfer object (order executions).
 // https://bingx-api.github.io/docs-v3/#/en/Swap/Websocket%20Account%20Data/Order%20update%20push
 type ExecutionDTO struct {
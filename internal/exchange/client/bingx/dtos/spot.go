@@ -0,0 +1,54 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// SpotSymbolDTO represents a spot symbol specification.
+// https://bingx-api.github.io/docs-v3/#/en/Spot/Market%20Data/Spot%20trading%20symbols
+type SpotSymbolDTO struct {
+	Symbol   string        `json:"symbol"` // BTC-USDT
+	MinQty   utils.Decimal `json:"minQty"`
+	StepSize utils.Decimal `json:"stepSize"`
+	TickSize utils.Decimal `json:"tickSize"`
+	Status   int64         `json:"status"` // 1 - online
+}
+
+// ResponseSpotSymbolsDTO represents a response to a spot symbols request.
+type ResponseSpotSymbolsDTO struct {
+	Data struct {
+		Symbols []SpotSymbolDTO `json:"symbols"`
+	} `json:"data"`
+}
+
+// SpotBalanceDTO represents a balance of the asset in the spot account.
+type SpotBalanceDTO struct {
+	Asset  string        `json:"asset"`
+	Free   utils.Decimal `json:"free"`
+	Locked utils.Decimal `json:"locked"`
+}
+
+// ResponseSpotBalanceDTO represents a response to a spot balance request.
+// https://bingx-api.github.io/docs-v3/#/en/Spot/Account%20Endpoints/Query%20Assets
+type ResponseSpotBalanceDTO struct {
+	Data struct {
+		Balances []SpotBalanceDTO `json:"balances"`
+	} `json:"data"`
+}
+
+// SpotOrderDTO represents a spot order of place and query order responses.
+// Fee is negative and charged in the received asset: base coin for buy orders, USDT for sell orders.
+type SpotOrderDTO struct {
+	Symbol              string        `json:"symbol"`
+	OrderID             int64         `json:"orderId"`
+	Side                string        `json:"side"`
+	Status              string        `json:"status"`
+	ExecutedQty         utils.Decimal `json:"executedQty"`
+	CummulativeQuoteQty utils.Decimal `json:"cummulativeQuoteQty"`
+	Fee                 utils.Decimal `json:"fee"`
+	ClientOrderID       string        `json:"clientOrderID"`
+}
+
+// ResponseSpotOrderDTO represents a response to spot place and query order requests.
+// https://bingx-api.github.io/docs-v3/#/en/Spot/Trades%20Endpoints/Place%20order
+type ResponseSpotOrderDTO struct {
+	Data SpotOrderDTO `json:"data"`
+}
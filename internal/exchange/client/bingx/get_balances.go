@@ -10,14 +10,26 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
 	"github.com/lucrumx/bot/internal/models"
 )
 
-const balanceURL = "/openApi/swap/v3/user/balance"
+const (
+	balanceURL     = "/openApi/swap/v3/user/balance"
+	spotBalanceURL = "/openApi/spot/v1/account/balance"
+)
+
+// GetBalances returns the balances of the user's perpetual futures (linear) or spot account on the exchange.
+func (c *Client) GetBalances(ctx context.Context, category exchange.Category) ([]models.Balance, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotBalances(ctx)
+	default:
+		return nil, fmt.Errorf("BingX GetBalances: unsupported category %s", category)
+	}
 
-// GetBalances returns the balances of the user's account on the exchange.
-func (c *Client) GetBalances(ctx context.Context) ([]models.Balance, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
@@ -78,3 +90,23 @@ func (c *Client) mapper(raw dtos.ResponseGetBalanceDTO) []models.Balance {
 
 	return result
 }
+
+func (c *Client) getSpotBalances(ctx context.Context) ([]models.Balance, error) {
+	var raw dtos.ResponseSpotBalanceDTO
+	if _, err := c.doSignedRequest(ctx, http.MethodGet, spotBalanceURL, nil, &raw); err != nil {
+		return nil, fmt.Errorf("BingX GetBalances: spot: %w", err)
+	}
+
+	result := make([]models.Balance, 0, len(raw.Data.Balances))
+	for _, b := range raw.Data.Balances {
+		result = append(result, models.Balance{
+			ExchangeName: c.GetExchangeName(),
+			Asset:        b.Asset,
+			Free:         b.Free.Decimal,
+			Locked:       b.Locked.Decimal,
+			Total:        b.Free.Add(b.Locked.Decimal),
+		})
+	}
+
+	return result, nil
+}
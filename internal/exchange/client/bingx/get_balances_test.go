@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
 )
 
 func TestClient_GetBalances(t *testing.T) {
@@ -25,7 +26,7 @@ func TestClient_GetBalances(t *testing.T) {
 	bingx := NewClient(cfg, zerolog.Nop())
 
 	ctx := t.Context()
-	balances, err := bingx.GetBalances(ctx)
+	balances, err := bingx.GetBalances(ctx, exchange.CategoryLinear)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, balances)
@@ -0,0 +1,62 @@
+package bingx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
+)
+
+const commissionRateURL = "/openApi/swap/v2/user/commissionRate"
+
+// GetFeeSchedule retrieves the account fee rates from BingX. BingX has one rate for all perpetual symbols.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+commissionRateURL, nil)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client failed to create request: %w", err)
+	}
+
+	query := make(map[string]string)
+	timestamp := time.Now().UnixMilli()
+	queryStr := getSortedQuery(query, timestamp, false)
+	signature := computeHmac256(c.cfg, queryStr)
+	req.URL.RawQuery = fmt.Sprintf("%s&signature=%s", getSortedQuery(query, timestamp, true), signature)
+
+	req.Header.Set("X-BX-APIKEY", c.cfg.Exchange.BingX.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client http commission rate request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client unexpected http while getting commission rate, status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client failed to read commission rate response body: %w", err)
+	}
+
+	var raw dtos.ResponseCommissionRateDTO
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client failed to unmarshal commission rate response: %w", err)
+	}
+
+	if raw.Code != 0 {
+		return exchange.FeeSchedule{}, fmt.Errorf("BingX client failed to get commission rate, code: %d, msg: %s", raw.Code, raw.Msg)
+	}
+
+	return exchange.FeeSchedule{
+		Default: exchange.FeeRate{
+			Maker: float64(raw.Data.Commission.MakerCommissionRate),
+			Taker: float64(raw.Data.Commission.TakerCommissionRate),
+		},
+	}, nil
+}
@@ -0,0 +1,43 @@
+package bingx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			BingX: config.BingXConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, commissionRateURL, r.URL.Path)
+		assert.Equal(t, cfg.Exchange.BingX.APIKey, r.Header.Get("X-BX-APIKEY"))
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"commission":{"takerCommissionRate":0.0005,"makerCommissionRate":0.0002}}}`))
+	}))
+	defer server.Close()
+
+	bingx := NewClient(cfg, zerolog.Nop())
+	bingx.baseURL = server.URL
+	bingx.httpClient = server.Client()
+
+	schedule, err := bingx.GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FeeRate{Maker: 0.0002, Taker: 0.0005}, schedule.For("BTCUSDT"))
+}
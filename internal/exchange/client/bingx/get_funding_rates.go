@@ -0,0 +1,60 @@
+package bingx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
+)
+
+const premiumIndexURL = "/openApi/swap/v2/quote/premiumIndex"
+
+// GetFundingRates retrieves current funding rates of all perpetual symbols from BingX.
+// BingX premium index has no funding interval, exchange.DefaultFundingIntervalHours is used.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+premiumIndexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("BingX client failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("BingX client http premium index request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("BingX client unexpected http while getting premium index, status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("BingX client failed to read premium index response body: %w", err)
+	}
+
+	var raw dtos.ResponsePremiumIndexDTO
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("BingX client failed to unmarshal premium index response: %w", err)
+	}
+
+	if raw.Code != 0 {
+		return nil, fmt.Errorf("BingX client failed to get premium index, code: %d, msg: %s", raw.Code, raw.Msg)
+	}
+
+	result := make(map[string]exchange.FundingRate, len(raw.Data))
+	for _, dto := range raw.Data {
+		symbol := normalizeTickerName(dto.Symbol)
+		result[symbol] = exchange.FundingRate{
+			Symbol:          symbol,
+			Rate:            float64(dto.LastFundingRate),
+			IntervalHours:   exchange.DefaultFundingIntervalHours,
+			NextFundingTime: dto.NextFundingTime,
+		}
+	}
+
+	return result, nil
+}
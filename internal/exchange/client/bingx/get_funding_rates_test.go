@@ -0,0 +1,41 @@
+package bingx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, premiumIndexURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":[
+			{"symbol":"BTC-USDT","markPrice":"65000.5","indexPrice":"65001","lastFundingRate":"0.00015","nextFundingTime":1760000000000},
+			{"symbol":"TONCOIN-USDT","markPrice":"5.1","indexPrice":"5.1","lastFundingRate":"-0.0003","nextFundingTime":1760000000000}
+		]}`))
+	}))
+	defer server.Close()
+
+	bingx := NewClient(&config.Config{}, zerolog.Nop())
+	bingx.baseURL = server.URL
+	bingx.httpClient = server.Client()
+
+	rates, err := bingx.GetFundingRates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.00015,
+		IntervalHours:   exchange.DefaultFundingIntervalHours,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, -0.0003, rates["TONUSDT"].Rate)
+}
@@ -6,15 +6,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
 )
 
-// GetInstruments retrieves contract specifications for all instruments from BingX.
-func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+const spotSymbolsURL = "/openApi/spot/v1/common/symbols"
+
+// GetInstruments retrieves contract specifications for all linear instruments or USDT spot symbols from BingX.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotInstruments(ctx)
+	default:
+		return nil, fmt.Errorf("BingX GetInstruments: unsupported category %s", category)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/openApi/swap/v2/quote/contracts", nil)
 	if err != nil {
 		return nil, fmt.Errorf("BingX GetInstruments: failed to create request: %w", err)
@@ -78,3 +90,28 @@ func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instru
 
 	return result, nil
 }
+
+func (c *Client) getSpotInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+	var raw dtos.ResponseSpotSymbolsDTO
+	if _, err := c.doSignedRequest(ctx, http.MethodGet, spotSymbolsURL, nil, &raw); err != nil {
+		return nil, fmt.Errorf("BingX GetInstruments: spot: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(raw.Data.Symbols))
+	for _, dto := range raw.Data.Symbols {
+		if dto.Status != 1 || !strings.HasSuffix(dto.Symbol, "-USDT") {
+			continue
+		}
+
+		symbol := normalizeTickerName(dto.Symbol)
+		result[symbol] = exchange.Instrument{
+			Symbol:       symbol,
+			VolStep:      dto.StepSize.Decimal,
+			MinVol:       decimal.Max(dto.MinQty.Decimal, dto.StepSize.Decimal),
+			PriceStep:    dto.TickSize.Decimal,
+			ContractSize: decimal.NewFromInt(1),
+		}
+	}
+
+	return result, nil
+}
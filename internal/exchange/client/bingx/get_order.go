@@ -6,20 +6,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
 )
 
 const (
-	getOrderURL = "/openApi/swap/v2/trade/order"
+	getOrderURL     = "/openApi/swap/v2/trade/order"
+	getSpotOrderURL = "/openApi/spot/v1/trade/query"
 )
 
 // GetOrder retrieves the details of an order from the exchange using its exchange order ID and symbol. It returns an ExchangeOrder struct containing the average price, fees, and other relevant information about the order.
-func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (exchange.ExchangeOrder, error) {
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	if category == exchange.CategorySpot {
+		return c.getSpotOrder(ctx, orderID, exchangeOrderID, symbol)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+getOrderURL, nil)
 	if err != nil {
 		return exchange.ExchangeOrder{}, fmt.Errorf("BingX client failed to create get order request: %w", err)
@@ -68,3 +75,37 @@ func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderI
 		Fees:            raw.Data.Order.Commission.Decimal,
 	}, nil
 }
+
+// getSpotOrder retrieves the spot order. Fee of buy orders is charged in base coin, so it is converted
+// to USDT by the average price.
+func (c *Client) getSpotOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (exchange.ExchangeOrder, error) {
+	query := map[string]string{"symbol": denormalizeTickerName(symbol)}
+	if exchangeOrderID != "" {
+		query["orderId"] = exchangeOrderID
+	} else {
+		query["clientOrderID"] = orderID.String()
+	}
+
+	var raw dtos.ResponseSpotOrderDTO
+	if _, err := c.doSignedRequest(ctx, http.MethodGet, getSpotOrderURL, query, &raw); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("BingX GetOrder: spot: %w", err)
+	}
+
+	avgPrice := decimal.Zero
+	if raw.Data.ExecutedQty.IsPositive() {
+		avgPrice = raw.Data.CummulativeQuoteQty.Div(raw.Data.ExecutedQty.Decimal)
+	}
+
+	fees := raw.Data.Fee.Abs().Neg()
+	if raw.Data.Side == string(dtos.OrderSideBuy) {
+		fees = fees.Mul(avgPrice)
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: strconv.FormatInt(raw.Data.OrderID, 10),
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        avgPrice,
+		Fees:            fees,
+	}, nil
+}
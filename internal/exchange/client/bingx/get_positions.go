@@ -0,0 +1,96 @@
+package bingx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /openApi/swap/v2/user/positions
+// Docs: https://bingx-api.github.io/docs-v3/#/en/Swap/Account%20Endpoints/Query%20position%20data
+// Open orders: GET /openApi/swap/v2/trade/openOrders, spot: GET /openApi/spot/v1/trade/openOrders
+
+const (
+	positionsURL      = "/openApi/swap/v2/user/positions"
+	openOrdersURL     = "/openApi/swap/v2/trade/openOrders"
+	spotOpenOrdersURL = "/openApi/spot/v1/trade/openOrders"
+)
+
+// GetPositions returns open perpetual futures positions.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	var raw dtos.ResponsePositionsDTO
+	if _, err := c.doSignedRequest(ctx, http.MethodGet, positionsURL, map[string]string{}, &raw); err != nil {
+		return nil, fmt.Errorf("BingX | GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw.Data))
+	for _, p := range raw.Data {
+		if p.PositionAmt.IsZero() {
+			continue
+		}
+
+		side := models.OrderSideBuy
+		if p.PositionSide == "SHORT" || p.PositionAmt.IsNegative() {
+			side = models.OrderSideSell
+		}
+
+		positions = append(positions, exchange.Position{
+			Symbol:     normalizeTickerName(p.Symbol),
+			Side:       side,
+			Qty:        p.PositionAmt.Abs(),
+			EntryPrice: p.AvgPrice.Decimal,
+		})
+	}
+
+	return positions, nil
+}
+
+// GetOpenOrders returns orders resting on the book of the category.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	path := openOrdersURL
+	if category == exchange.CategorySpot {
+		path = spotOpenOrdersURL
+	}
+
+	var raw dtos.ResponseOpenOrdersDTO
+	if _, err := c.doSignedRequest(ctx, http.MethodGet, path, map[string]string{}, &raw); err != nil {
+		return nil, fmt.Errorf("BingX | GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw.Data.Orders))
+	for _, o := range raw.Data.Orders {
+		clientOrderID := o.ClientOrderID
+		if category == exchange.CategorySpot {
+			clientOrderID = o.SpotClientOrderID
+		}
+
+		// orders placed not by the bot have no or foreign client order id
+		orderID, err := uuid.Parse(clientOrderID)
+		if err != nil {
+			orderID = uuid.Nil
+		}
+
+		side := models.OrderSideBuy
+		if o.Side == string(models.OrderSideSell) {
+			side = models.OrderSideSell
+		}
+
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         orderID,
+			ExchangeOrderID: strconv.FormatInt(o.OrderID, 10),
+			Symbol:          normalizeTickerName(o.Symbol),
+			Side:            side,
+			Qty:             o.OrigQty.Decimal,
+			Price:           o.Price.Decimal,
+		})
+	}
+
+	return orders, nil
+}
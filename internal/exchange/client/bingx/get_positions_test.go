@@ -0,0 +1,76 @@
+package bingx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newTestClient(baseURL string) *Client {
+	c := NewClient(&config.Config{
+		Exchange: config.ExchangeConfig{
+			BingX: config.BingXConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+			},
+		},
+	}, zerolog.Nop())
+	c.baseURL = baseURL
+
+	return c
+}
+
+func Test_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, positionsURL, r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":[
+			{"symbol":"BTC-USDT","positionSide":"SHORT","positionAmt":"0.01","avgPrice":"65000.5"},
+			{"symbol":"ETH-USDT","positionSide":"LONG","positionAmt":"0","avgPrice":"0"}
+		]}`))
+	}))
+	defer server.Close()
+
+	positions, err := newTestClient(server.URL).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "0.01", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+}
+
+func Test_GetOpenOrders_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotOpenOrdersURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"orders":[
+			{"symbol":"BTC-USDT","orderId":1737394810000,"side":"BUY","origQty":"0.01","price":"64000","clientOrderID":"550e8400-e29b-41d4-a716-446655440000"},
+			{"symbol":"ETH-USDT","orderId":1737394810001,"side":"SELL","origQty":"1","price":"3000","clientOrderID":""}
+		]}}`))
+	}))
+	defer server.Close()
+
+	orders, err := newTestClient(server.URL).GetOpenOrders(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), orders[0].OrderID)
+	assert.Equal(t, "1737394810000", orders[0].ExchangeOrderID)
+	assert.Equal(t, "BTCUSDT", orders[0].Symbol)
+	assert.Equal(t, models.OrderSideBuy, orders[0].Side)
+	assert.Equal(t, "0.01", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+	assert.Equal(t, models.OrderSideSell, orders[1].Side)
+}
@@ -18,8 +18,12 @@ import (
 )
 
 // Api description: https://bingx-api.github.io/docs-v3/#/en/Swap/Trades%20Endpoints/Place%20order
+// Spot: https://bingx-api.github.io/docs-v3/#/en/Spot/Trades%20Endpoints/Place%20order
 
-const createOrderURL = "/openApi/swap/v2/trade/order"
+const (
+	createOrderURL     = "/openApi/swap/v2/trade/order"
+	createSpotOrderURL = "/openApi/spot/v1/trade/order"
+)
 
 // CreateOrder sends a market order to the exchange.
 // On success, mutates order: sets ExchangeOrderID, ExchangeName, Status, RawResponse.
@@ -28,6 +32,10 @@ func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
 		return err
 	}
 
+	if order.Market == models.OrderMarketSpot {
+		return c.submitSpotOrder(ctx, order, mapRequestDataToSpotOrderDTO(order))
+	}
+
 	timestamp := time.Now().UnixMilli()
 	return c.submitOrder(ctx, order, mapRequestDataToOrderDTO(order, timestamp), timestamp)
 }
@@ -38,6 +46,10 @@ func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
 		return err
 	}
 
+	if order.Market == models.OrderMarketSpot {
+		return c.closeSpotOrder(ctx, order)
+	}
+
 	// flip side, keep positionSide — this closes the position
 	side := dtos.OrderSideSell
 	positionSide := dtos.OrderPositionSideLong
@@ -121,6 +133,56 @@ func (c *Client) submitOrder(ctx context.Context, order *models.Order, query map
 	return nil
 }
 
+// closeSpotOrder sells the bought coins back with a market order, spot has no positions to close.
+func (c *Client) closeSpotOrder(ctx context.Context, order *models.Order) error {
+	side := dtos.OrderSideSell
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideBuy
+	}
+
+	query := map[string]string{
+		"symbol":           denormalizeTickerName(order.Symbol),
+		"side":             string(side),
+		"type":             string(dtos.OrderTypeMarket),
+		"quantity":         order.Quantity.String(),
+		"newClientOrderId": order.ID.String(),
+	}
+
+	return c.submitSpotOrder(ctx, order, query)
+}
+
+func (c *Client) submitSpotOrder(ctx context.Context, order *models.Order, query map[string]string) error {
+	var raw dtos.ResponseSpotOrderDTO
+	body, err := c.doSignedRequest(ctx, http.MethodPost, createSpotOrderURL, query, &raw)
+	if err != nil {
+		return fmt.Errorf("BingX client spot order failed: %w", err)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	if raw.Data.OrderID > 0 {
+		confirmed.ExchangeOrderID = strconv.FormatInt(raw.Data.OrderID, 10)
+	}
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusPending
+
+	// the same as futures, execution is confirmed from REST response
+	if raw.Data.Status == "FILLED" && raw.Data.ExecutedQty.IsPositive() && c.wsPrivate != nil {
+		execQty := raw.Data.ExecutedQty.Decimal
+
+		c.wsPrivate.executionChannel <- exchange.OrderExecutionEvent{
+			OrderID:         order.ID,
+			ExchangeOrderID: confirmed.ExchangeOrderID,
+			ExecPrice:       raw.Data.CummulativeQuoteQty.Div(execQty),
+			ExecQty:         execQty,
+			ExecValue:       raw.Data.CummulativeQuoteQty.Decimal,
+			OrderQty:        order.Quantity,
+		}
+	}
+
+	return nil
+}
+
 func validateBeforeCreateOrder(order *models.Order) error {
 	if order.Quantity.LessThanOrEqual(decimal.NewFromInt(0)) {
 		return fmt.Errorf("BingX client order quantity must be greater than 0")
@@ -168,6 +230,29 @@ func mapRequestDataToOrderDTO(order *models.Order, timestamp int64) map[string]s
 	return query
 }
 
+func mapRequestDataToSpotOrderDTO(order *models.Order) map[string]string {
+	side := dtos.OrderSideBuy
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideSell
+	}
+
+	query := map[string]string{
+		"symbol":           denormalizeTickerName(order.Symbol),
+		"side":             string(side),
+		"type":             string(dtos.OrderTypeMarket),
+		"quantity":         order.Quantity.String(),
+		"newClientOrderId": order.ID.String(),
+	}
+
+	if order.Type == models.OrderTypeLimit {
+		query["type"] = string(dtos.OrderTypeLimit)
+		query["price"] = order.Price.String()
+		query["timeInForce"] = string(mapTimeInForce(order.TimeInForce))
+	}
+
+	return query
+}
+
 // mapTimeInForce maps the generic models.TimeInForce to BingX-specific values.
 // Defaults to GTC when unspecified.
 func mapTimeInForce(tif models.TimeInForce) dtos.TimeInForce {
@@ -0,0 +1,62 @@
+package bingx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// doSignedRequest sends a signed request with params in the query and unmarshals the response body into out.
+// BingX returns errors as {"code": 100001, "msg": "..."} with http status 200.
+func (c *Client) doSignedRequest(ctx context.Context, method string, path string, query map[string]string, out interface{}) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := time.Now().UnixMilli()
+	queryStr := getSortedQuery(query, timestamp, false)
+	signature := computeHmac256(c.cfg, queryStr)
+	req.URL.RawQuery = fmt.Sprintf("%s&signature=%s", getSortedQuery(query, timestamp, true), signature)
+	req.Header.Set("X-BX-APIKEY", c.cfg.Exchange.BingX.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var status struct {
+		Code int64  `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if status.Code != 0 {
+		return nil, fmt.Errorf("API error, code: %d, msg: %s", status.Code, status.Msg)
+	}
+
+	if out == nil {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
@@ -1,8 +1,56 @@
 package bingx
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
+)
+
+// Api description: https://bingx-api.github.io/docs-v3/#/en/Swap/Trades%20Endpoints/Set%20Leverage
+// Margin type: https://bingx-api.github.io/docs-v3/#/en/Swap/Trades%20Endpoints/Change%20Margin%20Type
+
+const (
+	setLeverageURL   = "/openApi/swap/v2/trade/leverage"
+	setMarginTypeURL = "/openApi/swap/v2/trade/marginType"
+)
+
+// SetLeverage sets the leverage of a symbol. The account is in hedge mode, so the leverage is set
+// for both the long and the short side.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	for _, side := range []dtos.OrderPositionSide{dtos.OrderPositionSideLong, dtos.OrderPositionSideShort} {
+		query := map[string]string{
+			"symbol":   denormalizeTickerName(symbol),
+			"side":     string(side),
+			"leverage": strconv.FormatInt(leverage, 10),
+		}
+
+		if _, err := c.doSignedRequest(ctx, http.MethodPost, setLeverageURL, query, nil); err != nil {
+			return fmt.Errorf("BingX SetLeverage %s: %w", side, err)
+		}
+	}
+
+	return nil
+}
+
+// SetMarginMode sets the margin type of a symbol.
+func (c *Client) SetMarginMode(ctx context.Context, symbol string, mode exchange.MarginMode) error {
+	marginType := "CROSSED"
+	if mode == exchange.MarginModeIsolated {
+		marginType = "ISOLATED"
+	}
+
+	query := map[string]string{
+		"symbol":     denormalizeTickerName(symbol),
+		"marginType": marginType,
+	}
+
+	if _, err := c.doSignedRequest(ctx, http.MethodPost, setMarginTypeURL, query, nil); err != nil {
+		return fmt.Errorf("BingX SetMarginMode: %w", err)
+	}
 
-// SetLeverage sets the leverage for a symbol.
-func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error { //nolint
 	return nil
 }
@@ -0,0 +1,44 @@
+package bingx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func Test_SetLeverage(t *testing.T) {
+	var sides []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, setLeverageURL, r.URL.Path)
+		assert.Equal(t, "BTC-USDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "5", r.URL.Query().Get("leverage"))
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+		sides = append(sides, r.URL.Query().Get("side"))
+
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"leverage":5,"symbol":"BTC-USDT"}}`))
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(server.URL).SetLeverage(t.Context(), "BTCUSDT", 5))
+	assert.Equal(t, []string{"LONG", "SHORT"}, sides)
+}
+
+func Test_SetMarginMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, setMarginTypeURL, r.URL.Path)
+		assert.Equal(t, "BTC-USDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "ISOLATED", r.URL.Query().Get("marginType"))
+
+		_, _ = w.Write([]byte(`{"code":100001,"msg":"signature verification failed"}`))
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated)
+	assert.ErrorContains(t, err, "100001")
+}
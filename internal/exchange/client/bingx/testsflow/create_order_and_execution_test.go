@@ -34,7 +34,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 	bingx := bingx.NewClient(cfg, logger)
 
 	// --- Check balance
-	balance, err := bingx.GetBalances(ctx)
+	balance, err := bingx.GetBalances(ctx, exchange.CategoryLinear)
 	require.NoError(t, err, "Error getting balances")
 	require.NotEmpty(t, balance, "No balances found")
 
@@ -58,7 +58,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 	require.Equal(t, symbol, ticker[0].Symbol, "Ticker symbol mismatch")
 
 	// Get instrument info
-	instruments, err := bingx.GetInstruments(ctx)
+	instruments, err := bingx.GetInstruments(ctx, exchange.CategoryLinear)
 	require.NoError(t, err, "Error getting instruments")
 	require.NotEmpty(t, instruments, "No instruments found")
 	_, ok := instruments[symbol]
@@ -99,7 +99,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 	wg.Wait()
 
 	// Get order info
-	orderInfo, err := bingx.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol)
+	orderInfo, err := bingx.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol, exchange.CategoryLinear)
 	testutils.PrintStruct(t, orderInfo, "Order info")
 	require.NoError(t, err, "Error getting order info")
 	require.Greater(t, orderInfo.AvgPrice.InexactFloat64(), 0, "Avg price should be greater than 0")
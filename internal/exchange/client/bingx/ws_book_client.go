@@ -0,0 +1,303 @@
+package bingx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bingx/dtos"
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+const (
+	bookTickerTopic = "bookTicker"
+	// orderBookTopic is full snapshot of 20 levels every 100ms.
+	orderBookTopic = "depth20@100ms"
+)
+
+// wsBookClient represents a WebSocket client streaming orderbook of BingX swap symbols.
+// T is exchange.BookTop for bookTicker or exchange.OrderBookUpdate for depth.
+type wsBookClient[T any] struct {
+	Metrics    *Metrics
+	cfg        *config.Config
+	wsMu       sync.Mutex
+	backoff    *exchange.Backoff
+	topic      string
+	mapMessage func(message []byte) (T, bool)
+}
+
+func newWsBookClient(cfg *config.Config) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		topic:      bookTickerTopic,
+		mapMessage: mapBookTicker,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		topic:      orderBookTopic,
+		mapMessage: mapDepth,
+	}
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsBookClient[T]) writeMessage(wsConn *websocket.Conn, messageType int, data []byte) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteMessage(messageType, data)
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the bookTicker of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.BingX.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	if err = c.subscribe(wsConn, symbols); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads bookTicker messages from the connection and reconnects with backoff until ctx is done.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			log.Warn().Err(err).Uint64("reconnects", reconnects).Msg("Failed to reconnect to BingX book websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		log.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("Reconnected to BingX book websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) subscribe(wsCon *websocket.Conn, symbols []string) error {
+	for _, symbol := range symbols {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("failed to generate uuid: %w", err)
+		}
+
+		payload := map[string]string{
+			"id":       id.String(),
+			"reqType":  "sub",
+			"dataType": fmt.Sprintf("%s@%s", denormalizeTickerName(symbol), c.topic),
+		}
+
+		if err := c.writeJSON(wsCon, payload); err != nil {
+			return fmt.Errorf("failed to send subscription request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) readMessage(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warn().Err(err).Msg("Failed to read message from BingX book websocket")
+			return
+		}
+
+		if mt != websocket.BinaryMessage {
+			continue
+		}
+
+		message, err := decodeGzip(messageByte)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to decode gzip message from BingX websocket, book sub")
+			continue
+		}
+
+		if message == "Ping" {
+			if err = c.writeMessage(wsConn, websocket.TextMessage, []byte("Pong")); err != nil {
+				log.Warn().Err(err).Msg("Failed to send pong to BingX book websocket")
+			}
+			continue
+		}
+
+		if message == "Pong" {
+			continue
+		}
+
+		update, ok := c.mapMessage([]byte(message))
+		if !ok {
+			continue
+		}
+
+		select {
+		case outChan <- update:
+		default:
+			c.Metrics.droppedBookUpdates.Add(1)
+		}
+	}
+}
+
+func (c *wsBookClient[T]) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(time.Second * 5)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeMessage(wsConn, websocket.TextMessage, []byte("Ping")); err != nil {
+				log.Warn().Err(err).Msg("Failed to send ping to BingX book websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCnt := c.Metrics.droppedBookUpdates.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedCnt > 0 || reconnectsCnt > 0 {
+				log.Warn().Msgf("BingX %s metrics: dropped book updates=%d, reconnects=%d", c.topic, droppedCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+func mapBookTicker(message []byte) (exchange.BookTop, bool) {
+	var jsonMessage dtos.WsBookTickerMessageDTO
+	if err := json.Unmarshal(message, &jsonMessage); err != nil {
+		log.Warn().Err(err).Msgf("Failed to unmarshal message from BingX bookTicker websocket message: %s", message)
+		return exchange.BookTop{}, false
+	}
+
+	if jsonMessage.Code != 0 || jsonMessage.Data.Symbol == "" {
+		return exchange.BookTop{}, false
+	}
+
+	return exchange.BookTop{
+		Symbol:   normalizeTickerName(jsonMessage.Data.Symbol),
+		Category: exchange.CategoryLinear,
+		Ts:       jsonMessage.Data.T,
+		BidPrice: float64(jsonMessage.Data.BidPrice),
+		BidQty:   float64(jsonMessage.Data.BidQty),
+		AskPrice: float64(jsonMessage.Data.AskPrice),
+		AskQty:   float64(jsonMessage.Data.AskQty),
+	}, true
+}
+
+// mapDepth maps depth message to exchange.OrderBookUpdate. BingX depth stream pushes full snapshots,
+// symbol is taken from dataType (BTC-USDT@depth20@100ms).
+func mapDepth(message []byte) (exchange.OrderBookUpdate, bool) {
+	var jsonMessage dtos.WsDepthMessageDTO
+	if err := json.Unmarshal(message, &jsonMessage); err != nil {
+		log.Warn().Err(err).Msgf("Failed to unmarshal message from BingX depth websocket message: %s", message)
+		return exchange.OrderBookUpdate{}, false
+	}
+
+	symbol, _, found := strings.Cut(jsonMessage.DataType, "@")
+	if jsonMessage.Code != 0 || !found {
+		return exchange.OrderBookUpdate{}, false
+	}
+
+	ts := jsonMessage.Ts
+	if ts == 0 {
+		ts = time.Now().UnixMilli()
+	}
+
+	return exchange.OrderBookUpdate{
+		Symbol:   normalizeTickerName(symbol),
+		Category: exchange.CategoryLinear,
+		Ts:       ts,
+		Snapshot: true,
+		Bids:     mapPriceLevels(jsonMessage.Data.Bids),
+		Asks:     mapPriceLevels(jsonMessage.Data.Asks),
+	}, true
+}
+
+func mapPriceLevels(levels [][2]utils.JSONFloat64) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, len(levels))
+	for i, l := range levels {
+		res[i] = exchange.PriceLevel{Price: float64(l[0]), Qty: float64(l[1])}
+	}
+	return res
+}
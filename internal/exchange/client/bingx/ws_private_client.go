@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/exchange"
@@ -186,15 +187,23 @@ func (c *WsPrivateClient) handleExecutionEvent(message *dtos.PrivateMessageDTO)
 		return exchange.OrderExecutionEvent{}, false
 	}
 
+	// the rest of a canceled or expired order is not filled anymore
+	canceled := execution.OrderStatus == dtos.OrderStatusCanceled || execution.OrderStatus == dtos.OrderStatusExpired
+	leavesQty := execution.Qty.Sub(execution.FilledQty)
+	if canceled {
+		leavesQty = decimal.Zero
+	}
+
 	return exchange.OrderExecutionEvent{
 		OrderID:         execution.OrderID,
 		ExchangeOrderID: strconv.FormatInt(execution.BingXOrderID, 10),
 		ExecPrice:       execution.AvgPrice,
 		ExecQty:         execution.FilledQty,
 		ExecValue:       execution.TradeValue,
-		LeavesQty:       execution.Qty.Sub(execution.FilledQty),
+		LeavesQty:       leavesQty,
 		OrderPrice:      execution.Price,
 		OrderQty:        execution.Qty,
+		Canceled:        canceled,
 	}, true
 }
 
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
 )
 
 // API: POST /v5/order/cancel
@@ -19,9 +21,9 @@ import (
 const cancelOrderURL = "/v5/order/cancel"
 
 // CancelOrder cancels a pending limit order by orderLinkId (our internal UUID).
-func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string) error {
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string, category exchange.Category) error {
 	payload := map[string]interface{}{
-		"category":    "linear",
+		"category":    string(category),
 		"symbol":      symbol,
 		"orderLinkId": orderID.String(),
 	}
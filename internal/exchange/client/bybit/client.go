@@ -20,6 +20,8 @@ type Client struct {
 	logger       zerolog.Logger
 
 	wsManager        *exchange.WSManager
+	bookManager      *exchange.StreamManager[exchange.BookTop]
+	orderBookManager *exchange.StreamManager[exchange.OrderBookUpdate]
 	wsPrivate        *WsPrivateClient
 	wsPrivateStarted bool
 }
@@ -35,6 +37,12 @@ func NewByBitClient(cfg *config.Config, logger zerolog.Logger) *Client {
 		wsManager: exchange.NewWSManager(cfg, func(c *config.Config) exchange.WsClient {
 			return newWsClient(c)
 		}),
+		bookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.BookTop] {
+			return newWsBookClient(c)
+		}),
+		orderBookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.OrderBookUpdate] {
+			return newWsOrderBookClient(c)
+		}),
 	}
 }
 
@@ -48,6 +56,16 @@ func (c *Client) SubscribeTrades(ctx context.Context, symbols []string, category
 	return c.wsManager.SubscribeTrades(ctx, symbols, category)
 }
 
+// SubscribeBookTicker subscribes to best bid/ask (orderbook.1) of the given linear symbols and streams them to the returned channel.
+func (c *Client) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	return c.bookManager.Subscribe(ctx, symbols)
+}
+
+// SubscribeOrderBook subscribes to L2 orderbook (orderbook.50 snapshot and deltas) of the given linear symbols.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	return c.orderBookManager.Subscribe(ctx, symbols)
+}
+
 // SubscribeExecutions subscribes to order execution events and streams them to the returned channel. Implements the interface Provider
 func (c *Client) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
 	if !c.wsPrivateStarted {
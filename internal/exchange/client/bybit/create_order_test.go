@@ -128,3 +128,50 @@ func Test_CreateOrder_Integration(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, id, order.ID.String())
 }
+
+func TestMapRequestDataToOrderDTO_SpotMarket(t *testing.T) {
+	order := models.Order{
+		ID:       uuid.New(),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketSpot,
+		Quantity: decimal.RequireFromString("0.01"),
+	}
+
+	assert.NoError(t, validateBeforeCreateOrder(&order))
+
+	payload := mapRequestDataToOrderDTO(&order)
+	assert.Equal(t, "spot", payload["category"])
+	assert.Equal(t, "baseCoin", payload["marketUnit"])
+	assert.Equal(t, "0.01", payload["qty"])
+}
+
+func TestCloseOrder_Spot(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1"}}`))
+	}))
+	defer server.Close()
+
+	bybit := NewByBitClient(&config.Config{}, zerolog.Nop())
+	bybit.baseURL = server.URL
+	bybit.http = server.Client()
+
+	order := models.Order{
+		ID:       uuid.New(),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketSpot,
+		Quantity: decimal.RequireFromString("0.01"),
+	}
+
+	assert.NoError(t, bybit.CloseOrder(t.Context(), &order))
+
+	assert.Equal(t, "spot", payload["category"])
+	assert.Equal(t, string(dtos.OrderSideSell), payload["side"])
+	assert.Equal(t, "baseCoin", payload["marketUnit"])
+	assert.NotContains(t, payload, "reduceOnly")
+}
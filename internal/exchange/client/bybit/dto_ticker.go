@@ -17,4 +17,7 @@ type TickerDTO struct {
 	Volume5m          string `json:"volume5m"`
 	Volume15m         string `json:"volume15m"`
 	Turnover24h       string `json:"turnover24h"`
+	FundingRate       string `json:"fundingRate"`
+	NextFundingTime   string `json:"nextFundingTime"`
+	FundingInterval   string `json:"fundingIntervalHour"`
 }
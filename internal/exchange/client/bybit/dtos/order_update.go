@@ -0,0 +1,27 @@
+package dtos
+
+import "github.com/shopspring/decimal"
+
+// Order statuses of the order topic that end the order before it's fully filled.
+const (
+	OrderStatusCancelled               = "Cancelled"
+	OrderStatusPartiallyFilledCanceled = "PartiallyFilledCanceled"
+	OrderStatusDeactivated             = "Deactivated"
+	OrderStatusRejected                = "Rejected"
+)
+
+// OrderUpdateDTO represent data for order topic
+type OrderUpdateDTO struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"` // customer order id, not an uuid for orders placed outside the bot
+	OrderStatus string `json:"orderStatus"`
+	// Cumulative executed quantity, value and average price of the order
+	CumExecQty   decimal.Decimal `json:"cumExecQty"`
+	CumExecValue decimal.Decimal `json:"cumExecValue"`
+	AvgPrice     decimal.Decimal `json:"avgPrice"`
+	LeavesQty    decimal.Decimal `json:"leavesQty"`
+	Price        decimal.Decimal `json:"price"`
+	Qty          decimal.Decimal `json:"qty"`
+}
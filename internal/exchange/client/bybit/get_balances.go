@@ -11,6 +11,7 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/exchange/client/bybit/dtos"
 	"github.com/lucrumx/bot/internal/models"
 )
@@ -19,7 +20,8 @@ const balanceURL = "/v5/account/wallet-balance"
 const accountType = "UNIFIED" // единый торговый аккаунт
 
 // GetBalances returns the balances of the user's account on the exchange.
-func (c *Client) GetBalances(ctx context.Context) ([]models.Balance, error) {
+// Spot and linear share the unified trading account, so the balances are the same for both categories.
+func (c *Client) GetBalances(ctx context.Context, _ exchange.Category) ([]models.Balance, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
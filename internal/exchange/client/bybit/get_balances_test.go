@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
 )
 
 func TestClient_GetBalances(t *testing.T) {
@@ -23,7 +24,7 @@ func TestClient_GetBalances(t *testing.T) {
 	}
 
 	client := NewByBitClient(&cfg, zerolog.Nop())
-	balances, err := client.GetBalances(t.Context())
+	balances, err := client.GetBalances(t.Context(), exchange.CategoryLinear)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, balances)
@@ -0,0 +1,84 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+const feeRateURL = "/v5/account/fee-rate"
+
+// Base (non-VIP) derivatives fee rates, used for symbols missing in the fee-rate response.
+const (
+	defaultTakerFeeRate = 0.00055
+	defaultMakerFeeRate = 0.0002
+)
+
+// GetFeeSchedule retrieves the account fee rates of all linear symbols from ByBit.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+feeRateURL, nil)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: failed to create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Set("category", "linear")
+	req.URL.RawQuery = q.Encode()
+
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	recvWindow := "5000"
+	payload := timestamp + c.cfg.Exchange.ByBit.APIKey + recvWindow + req.URL.RawQuery
+
+	c.setHeader(req, sign(c.cfg.Exchange.ByBit.APISecret, payload), timestamp, recvWindow)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: failed to read body: %w", err)
+	}
+
+	var raw response[struct {
+		List []struct {
+			Symbol       string `json:"symbol"`
+			TakerFeeRate string `json:"takerFeeRate"`
+			MakerFeeRate string `json:"makerFeeRate"`
+		} `json:"list"`
+	}]
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: failed to unmarshal: %w", err)
+	}
+
+	if raw.RetCode != 0 {
+		return exchange.FeeSchedule{}, &apiError{Code: raw.RetCode, Message: raw.RetMsg}
+	}
+
+	schedule := exchange.FeeSchedule{
+		Default: exchange.FeeRate{Maker: defaultMakerFeeRate, Taker: defaultTakerFeeRate},
+		Symbols: make(map[string]exchange.FeeRate, len(raw.Result.List)),
+	}
+	for _, item := range raw.Result.List {
+		taker, err := strconv.ParseFloat(item.TakerFeeRate, 64)
+		if err != nil {
+			return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: invalid takerFeeRate for %s: %w", item.Symbol, err)
+		}
+		maker, err := strconv.ParseFloat(item.MakerFeeRate, 64)
+		if err != nil {
+			return exchange.FeeSchedule{}, fmt.Errorf("ByBit GetFeeSchedule: invalid makerFeeRate for %s: %w", item.Symbol, err)
+		}
+		schedule.Symbols[item.Symbol] = exchange.FeeRate{Maker: maker, Taker: taker}
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,44 @@
+package bybit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, feeRateURL, r.URL.Path)
+		assert.Equal(t, "linear", r.URL.Query().Get("category"))
+		assert.Equal(t, "some-api-key", r.Header.Get("X-BAPI-API-KEY"))
+		assert.NotEmpty(t, r.Header.Get("X-BAPI-SIGN"))
+
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[
+			{"symbol":"BTCUSDT","takerFeeRate":"0.0004","makerFeeRate":"0.0001"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			ByBit: config.ByBitConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+				BaseURL:   server.URL,
+			},
+		},
+	}
+
+	schedule, err := NewByBitClient(cfg, zerolog.Nop()).GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FeeRate{Maker: 0.0001, Taker: 0.0004}, schedule.For("BTCUSDT"))
+	assert.Equal(t, exchange.FeeRate{Maker: defaultMakerFeeRate, Taker: defaultTakerFeeRate}, schedule.For("ETHUSDT"))
+}
@@ -0,0 +1,73 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// GetFundingRates retrieves current funding rates of all linear symbols from ByBit tickers.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v5/market/tickers", nil)
+	if err != nil {
+		return nil, fmt.Errorf("ByBit GetFundingRates: failed to create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Set("category", string(exchange.CategoryLinear))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ByBit GetFundingRates: http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ByBit GetFundingRates: failed to read body: %w", err)
+	}
+
+	var raw response[struct {
+		List []TickerDTO `json:"list"`
+	}]
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("ByBit GetFundingRates: failed to unmarshal: %w", err)
+	}
+
+	if raw.RetCode != 0 {
+		return nil, &apiError{Code: raw.RetCode, Message: raw.RetMsg}
+	}
+
+	result := make(map[string]exchange.FundingRate, len(raw.Result.List))
+	for _, dto := range raw.Result.List {
+		if dto.FundingRate == "" {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(dto.FundingRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ByBit GetFundingRates: invalid fundingRate for %s: %w", dto.Symbol, err)
+		}
+		nextFundingTime, _ := strconv.ParseInt(dto.NextFundingTime, 10, 64)
+
+		interval, err := strconv.ParseInt(dto.FundingInterval, 10, 64)
+		if err != nil || interval <= 0 {
+			interval = exchange.DefaultFundingIntervalHours
+		}
+
+		result[dto.Symbol] = exchange.FundingRate{
+			Symbol:          dto.Symbol,
+			Rate:            rate,
+			IntervalHours:   interval,
+			NextFundingTime: nextFundingTime,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package bybit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v5/market/tickers", r.URL.Path)
+		assert.Equal(t, "linear", r.URL.Query().Get("category"))
+
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[
+			{"symbol":"BTCUSDT","fundingRate":"0.0001","nextFundingTime":"1760000000000","fundingIntervalHour":"8"},
+			{"symbol":"ETHUSDT","fundingRate":"-0.0002","nextFundingTime":"1760000000000","fundingIntervalHour":"4"},
+			{"symbol":"BTCUSDT-26DEC25","fundingRate":"","nextFundingTime":"0"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			ByBit: config.ByBitConfig{BaseURL: server.URL},
+		},
+	}
+
+	rates, err := NewByBitClient(cfg, zerolog.Nop()).GetFundingRates(t.Context())
+	require.NoError(t, err)
+
+	assert.Len(t, rates, 2)
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.0001,
+		IntervalHours:   8,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, int64(4), rates["ETHUSDT"].IntervalHours)
+	assert.Equal(t, -0.0002, rates["ETHUSDT"].Rate)
+}
@@ -12,15 +12,19 @@ import (
 	"github.com/lucrumx/bot/internal/exchange"
 )
 
-// GetInstruments retrieves contract specifications for all linear instruments from ByBit.
-func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+// GetInstruments retrieves specifications for all linear (perpetual) or spot instruments from ByBit.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	if category != exchange.CategoryLinear && category != exchange.CategorySpot {
+		return nil, fmt.Errorf("ByBit GetInstruments: unsupported category %s", category)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v5/market/instruments-info", nil)
 	if err != nil {
 		return nil, fmt.Errorf("ByBit GetInstruments: failed to create request: %w", err)
 	}
 
 	q := req.URL.Query()
-	q.Set("category", "linear")
+	q.Set("category", string(category))
 	q.Set("limit", "1000")
 	// Explicit status filter: the v5 default for linear is also "Trading", but make it explicit so
 	// behaviour doesn't change silently if Bybit ever revises the default. Status enum:
@@ -44,8 +48,9 @@ func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instru
 			Symbol        string `json:"symbol"`
 			Status        string `json:"status"` // PreLaunch / Trading / Delivering / Closed
 			LotSizeFilter struct {
-				QtyStep     string `json:"qtyStep"`
-				MinOrderQty string `json:"minOrderQty"`
+				QtyStep       string `json:"qtyStep"`       // linear only
+				BasePrecision string `json:"basePrecision"` // spot only, qty step in base coin
+				MinOrderQty   string `json:"minOrderQty"`
 			} `json:"lotSizeFilter"`
 			PriceFilter struct {
 				TickSize string `json:"tickSize"`
@@ -71,7 +76,11 @@ func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instru
 			skippedStatus++
 			continue
 		}
-		volStep, err := decimal.NewFromString(item.LotSizeFilter.QtyStep)
+		qtyStep := item.LotSizeFilter.QtyStep
+		if category == exchange.CategorySpot {
+			qtyStep = item.LotSizeFilter.BasePrecision
+		}
+		volStep, err := decimal.NewFromString(qtyStep)
 		if err != nil {
 			return nil, fmt.Errorf("ByBit GetInstruments: invalid qtyStep for %s: %w", item.Symbol, err)
 		}
@@ -93,6 +102,7 @@ func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instru
 	}
 
 	c.logger.Info().
+		Str("category", string(category)).
 		Int("kept", len(result)).
 		Int("skipped_non_trading", skippedStatus).
 		Msg("ByBit instruments loaded")
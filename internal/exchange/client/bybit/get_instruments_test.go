@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/utils/testutils"
 )
 
@@ -20,7 +21,7 @@ func Test_GetInstruments_Integration(t *testing.T) {
 
 	bybit := NewByBitClient(cfg, logger)
 
-	intruments, err := bybit.GetInstruments(t.Context())
+	intruments, err := bybit.GetInstruments(t.Context(), exchange.CategoryLinear)
 	require.NoError(t, err, "Error getting instruments")
 	require.NotEmpty(t, intruments, "No instruments found")
 	require.Contains(t, intruments, "TONUSDT", "Expected TONUSDT in instruments")
@@ -0,0 +1,65 @@
+package bybit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// API: GET /v5/order/realtime
+// Docs: https://bybit-exchange.github.io/docs/v5/order/open-order
+
+type openOrdersDTO struct {
+	List []struct {
+		OrderID     string `json:"orderId"`
+		OrderLinkID string `json:"orderLinkId"`
+		Symbol      string `json:"symbol"`
+		Side        string `json:"side"`
+		Qty         string `json:"qty"`
+		Price       string `json:"price"`
+	} `json:"list"`
+}
+
+// GetOpenOrders returns orders resting on the book of the category.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	query := url.Values{
+		"category": {string(category)},
+		"openOnly": {"0"},
+		"limit":    {"50"},
+	}
+	if category == exchange.CategoryLinear {
+		query.Set("settleCoin", "USDT")
+	}
+
+	raw, err := getSigned[openOrdersDTO](ctx, c, getOrderURL, query)
+	if err != nil {
+		return nil, fmt.Errorf("ByBit | GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw.List))
+	for _, o := range raw.List {
+		qty, _ := decimal.NewFromString(o.Qty)
+		price, _ := decimal.NewFromString(o.Price)
+		// orders placed not by the bot have no or foreign orderLinkId
+		orderID, err := uuid.Parse(o.OrderLinkID)
+		if err != nil {
+			orderID = uuid.Nil
+		}
+
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         orderID,
+			ExchangeOrderID: o.OrderID,
+			Symbol:          o.Symbol,
+			Side:            mapOrderSide(o.Side),
+			Qty:             qty,
+			Price:           price,
+		})
+	}
+
+	return orders, nil
+}
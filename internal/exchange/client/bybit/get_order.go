@@ -13,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bybit/dtos"
 )
 
 const getOrderURL = "/v5/order/realtime"
@@ -23,6 +24,7 @@ type getOrderResponseDTO struct {
 	Result  struct {
 		List []struct {
 			OrderID    string `json:"orderId"`
+			Side       string `json:"side"`
 			AvgPrice   string `json:"avgPrice"`
 			CumExecFee string `json:"cumExecFee"`
 		} `json:"list"`
@@ -30,14 +32,14 @@ type getOrderResponseDTO struct {
 }
 
 // GetOrder retrieves order details from ByBit by orderLinkId (our internal order UUID).
-func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string) (exchange.ExchangeOrder, error) {
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+getOrderURL, nil)
 	if err != nil {
 		return exchange.ExchangeOrder{}, fmt.Errorf("ByBit | GetOrder: failed to create request: %w", err)
 	}
 
 	query := req.URL.Query()
-	query.Set("category", "linear")
+	query.Set("category", string(category))
 	query.Set("symbol", symbol)
 	query.Set("orderLinkId", orderID.String())
 	req.URL.RawQuery = query.Encode()
@@ -81,6 +83,11 @@ func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, _ string, symb
 	avgPrice, _ := decimal.NewFromString(order.AvgPrice)
 	fees, _ := decimal.NewFromString(order.CumExecFee)
 
+	// spot buy fee is charged in the received base coin, convert it to quote
+	if category == exchange.CategorySpot && order.Side == string(dtos.OrderSideBuy) {
+		fees = fees.Mul(avgPrice)
+	}
+
 	return exchange.ExchangeOrder{
 		OrderID:         orderID,
 		ExchangeOrderID: order.OrderID,
@@ -0,0 +1,70 @@
+package bybit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/bybit/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /v5/position/list
+// Docs: https://bybit-exchange.github.io/docs/v5/position
+
+const positionListURL = "/v5/position/list"
+
+type positionListDTO struct {
+	List []struct {
+		Symbol   string `json:"symbol"`
+		Side     string `json:"side"` // Buy, Sell, empty for a closed position
+		Size     string `json:"size"`
+		AvgPrice string `json:"avgPrice"`
+	} `json:"list"`
+}
+
+// GetPositions returns open linear USDT positions.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	query := url.Values{
+		"category":   {string(exchange.CategoryLinear)},
+		"settleCoin": {"USDT"},
+		"limit":      {"200"},
+	}
+
+	raw, err := getSigned[positionListDTO](ctx, c, positionListURL, query)
+	if err != nil {
+		return nil, fmt.Errorf("ByBit | GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw.List))
+	for _, p := range raw.List {
+		size, err := parseDecimal(p.Size)
+		if err != nil {
+			return nil, fmt.Errorf("ByBit | GetPositions: failed to parse size %q: %w", p.Size, err)
+		}
+		if !size.IsPositive() {
+			continue
+		}
+
+		entryPrice, _ := decimal.NewFromString(p.AvgPrice)
+
+		positions = append(positions, exchange.Position{
+			Symbol:     p.Symbol,
+			Side:       mapOrderSide(p.Side),
+			Qty:        size,
+			EntryPrice: entryPrice,
+		})
+	}
+
+	return positions, nil
+}
+
+func mapOrderSide(side string) models.OrderSide {
+	if side == string(dtos.OrderSideSell) {
+		return models.OrderSideSell
+	}
+	return models.OrderSideBuy
+}
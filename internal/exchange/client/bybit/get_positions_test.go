@@ -0,0 +1,84 @@
+package bybit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newTestClient(baseURL string) *Client {
+	return NewByBitClient(&config.Config{
+		Exchange: config.ExchangeConfig{
+			ByBit: config.ByBitConfig{
+				APIKey:    "some-api-key",
+				APISecret: "some-api-secret",
+				BaseURL:   baseURL,
+			},
+		},
+	}, zerolog.Nop())
+}
+
+func TestClient_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, positionListURL, r.URL.Path)
+		assert.Equal(t, "linear", r.URL.Query().Get("category"))
+		assert.Equal(t, "USDT", r.URL.Query().Get("settleCoin"))
+		assert.NotEmpty(t, r.Header.Get("X-BAPI-SIGN"))
+
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[
+			{"symbol":"BTCUSDT","side":"Sell","size":"0.01","avgPrice":"65000.5"},
+			{"symbol":"ETHUSDT","side":"","size":"0","avgPrice":""}
+		]}}`))
+	}))
+	defer server.Close()
+
+	positions, err := newTestClient(server.URL).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "0.01", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	orderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, getOrderURL, r.URL.Path)
+		assert.Equal(t, "spot", r.URL.Query().Get("category"))
+		assert.Empty(t, r.URL.Query().Get("settleCoin"))
+
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[
+			{"orderId":"1","orderLinkId":"550e8400-e29b-41d4-a716-446655440000","symbol":"BTCUSDT","side":"Buy","qty":"0.01","price":"64000"},
+			{"orderId":"2","orderLinkId":"manual-order","symbol":"ETHUSDT","side":"Sell","qty":"1","price":"3000"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	orders, err := newTestClient(server.URL).GetOpenOrders(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, exchange.OpenOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: "1",
+		Symbol:          "BTCUSDT",
+		Side:            models.OrderSideBuy,
+		Qty:             orders[0].Qty,
+		Price:           orders[0].Price,
+	}, orders[0])
+	assert.Equal(t, "0.01", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+	assert.Equal(t, models.OrderSideSell, orders[1].Side)
+}
@@ -35,6 +35,7 @@ func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
 }
 
 // CloseOrder closes an existing position by placing a reduce-only order in the opposite direction.
+// Spot has no positions, so a spot leg is closed by a plain market order of the opposite side.
 func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
 	// flip side to close the position
 	side := dtos.OrderSideSell
@@ -57,6 +58,11 @@ func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
 		"reduceOnly":  true,
 	}
 
+	if order.Market == models.OrderMarketSpot {
+		delete(payload, "reduceOnly")
+		payload["marketUnit"] = "baseCoin"
+	}
+
 	return c.submitOrder(ctx, order, payload)
 }
 
@@ -121,8 +127,8 @@ func (c *Client) submitOrder(ctx context.Context, order *models.Order, payload m
 }
 
 func validateBeforeCreateOrder(order *models.Order) error {
-	if order.Market != models.OrderMarketLinear {
-		return fmt.Errorf("ByBit client linear (category) market")
+	if order.Market != models.OrderMarketLinear && order.Market != models.OrderMarketSpot {
+		return fmt.Errorf("ByBit client supports only linear and spot (category) markets")
 	}
 
 	if order.Quantity.LessThanOrEqual(decimal.NewFromInt(0)) {
@@ -166,6 +172,11 @@ func mapRequestDataToOrderDTO(order *models.Order) map[string]interface{} {
 		//"isLeverage":  0, // если спот и ордер за счет маржи (заемных средств) - должен быть = 1
 	}
 
+	// spot market buy qty is in quote coin by default, order qty is always in base coin
+	if order.Market == models.OrderMarketSpot && order.Type == models.OrderTypeMarket {
+		payload["marketUnit"] = "baseCoin"
+	}
+
 	if order.Type == models.OrderTypeLimit {
 		payload["price"] = order.Price.String()
 		payload["timeInForce"] = string(mapTimeInForce(order.TimeInForce))
@@ -0,0 +1,100 @@
+package bybit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// getSigned sends the signed GET request and returns the result of the response.
+func getSigned[T any](ctx context.Context, c *Client, path string, query url.Values) (T, error) {
+	var raw response[T]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return raw.Result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	recvWindow := "5000"
+	payload := timestamp + c.cfg.Exchange.ByBit.APIKey + recvWindow + req.URL.RawQuery
+	c.setHeader(req, sign(c.cfg.Exchange.ByBit.APISecret, payload), timestamp, recvWindow)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return raw.Result, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return raw.Result, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return raw.Result, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return raw.Result, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if raw.RetCode != 0 {
+		return raw.Result, &apiError{Code: raw.RetCode, Message: raw.RetMsg}
+	}
+
+	return raw.Result, nil
+}
+
+// postSigned sends the signed POST request with the JSON payload and returns the result of the response.
+func postSigned[T any](ctx context.Context, c *Client, path string, payload any) (T, error) {
+	var raw response[T]
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return raw.Result, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return raw.Result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	recvWindow := "5000"
+	payloadStr := timestamp + c.cfg.Exchange.ByBit.APIKey + recvWindow + string(bodyBytes)
+	c.setHeader(req, sign(c.cfg.Exchange.ByBit.APISecret, payloadStr), timestamp, recvWindow)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return raw.Result, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return raw.Result, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return raw.Result, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return raw.Result, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if raw.RetCode != 0 {
+		return raw.Result, &apiError{Code: raw.RetCode, Message: raw.RetMsg}
+	}
+
+	return raw.Result, nil
+}
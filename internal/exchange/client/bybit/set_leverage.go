@@ -1,8 +1,63 @@
 package bybit
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Api description: https://bybit-exchange.github.io/docs/v5/position/leverage
+// Margin mode: https://bybit-exchange.github.io/docs/v5/account/set-margin-mode
+
+const (
+	setLeverageURL   = "/v5/position/set-leverage"
+	setMarginModeURL = "/v5/account/set-margin-mode"
+
+	// retCodeLeverageNotModified is returned when the leverage is already set.
+	retCodeLeverageNotModified = 110043
+)
+
+// SetLeverage sets the buy and sell leverage of a linear symbol.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	lever := strconv.FormatInt(leverage, 10)
+	payload := map[string]string{
+		"category":     string(exchange.CategoryLinear),
+		"symbol":       symbol,
+		"buyLeverage":  lever,
+		"sellLeverage": lever,
+	}
+
+	_, err := postSigned[struct{}](ctx, c, setLeverageURL, payload)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Code == retCodeLeverageNotModified {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ByBit SetLeverage: %w", err)
+	}
+
+	return nil
+}
+
+// SetMarginMode does nothing: the unified account has a single margin mode for all symbols, it is set
+// only by SetAccountMarginMode.
+func (c *Client) SetMarginMode(_ context.Context, _ string, _ exchange.MarginMode) error {
+	return nil
+}
+
+// SetAccountMarginMode sets the margin mode of the unified account, it applies to all symbols.
+func (c *Client) SetAccountMarginMode(ctx context.Context, mode exchange.MarginMode) error {
+	marginMode := "REGULAR_MARGIN"
+	if mode == exchange.MarginModeIsolated {
+		marginMode = "ISOLATED_MARGIN"
+	}
+
+	if _, err := postSigned[struct{}](ctx, c, setMarginModeURL, map[string]string{"setMarginMode": marginMode}); err != nil {
+		return fmt.Errorf("ByBit SetAccountMarginMode: %w", err)
+	}
 
-// SetLeverage sets the leverage for a symbol.
-func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error { //nolint
 	return nil
 }
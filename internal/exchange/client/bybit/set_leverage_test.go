@@ -0,0 +1,54 @@
+package bybit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_SetLeverage(t *testing.T) {
+	retCode := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, setLeverageURL, r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("X-BAPI-SIGN"))
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"category": "linear", "symbol": "BTCUSDT", "buyLeverage": "5", "sellLeverage": "5"}, payload)
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"retCode": retCode, "retMsg": "", "result": map[string]any{}})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+
+	// leverage not modified is not an error
+	retCode = retCodeLeverageNotModified
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+
+	retCode = 10001
+	assert.ErrorContains(t, c.SetLeverage(t.Context(), "BTCUSDT", 5), "10001")
+}
+
+func TestClient_SetAccountMarginMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, setMarginModeURL, r.URL.Path)
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"setMarginMode": "ISOLATED_MARGIN"}, payload)
+
+		_, _ = w.Write([]byte(`{"retCode":0,"retMsg":"Request accepted","result":{"reasons":[]}}`))
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(server.URL).SetAccountMarginMode(t.Context(), exchange.MarginModeIsolated))
+}
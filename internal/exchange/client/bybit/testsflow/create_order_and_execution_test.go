@@ -35,7 +35,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 	bybit := bybit.NewByBitClient(cfg, logger)
 
 	// --- Check balance
-	balance, err := bybit.GetBalances(ctx)
+	balance, err := bybit.GetBalances(ctx, exchange.CategoryLinear)
 	require.NoError(t, err, "Error getting balances")
 	require.NotEmpty(t, balance, "No balances found")
 
@@ -59,7 +59,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 	// testutils.PrintStruct(t, ticker, "Ticker")
 
 	// Get instrument info
-	instruments, err := bybit.GetInstruments(ctx)
+	instruments, err := bybit.GetInstruments(ctx, exchange.CategoryLinear)
 	require.NoError(t, err, "Error getting instruments")
 	require.NotEmpty(t, instruments, "No instruments found")
 	instrument, ok := instruments[symbol]
@@ -101,7 +101,7 @@ func Test_CreateOrderAndExecution_Integration(t *testing.T) {
 
 	wg.Wait()
 
-	orderInfo, err := bybit.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol)
+	orderInfo, err := bybit.GetOrder(ctx, order.ID, order.ExchangeOrderID, order.Symbol, exchange.CategoryLinear)
 	testutils.PrintStruct(t, orderInfo, "Order info")
 	require.NoError(t, err, "Error getting order info")
 	require.Greater(t, orderInfo.AvgPrice.InexactFloat64(), float64(0), "Avg price should be greater than 0")
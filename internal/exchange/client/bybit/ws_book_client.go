@@ -0,0 +1,362 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+
+	"github.com/lucrumx/bot/internal/config"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+const (
+	// bookTickerDepth is the orderbook depth used for best bid/ask stream (orderbook.1).
+	bookTickerDepth = 1
+	// orderBookDepth is the orderbook depth used for L2 stream (orderbook.50).
+	orderBookDepth = 50
+)
+
+// bookMapperFactory creates a mapper of orderbook messages for one connection (mapper may keep state between messages).
+type bookMapperFactory[T any] func() func(message wsOrderBookMessageDTO) (T, bool)
+
+// wsBookClient represents a WebSocket client streaming orderbook of ByBit linear symbols.
+// T is exchange.BookTop for orderbook.1 or exchange.OrderBookUpdate for L2 orderbook.
+type wsBookClient[T any] struct {
+	url       string
+	Metrics   *Metrics
+	wsMu      sync.Mutex // for protects wsConn writes
+	backoff   *exchange.Backoff
+	depth     int
+	newMapper bookMapperFactory[T]
+	// sequenced - the stream is deltas of the L2 book: updates are never dropped and a gap in the update
+	// sequence resubscribes the symbol for a new snapshot
+	sequenced bool
+}
+
+func newWsBookClient(cfg *config.Config) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		url:       cfg.Exchange.ByBit.WsBaseURL + linearPublicWsURL,
+		Metrics:   &Metrics{},
+		backoff:   exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		depth:     bookTickerDepth,
+		newMapper: newBookTopMapper,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		url:     cfg.Exchange.ByBit.WsBaseURL + linearPublicWsURL,
+		Metrics: &Metrics{},
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		depth:   orderBookDepth,
+		newMapper: func() func(message wsOrderBookMessageDTO) (exchange.OrderBookUpdate, bool) {
+			return mapOrderBookUpdate
+		},
+		sequenced: true,
+	}
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetric(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the orderbook of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	if err := c.subscribeBatch(wsConn, symbols); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads orderbook messages from the connection and reconnects with backoff until ctx is done.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPong(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessages(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.Reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			log.Warn().Err(err).Uint64("reconnects", reconnects).Msg("Failed to reconnect to Bybit orderbook websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		log.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("Reconnected to Bybit orderbook websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+// subscribeBatch sends orderbook subscription requests for a batch of symbols to the WebSocket connection.
+func (c *wsBookClient[T]) subscribeBatch(wsConn *websocket.Conn, symbols []string) error {
+	for i := 0; i < len(symbols); i += batchSize {
+		end := i + batchSize
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		batch := symbols[i:end]
+		args := make([]string, len(batch))
+		for j, symbol := range batch {
+			args[j] = fmt.Sprintf("orderbook.%d.%s", c.depth, symbol)
+		}
+
+		subReq := map[string]interface{}{
+			"op":     "subscribe",
+			"req_id": fmt.Sprintf("sub-ob-%d-%d", time.Now().UnixNano(), i),
+			"args":   args,
+		}
+
+		if err := c.writeJSON(wsConn, subReq); err != nil {
+			return fmt.Errorf("failed to send subscription request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resubscribe re-subscribes the orderbook of the symbol, ByBit sends a new snapshot on subscription.
+func (c *wsBookClient[T]) resubscribe(wsConn *websocket.Conn, symbol string) error {
+	unsubReq := map[string]interface{}{
+		"op":     "unsubscribe",
+		"req_id": fmt.Sprintf("unsub-ob-%d", time.Now().UnixNano()),
+		"args":   []string{fmt.Sprintf("orderbook.%d.%s", c.depth, symbol)},
+	}
+	if err := c.writeJSON(wsConn, unsubReq); err != nil {
+		return fmt.Errorf("failed to send unsubscription request: %w", err)
+	}
+
+	return c.subscribeBatch(wsConn, []string{symbol})
+}
+
+func (c *wsBookClient[T]) pingPong(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(pingPongInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeJSON(wsConn, map[string]interface{}{"op": "ping"}); err != nil {
+				log.Warn().Err(err).Msg("Failed to send ping pong to Bybit orderbook websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) readMessages(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	mapMessage := c.newMapper()
+	lastUpdateIDs := make(map[string]int64) // symbol -> update id of the last message, sequenced streams only
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		_, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Warn().Err(err).Msg("Failed to read message from Bybit orderbook websocket, reconnecting")
+			return
+		}
+
+		var message wsOrderBookMessageDTO
+		if err := json.Unmarshal(messageByte, &message); err != nil {
+			log.Warn().Err(err).Msg("Failed to unmarshal message from Bybit orderbook websocket")
+			continue
+		}
+
+		if message.Topic == "" {
+			continue
+		}
+
+		// the delta after the gap still goes out, it unsyncs the book until the new snapshot
+		if c.sequenced && !inSequence(lastUpdateIDs, message) {
+			c.Metrics.BookResyncs.Add(1)
+			log.Warn().Str("symbol", message.Data.Symbol).Msg("Gap in Bybit orderbook updates, resubscribing for a snapshot")
+			if err := c.resubscribe(wsConn, message.Data.Symbol); err != nil {
+				log.Warn().Err(err).Msg("Failed to resubscribe to Bybit orderbook, reconnecting")
+				return
+			}
+		}
+
+		update, ok := mapMessage(message)
+		if !ok {
+			continue
+		}
+
+		if c.sequenced {
+			select {
+			case outChan <- update:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		select {
+		case outChan <- update:
+		default:
+			c.Metrics.DroppedBookUpdates.Add(1)
+		}
+	}
+}
+
+// inSequence tracks the update ids of the symbols and returns false for a delta that doesn't follow the
+// previous message of its symbol. Deltas after a gap are in sequence again after the next snapshot.
+func inSequence(lastUpdateIDs map[string]int64, message wsOrderBookMessageDTO) bool {
+	symbol := message.Data.Symbol
+	if message.Typ == "snapshot" {
+		lastUpdateIDs[symbol] = message.Data.UpdateID
+		return true
+	}
+
+	last, ok := lastUpdateIDs[symbol]
+	if !ok {
+		return true // no snapshot yet or waiting for one after a gap
+	}
+	if message.Data.UpdateID != last+1 {
+		delete(lastUpdateIDs, symbol)
+		return false
+	}
+
+	lastUpdateIDs[symbol] = message.Data.UpdateID
+	return true
+}
+
+// newBookTopMapper creates a mapper of orderbook.1 messages. orderbook.1 deltas contain only the changed side,
+// so the last known top is kept per symbol.
+func newBookTopMapper() func(message wsOrderBookMessageDTO) (exchange.BookTop, bool) {
+	tops := make(map[string]exchange.BookTop)
+
+	return func(message wsOrderBookMessageDTO) (exchange.BookTop, bool) {
+		top, ok := mergeBookTop(tops[message.Data.Symbol], message)
+		if !ok {
+			return top, false
+		}
+		tops[message.Data.Symbol] = top
+		return top, true
+	}
+}
+
+// mapOrderBookUpdate maps L2 orderbook snapshot or delta to exchange.OrderBookUpdate.
+func mapOrderBookUpdate(message wsOrderBookMessageDTO) (exchange.OrderBookUpdate, bool) {
+	return exchange.OrderBookUpdate{
+		Symbol:   message.Data.Symbol,
+		Category: exchange.CategoryLinear,
+		Ts:       message.Ts,
+		Snapshot: message.Typ == "snapshot",
+		UpdateID: message.Data.UpdateID,
+		Bids:     mapPriceLevels(message.Data.Bids),
+		Asks:     mapPriceLevels(message.Data.Asks),
+	}, true
+}
+
+func mapPriceLevels(levels [][2]utils.JSONFloat64) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, len(levels))
+	for i, l := range levels {
+		res[i] = exchange.PriceLevel{Price: float64(l[0]), Qty: float64(l[1])}
+	}
+	return res
+}
+
+// mergeBookTop applies orderbook.1 snapshot or delta to the previous top of the book.
+// Returns false if the book is not complete yet (no bid or no ask).
+func mergeBookTop(prev exchange.BookTop, message wsOrderBookMessageDTO) (exchange.BookTop, bool) {
+	top := prev
+	if message.Typ == "snapshot" {
+		top = exchange.BookTop{}
+	}
+
+	top.Symbol = message.Data.Symbol
+	top.Category = exchange.CategoryLinear
+	top.Ts = message.Ts
+
+	if len(message.Data.Bids) > 0 && message.Data.Bids[0][1] > 0 {
+		top.BidPrice = float64(message.Data.Bids[0][0])
+		top.BidQty = float64(message.Data.Bids[0][1])
+	}
+	if len(message.Data.Asks) > 0 && message.Data.Asks[0][1] > 0 {
+		top.AskPrice = float64(message.Data.Asks[0][0])
+		top.AskQty = float64(message.Data.Asks[0][1])
+	}
+
+	return top, top.BidPrice > 0 && top.AskPrice > 0
+}
+
+func (c *wsBookClient[T]) logMetric(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			droppedCnt := c.Metrics.DroppedBookUpdates.Load()
+			resyncsCnt := c.Metrics.BookResyncs.Load()
+			reconnectsCnt := c.Metrics.Reconnects.Load()
+			if droppedCnt > 0 || resyncsCnt > 0 || reconnectsCnt > 0 {
+				log.Warn().Msgf("ByBit orderbook.%d metrics: dropped book updates=%d, resyncs=%d, reconnects=%d", c.depth, droppedCnt, resyncsCnt, reconnectsCnt)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
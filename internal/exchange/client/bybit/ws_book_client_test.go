@@ -0,0 +1,84 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestMergeBookTop_SnapshotAndDelta(t *testing.T) {
+	var snapshot wsOrderBookMessageDTO
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"topic": "orderbook.1.BTCUSDT",
+		"type": "snapshot",
+		"ts": 1672304484978,
+		"data": {"s": "BTCUSDT", "b": [["16493.50", "0.006"]], "a": [["16611.00", "0.029"]], "u": 18521288}
+	}`), &snapshot))
+
+	top, ok := mergeBookTop(exchange.BookTop{}, snapshot)
+	require.True(t, ok)
+	assert.Equal(t, exchange.BookTop{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1672304484978,
+		BidPrice: 16493.5,
+		BidQty:   0.006,
+		AskPrice: 16611,
+		AskQty:   0.029,
+	}, top)
+
+	// delta with ask side only keeps previous bid
+	var delta wsOrderBookMessageDTO
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"topic": "orderbook.1.BTCUSDT",
+		"type": "delta",
+		"ts": 1672304484990,
+		"data": {"s": "BTCUSDT", "b": [], "a": [["16600.00", "0.5"]], "u": 18521289}
+	}`), &delta))
+
+	top, ok = mergeBookTop(top, delta)
+	require.True(t, ok)
+	assert.Equal(t, 16493.5, top.BidPrice)
+	assert.Equal(t, 16600.0, top.AskPrice)
+	assert.Equal(t, 0.5, top.AskQty)
+	assert.Equal(t, int64(1672304484990), top.Ts)
+}
+
+func TestMergeBookTop_IncompleteBook(t *testing.T) {
+	var snapshot wsOrderBookMessageDTO
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"topic": "orderbook.1.BTCUSDT",
+		"type": "snapshot",
+		"ts": 1672304484978,
+		"data": {"s": "BTCUSDT", "b": [["16493.50", "0.006"]], "a": []}
+	}`), &snapshot))
+
+	_, ok := mergeBookTop(exchange.BookTop{}, snapshot)
+	assert.False(t, ok)
+}
+
+func TestInSequence(t *testing.T) {
+	message := func(typ string, updateID int64) wsOrderBookMessageDTO {
+		return wsOrderBookMessageDTO{
+			Topic: "orderbook.50.BTCUSDT",
+			Typ:   typ,
+			Data:  wsOrderBookDataDTO{Symbol: "BTCUSDT", UpdateID: updateID},
+		}
+	}
+	last := make(map[string]int64)
+
+	assert.True(t, inSequence(last, message("delta", 5)), "deltas before the snapshot are left to the book")
+	assert.True(t, inSequence(last, message("snapshot", 10)))
+	assert.True(t, inSequence(last, message("delta", 11)))
+
+	// update 12 is lost
+	assert.False(t, inSequence(last, message("delta", 13)))
+	assert.True(t, inSequence(last, message("delta", 14)), "resubscribed, waiting for the snapshot")
+
+	assert.True(t, inSequence(last, message("snapshot", 1)))
+	assert.True(t, inSequence(last, message("delta", 2)))
+}
@@ -25,7 +25,10 @@ const pingPongInterval = 20
 // Metrics TODO: вынести в общий пакет метрик
 // Metrics represents metrics for the WebSocket client.
 type Metrics struct {
-	DroppedTrades atomic.Uint64
+	DroppedTrades      atomic.Uint64
+	DroppedBookUpdates atomic.Uint64
+	BookResyncs        atomic.Uint64 // L2 orderbook resubscriptions after a gap in the update sequence
+	Reconnects         atomic.Uint64
 }
 
 // wsClient represents a WebSocket client for ByBit exchange.
@@ -33,12 +36,14 @@ type wsClient struct {
 	url     string
 	Metrics *Metrics
 	wsMu    sync.Mutex // for protects wsConn writes
+	backoff *exchange.Backoff
 }
 
 func newWsClient(cfg *config.Config) *wsClient {
 	return &wsClient{
 		url:     cfg.Exchange.ByBit.WsBaseURL + linearPublicWsURL,
 		Metrics: &Metrics{},
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
 	}
 }
 
@@ -48,23 +53,68 @@ func (c *wsClient) Start(ctx context.Context, symbols []string, category exchang
 		return err
 	}
 
+	wsConn, err := c.connect(wsURL, symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, wsURL, symbols, category, outChan)
+	go c.LogMetric(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the trades of the given symbols.
+func (c *wsClient) connect(wsURL string, symbols []string) (*websocket.Conn, error) {
 	wsConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to dial websocket: %w", err)
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
 	}
 
 	if err := c.subscribeBatch(wsConn, symbols); err != nil {
 		_ = wsConn.Close()
-		return fmt.Errorf("failed to subscribe to symbols: %w", err)
+		return nil, fmt.Errorf("failed to subscribe to symbols: %w", err)
 	}
 
-	go c.pingPong(ctx, wsConn)
-	go c.readMessages(ctx, wsConn, category, outChan)
-	go c.LogMetric(ctx)
-	go func() {
-		<-ctx.Done()
-		_ = wsConn.Close()
-	}()
+	return wsConn, nil
+}
+
+// serve reads trades from the connection and reconnects with backoff (replaying subscriptions) until ctx is done.
+func (c *wsClient) serve(ctx context.Context, wsConn *websocket.Conn, wsURL string, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPong(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessages(connCtx, wsConn, category, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, wsURL, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsClient) reconnect(ctx context.Context, wsURL string, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.Reconnects.Add(1)
+
+		wsConn, err := c.connect(wsURL, symbols)
+		if err != nil {
+			log.Warn().Err(err).Uint64("reconnects", reconnects).Msg("Failed to reconnect to Bybit websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		log.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("Reconnected to Bybit websocket")
+
+		return wsConn
+	}
 
 	return nil
 }
@@ -154,8 +204,7 @@ func (c *wsClient) readMessages(ctx context.Context, wsConn *websocket.Conn, cat
 				return
 			}
 
-			// TODO: тут по идее реконект
-			log.Warn().Err(err).Msg("Failed to read message from Bybit websocket")
+			log.Warn().Err(err).Msg("Failed to read message from Bybit websocket, reconnecting")
 			return
 		}
 
@@ -188,8 +237,9 @@ func (c *wsClient) LogMetric(ctx context.Context) {
 		select {
 		case <-ticker.C:
 			droppedTradesCnt := c.Metrics.DroppedTrades.Load()
-			if int(droppedTradesCnt) > 0 {
-				log.Warn().Msgf("ByBit metrics: dropped trades=%d", droppedTradesCnt)
+			reconnectsCnt := c.Metrics.Reconnects.Load()
+			if droppedTradesCnt > 0 || reconnectsCnt > 0 {
+				log.Warn().Msgf("ByBit metrics: dropped trades=%d, reconnects=%d", droppedTradesCnt, reconnectsCnt)
 			}
 		case <-ctx.Done():
 			return
@@ -0,0 +1,83 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestWsClient_ReconnectAndResubscribe(t *testing.T) {
+	var connections atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		assert.NoError(t, err)
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		// every connection should (re)subscribe to the same symbols
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var subReq map[string]interface{}
+		assert.NoError(t, json.Unmarshal(msg, &subReq))
+		assert.Equal(t, "subscribe", subReq["op"])
+		assert.Equal(t, []interface{}{"publicTrade.BTCUSDT"}, subReq["args"])
+
+		// first connection is dropped right after subscription
+		if connections.Add(1) == 1 {
+			return
+		}
+
+		tradeMsg := map[string]interface{}{
+			"topic": "publicTrade.BTCUSDT",
+			"type":  "snapshot",
+			"ts":    time.Now().UnixMilli(),
+			"data": []map[string]interface{}{
+				{"T": time.Now().UnixMilli(), "s": "BTCUSDT", "S": "Buy", "v": "0.01", "p": "50000"},
+			},
+		}
+		assert.NoError(t, conn.WriteJSON(tradeMsg))
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	cfg := getConfig("ws" + strings.TrimPrefix(server.URL, "http"))
+
+	client := newWsClient(cfg)
+	client.backoff = exchange.NewBackoff(10*time.Millisecond, 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	outChan := make(chan exchange.Trade, 10)
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, exchange.CategoryLinear, outChan))
+
+	select {
+	case trade := <-outChan:
+		assert.Equal(t, "BTCUSDT", trade.Symbol)
+		assert.Equal(t, 50000.0, trade.Price)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for trade after reconnect")
+	}
+
+	assert.Equal(t, int32(2), connections.Load())
+	assert.GreaterOrEqual(t, client.Metrics.Reconnects.Load(), uint64(1))
+}
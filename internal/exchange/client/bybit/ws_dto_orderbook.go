@@ -0,0 +1,21 @@
+package bybit
+
+import (
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+// wsOrderBookDataDTO represents order book levels in websocket orderbook message.
+type wsOrderBookDataDTO struct {
+	Symbol   string                 `json:"s"`
+	Bids     [][2]utils.JSONFloat64 `json:"b"` // [price, size], size 0 means the level is removed
+	Asks     [][2]utils.JSONFloat64 `json:"a"` // [price, size], size 0 means the level is removed
+	UpdateID int64                  `json:"u"`
+}
+
+// wsOrderBookMessageDTO represents a websocket orderbook message (snapshot or delta).
+type wsOrderBookMessageDTO struct {
+	Topic string             `json:"topic"`
+	Typ   string             `json:"type"`
+	Ts    int64              `json:"ts"`
+	Data  wsOrderBookDataDTO `json:"data"`
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/exchange"
@@ -27,6 +28,10 @@ type WsPrivateClient struct {
 	wsMut  sync.Mutex
 	wsConn *websocket.Conn
 
+	// filled holds the cumulative fills of partially filled orders, ByBit sends every execution separately.
+	// An order leaves it once it's fully filled or canceled.
+	filled map[uuid.UUID]exchange.OrderExecutionEvent
+
 	executionChannel    chan exchange.OrderExecutionEvent
 	executionSubscribed bool
 }
@@ -37,6 +42,7 @@ func NewWsPrivateClient(cfg *config.Config, logger zerolog.Logger) *WsPrivateCli
 		url:    cfg.Exchange.ByBit.WsBaseURL + wsPrivateURL,
 		cfg:    cfg,
 		logger: logger,
+		filled: make(map[uuid.UUID]exchange.OrderExecutionEvent),
 
 		executionChannel:    make(chan exchange.OrderExecutionEvent, 100),
 		executionSubscribed: false,
@@ -146,12 +152,13 @@ func (c *WsPrivateClient) pingPing(ctx context.Context) {
 	}
 }
 
-// SubscribeToExecutions subscribe to execution stream
+// SubscribeToExecutions subscribe to execution stream and to order stream for orders canceled or expired
+// by the exchange
 func (c *WsPrivateClient) SubscribeToExecutions() (<-chan exchange.OrderExecutionEvent, error) {
 	if !c.executionSubscribed {
 		payload := map[string]interface{}{
 			"op":   "subscribe",
-			"args": [1]string{"execution"},
+			"args": [2]string{wstopics.Execution, wstopics.Order},
 		}
 
 		if err := c.writeJSON(payload); err != nil {
@@ -193,11 +200,17 @@ func (c *WsPrivateClient) handleMessage() error {
 				c.executionChannel <- order
 			}
 		}
+	case wstopics.Order:
+		for _, order := range c.handleOrderEvent(&message) {
+			c.executionChannel <- order
+		}
 	}
 
 	return nil
 }
 
+// handleExecutionEvent maps executions to exchange.OrderExecutionEvent, one per order. Executions are accumulated
+// per order until it's fully filled, so the event carries the cumulative filled qty and its average price.
 func (c *WsPrivateClient) handleExecutionEvent(message *dtos.MessageDTO) []exchange.OrderExecutionEvent {
 	var executions []dtos.ExecutionDTO
 	if err := json.Unmarshal(message.Data, &executions); err != nil {
@@ -209,24 +222,29 @@ func (c *WsPrivateClient) handleExecutionEvent(message *dtos.MessageDTO) []excha
 	for _, execution := range executions {
 		orderID := execution.OrderLinkID
 
-		if _, ok := orders[orderID]; !ok {
-			orders[orderID] = exchange.OrderExecutionEvent{
-				OrderID:         execution.OrderLinkID,
-				ExchangeOrderID: execution.OrderID,
-				ExecPrice:       execution.ExecPrice,
-				ExecQty:         execution.ExecQty,
-				ExecValue:       execution.ExecValue,
-				LeavesQty:       execution.LeavesQty,
-				OrderPrice:      execution.OrderPrice,
-				OrderQty:        execution.OrderQty,
-			}
-		} else {
-			order := orders[orderID]
-			order.ExecQty = order.ExecQty.Add(execution.ExecQty)
-			order.ExecValue = order.ExecValue.Add(execution.ExecValue)
+		order, ok := orders[orderID]
+		if !ok {
+			order = c.filled[orderID]
+		}
+
+		order.OrderID = execution.OrderLinkID
+		order.ExchangeOrderID = execution.OrderID
+		order.ExecQty = order.ExecQty.Add(execution.ExecQty)
+		order.ExecValue = order.ExecValue.Add(execution.ExecValue)
+		if order.ExecQty.IsPositive() {
 			order.ExecPrice = order.ExecValue.Div(order.ExecQty)
-			order.LeavesQty = execution.LeavesQty
-			orders[orderID] = order
+		}
+		order.LeavesQty = execution.LeavesQty
+		order.OrderPrice = execution.OrderPrice
+		order.OrderQty = execution.OrderQty
+		orders[orderID] = order
+	}
+
+	for orderID, order := range orders {
+		if order.LeavesQty.IsPositive() {
+			c.filled[orderID] = order
+		} else {
+			delete(c.filled, orderID)
 		}
 	}
 
@@ -238,6 +256,47 @@ func (c *WsPrivateClient) handleExecutionEvent(message *dtos.MessageDTO) []excha
 	return res
 }
 
+// handleOrderEvent maps orders canceled, expired or rejected by the exchange to exchange.OrderExecutionEvent
+// with Canceled set, so the order is terminated at its cumulative filled qty. Other statuses are skipped,
+// fills come from the execution stream.
+func (c *WsPrivateClient) handleOrderEvent(message *dtos.MessageDTO) []exchange.OrderExecutionEvent {
+	var orders []dtos.OrderUpdateDTO
+	if err := json.Unmarshal(message.Data, &orders); err != nil {
+		c.logger.Warn().Err(err).Msg("BiBit ws private: failed to unmarshal order message from ByBit privates websocket")
+		return nil
+	}
+
+	var res []exchange.OrderExecutionEvent
+	for _, order := range orders {
+		switch order.OrderStatus {
+		case dtos.OrderStatusCancelled, dtos.OrderStatusPartiallyFilledCanceled,
+			dtos.OrderStatusDeactivated, dtos.OrderStatusRejected:
+		default:
+			continue
+		}
+
+		orderID, err := uuid.Parse(order.OrderLinkID)
+		if err != nil {
+			continue // placed outside the bot
+		}
+		delete(c.filled, orderID)
+
+		res = append(res, exchange.OrderExecutionEvent{
+			OrderID:         orderID,
+			ExchangeOrderID: order.OrderID,
+			ExecPrice:       order.AvgPrice,
+			ExecQty:         order.CumExecQty,
+			ExecValue:       order.CumExecValue,
+			LeavesQty:       decimal.Zero,
+			OrderPrice:      order.Price,
+			OrderQty:        order.Qty,
+			Canceled:        true,
+		})
+	}
+
+	return res
+}
+
 func (c *WsPrivateClient) writeJSON(payload interface{}) error {
 	c.wsMut.Lock()
 	defer c.wsMut.Unlock()
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange/client/bybit/dtos"
 )
 
 const orderID = "e48d152d-1d81-479b-ba4a-94d6259afd00"
@@ -69,8 +70,7 @@ func getServer(t *testing.T, falseAuth bool) *httptest.Server {
 		}
 		assert.NoError(t, json.Unmarshal(msg, &subReq))
 		assert.Equal(t, "subscribe", subReq.Op)
-		assert.Equal(t, 1, len(subReq.Args))
-		assert.Equal(t, subReq.Args[0], "execution")
+		assert.Equal(t, []string{"execution", "order"}, subReq.Args)
 
 		// Send execution event
 		execMsg := map[string]interface{}{
@@ -165,3 +165,82 @@ func TestWsPrivateClient_AuthFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "auth response not successful")
 }
+
+// Partial fills are accumulated per order until it's fully filled
+func TestWsPrivateClient_HandleExecutionEvent_PartialFills(t *testing.T) {
+	client := NewWsPrivateClient(getConfig(""), zerolog.Nop())
+
+	execution := func(price, qty, leaves string) map[string]interface{} {
+		p, q := decimal.RequireFromString(price), decimal.RequireFromString(qty)
+		return map[string]interface{}{
+			"symbol":      "BTCUSDT",
+			"execPrice":   price,
+			"execQty":     qty,
+			"execValue":   p.Mul(q).String(),
+			"leavesQty":   leaves,
+			"orderID":     "exchange-order-123",
+			"orderLinkId": orderID,
+			"orderPrice":  "100",
+			"orderQty":    "4",
+		}
+	}
+	message := func(executions ...map[string]interface{}) *dtos.MessageDTO {
+		data, err := json.Marshal(executions)
+		require.NoError(t, err)
+		return &dtos.MessageDTO{Topic: "execution", Data: data}
+	}
+
+	events := client.handleExecutionEvent(message(execution("100", "1", "3")))
+	require.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].ExecQty.String())
+	assert.Equal(t, "3", events[0].LeavesQty.String())
+
+	events = client.handleExecutionEvent(message(execution("101", "1", "2"), execution("104", "2", "0")))
+	require.Len(t, events, 1)
+	assert.Equal(t, "4", events[0].ExecQty.String())
+	assert.Equal(t, "102.25", events[0].ExecPrice.String())
+	assert.True(t, events[0].LeavesQty.IsZero())
+	assert.Empty(t, client.filled)
+}
+
+// An order canceled by the exchange ends at its cumulative filled qty and leaves the accumulated fills
+func TestWsPrivateClient_HandleOrderEvent_Canceled(t *testing.T) {
+	client := NewWsPrivateClient(getConfig(""), zerolog.Nop())
+
+	executions, err := json.Marshal([]map[string]interface{}{{
+		"execPrice":   "100",
+		"execQty":     "1",
+		"execValue":   "100",
+		"leavesQty":   "3",
+		"orderLinkId": orderID,
+		"orderQty":    "4",
+	}})
+	require.NoError(t, err)
+	require.Len(t, client.handleExecutionEvent(&dtos.MessageDTO{Topic: "execution", Data: executions}), 1)
+	require.Len(t, client.filled, 1)
+
+	orders, err := json.Marshal([]map[string]interface{}{
+		{
+			"orderId":      "exchange-order-123",
+			"orderLinkId":  orderID,
+			"orderStatus":  "PartiallyFilledCanceled",
+			"cumExecQty":   "1",
+			"cumExecValue": "100",
+			"avgPrice":     "100",
+			"leavesQty":    "0",
+			"qty":          "4",
+		},
+		{"orderLinkId": orderID, "orderStatus": "PartiallyFilled"},
+		{"orderLinkId": "manual-order", "orderStatus": "Cancelled"},
+	})
+	require.NoError(t, err)
+
+	events := client.handleOrderEvent(&dtos.MessageDTO{Topic: "order", Data: orders})
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Canceled)
+	assert.Equal(t, orderID, events[0].OrderID.String())
+	assert.Equal(t, "1", events[0].ExecQty.String())
+	assert.Equal(t, "100", events[0].ExecPrice.String())
+	assert.True(t, events[0].LeavesQty.IsZero())
+	assert.Empty(t, client.filled)
+}
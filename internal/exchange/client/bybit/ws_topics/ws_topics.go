@@ -4,4 +4,6 @@ package wstopics
 const (
 	// Execution stream to see your executions in real-time
 	Execution string = "execution"
+	// Order stream of order status changes, used to see orders canceled or expired by the exchange
+	Order string = "order"
 )
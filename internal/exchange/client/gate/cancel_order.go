@@ -0,0 +1,61 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+// API: DELETE /futures/usdt/orders/{order_id}
+// Docs: https://www.gate.io/docs/developers/apiv4/#cancel-a-single-order
+// Spot: DELETE /spot/orders/{order_id}?currency_pair=BTC_USDT
+
+// CancelOrder cancels a pending limit order by exchange order id, or by the client order id (text) if it is unknown.
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) error {
+	if category == exchange.CategorySpot {
+		return c.cancelSpotOrder(ctx, orderID, exchangeOrderID, symbol)
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodDelete, fmt.Sprintf(orderURL, orderRef(orderID, exchangeOrderID)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("Gate CancelOrder: failed to create request: %w", err)
+	}
+
+	var raw dtos.OrderDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("Gate CancelOrder: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) cancelSpotOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) error {
+	query := url.Values{"currency_pair": {denormalizeTickerName(symbol)}}
+
+	req, err := c.newSignedRequest(ctx, http.MethodDelete, fmt.Sprintf(spotOrderURL, orderRef(orderID, exchangeOrderID)), query, nil)
+	if err != nil {
+		return fmt.Errorf("Gate CancelOrder: failed to create spot request: %w", err)
+	}
+
+	var raw dtos.SpotOrderDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("Gate CancelOrder: spot: %w", err)
+	}
+
+	return nil
+}
+
+// orderRef returns the id of the order for REST paths. Gate resolves the text only for open orders
+// and within 60 seconds after the order is finished, so the exchange order id is preferred.
+func orderRef(orderID uuid.UUID, exchangeOrderID string) string {
+	if exchangeOrderID != "" {
+		return exchangeOrderID
+	}
+	return clientOrderID(orderID)
+}
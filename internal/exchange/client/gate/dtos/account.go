@@ -0,0 +1,15 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// AccountDTO represents the futures account. Total doesn't include unrealised pnl.
+// https://www.gate.io/docs/developers/apiv4/#query-futures-account
+type AccountDTO struct {
+	User           int64         `json:"user"`
+	Currency       string        `json:"currency"`
+	Total          utils.Decimal `json:"total"`
+	UnrealisedPnl  utils.Decimal `json:"unrealised_pnl"`
+	PositionMargin utils.Decimal `json:"position_margin"`
+	OrderMargin    utils.Decimal `json:"order_margin"`
+	Available      utils.Decimal `json:"available"`
+}
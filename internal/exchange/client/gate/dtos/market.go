@@ -0,0 +1,39 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// ContractDTO represents a futures contract specification, it also carries the current funding rate.
+// https://www.gate.io/docs/developers/apiv4/#list-all-futures-contracts
+type ContractDTO struct {
+	Name             string        `json:"name"`
+	Type             string        `json:"type"`              // direct (linear) / inverse
+	QuantoMultiplier utils.Decimal `json:"quanto_multiplier"` // contract value in coins
+	OrderPriceRound  utils.Decimal `json:"order_price_round"`
+	OrderSizeMin     int64         `json:"order_size_min"` // in contracts
+	InDelisting      bool          `json:"in_delisting"`
+	Status           string        `json:"status"` // trading / delisting / delisted, may be empty
+
+	FundingRate      utils.JSONFloat64 `json:"funding_rate"`
+	FundingInterval  int64             `json:"funding_interval"`   // seconds
+	FundingNextApply utils.JSONFloat64 `json:"funding_next_apply"` // unix seconds
+}
+
+// TickerDTO represents a ticker of a contract.
+// https://www.gate.io/docs/developers/apiv4/#list-futures-tickers
+type TickerDTO struct {
+	Contract         string        `json:"contract"`
+	Last             utils.Decimal `json:"last"`
+	ChangePercentage utils.Decimal `json:"change_percentage"` // percent, 4.43 = 4.43%
+	High24h          utils.Decimal `json:"high_24h"`
+	Low24h           utils.Decimal `json:"low_24h"`
+	Volume24hQuote   utils.Decimal `json:"volume_24h_quote"` // in USDT
+	MarkPrice        utils.Decimal `json:"mark_price"`
+	IndexPrice       utils.Decimal `json:"index_price"`
+}
+
+// FeeDTO represents trading fee rates of the account.
+// https://www.gate.io/docs/developers/apiv4/#retrieve-personal-trading-fee
+type FeeDTO struct {
+	FuturesTakerFee utils.Decimal `json:"futures_taker_fee"`
+	FuturesMakerFee utils.Decimal `json:"futures_maker_fee"`
+}
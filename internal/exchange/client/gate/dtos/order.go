@@ -0,0 +1,58 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// TimeInForce represents the time in force of an order.
+type TimeInForce string
+
+const (
+	// TimeInForceGTC - Good Till Cancelled.
+	TimeInForceGTC TimeInForce = "gtc"
+	// TimeInForceIOC - Immediate or Cancel, used for market orders (price 0).
+	TimeInForceIOC TimeInForce = "ioc"
+	// TimeInForceFOK - Fill or Kill.
+	TimeInForceFOK TimeInForce = "fok"
+	// TimeInForcePostOnly - Pending or Cancelled, post only.
+	TimeInForcePostOnly TimeInForce = "poc"
+
+	// OrderStatusFinished is a status of filled or cancelled order.
+	OrderStatusFinished = "finished"
+)
+
+// PlaceOrderDTO represents a request to place an order. Size is in contracts, negative size is sell.
+// Market order is price "0" with ioc.
+// https://www.gate.io/docs/developers/apiv4/#create-a-futures-order
+type PlaceOrderDTO struct {
+	Contract   string      `json:"contract"`
+	Size       int64       `json:"size"`
+	Price      string      `json:"price"`
+	Tif        TimeInForce `json:"tif"`
+	Text       string      `json:"text"`
+	ReduceOnly bool        `json:"reduce_only,omitempty"`
+}
+
+// OrderDTO represents a futures order of REST responses and futures.orders channel.
+// Prices are strings in REST and numbers in websocket.
+type OrderDTO struct {
+	ID        int64         `json:"id"`
+	Contract  string        `json:"contract"`
+	Size      int64         `json:"size"`
+	Left      int64         `json:"left"`
+	Price     utils.Decimal `json:"price"`
+	FillPrice utils.Decimal `json:"fill_price"` // average fill price
+	Status    string        `json:"status"`     // open / finished
+	FinishAs  string        `json:"finish_as"`  // filled / cancelled / ioc / reduce_only ...
+	Text      string        `json:"text"`
+}
+
+// TradeDTO represents a personal trade (fill).
+// https://www.gate.io/docs/developers/apiv4/#list-personal-trading-history
+type TradeDTO struct {
+	ID       int64         `json:"id"`
+	OrderID  string        `json:"order_id"`
+	Contract string        `json:"contract"`
+	Size     int64         `json:"size"`
+	Price    utils.Decimal `json:"price"`
+	Role     string        `json:"role"`
+	Fee      utils.Decimal `json:"fee"` // positive - fee paid
+}
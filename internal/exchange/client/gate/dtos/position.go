@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// PositionDTO represents a futures position. Size is in contracts, negative size is short.
+// https://www.gate.io/docs/developers/apiv4/#list-all-positions-of-a-user
+type PositionDTO struct {
+	Contract   string        `json:"contract"`
+	Size       int64         `json:"size"`
+	EntryPrice utils.Decimal `json:"entry_price"`
+}
@@ -0,0 +1,60 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// SpotPairDTO represents a spot currency pair specification.
+// Precisions are the number of decimal places of the amount (base coin) and the price.
+// https://www.gate.io/docs/developers/apiv4/#list-all-currency-pairs-supported
+type SpotPairDTO struct {
+	ID              string        `json:"id"` // BTC_USDT
+	Base            string        `json:"base"`
+	Quote           string        `json:"quote"`
+	MinBaseAmount   utils.Decimal `json:"min_base_amount"`
+	AmountPrecision int32         `json:"amount_precision"`
+	Precision       int32         `json:"precision"`
+	TradeStatus     string        `json:"trade_status"` // tradable / untradable / buyable / sellable
+}
+
+// SpotAccountDTO represents the balance of a currency in the spot account.
+// https://www.gate.io/docs/developers/apiv4/#list-spot-accounts
+type SpotAccountDTO struct {
+	Currency  string        `json:"currency"`
+	Available utils.Decimal `json:"available"`
+	Locked    utils.Decimal `json:"locked"`
+}
+
+// PlaceSpotOrderDTO represents a request to place a spot order. Amount is in base coin,
+// except for market buy orders where it is in quote coin.
+// https://www.gate.io/docs/developers/apiv4/#create-an-order
+type PlaceSpotOrderDTO struct {
+	CurrencyPair string      `json:"currency_pair"`
+	Type         string      `json:"type"` // limit / market
+	Account      string      `json:"account"`
+	Side         string      `json:"side"` // buy / sell
+	Amount       string      `json:"amount"`
+	Price        string      `json:"price,omitempty"`
+	TimeInForce  TimeInForce `json:"time_in_force"`
+	Text         string      `json:"text"`
+}
+
+// SpotOrderDTO represents a spot order of REST responses. Ids are strings, unlike futures.
+type SpotOrderDTO struct {
+	ID           string        `json:"id"`
+	Text         string        `json:"text"`
+	CurrencyPair string        `json:"currency_pair"`
+	Side         string        `json:"side"`
+	Amount       utils.Decimal `json:"amount"`
+	Price        utils.Decimal `json:"price"`
+	Status       string        `json:"status"` // open / closed / cancelled
+	AvgDealPrice utils.Decimal `json:"avg_deal_price"`
+	FilledAmount utils.Decimal `json:"filled_amount"`
+	Fee          utils.Decimal `json:"fee"` // positive - fee paid
+	FeeCurrency  string        `json:"fee_currency"`
+}
+
+// SpotOpenOrdersDTO represents open orders of a currency pair.
+// https://www.gate.io/docs/developers/apiv4/#list-all-open-orders
+type SpotOpenOrdersDTO struct {
+	CurrencyPair string         `json:"currency_pair"`
+	Orders       []SpotOrderDTO `json:"orders"`
+}
@@ -0,0 +1,63 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+// WsErrorDTO represents an error of a websocket request.
+type WsErrorDTO struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// WsMessageDTO represents a message of Gate futures websocket.
+// Event is subscribe / update (pushes) / all (order book snapshot).
+type WsMessageDTO struct {
+	Time    int64           `json:"time"`
+	Channel string          `json:"channel"`
+	Event   string          `json:"event"`
+	Error   *WsErrorDTO     `json:"error"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// WsTradeDTO represents a trade of futures.trades channel. Negative size is sell (taker side).
+type WsTradeDTO struct {
+	Contract     string            `json:"contract"`
+	Size         int64             `json:"size"`
+	Price        utils.JSONFloat64 `json:"price"`
+	CreateTimeMs int64             `json:"create_time_ms"`
+}
+
+// WsBookTickerDTO represents best bid/ask of futures.book_ticker channel. Sizes are in contracts.
+type WsBookTickerDTO struct {
+	T        int64             `json:"t"`
+	Contract string            `json:"s"`
+	BidPrice utils.JSONFloat64 `json:"b"`
+	BidSize  int64             `json:"B"`
+	AskPrice utils.JSONFloat64 `json:"a"`
+	AskSize  int64             `json:"A"`
+}
+
+// WsPriceLevelDTO represents an order book level of futures.order_book snapshot.
+type WsPriceLevelDTO struct {
+	P utils.JSONFloat64 `json:"p"`
+	S int64             `json:"s"`
+}
+
+// WsOrderBookDTO represents futures.order_book snapshot (event all).
+type WsOrderBookDTO struct {
+	T        int64             `json:"t"`
+	Contract string            `json:"contract"`
+	Asks     []WsPriceLevelDTO `json:"asks"`
+	Bids     []WsPriceLevelDTO `json:"bids"`
+}
+
+// WsOrderBookUpdateDTO represents a level change of futures.order_book (event update).
+// Positive size is bid, negative is ask, 0 removes the level.
+type WsOrderBookUpdateDTO struct {
+	P        utils.JSONFloat64 `json:"p"`
+	S        int64             `json:"s"`
+	Contract string            `json:"c"`
+}
@@ -0,0 +1,81 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const (
+	accountsURL     = "/api/v4/futures/usdt/accounts"
+	spotAccountsURL = "/api/v4/spot/accounts"
+)
+
+// GetBalances returns the balance of the user's USDT futures (linear) account or the spot account balances.
+func (c *Client) GetBalances(ctx context.Context, category exchange.Category) ([]models.Balance, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotBalances(ctx)
+	default:
+		return nil, fmt.Errorf("Gate GetBalances: unsupported category %s", category)
+	}
+
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetBalances: %w", err)
+	}
+
+	total := account.Total.Add(account.UnrealisedPnl.Decimal)
+
+	return []models.Balance{{
+		ExchangeName: c.GetExchangeName(),
+		Asset:        account.Currency,
+		Free:         account.Available.Decimal,
+		Locked:       total.Sub(account.Available.Decimal),
+		Total:        total,
+	}}, nil
+}
+
+func (c *Client) getAccount(ctx context.Context) (dtos.AccountDTO, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, accountsURL, nil, nil)
+	if err != nil {
+		return dtos.AccountDTO{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var raw dtos.AccountDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return dtos.AccountDTO{}, err
+	}
+
+	return raw, nil
+}
+
+func (c *Client) getSpotBalances(ctx context.Context) ([]models.Balance, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, spotAccountsURL, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetBalances: failed to create spot request: %w", err)
+	}
+
+	var raw []dtos.SpotAccountDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Gate GetBalances: spot: %w", err)
+	}
+
+	result := make([]models.Balance, 0, len(raw))
+	for _, b := range raw {
+		result = append(result, models.Balance{
+			ExchangeName: c.GetExchangeName(),
+			Asset:        b.Currency,
+			Free:         b.Available.Decimal,
+			Locked:       b.Locked.Decimal,
+			Total:        b.Available.Add(b.Locked.Decimal),
+		})
+	}
+
+	return result, nil
+}
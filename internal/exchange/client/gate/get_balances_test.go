@@ -0,0 +1,56 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetBalances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, accountsURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("KEY"))
+		assert.NotEmpty(t, r.Header.Get("SIGN"))
+
+		_, _ = w.Write([]byte(`{"user":1666,"currency":"USDT","total":"1000.5","unrealised_pnl":"-0.5","position_margin":"150","order_margin":"50","available":"800"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	balances, err := c.GetBalances(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+
+	assert.Equal(t, "Gate", balances[0].ExchangeName)
+	assert.Equal(t, "USDT", balances[0].Asset)
+	assert.Equal(t, "800", balances[0].Free.String())
+	assert.Equal(t, "200", balances[0].Locked.String())
+	assert.Equal(t, "1000", balances[0].Total.String())
+}
+
+func TestClient_GetBalances_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotAccountsURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`[{"currency":"USDT","available":"500.5","locked":"20"},{"currency":"BTC","available":"0.01","locked":"0"}]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	balances, err := c.GetBalances(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	assert.Equal(t, "USDT", balances[0].Asset)
+	assert.Equal(t, "500.5", balances[0].Free.String())
+	assert.Equal(t, "520.5", balances[0].Total.String())
+	assert.Equal(t, "BTC", balances[1].Asset)
+}
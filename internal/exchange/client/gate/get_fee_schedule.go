@@ -0,0 +1,33 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+const feeURL = "/api/v4/wallet/fee"
+
+// GetFeeSchedule retrieves the account futures fee rates from Gate. Gate has one rate per VIP level, used as Default.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, feeURL, url.Values{"settle": {"usdt"}}, nil)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("Gate GetFeeSchedule: failed to create request: %w", err)
+	}
+
+	var raw dtos.FeeDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("Gate GetFeeSchedule: %w", err)
+	}
+
+	return exchange.FeeSchedule{
+		Default: exchange.FeeRate{
+			Maker: raw.FuturesMakerFee.InexactFloat64(),
+			Taker: raw.FuturesTakerFee.InexactFloat64(),
+		},
+	}, nil
+}
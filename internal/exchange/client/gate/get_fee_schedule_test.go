@@ -0,0 +1,30 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, feeURL, r.URL.Path)
+		assert.Equal(t, "usdt", r.URL.Query().Get("settle"))
+		assert.NotEmpty(t, r.Header.Get("SIGN"))
+
+		_, _ = w.Write([]byte(`{"user_id":10001,"taker_fee":"0.002","maker_fee":"0.002","gt_discount":false,"gt_taker_fee":"0","gt_maker_fee":"0","loan_fee":"0.18","point_type":"1","futures_taker_fee":"0.0005","futures_maker_fee":"0.0002"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	fees, err := c.GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0002, fees.Default.Maker)
+	assert.Equal(t, 0.0005, fees.Default.Taker)
+}
@@ -0,0 +1,39 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// GetFundingRates retrieves current funding rates of all USDT futures from Gate. Contracts list carries funding info,
+// so no separate request is needed.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	contracts, err := c.getContracts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetFundingRates: %w", err)
+	}
+
+	result := make(map[string]exchange.FundingRate, len(contracts))
+	for _, dto := range contracts {
+		if !isTradable(dto) {
+			continue
+		}
+
+		interval := dto.FundingInterval / 3600
+		if interval <= 0 {
+			interval = exchange.DefaultFundingIntervalHours
+		}
+
+		symbol := normalizeTickerName(dto.Name)
+		result[symbol] = exchange.FundingRate{
+			Symbol:          symbol,
+			Rate:            float64(dto.FundingRate),
+			IntervalHours:   interval,
+			NextFundingTime: int64(dto.FundingNextApply) * 1000,
+		}
+	}
+
+	return result, nil
+}
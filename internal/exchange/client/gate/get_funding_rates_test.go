@@ -0,0 +1,37 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, contractsURL, r.URL.Path)
+		_, _ = w.Write([]byte(testContractsResponse))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	rates, err := c.GetFundingRates(t.Context())
+	require.NoError(t, err)
+	// LUNA_USDT is delisting
+	require.Len(t, rates, 2)
+
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.0001,
+		IntervalHours:   8,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, -0.0025, rates["NEWCOINUSDT"].Rate)
+	assert.Equal(t, int64(4), rates["NEWCOINUSDT"].IntervalHours)
+}
@@ -0,0 +1,112 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+const (
+	contractsURL         = "/api/v4/futures/usdt/contracts"
+	spotCurrencyPairsURL = "/api/v4/spot/currency_pairs"
+)
+
+// GetInstruments retrieves contract specifications of USDT futures (linear) or USDT spot pairs from Gate.
+// Gate futures trade in whole contracts, quanto_multiplier is the contract value in coins (ContractSize).
+// Spot amount is in coins, so ContractSize is 1.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotInstruments(ctx)
+	default:
+		return nil, fmt.Errorf("Gate GetInstruments: unsupported category %s", category)
+	}
+
+	contracts, err := c.getContracts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetInstruments: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(contracts))
+	for _, dto := range contracts {
+		if !isTradable(dto) {
+			continue
+		}
+
+		if !dto.QuantoMultiplier.IsPositive() {
+			return nil, fmt.Errorf("Gate GetInstruments: invalid quanto_multiplier for %s", dto.Name)
+		}
+
+		symbol := normalizeTickerName(dto.Name)
+		result[symbol] = exchange.Instrument{
+			Symbol:       symbol,
+			VolStep:      decimal.NewFromInt(1),
+			MinVol:       decimal.NewFromInt(max(dto.OrderSizeMin, 1)),
+			PriceStep:    dto.OrderPriceRound.Decimal,
+			ContractSize: dto.QuantoMultiplier.Decimal,
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) getContracts(ctx context.Context) ([]dtos.ContractDTO, error) {
+	req, err := c.newRequest(ctx, contractsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var raw []dtos.ContractDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+func (c *Client) getSpotInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+	req, err := c.newRequest(ctx, spotCurrencyPairsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetInstruments: failed to create spot request: %w", err)
+	}
+
+	var pairs []dtos.SpotPairDTO
+	if _, err := c.do(req, &pairs); err != nil {
+		return nil, fmt.Errorf("Gate GetInstruments: spot: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(pairs))
+	for _, dto := range pairs {
+		if dto.Quote != "USDT" || dto.TradeStatus != "tradable" {
+			continue
+		}
+
+		symbol := normalizeTickerName(dto.ID)
+		result[symbol] = exchange.Instrument{
+			Symbol:       symbol,
+			VolStep:      decimal.New(1, -dto.AmountPrecision),
+			MinVol:       dto.MinBaseAmount.Decimal,
+			PriceStep:    decimal.New(1, -dto.Precision),
+			ContractSize: decimal.NewFromInt(1),
+		}
+	}
+
+	return result, nil
+}
+
+// isTradable reports whether the contract is a linear USDT contract which is not being delisted.
+func isTradable(dto dtos.ContractDTO) bool {
+	if !strings.HasSuffix(dto.Name, contractSuffix) || dto.InDelisting {
+		return false
+	}
+	if dto.Type != "" && dto.Type != "direct" {
+		return false
+	}
+	return dto.Status == "" || dto.Status == "trading"
+}
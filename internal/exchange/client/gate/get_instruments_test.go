@@ -0,0 +1,69 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+const testContractsResponse = `[
+	{"name":"BTC_USDT","type":"direct","quanto_multiplier":"0.0001","order_price_round":"0.1","order_size_min":1,"in_delisting":false,"status":"trading",
+		"funding_rate":"0.0001","funding_interval":28800,"funding_next_apply":1760000000},
+	{"name":"NEWCOIN_USDT","type":"direct","quanto_multiplier":"10","order_price_round":"0.00001","order_size_min":1,"in_delisting":false,
+		"funding_rate":"-0.0025","funding_interval":14400,"funding_next_apply":1760014400},
+	{"name":"LUNA_USDT","type":"direct","quanto_multiplier":"1","order_price_round":"0.0001","order_size_min":1,"in_delisting":true,"status":"delisting",
+		"funding_rate":"0","funding_interval":28800,"funding_next_apply":1760000000}
+]`
+
+func TestClient_GetInstruments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, contractsURL, r.URL.Path)
+		_, _ = w.Write([]byte(testContractsResponse))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	instruments, err := c.GetInstruments(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, instruments, 2)
+
+	btc := instruments["BTCUSDT"]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, "1", btc.VolStep.String())
+	assert.Equal(t, "1", btc.MinVol.String())
+	assert.Equal(t, "0.1", btc.PriceStep.String())
+	assert.Equal(t, "0.0001", btc.ContractSize.String())
+
+	assert.Equal(t, "10", instruments["NEWCOINUSDT"].ContractSize.String())
+}
+
+func TestClient_GetInstruments_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotCurrencyPairsURL, r.URL.Path)
+		_, _ = w.Write([]byte(`[
+			{"id":"BTC_USDT","base":"BTC","quote":"USDT","min_base_amount":"0.0001","amount_precision":4,"precision":1,"trade_status":"tradable"},
+			{"id":"ETH_BTC","base":"ETH","quote":"BTC","min_base_amount":"0.001","amount_precision":3,"precision":6,"trade_status":"tradable"},
+			{"id":"LUNA_USDT","base":"LUNA","quote":"USDT","min_base_amount":"1","amount_precision":0,"precision":4,"trade_status":"untradable"}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	instruments, err := c.GetInstruments(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, instruments, 1)
+
+	btc := instruments["BTCUSDT"]
+	assert.Equal(t, "0.0001", btc.VolStep.String())
+	assert.Equal(t, "0.0001", btc.MinVol.String())
+	assert.Equal(t, "0.1", btc.PriceStep.String())
+	assert.Equal(t, "1", btc.ContractSize.String())
+}
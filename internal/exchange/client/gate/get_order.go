@@ -0,0 +1,100 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+const myTradesURL = "/api/v4/futures/usdt/my_trades"
+
+// GetOrder retrieves the order from the exchange. Order response has no fees, so fees are summed up
+// from the trades (fills) of the order. Fees are returned negative (paid), the same way they are added to the spread profit.
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	if category == exchange.CategorySpot {
+		return c.getSpotOrder(ctx, orderID, exchangeOrderID, symbol)
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, fmt.Sprintf(orderURL, orderRef(orderID, exchangeOrderID)), nil, nil)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: failed to create request: %w", err)
+	}
+
+	var order dtos.OrderDTO
+	if _, err := c.do(req, &order); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: %w", err)
+	}
+
+	if exchangeOrderID == "" {
+		exchangeOrderID = strconv.FormatInt(order.ID, 10)
+	}
+
+	tradesQuery := url.Values{
+		"contract": {denormalizeTickerName(symbol)},
+		"order":    {exchangeOrderID},
+	}
+
+	req, err = c.newSignedRequest(ctx, http.MethodGet, myTradesURL, tradesQuery, nil)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: failed to create trades request: %w", err)
+	}
+
+	var trades []dtos.TradeDTO
+	if _, err := c.do(req, &trades); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: trades: %w", err)
+	}
+
+	fees := decimal.Zero
+	for _, t := range trades {
+		fees = fees.Sub(t.Fee.Decimal)
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: exchangeOrderID,
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        order.FillPrice.Decimal,
+		Fees:            fees,
+	}, nil
+}
+
+// getSpotOrder retrieves the spot order, spot order response carries the fee itself.
+// Buy fee is charged in base coin and converted to USDT by the average price,
+// fees paid in other currencies (GT deduction) are not counted.
+func (c *Client) getSpotOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (exchange.ExchangeOrder, error) {
+	query := url.Values{"currency_pair": {denormalizeTickerName(symbol)}}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, fmt.Sprintf(spotOrderURL, orderRef(orderID, exchangeOrderID)), query, nil)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: failed to create spot request: %w", err)
+	}
+
+	var order dtos.SpotOrderDTO
+	if _, err := c.do(req, &order); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("Gate GetOrder: spot: %w", err)
+	}
+
+	fees := decimal.Zero
+	switch {
+	case order.FeeCurrency == "USDT":
+		fees = fees.Sub(order.Fee.Decimal)
+	case order.FeeCurrency+"USDT" == symbol:
+		fees = fees.Sub(order.Fee.Mul(order.AvgDealPrice.Decimal))
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: order.ID,
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        order.AvgDealPrice.Decimal,
+		Fees:            fees,
+	}, nil
+}
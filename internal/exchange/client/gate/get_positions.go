@@ -0,0 +1,136 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /futures/usdt/positions
+// Docs: https://www.gate.io/docs/developers/apiv4/#list-all-positions-of-a-user
+// Open orders: GET /futures/usdt/orders?status=open, spot: GET /spot/open_orders
+
+const (
+	positionsURL      = "/api/v4/futures/usdt/positions"
+	spotOpenOrdersURL = "/api/v4/spot/open_orders"
+)
+
+// GetPositions returns open USDT futures positions, qty is in contracts.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, positionsURL, url.Values{"holding": {"true"}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetPositions: failed to create request: %w", err)
+	}
+
+	var raw []dtos.PositionDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Gate GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw))
+	for _, p := range raw {
+		if p.Size == 0 {
+			continue
+		}
+
+		positions = append(positions, exchange.Position{
+			Symbol:     normalizeTickerName(p.Contract),
+			Side:       sideOfSize(p.Size),
+			Qty:        decimal.NewFromInt(p.Size).Abs(),
+			EntryPrice: p.EntryPrice.Decimal,
+		})
+	}
+
+	return positions, nil
+}
+
+// GetOpenOrders returns orders resting on the book of the category.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	if category == exchange.CategorySpot {
+		return c.getSpotOpenOrders(ctx)
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, ordersURL, url.Values{"status": {"open"}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetOpenOrders: failed to create request: %w", err)
+	}
+
+	var raw []dtos.OrderDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Gate GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw))
+	for _, o := range raw {
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         openOrderID(o.Text),
+			ExchangeOrderID: strconv.FormatInt(o.ID, 10),
+			Symbol:          normalizeTickerName(o.Contract),
+			Side:            sideOfSize(o.Size),
+			Qty:             decimal.NewFromInt(o.Size).Abs(),
+			Price:           o.Price.Decimal,
+		})
+	}
+
+	return orders, nil
+}
+
+func (c *Client) getSpotOpenOrders(ctx context.Context) ([]exchange.OpenOrder, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, spotOpenOrdersURL, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetOpenOrders: failed to create spot request: %w", err)
+	}
+
+	var raw []dtos.SpotOpenOrdersDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Gate GetOpenOrders: spot: %w", err)
+	}
+
+	var orders []exchange.OpenOrder
+	for _, pair := range raw {
+		for _, o := range pair.Orders {
+			side := models.OrderSideBuy
+			if strings.EqualFold(o.Side, string(models.OrderSideSell)) {
+				side = models.OrderSideSell
+			}
+
+			orders = append(orders, exchange.OpenOrder{
+				OrderID:         openOrderID(o.Text),
+				ExchangeOrderID: o.ID,
+				Symbol:          normalizeTickerName(pair.CurrencyPair),
+				Side:            side,
+				Qty:             o.Amount.Decimal,
+				Price:           o.Price.Decimal,
+			})
+		}
+	}
+
+	return orders, nil
+}
+
+// sideOfSize returns the side of the signed futures size: negative is short (sell).
+func sideOfSize(size int64) models.OrderSide {
+	if size < 0 {
+		return models.OrderSideSell
+	}
+	return models.OrderSideBuy
+}
+
+// openOrderID returns the order id of the bot order, uuid.Nil for orders placed not by the bot.
+func openOrderID(text string) uuid.UUID {
+	orderID, err := parseClientOrderID(text)
+	if err != nil {
+		return uuid.Nil
+	}
+	return orderID
+}
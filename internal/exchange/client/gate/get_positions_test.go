@@ -0,0 +1,81 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestClient_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, positionsURL, r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("SIGN"))
+
+		_, _ = w.Write([]byte(`[
+			{"contract":"BTC_USDT","size":-100,"entry_price":"65000.5"},
+			{"contract":"ETH_USDT","size":0,"entry_price":"0"}
+		]`))
+	}))
+	defer server.Close()
+
+	positions, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "100", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ordersURL, r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("status"))
+
+		_, _ = w.Write([]byte(`[
+			{"id":15675395,"contract":"BTC_USDT","size":-100,"left":100,"price":"66000","status":"open","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"},
+			{"id":15675396,"contract":"ETH_USDT","size":10,"left":10,"price":"3000","status":"open","text":"web"}
+		]`))
+	}))
+	defer server.Close()
+
+	orders, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetOpenOrders(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), orders[0].OrderID)
+	assert.Equal(t, "15675395", orders[0].ExchangeOrderID)
+	assert.Equal(t, models.OrderSideSell, orders[0].Side)
+	assert.Equal(t, "100", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+	assert.Equal(t, models.OrderSideBuy, orders[1].Side)
+}
+
+func TestClient_GetOpenOrders_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotOpenOrdersURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`[{"currency_pair":"BTC_USDT","total":1,"orders":[
+			{"id":"1852454420","text":"t-VQ6EAOKbQdSnFkRmVUQAAA","side":"buy","amount":"0.01","price":"64000","status":"open"}
+		]}]`))
+	}))
+	defer server.Close()
+
+	orders, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetOpenOrders(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 1)
+	assert.Equal(t, "BTCUSDT", orders[0].Symbol)
+	assert.Equal(t, "1852454420", orders[0].ExchangeOrderID)
+	assert.Equal(t, models.OrderSideBuy, orders[0].Side)
+	assert.Equal(t, "0.01", orders[0].Qty.String())
+}
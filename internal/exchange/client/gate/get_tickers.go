@@ -0,0 +1,67 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+const tickersURL = "/api/v4/futures/usdt/tickers"
+
+var percentDivider = decimal.NewFromInt(100)
+
+// GetTickers returns 24h tickers of USDT futures. All symbols are returned when symbols is empty.
+func (c *Client) GetTickers(ctx context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	if category != exchange.CategoryLinear {
+		return nil, fmt.Errorf("Gate GetTickers: unsupported category %s", category)
+	}
+
+	req, err := c.newRequest(ctx, tickersURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Gate GetTickers: failed to create request: %w", err)
+	}
+
+	var raw []dtos.TickerDTO
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("Gate GetTickers: %w", err)
+	}
+
+	filter := make(map[string]struct{}, len(symbols))
+	for _, s := range symbols {
+		filter[s] = struct{}{}
+	}
+
+	result := make([]exchange.Ticker, 0, len(raw))
+	for _, dto := range raw {
+		if !strings.HasSuffix(dto.Contract, contractSuffix) {
+			continue
+		}
+
+		t := mapTicker(dto)
+		if _, ok := filter[t.Symbol]; len(filter) > 0 && !ok {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func mapTicker(d dtos.TickerDTO) exchange.Ticker {
+	return exchange.Ticker{
+		Symbol:       normalizeTickerName(d.Contract),
+		LastPrice:    d.Last.Decimal,
+		IndexPrice:   d.IndexPrice.Decimal,
+		MarkPrice:    d.MarkPrice.Decimal,
+		Price24hPcnt: d.ChangePercentage.Div(percentDivider),
+		HighPrice24h: d.High24h.Decimal,
+		LowPrice24h:  d.Low24h.Decimal,
+		Turnover24h:  d.Volume24hQuote.Decimal,
+	}
+}
@@ -0,0 +1,42 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tickersURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`[
+			{"contract":"BTC_USDT","last":"66000","low_24h":"59000","high_24h":"67000","change_percentage":"10","volume_24h_quote":"1320000000","mark_price":"66001","index_price":"66002","funding_rate":"0.0001"},
+			{"contract":"ETH_USDT","last":"2500","low_24h":"2400","high_24h":"2600","change_percentage":"-1.5","volume_24h_quote":"25000","mark_price":"2500","index_price":"2500","funding_rate":"0.0001"}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	tickers, err := c.GetTickers(t.Context(), nil, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 2)
+
+	btc := tickers[0]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, "66000", btc.LastPrice.String())
+	assert.Equal(t, "0.1", btc.Price24hPcnt.String())
+	assert.Equal(t, "1320000000", btc.Turnover24h.String())
+
+	tickers, err = c.GetTickers(t.Context(), []string{"ETHUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+	assert.Equal(t, "-0.015", tickers[0].Price24hPcnt.String())
+}
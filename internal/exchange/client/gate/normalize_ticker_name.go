@@ -0,0 +1,45 @@
+package gate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	contractSuffix = "_USDT"
+	// clientOrderPrefix is required by Gate for user defined order ids (text field).
+	clientOrderPrefix = "t-"
+)
+
+// normalizeTickerName converts Gate contract name to the common symbol: BTC_USDT -> BTCUSDT.
+func normalizeTickerName(contract string) string {
+	return strings.TrimSuffix(contract, contractSuffix) + "USDT"
+}
+
+// denormalizeTickerName converts the common symbol to Gate contract name: BTCUSDT -> BTC_USDT.
+func denormalizeTickerName(symbol string) string {
+	return strings.TrimSuffix(symbol, "USDT") + contractSuffix
+}
+
+// clientOrderID converts order id to Gate text field. Text is limited to 28 chars including "t-" prefix,
+// so uuid is encoded as unpadded base64url of its 16 bytes (22 chars).
+func clientOrderID(orderID uuid.UUID) string {
+	return clientOrderPrefix + base64.RawURLEncoding.EncodeToString(orderID[:])
+}
+
+// parseClientOrderID is the reverse of clientOrderID. Returns an error for orders which were not placed by the bot.
+func parseClientOrderID(text string) (uuid.UUID, error) {
+	if !strings.HasPrefix(text, clientOrderPrefix) {
+		return uuid.Nil, fmt.Errorf("unexpected order text %q", text)
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(text, clientOrderPrefix))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("unexpected order text %q: %w", text, err)
+	}
+
+	return uuid.FromBytes(raw)
+}
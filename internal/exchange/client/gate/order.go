@@ -0,0 +1,221 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// Api description: https://www.gate.io/docs/developers/apiv4/#create-a-futures-order
+// Orders are placed in single position mode, positions are closed with reduce_only orders.
+// Order size is in whole contracts, sell orders have negative size.
+// Spot: https://www.gate.io/docs/developers/apiv4/#create-an-order, amount is in base coin.
+// Spot market buy amount is in quote coin, so such orders are rejected, limit ioc is to be used instead.
+
+const (
+	ordersURL     = "/api/v4/futures/usdt/orders"
+	orderURL      = "/api/v4/futures/usdt/orders/%s"
+	spotOrdersURL = "/api/v4/spot/orders"
+	spotOrderURL  = "/api/v4/spot/orders/%s"
+)
+
+// CreateOrder sends an order to the exchange.
+// On success, mutates order: sets ExchangeOrderID, ExchangeName, Status, RawResponse.
+func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	if order.Market == models.OrderMarketSpot {
+		if order.Type == models.OrderTypeMarket && order.Side == models.OrderSideBuy {
+			return fmt.Errorf("Gate client spot market buy is not supported, amount of market buy is in quote coin")
+		}
+		return c.submitSpotOrder(ctx, order, mapRequestDataToSpotOrderDTO(order))
+	}
+
+	return c.submitOrder(ctx, order, mapRequestDataToOrderDTO(order))
+}
+
+// CloseOrder closes an existing position by placing a reduce-only market order in the opposite direction.
+func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	if order.Market == models.OrderMarketSpot {
+		return c.closeSpotOrder(ctx, order)
+	}
+
+	// flip side to close the position
+	size := order.Quantity.IntPart()
+	if order.Side == models.OrderSideBuy {
+		size = -size
+	}
+
+	payload := dtos.PlaceOrderDTO{
+		Contract:   denormalizeTickerName(order.Symbol),
+		Size:       size,
+		Price:      "0",
+		Tif:        dtos.TimeInForceIOC,
+		Text:       clientOrderID(order.ID),
+		ReduceOnly: true,
+	}
+
+	return c.submitOrder(ctx, order, payload)
+}
+
+func (c *Client) submitOrder(ctx context.Context, order *models.Order, payload dtos.PlaceOrderDTO) error {
+	req, err := c.newSignedRequest(ctx, http.MethodPost, ordersURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("Gate client failed to create order request: %w", err)
+	}
+
+	var raw dtos.OrderDTO
+	body, err := c.do(req, &raw)
+	if err != nil {
+		return fmt.Errorf("Gate client order failed: %w", err)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	confirmed.ExchangeOrderID = strconv.FormatInt(raw.ID, 10)
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusNew
+
+	return nil
+}
+
+// closeSpotOrder sells the bought coins back (or buys back the sold ones) with a market order.
+// Spot has no positions, so there is no reduce only.
+func (c *Client) closeSpotOrder(ctx context.Context, order *models.Order) error {
+	side := models.OrderSideSell
+	if order.Side == models.OrderSideSell {
+		side = models.OrderSideBuy
+	}
+
+	if side == models.OrderSideBuy {
+		return fmt.Errorf("Gate client spot market buy is not supported, amount of market buy is in quote coin")
+	}
+
+	payload := dtos.PlaceSpotOrderDTO{
+		CurrencyPair: denormalizeTickerName(order.Symbol),
+		Type:         "market",
+		Account:      "spot",
+		Side:         strings.ToLower(string(side)),
+		Amount:       order.Quantity.String(),
+		TimeInForce:  dtos.TimeInForceIOC,
+		Text:         clientOrderID(order.ID),
+	}
+
+	return c.submitSpotOrder(ctx, order, payload)
+}
+
+func (c *Client) submitSpotOrder(ctx context.Context, order *models.Order, payload dtos.PlaceSpotOrderDTO) error {
+	req, err := c.newSignedRequest(ctx, http.MethodPost, spotOrdersURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("Gate client failed to create spot order request: %w", err)
+	}
+
+	var raw dtos.SpotOrderDTO
+	body, err := c.do(req, &raw)
+	if err != nil {
+		return fmt.Errorf("Gate client spot order failed: %w", err)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	confirmed.ExchangeOrderID = raw.ID
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusNew
+
+	return nil
+}
+
+func validateBeforeCreateOrder(order *models.Order) error {
+	if order.Market != models.OrderMarketLinear && order.Market != models.OrderMarketSpot {
+		return fmt.Errorf("Gate client supports only linear and spot markets")
+	}
+
+	if order.Quantity.LessThanOrEqual(decimal.NewFromInt(0)) {
+		return fmt.Errorf("Gate client order quantity must be greater than 0")
+	}
+
+	if order.Market == models.OrderMarketLinear && !order.Quantity.Equal(order.Quantity.Truncate(0)) {
+		return fmt.Errorf("Gate client order quantity must be a whole number of contracts, got %s", order.Quantity)
+	}
+
+	if len(order.Symbol) <= 0 {
+		return fmt.Errorf("Gate client order symbol must be specified")
+	}
+
+	if order.ID == uuid.Nil {
+		return fmt.Errorf("Gate client order id must be valid uuid")
+	}
+
+	return nil
+}
+
+func mapRequestDataToOrderDTO(order *models.Order) dtos.PlaceOrderDTO {
+	size := order.Quantity.IntPart()
+	if order.Side == models.OrderSideSell {
+		size = -size
+	}
+
+	payload := dtos.PlaceOrderDTO{
+		Contract: denormalizeTickerName(order.Symbol),
+		Size:     size,
+		Price:    "0",
+		Tif:      dtos.TimeInForceIOC,
+		Text:     clientOrderID(order.ID),
+	}
+
+	if order.Type == models.OrderTypeLimit {
+		payload.Price = order.Price.String()
+		payload.Tif = mapTimeInForce(order.TimeInForce)
+	}
+
+	return payload
+}
+
+func mapRequestDataToSpotOrderDTO(order *models.Order) dtos.PlaceSpotOrderDTO {
+	payload := dtos.PlaceSpotOrderDTO{
+		CurrencyPair: denormalizeTickerName(order.Symbol),
+		Type:         "market",
+		Account:      "spot",
+		Side:         strings.ToLower(string(order.Side)),
+		Amount:       order.Quantity.String(),
+		TimeInForce:  dtos.TimeInForceIOC,
+		Text:         clientOrderID(order.ID),
+	}
+
+	if order.Type == models.OrderTypeLimit {
+		payload.Type = "limit"
+		payload.Price = order.Price.String()
+		payload.TimeInForce = mapTimeInForce(order.TimeInForce)
+	}
+
+	return payload
+}
+
+// mapTimeInForce maps the generic models.TimeInForce to Gate-specific values.
+// Defaults to GTC when unspecified.
+func mapTimeInForce(tif models.TimeInForce) dtos.TimeInForce {
+	switch tif {
+	case models.TimeInForceIOC:
+		return dtos.TimeInForceIOC
+	case models.TimeInForceFOK:
+		return dtos.TimeInForceFOK
+	case models.TimeInForcePostOnly:
+		return dtos.TimeInForcePostOnly
+	default:
+		return dtos.TimeInForceGTC
+	}
+}
@@ -0,0 +1,267 @@
+package gate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const testOrderText = "t-VQ6EAOKbQdSnFkRmVUQAAA"
+
+func newTestOrder() *models.Order {
+	return &models.Order{
+		ID:       uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketLinear,
+		Quantity: decimal.RequireFromString("100"),
+	}
+}
+
+func decodePlaceOrder(t *testing.T, r *http.Request) dtos.PlaceOrderDTO {
+	t.Helper()
+
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, ordersURL, r.URL.Path)
+	assert.Equal(t, "test-api-key", r.Header.Get("KEY"))
+
+	var payload dtos.PlaceOrderDTO
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+	return payload
+}
+
+func TestClient_CreateOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dtos.PlaceOrderDTO{
+			Contract: "BTC_USDT",
+			Size:     100,
+			Price:    "0",
+			Tif:      dtos.TimeInForceIOC,
+			Text:     testOrderText,
+		}, decodePlaceOrder(t, r))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":15675394,"contract":"BTC_USDT","size":100,"left":0,"price":"0","fill_price":"65000.1","status":"finished","finish_as":"filled","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+
+	assert.Equal(t, "Gate", order.ExchangeName)
+	assert.Equal(t, "15675394", order.ExchangeOrderID)
+	assert.Equal(t, models.OrderStatusNew, order.Status)
+	assert.NotEmpty(t, order.RawResponse)
+}
+
+func TestClient_CreateOrder_Limit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceOrder(t, r)
+		assert.Equal(t, int64(-100), payload.Size)
+		assert.Equal(t, "65100.5", payload.Price)
+		assert.Equal(t, dtos.TimeInForcePostOnly, payload.Tif)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":15675395,"status":"open"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Side = models.OrderSideSell
+	order.Type = models.OrderTypeLimit
+	order.Price = decimal.RequireFromString("65100.5")
+	order.TimeInForce = models.TimeInForcePostOnly
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "15675395", order.ExchangeOrderID)
+}
+
+func TestClient_CreateOrder_Validation(t *testing.T) {
+	c := NewClient(getTestConfig(""), zerolog.Nop())
+
+	order := newTestOrder()
+	order.Quantity = decimal.Zero
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+
+	// Gate trades in whole contracts
+	order = newTestOrder()
+	order.Quantity = decimal.RequireFromString("1.5")
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+
+	order = newTestOrder()
+	order.Market = models.OrderMarket("INVERSE")
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+
+	// amount of spot market buy is in quote coin
+	order = newTestOrder()
+	order.Market = models.OrderMarketSpot
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+}
+
+func TestClient_CloseOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceOrder(t, r)
+		// open order was buy, close flips the side
+		assert.Equal(t, int64(-100), payload.Size)
+		assert.Equal(t, "0", payload.Price)
+		assert.Equal(t, dtos.TimeInForceIOC, payload.Tif)
+		assert.True(t, payload.ReduceOnly)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":15675396,"status":"finished"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+	assert.Equal(t, "15675396", order.ExchangeOrderID)
+}
+
+func TestClient_CancelOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v4/futures/usdt/orders/15675395", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"id":15675395,"status":"finished","finish_as":"cancelled"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	err := c.CancelOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "15675395", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+}
+
+func TestClient_GetOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/v4/futures/usdt/orders/" + testOrderText:
+			_, _ = w.Write([]byte(`{"id":15675394,"contract":"BTC_USDT","size":100,"left":0,"price":"0","fill_price":"65000.1","status":"finished","finish_as":"filled","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}`))
+		case myTradesURL:
+			assert.Equal(t, "BTC_USDT", r.URL.Query().Get("contract"))
+			assert.Equal(t, "15675394", r.URL.Query().Get("order"))
+			_, _ = w.Write([]byte(`[
+				{"id":121234231,"order_id":"15675394","contract":"BTC_USDT","size":60,"price":"65000","role":"taker","fee":"0.195"},
+				{"id":121234232,"order_id":"15675394","contract":"BTC_USDT","size":40,"price":"65000.25","role":"taker","fee":"0.13"}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	orderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	order, err := c.GetOrder(t.Context(), orderID, "", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	assert.Equal(t, orderID, order.OrderID)
+	assert.Equal(t, "15675394", order.ExchangeOrderID)
+	assert.Equal(t, "Gate", order.ExchangeName)
+	assert.Equal(t, "65000.1", order.AvgPrice.String())
+	assert.Equal(t, "-0.325", order.Fees.String())
+}
+
+func decodePlaceSpotOrder(t *testing.T, r *http.Request) dtos.PlaceSpotOrderDTO {
+	t.Helper()
+
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, spotOrdersURL, r.URL.Path)
+
+	var payload dtos.PlaceSpotOrderDTO
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+	return payload
+}
+
+func TestClient_CreateOrder_SpotLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dtos.PlaceSpotOrderDTO{
+			CurrencyPair: "BTC_USDT",
+			Type:         "limit",
+			Account:      "spot",
+			Side:         "buy",
+			Amount:       "0.01",
+			Price:        "65000",
+			TimeInForce:  dtos.TimeInForceIOC,
+			Text:         testOrderText,
+		}, decodePlaceSpotOrder(t, r))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1852454420","text":"t-VQ6EAOKbQdSnFkRmVUQAAA","status":"closed"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+	order.Type = models.OrderTypeLimit
+	order.Price = decimal.RequireFromString("65000")
+	order.TimeInForce = models.TimeInForceIOC
+	order.Quantity = decimal.RequireFromString("0.01")
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "1852454420", order.ExchangeOrderID)
+}
+
+func TestClient_CloseOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceSpotOrder(t, r)
+		assert.Equal(t, "sell", payload.Side)
+		assert.Equal(t, "market", payload.Type)
+		assert.Equal(t, "0.01", payload.Amount)
+		assert.Empty(t, payload.Price)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1852454421","status":"closed"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+	order.Quantity = decimal.RequireFromString("0.01")
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+	assert.Equal(t, "1852454421", order.ExchangeOrderID)
+}
+
+func TestClient_GetOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/spot/orders/1852454420", r.URL.Path)
+		assert.Equal(t, "BTC_USDT", r.URL.Query().Get("currency_pair"))
+
+		_, _ = w.Write([]byte(`{"id":"1852454420","text":"t-VQ6EAOKbQdSnFkRmVUQAAA","side":"buy","status":"closed","avg_deal_price":"65000","filled_amount":"0.01","fee":"0.00002","fee_currency":"BTC"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	order, err := c.GetOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "1852454420", "BTCUSDT", exchange.CategorySpot)
+	require.NoError(t, err)
+
+	assert.Equal(t, "65000", order.AvgPrice.String())
+	// fee of spot buy is in base coin
+	assert.Equal(t, "-1.3", order.Fees.String())
+}
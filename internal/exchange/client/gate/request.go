@@ -0,0 +1,114 @@
+package gate
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// apiError is an error response of Gate API, returned with non 2xx http status.
+type apiError struct {
+	Label   string `json:"label"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("Gate api error, %s, %s", e.Label, e.Message)
+}
+
+// sign returns hex encoded HMAC SHA512 of the message.
+func sign(secret, message string) string {
+	h := hmac.New(sha512.New, []byte(secret))
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashPayload(body []byte) string {
+	h := sha512.Sum512(body)
+	return hex.EncodeToString(h[:])
+}
+
+// newRequest creates a public (unsigned) GET request.
+func (c *Client) newRequest(ctx context.Context, path string, query url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return req, nil
+}
+
+// newSignedRequest creates an APIv4 signed request, payload is sent as JSON body.
+// Signature string is method\npath\nquery\nhex(sha512(body))\ntimestamp.
+// Docs: https://www.gate.io/docs/developers/apiv4/#apiv4-signed-request-requirements
+func (c *Client) newSignedRequest(ctx context.Context, method string, path string, query url.Values, payload interface{}) (*http.Request, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	rawQuery := query.Encode()
+	req.URL.RawQuery = rawQuery
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	message := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", method, path, rawQuery, hashPayload(body), timestamp)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("KEY", c.cfg.Exchange.Gate.APIKey)
+	req.Header.Set("Timestamp", timestamp)
+	req.Header.Set("SIGN", sign(c.cfg.Exchange.Gate.APISecret, message))
+
+	return req, nil
+}
+
+// do sends the request and unmarshals the response body into out.
+// Gate returns errors as {"label": "INVALID_PARAM_VALUE", "message": "..."} with non 2xx http status.
+func (c *Client) do(req *http.Request, out interface{}) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Label != "" {
+			return nil, &apiErr
+		}
+		return nil, fmt.Errorf("unexpected http status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if out == nil {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
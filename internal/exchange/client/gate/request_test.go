@@ -0,0 +1,89 @@
+package gate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+func getTestConfig(baseURL string) *config.Config {
+	return &config.Config{
+		Exchange: config.ExchangeConfig{
+			Gate: config.GateConfig{
+				APIBaseURL: baseURL,
+				APIKey:     "test-api-key",
+				APISecret:  "test-api-secret",
+			},
+		},
+	}
+}
+
+func TestClient_NewSignedRequest(t *testing.T) {
+	c := NewClient(getTestConfig("https://api.gateio.ws"), zerolog.Nop())
+
+	req, err := c.newSignedRequest(t.Context(), http.MethodPost, ordersURL, url.Values{"settle": {"usdt"}}, map[string]string{"contract": "BTC_USDT"})
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contract":"BTC_USDT"}`, string(body))
+
+	timestamp := req.Header.Get("Timestamp")
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "test-api-key", req.Header.Get("KEY"))
+
+	message := fmt.Sprintf("POST\n%s\nsettle=usdt\n%s\n%s", ordersURL, hashPayload(body), timestamp)
+	assert.Equal(t, sign("test-api-secret", message), req.Header.Get("SIGN"))
+}
+
+func TestHashPayload_Empty(t *testing.T) {
+	// hex(sha512("")) is used for requests without body
+	assert.Equal(t, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", hashPayload(nil))
+}
+
+func TestClient_Do_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"label":"INSUFFICIENT_AVAILABLE","message":"Insufficient available balance"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	req, err := c.newRequest(t.Context(), tickersURL, nil)
+	require.NoError(t, err)
+
+	_, err = c.do(req, nil)
+
+	var apiErr *apiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "INSUFFICIENT_AVAILABLE", apiErr.Label)
+	assert.Equal(t, "Insufficient available balance", apiErr.Message)
+}
+
+func TestClientOrderID(t *testing.T) {
+	orderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	text := clientOrderID(orderID)
+	assert.Equal(t, "t-VQ6EAOKbQdSnFkRmVUQAAA", text)
+	assert.LessOrEqual(t, len(text), 28)
+
+	parsed, err := parseClientOrderID(text)
+	require.NoError(t, err)
+	assert.Equal(t, orderID, parsed)
+
+	_, err = parseClientOrderID("web")
+	assert.Error(t, err)
+	_, err = parseClientOrderID("t-manual-order")
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Docs: https://www.gate.io/docs/developers/apiv4/#update-position-leverage
+
+const leverageURL = "/api/v4/futures/usdt/positions/%s/leverage"
+
+// SetLeverage sets the leverage of a contract position in the margin mode set by SetMarginMode, cross by default.
+// Gate selects the margin mode by the leverage: 0 with cross_leverage_limit is cross, a positive one is isolated.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	query := url.Values{
+		"leverage": {strconv.FormatInt(leverage, 10)},
+	}
+	if c.margin.Mode(symbol) == exchange.MarginModeCross {
+		query.Set("leverage", "0")
+		query.Set("cross_leverage_limit", strconv.FormatInt(leverage, 10))
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodPost, fmt.Sprintf(leverageURL, denormalizeTickerName(symbol)), query, nil)
+	if err != nil {
+		return fmt.Errorf("Gate SetLeverage: failed to create request: %w", err)
+	}
+
+	if _, err := c.do(req, nil); err != nil {
+		return fmt.Errorf("Gate SetLeverage: %w", err)
+	}
+
+	return nil
+}
+
+// SetMarginMode sets the margin mode of a contract. It's applied by the next SetLeverage on the symbol.
+func (c *Client) SetMarginMode(_ context.Context, symbol string, mode exchange.MarginMode) error {
+	c.margin.SetMode(symbol, mode)
+	return nil
+}
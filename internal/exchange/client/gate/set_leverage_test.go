@@ -0,0 +1,44 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_SetLeverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/futures/usdt/positions/BTC_USDT/leverage", r.URL.Path)
+		assert.Equal(t, "0", r.URL.Query().Get("leverage"))
+		assert.Equal(t, "5", r.URL.Query().Get("cross_leverage_limit"))
+
+		_, _ = w.Write([]byte(`{"contract":"BTC_USDT","size":0,"leverage":"0","cross_leverage_limit":"5","mode":"single"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+}
+
+func TestClient_SetLeverage_Isolated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "3", r.URL.Query().Get("leverage"))
+		assert.False(t, r.URL.Query().Has("cross_leverage_limit"))
+
+		_, _ = w.Write([]byte(`{"contract":"BTC_USDT","size":0,"leverage":"3","cross_leverage_limit":"0","mode":"single"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated))
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 3))
+}
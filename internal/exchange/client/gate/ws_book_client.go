@@ -0,0 +1,333 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+const (
+	bookTickerChannel = "futures.book_ticker"
+	// orderBookChannel pushes a snapshot of orderBookDepth levels (event all) and level changes (event update).
+	orderBookChannel = "futures.order_book"
+	orderBookDepth   = "20"
+
+	eventAll = "all"
+)
+
+// wsBookClient represents a WebSocket client streaming orderbook of Gate USDT futures.
+// T is exchange.BookTop for book_ticker or exchange.OrderBookUpdate for order_book.
+type wsBookClient[T any] struct {
+	Metrics    *Metrics
+	cfg        *config.Config
+	wsMu       sync.Mutex
+	backoff    *exchange.Backoff
+	logger     zerolog.Logger
+	channel    string
+	subscribe  func(symbols []string) []map[string]interface{}
+	mapMessage func(push *dtos.WsMessageDTO) ([]T, error)
+}
+
+func newWsBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		channel:    bookTickerChannel,
+		subscribe:  bookTickerSubscriptions,
+		mapMessage: mapBookTicker,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		channel:    orderBookChannel,
+		subscribe:  orderBookSubscriptions,
+		mapMessage: mapOrderBook,
+	}
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the channel of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.Gate.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gate failed to dial websocket: %w", err)
+	}
+
+	for _, payload := range c.subscribe(symbols) {
+		if err = c.writeJSON(wsConn, payload); err != nil {
+			_ = wsConn.Close()
+			return nil, fmt.Errorf("gate failed to subscribe to symbols: %w", err)
+		}
+	}
+
+	return wsConn, nil
+}
+
+// serve reads book messages from the connection and reconnects with backoff until ctx is done.
+// On resubscribe Gate sends a new order book snapshot, so the local book is rebuilt.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("gate failed to reconnect to Gate book websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("gate reconnected to Gate book websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) readMessage(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("gate failed to read message from Gate book websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		var push dtos.WsMessageDTO
+		if err = json.Unmarshal(messageByte, &push); err != nil {
+			c.logger.Warn().Err(err).Msgf("gate failed to unmarshal message from Gate %s websocket: %s", c.channel, messageByte)
+			continue
+		}
+
+		if push.Error != nil {
+			c.logger.Warn().Int("code", push.Error.Code).Str("msg", push.Error.Message).Msgf("gate %s websocket error", c.channel)
+			continue
+		}
+
+		// subscribe responses and pongs
+		if push.Channel != c.channel || (push.Event != eventUpdate && push.Event != eventAll) {
+			continue
+		}
+
+		updates, err := c.mapMessage(&push)
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("gate failed to map message from Gate %s websocket: %s", c.channel, messageByte)
+			continue
+		}
+
+		for _, update := range updates {
+			select {
+			case outChan <- update:
+			default:
+				c.Metrics.droppedBookUpdates.Add(1)
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeJSON(wsConn, pingPayload()); err != nil {
+				c.logger.Warn().Err(err).Msg("gate failed to send ping to Gate book websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCnt := c.Metrics.droppedBookUpdates.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("Gate %s metrics: dropped book updates=%d, reconnects=%d", c.channel, droppedCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+// bookTickerSubscriptions subscribes all symbols with one request.
+func bookTickerSubscriptions(symbols []string) []map[string]interface{} {
+	contracts := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		contracts = append(contracts, denormalizeTickerName(symbol))
+	}
+
+	return []map[string]interface{}{subscribePayload(bookTickerChannel, contracts)}
+}
+
+// orderBookSubscriptions subscribes every symbol separately, payload is [contract, depth, accuracy].
+func orderBookSubscriptions(symbols []string) []map[string]interface{} {
+	payloads := make([]map[string]interface{}, 0, len(symbols))
+	for _, symbol := range symbols {
+		payloads = append(payloads, subscribePayload(orderBookChannel, []string{denormalizeTickerName(symbol), orderBookDepth, "0"}))
+	}
+
+	return payloads
+}
+
+func mapBookTicker(push *dtos.WsMessageDTO) ([]exchange.BookTop, error) {
+	var dto dtos.WsBookTickerDTO
+	if err := json.Unmarshal(push.Result, &dto); err != nil {
+		return nil, err
+	}
+
+	return []exchange.BookTop{{
+		Symbol:   normalizeTickerName(dto.Contract),
+		Category: exchange.CategoryLinear,
+		Ts:       dto.T,
+		BidPrice: float64(dto.BidPrice),
+		BidQty:   float64(dto.BidSize),
+		AskPrice: float64(dto.AskPrice),
+		AskQty:   float64(dto.AskSize),
+	}}, nil
+}
+
+// mapOrderBook maps order_book snapshot (event all) or level changes (event update) to exchange.OrderBookUpdate.
+func mapOrderBook(push *dtos.WsMessageDTO) ([]exchange.OrderBookUpdate, error) {
+	if push.Event == eventAll {
+		var dto dtos.WsOrderBookDTO
+		if err := json.Unmarshal(push.Result, &dto); err != nil {
+			return nil, err
+		}
+
+		return []exchange.OrderBookUpdate{{
+			Symbol:   normalizeTickerName(dto.Contract),
+			Category: exchange.CategoryLinear,
+			Ts:       dto.T,
+			Snapshot: true,
+			Bids:     mapPriceLevels(dto.Bids),
+			Asks:     mapPriceLevels(dto.Asks),
+		}}, nil
+	}
+
+	var levels []dtos.WsOrderBookUpdateDTO
+	if err := json.Unmarshal(push.Result, &levels); err != nil {
+		return nil, err
+	}
+
+	return mapOrderBookDeltas(levels, push.Time*1000), nil
+}
+
+// mapOrderBookDeltas groups level changes by contract. The side of a level is the sign of its size, a removed level (size 0)
+// has no side, so every change clears the price on the other side: a level can't be a bid and an ask at the same time.
+func mapOrderBookDeltas(levels []dtos.WsOrderBookUpdateDTO, ts int64) []exchange.OrderBookUpdate {
+	res := make([]exchange.OrderBookUpdate, 0, 1)
+	index := make(map[string]int, 1)
+
+	for _, l := range levels {
+		i, ok := index[l.Contract]
+		if !ok {
+			i = len(res)
+			index[l.Contract] = i
+			res = append(res, exchange.OrderBookUpdate{
+				Symbol:   normalizeTickerName(l.Contract),
+				Category: exchange.CategoryLinear,
+				Ts:       ts,
+			})
+		}
+
+		price := float64(l.P)
+		bid := exchange.PriceLevel{Price: price}
+		ask := exchange.PriceLevel{Price: price}
+		if l.S > 0 {
+			bid.Qty = float64(l.S)
+		} else {
+			ask.Qty = float64(-l.S)
+		}
+
+		res[i].Bids = append(res[i].Bids, bid)
+		res[i].Asks = append(res[i].Asks, ask)
+	}
+
+	return res
+}
+
+func mapPriceLevels(levels []dtos.WsPriceLevelDTO) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, len(levels))
+	for i, l := range levels {
+		res[i] = exchange.PriceLevel{Price: float64(l.P), Qty: float64(l.S)}
+	}
+	return res
+}
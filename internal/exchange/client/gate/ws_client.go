@@ -0,0 +1,269 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+// Docs: https://www.gate.io/docs/developers/futures/ws/en/
+
+const (
+	tradesChannel = "futures.trades"
+	pingChannel   = "futures.ping"
+	pingInterval  = 20 * time.Second
+
+	eventSubscribe = "subscribe"
+	eventUpdate    = "update"
+)
+
+// Metrics holds metrics related to websocket client operations.
+type Metrics struct {
+	droppedTrades      atomic.Uint64
+	droppedBookUpdates atomic.Uint64
+	reconnects         atomic.Uint64
+}
+
+// wsClient represents a WebSocket client streaming trades of Gate USDT futures.
+type wsClient struct {
+	Metrics *Metrics
+	cfg     *config.Config
+	wsMu    sync.Mutex
+	backoff *exchange.Backoff
+	logger  zerolog.Logger
+}
+
+func newWsClient(cfg *config.Config, logger zerolog.Logger) *wsClient {
+	return &wsClient{
+		Metrics: &Metrics{},
+		cfg:     cfg,
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:  logger,
+	}
+}
+
+func (c *wsClient) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsClient) Start(ctx context.Context, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) error {
+	if category != exchange.CategoryLinear {
+		return fmt.Errorf("gate websocket supports only %s trades", exchange.CategoryLinear)
+	}
+
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, category, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the trades of the given symbols.
+func (c *wsClient) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.Gate.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gate failed to dial websocket: %w", err)
+	}
+
+	contracts := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		contracts = append(contracts, denormalizeTickerName(symbol))
+	}
+
+	if err = c.writeJSON(wsConn, subscribePayload(tradesChannel, contracts)); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("gate failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads trades from the connection and reconnects with backoff (replaying subscriptions) until ctx is done.
+func (c *wsClient) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, category, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsClient) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("gate failed to reconnect to Gate websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("gate reconnected to Gate websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsClient) readMessage(ctx context.Context, wsConn *websocket.Conn, category exchange.Category, outChan chan<- exchange.Trade) {
+	defer func() {
+		err := wsConn.Close()
+		if err != nil && ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("gate failed to close websocket connection")
+		}
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("gate failed to read message from Gate websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		for _, trade := range c.mapTrades(messageByte, category) {
+			select {
+			case outChan <- trade:
+			default:
+				c.Metrics.droppedTrades.Add(1)
+			}
+		}
+	}
+}
+
+// mapTrades maps futures.trades update to exchange.Trade. Subscribe responses and pongs are skipped, errors are logged.
+func (c *wsClient) mapTrades(message []byte, category exchange.Category) []exchange.Trade {
+	var push dtos.WsMessageDTO
+	if err := json.Unmarshal(message, &push); err != nil {
+		c.logger.Warn().Err(err).Msg("gate failed to unmarshal message from Gate websocket")
+		return nil
+	}
+
+	if push.Error != nil {
+		c.logger.Warn().Int("code", push.Error.Code).Str("msg", push.Error.Message).Msg("gate websocket error")
+		return nil
+	}
+
+	if push.Channel != tradesChannel || push.Event != eventUpdate {
+		return nil
+	}
+
+	var data []dtos.WsTradeDTO
+	if err := json.Unmarshal(push.Result, &data); err != nil {
+		c.logger.Warn().Err(err).Msg("gate failed to unmarshal trade message from Gate websocket")
+		return nil
+	}
+
+	trades := make([]exchange.Trade, 0, len(data))
+	for _, val := range data {
+		side := exchange.Buy
+		volume := val.Size
+		if val.Size < 0 {
+			side = exchange.Sell
+			volume = -val.Size
+		}
+
+		trades = append(trades, exchange.Trade{
+			Symbol:   normalizeTickerName(val.Contract),
+			Category: category,
+			Ts:       val.CreateTimeMs,
+			Price:    float64(val.Price),
+			Volume:   float64(volume),
+			Side:     side,
+		})
+	}
+
+	return trades
+}
+
+func (c *wsClient) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeJSON(wsConn, pingPayload()); err != nil {
+				c.logger.Warn().Err(err).Msg("gate failed to send ping to Gate websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsClient) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedTradesCnt := c.Metrics.droppedTrades.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedTradesCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("Gate metrics: dropped trades=%d, reconnects=%d", droppedTradesCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+// subscribePayload builds a subscribe request to the channel.
+func subscribePayload(channel string, payload []string) map[string]interface{} {
+	return map[string]interface{}{
+		"time":    time.Now().Unix(),
+		"channel": channel,
+		"event":   eventSubscribe,
+		"payload": payload,
+	}
+}
+
+// pingPayload builds an application level ping, Gate answers with futures.pong.
+func pingPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"time":    time.Now().Unix(),
+		"channel": pingChannel,
+	}
+}
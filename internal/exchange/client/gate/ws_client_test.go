@@ -0,0 +1,161 @@
+package gate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+var testUpgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+type testSubscription struct {
+	Time    int64             `json:"time"`
+	Channel string            `json:"channel"`
+	Event   string            `json:"event"`
+	Payload []string          `json:"payload"`
+	Auth    map[string]string `json:"auth"`
+}
+
+// newTestWsServer starts a websocket stand-in, which checks the subscription requests and pushes messages.
+func newTestWsServer(t *testing.T, channel string, wantPayloads [][]string, messages ...string) (*httptest.Server, *config.Config) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		for _, want := range wantPayloads {
+			var sub testSubscription
+			require.NoError(t, conn.ReadJSON(&sub))
+			assert.Equal(t, channel, sub.Channel)
+			assert.Equal(t, "subscribe", sub.Event)
+			assert.Equal(t, want, sub.Payload)
+		}
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"time":1760000000,"channel":"`+channel+`","event":"subscribe","result":{"status":"success"}}`)))
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			Gate: config.GateConfig{
+				WSUrl: "ws" + strings.TrimPrefix(server.URL, "http"),
+			},
+		},
+	}
+
+	return server, cfg
+}
+
+func TestWsClient_Trades(t *testing.T) {
+	server, cfg := newTestWsServer(t, "futures.trades", [][]string{{"BTC_USDT", "ETH_USDT"}},
+		`{"time":1760000000,"time_ms":1760000000123,"channel":"futures.trades","event":"update","result":[
+			{"size":-108,"id":27753479,"create_time":1760000000,"create_time_ms":1760000000120,"price":"65000.1","contract":"BTC_USDT"},
+			{"size":5,"id":27753480,"create_time":1760000000,"create_time_ms":1760000000121,"price":"2450","contract":"ETH_USDT"}
+		]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	trades := make(chan exchange.Trade, 10)
+	client := newWsClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT", "ETHUSDT"}, exchange.CategoryLinear, trades))
+
+	assert.Equal(t, exchange.Trade{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1760000000120,
+		Price:    65000.1,
+		Volume:   108,
+		Side:     exchange.Sell,
+	}, <-trades)
+
+	second := <-trades
+	assert.Equal(t, "ETHUSDT", second.Symbol)
+	assert.Equal(t, exchange.Buy, second.Side)
+	assert.Equal(t, float64(5), second.Volume)
+}
+
+func TestWsBookClient_BookTicker(t *testing.T) {
+	server, cfg := newTestWsServer(t, "futures.book_ticker", [][]string{{"BTC_USDT"}},
+		`{"time":1760000000,"time_ms":1760000000123,"channel":"futures.book_ticker","event":"update","result":{"t":1760000000120,"u":3502782378,"s":"BTC_USDT","b":"65000.1","B":31,"a":"65000.2","A":40}}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.BookTop, 10)
+	client := newWsBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, out))
+
+	assert.Equal(t, exchange.BookTop{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1760000000120,
+		BidPrice: 65000.1,
+		BidQty:   31,
+		AskPrice: 65000.2,
+		AskQty:   40,
+	}, <-out)
+}
+
+func TestWsBookClient_OrderBook(t *testing.T) {
+	server, cfg := newTestWsServer(t, "futures.order_book", [][]string{{"BTC_USDT", "20", "0"}, {"ETH_USDT", "20", "0"}},
+		`{"time":1760000000,"channel":"futures.order_book","event":"all","result":{"t":1760000000120,"id":93973511,"contract":"BTC_USDT",
+			"asks":[{"p":"65000.2","s":7}],"bids":[{"p":"65000.1","s":15},{"p":"65000","s":20}]}}`,
+		`{"time":1760000001,"channel":"futures.order_book","event":"update","result":[
+			{"p":"65000.1","s":0,"c":"BTC_USDT","id":93973512},
+			{"p":"65000.3","s":-4,"c":"BTC_USDT","id":93973513}
+		]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.OrderBookUpdate, 10)
+	client := newWsOrderBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT", "ETHUSDT"}, out))
+
+	assert.Equal(t, exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1760000000120,
+		Snapshot: true,
+		Bids:     []exchange.PriceLevel{{Price: 65000.1, Qty: 15}, {Price: 65000, Qty: 20}},
+		Asks:     []exchange.PriceLevel{{Price: 65000.2, Qty: 7}},
+	}, <-out)
+
+	assert.Equal(t, exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1760000001000,
+		Bids:     []exchange.PriceLevel{{Price: 65000.1, Qty: 0}, {Price: 65000.3, Qty: 0}},
+		Asks:     []exchange.PriceLevel{{Price: 65000.1, Qty: 0}, {Price: 65000.3, Qty: 4}},
+	}, <-out)
+}
@@ -0,0 +1,251 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+// Docs: https://www.gate.io/docs/developers/futures/ws/en/#orders-api
+
+const ordersChannel = "futures.orders"
+
+// WsPrivateClient handles the private channels of Gate futures websocket (futures.orders).
+type WsPrivateClient struct {
+	cfg    *config.Config
+	rest   *Client
+	logger zerolog.Logger
+	wsConn *websocket.Conn
+	wsMu   sync.Mutex
+
+	// filled holds the last reported filled size of open orders, order updates are not only fills.
+	filled map[int64]int64
+
+	executionChannel    chan exchange.OrderExecutionEvent
+	executionSubscribed bool
+}
+
+// NewWsPrivateClient initializes a WsPrivateClient with the given configuration and logger for private WebSocket connections.
+func NewWsPrivateClient(cfg *config.Config, logger zerolog.Logger) *WsPrivateClient {
+	return &WsPrivateClient{
+		cfg:    cfg,
+		rest:   NewClient(cfg, logger),
+		logger: logger,
+		filled: make(map[int64]int64),
+
+		executionChannel:    make(chan exchange.OrderExecutionEvent, 100),
+		executionSubscribed: false,
+	}
+}
+
+// Start connects to the websocket, subscribes to orders of the account and starts message handling and ping routines.
+// futures.orders requires the user id, it is taken from the futures account.
+func (c *WsPrivateClient) Start(ctx context.Context) error {
+	if c.executionSubscribed {
+		return nil
+	}
+
+	account, err := c.rest.getAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("Gate ws private: failed to get user id: %w", err)
+	}
+
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.Gate.WSUrl, nil)
+	if err != nil {
+		return fmt.Errorf("Gate ws private: failed to connect to websocket: %w", err)
+	}
+
+	c.wsConn = wsConn
+
+	if err := c.writeJSON(c.subscribePayload(strconv.FormatInt(account.User, 10))); err != nil {
+		_ = wsConn.Close()
+		return fmt.Errorf("Gate ws private: failed to subscribe to orders: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = wsConn.Close()
+	}()
+
+	go c.pingPongInterval(ctx)
+
+	go func() {
+		defer c.closeChannels()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := c.handleMessage()
+				if err != nil {
+					c.logger.Error().Err(err).Str("exchange", "Gate").Msg("error processing message")
+					_ = wsConn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	c.executionSubscribed = true
+
+	return nil
+}
+
+// SubscribeToExecutions returns the execution events channel.
+func (c *WsPrivateClient) SubscribeToExecutions() (<-chan exchange.OrderExecutionEvent, error) {
+	return c.executionChannel, nil
+}
+
+func (c *WsPrivateClient) writeJSON(payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return c.wsConn.WriteJSON(payload)
+}
+
+// subscribePayload builds an authenticated subscribe request, sign is HMAC SHA512 of channel=<channel>&event=<event>&time=<time>.
+func (c *WsPrivateClient) subscribePayload(userID string) map[string]interface{} {
+	payload := subscribePayload(ordersChannel, []string{userID, "!all"})
+	message := fmt.Sprintf("channel=%s&event=%s&time=%d", ordersChannel, eventSubscribe, payload["time"])
+
+	payload["auth"] = map[string]string{
+		"method": "api_key",
+		"KEY":    c.cfg.Exchange.Gate.APIKey,
+		"SIGN":   sign(c.cfg.Exchange.Gate.APISecret, message),
+	}
+
+	return payload
+}
+
+func (c *WsPrivateClient) handleMessage() error {
+	mt, raw, err := c.wsConn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("Gate ws private: failed to read message from. Network issue? %v", err)
+	}
+
+	if mt != websocket.TextMessage {
+		c.logger.Warn().Str("exchange", "Gate").Msg("unexpected message type")
+		return nil
+	}
+
+	var r dtos.WsMessageDTO
+	if err := json.Unmarshal(raw, &r); err != nil {
+		c.logger.Warn().Err(err).Str("exchange", "Gate").Msg("Gate ws private: failed to unmarshal response message")
+		return nil
+	}
+
+	if r.Error != nil {
+		if r.Channel == ordersChannel && r.Event == eventSubscribe {
+			return fmt.Errorf("Gate ws private: failed to subscribe to orders: %d, %s", r.Error.Code, r.Error.Message)
+		}
+		c.logger.Warn().Int("code", r.Error.Code).Str("msg", r.Error.Message).Msg("Gate ws private: error message")
+		return nil
+	}
+
+	if r.Channel != ordersChannel || r.Event != eventUpdate {
+		c.logger.Debug().Str("channel", r.Channel).Str("event", r.Event).Msg("Gate ws private: skip message")
+		return nil
+	}
+
+	var orders []dtos.OrderDTO
+	if err := json.Unmarshal(r.Result, &orders); err != nil {
+		c.logger.Warn().Err(err).Msg("Gate ws private: failed to unmarshal orders message from Gate private websocket")
+		return nil
+	}
+
+	for _, order := range orders {
+		event, ok := c.handleExecutionEvent(&order)
+		if !ok {
+			continue
+		}
+		// Blocking, but channel has buffer
+		c.executionChannel <- event
+	}
+
+	return nil
+}
+
+// handleExecutionEvent maps order update to exchange.OrderExecutionEvent. Only updates with a new filled size and
+// orders finished with a size left (cancelled, ioc, ...) are mapped, the latter with Canceled set and no leaves qty.
+// Orders which were not placed by the bot are skipped. Quantities are in contracts.
+func (c *WsPrivateClient) handleExecutionEvent(order *dtos.OrderDTO) (exchange.OrderExecutionEvent, bool) {
+	size := abs(order.Size)
+	left := abs(order.Left)
+	filled := size - left
+
+	last := c.filled[order.ID]
+	finished := order.Status == dtos.OrderStatusFinished
+	if finished {
+		delete(c.filled, order.ID)
+	} else {
+		c.filled[order.ID] = filled
+	}
+
+	canceled := finished && left > 0
+	if filled <= last && !canceled {
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	orderID, err := parseClientOrderID(order.Text)
+	if err != nil {
+		c.logger.Debug().Str("text", order.Text).Msg("Gate ws private: skip execution of foreign order")
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	execQty := decimal.NewFromInt(filled)
+	leavesQty := decimal.NewFromInt(left)
+	if canceled {
+		leavesQty = decimal.Zero
+	}
+
+	return exchange.OrderExecutionEvent{
+		OrderID:         orderID,
+		ExchangeOrderID: strconv.FormatInt(order.ID, 10),
+		ExecPrice:       order.FillPrice.Decimal,
+		ExecQty:         execQty,
+		ExecValue:       order.FillPrice.Mul(execQty),
+		LeavesQty:       leavesQty,
+		OrderPrice:      order.Price.Decimal,
+		OrderQty:        decimal.NewFromInt(size),
+		Canceled:        canceled,
+	}, true
+}
+
+func (c *WsPrivateClient) pingPongInterval(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeJSON(pingPayload()); err != nil {
+				c.logger.Warn().Err(err).Str("exchange", "Gate").Msg("Gate ws private: failed to send ping")
+				return
+			}
+		}
+	}
+}
+
+func (c *WsPrivateClient) closeChannels() {
+	close(c.executionChannel)
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
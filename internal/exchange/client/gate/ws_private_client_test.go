@@ -0,0 +1,112 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange/client/gate/dtos"
+)
+
+func TestWsPrivateClient_Happy(t *testing.T) {
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, accountsURL, r.URL.Path)
+		_, _ = w.Write([]byte(`{"user":1666,"currency":"USDT","total":"1000","unrealised_pnl":"0","available":"1000"}`))
+	}))
+	defer httpSrv.Close()
+
+	wsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		var sub testSubscription
+		require.NoError(t, conn.ReadJSON(&sub))
+		assert.Equal(t, "futures.orders", sub.Channel)
+		assert.Equal(t, []string{"1666", "!all"}, sub.Payload)
+		assert.Equal(t, "test-api-key", sub.Auth["KEY"])
+		assert.Equal(t, sign("test-api-secret", fmt.Sprintf("channel=futures.orders&event=subscribe&time=%d", sub.Time)), sub.Auth["SIGN"])
+
+		messages := []string{
+			`{"time":1760000000,"channel":"futures.orders","event":"subscribe","error":null,"result":{"status":"success"}}`,
+			// new order, no fill yet - skipped
+			`{"time":1760000001,"channel":"futures.orders","event":"update","result":[{"id":15675394,"contract":"BTC_USDT","size":100,"left":100,"price":0,"fill_price":0,"status":"open","finish_as":"_new","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}]}`,
+			// order placed manually - skipped
+			`{"time":1760000002,"channel":"futures.orders","event":"update","result":[{"id":15675395,"contract":"BTC_USDT","size":-1,"left":0,"price":0,"fill_price":65000,"status":"finished","finish_as":"filled","text":"web"}]}`,
+			`{"time":1760000003,"channel":"futures.orders","event":"update","result":[{"id":15675394,"contract":"BTC_USDT","size":100,"left":40,"price":0,"fill_price":50000.5,"status":"open","finish_as":"_new","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}]}`,
+			// the same filled size again (amend, cancel) - skipped
+			`{"time":1760000004,"channel":"futures.orders","event":"update","result":[{"id":15675394,"contract":"BTC_USDT","size":100,"left":40,"price":0,"fill_price":50000.5,"status":"open","finish_as":"_new","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}]}`,
+			`{"time":1760000005,"channel":"futures.orders","event":"update","result":[{"id":15675394,"contract":"BTC_USDT","size":100,"left":0,"price":0,"fill_price":50000.7,"status":"finished","finish_as":"filled","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}]}`,
+		}
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer wsSrv.Close()
+
+	cfg := getTestConfig(httpSrv.URL)
+	cfg.Exchange.Gate.WSUrl = "ws" + strings.TrimPrefix(wsSrv.URL, "http")
+
+	client := NewWsPrivateClient(cfg, zerolog.Nop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.Start(ctx))
+
+	execCh, err := client.SubscribeToExecutions()
+	require.NoError(t, err)
+
+	var events []string
+	for len(events) < 2 {
+		select {
+		case event := <-execCh:
+			assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", event.OrderID.String())
+			assert.Equal(t, "15675394", event.ExchangeOrderID)
+			events = append(events, fmt.Sprintf("%s@%s leaves %s", event.ExecQty, event.ExecPrice, event.LeavesQty))
+		case <-time.After(3 * time.Second):
+			t.Fatal("timeout waiting for execution event")
+		}
+	}
+
+	assert.Equal(t, []string{"60@50000.5 leaves 40", "100@50000.7 leaves 0"}, events)
+}
+
+// An ioc order finished with a size left ends at its filled size, without a new fill too
+func TestWsPrivateClient_HandleExecutionEvent_FinishedWithLeft(t *testing.T) {
+	client := NewWsPrivateClient(getTestConfig(""), zerolog.Nop())
+
+	var orders []dtos.OrderDTO
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id":15675394,"contract":"BTC_USDT","size":100,"left":40,"price":0,"fill_price":50000.5,"status":"open","finish_as":"_new","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"},
+		{"id":15675394,"contract":"BTC_USDT","size":100,"left":40,"price":0,"fill_price":50000.5,"status":"finished","finish_as":"ioc","text":"t-VQ6EAOKbQdSnFkRmVUQAAA"}
+	]`), &orders))
+
+	event, ok := client.handleExecutionEvent(&orders[0])
+	require.True(t, ok)
+	assert.False(t, event.Canceled)
+	assert.Equal(t, "40", event.LeavesQty.String())
+
+	event, ok = client.handleExecutionEvent(&orders[1])
+	require.True(t, ok)
+	assert.True(t, event.Canceled)
+	assert.Equal(t, "60", event.ExecQty.String())
+	assert.True(t, event.LeavesQty.IsZero())
+	assert.Empty(t, client.filled)
+}
@@ -7,9 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
 )
 
 // API: POST /api/v1/private/order/cancel
@@ -25,7 +30,21 @@ import (
 const cancelOrderURL = "/api/v1/private/order/cancel"
 
 // CancelOrder cancels a pending limit order by the exchange-assigned order ID.
-func (c *Client) CancelOrder(ctx context.Context, _ uuid.UUID, exchangeOrderID string, _ string) error {
+// Spot orders are cancelled with DELETE /api/v3/order by the client order id.
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) error {
+	if category == exchange.CategorySpot {
+		query := url.Values{
+			"symbol":            {symbol},
+			"origClientOrderId": {strings.ReplaceAll(orderID.String(), "-", "")},
+		}
+
+		var raw dtos.SpotOrderDTO
+		if _, err := c.doSpotRequest(ctx, http.MethodDelete, spotOrderURL, query, true, &raw); err != nil {
+			return fmt.Errorf("MEXC | CancelOrder: spot: %w", err)
+		}
+		return nil
+	}
+
 	if exchangeOrderID == "" {
 		return fmt.Errorf("MEXC | CancelOrder: exchangeOrderID is empty (CreateOrder may not have completed)")
 	}
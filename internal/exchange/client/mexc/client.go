@@ -15,15 +15,21 @@ import (
 type Client struct {
 	exchangeName string
 	baseURL      string
+	spotBaseURL  string
 	httpClient   *http.Client
 	cfg          *config.Config
 	logger       zerolog.Logger
 	wsManager    *exchange.WSManager
+
+	bookManager      *exchange.StreamManager[exchange.BookTop]
+	orderBookManager *exchange.StreamManager[exchange.OrderBookUpdate]
 	//
 	createOrderURL string
 	//
 	wsPrivate        *WsPrivateClient
 	wsPrivateStarted bool
+	// margin mode and leverage are sent with every futures order
+	margin exchange.MarginSettings
 }
 
 // NewClient constructor.
@@ -31,12 +37,19 @@ func NewClient(cfg *config.Config, logger zerolog.Logger) *Client {
 	return &Client{
 		exchangeName: "MEXC",
 		baseURL:      cfg.Exchange.MEXC.APIBaseURL,
+		spotBaseURL:  cfg.Exchange.MEXC.SpotAPIBaseURL,
 		httpClient:   &http.Client{},
 		cfg:          cfg,
 		logger:       logger,
 		wsManager: exchange.NewWSManager(cfg, func(c *config.Config) exchange.WsClient {
 			return newWsClient(c, logger)
 		}),
+		bookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.BookTop] {
+			return newWsBookClient(c, logger)
+		}),
+		orderBookManager: exchange.NewStreamManager(cfg, func(c *config.Config) exchange.StreamWsClient[exchange.OrderBookUpdate] {
+			return newWsOrderBookClient(c, logger)
+		}),
 		createOrderURL: cfg.Exchange.MEXC.APIBaseURL + createOrderURL,
 	}
 }
@@ -51,6 +64,16 @@ func (c *Client) SubscribeTrades(ctx context.Context, symbols []string, category
 	return c.wsManager.SubscribeTrades(ctx, symbols, category)
 }
 
+// SubscribeBookTicker subscribes to best bid/ask (depth.full) of the given symbols and streams them to the returned channel.
+func (c *Client) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	return c.bookManager.Subscribe(ctx, symbols)
+}
+
+// SubscribeOrderBook subscribes to L2 orderbook (depth.full snapshots) of the given symbols. Quantities are in contracts.
+func (c *Client) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	return c.orderBookManager.Subscribe(ctx, symbols)
+}
+
 // SubscribeExecutions subscribes to order execution events via private WebSocket.
 func (c *Client) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
 	if !c.wsPrivateStarted {
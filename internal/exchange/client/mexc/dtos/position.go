@@ -0,0 +1,36 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// Direction of the futures position.
+const (
+	// PositionTypeLong is a long position.
+	PositionTypeLong = 1
+	// PositionTypeShort is a short position.
+	PositionTypeShort = 2
+)
+
+// OpenPositionsResponseDTO represents a response to the open positions request.
+// https://www.mexc.com/api-docs/futures/account-and-trading-endpoints#get-the-users-current-holding-position
+type OpenPositionsResponseDTO struct {
+	Data []struct {
+		Symbol       string        `json:"symbol"` // BTC_USDT
+		PositionType int           `json:"positionType"`
+		HoldVol      utils.Decimal `json:"holdVol"` // in contracts
+		HoldAvgPrice utils.Decimal `json:"holdAvgPrice"`
+	} `json:"data"`
+}
+
+// OpenOrdersResponseDTO represents a response to the current pending orders request.
+// Side: 1 open long, 2 close short, 3 open short, 4 close long.
+// https://www.mexc.com/api-docs/futures/account-and-trading-endpoints#get-all-of-the-users-current-pending-orders
+type OpenOrdersResponseDTO struct {
+	Data []struct {
+		OrderID     string        `json:"orderId"`
+		Symbol      string        `json:"symbol"`
+		Side        int           `json:"side"`
+		Vol         utils.Decimal `json:"vol"`
+		Price       utils.Decimal `json:"price"`
+		ExternalOID string        `json:"externalOid"`
+	} `json:"data"`
+}
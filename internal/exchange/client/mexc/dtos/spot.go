@@ -0,0 +1,50 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// SpotExchangeInfoDTO represents a response of spot exchangeInfo.
+// https://www.mexc.com/api-docs/spot-v3/market-data-endpoints#exchange-information
+type SpotExchangeInfoDTO struct {
+	Symbols []SpotSymbolDTO `json:"symbols"`
+}
+
+// SpotSymbolDTO represents a spot symbol specification.
+type SpotSymbolDTO struct {
+	Symbol               string        `json:"symbol"` // BTCUSDT
+	Status               string        `json:"status"` // 1 - online, 2 - pause, 3 - offline
+	QuoteAsset           string        `json:"quoteAsset"`
+	BaseAssetPrecision   int32         `json:"baseAssetPrecision"`
+	QuotePrecision       int32         `json:"quotePrecision"`
+	BaseSizePrecision    utils.Decimal `json:"baseSizePrecision"` // quantity step, may be 0
+	IsSpotTradingAllowed bool          `json:"isSpotTradingAllowed"`
+}
+
+// SpotAccountDTO represents the spot account.
+// https://www.mexc.com/api-docs/spot-v3/spot-account-trade#account-information
+type SpotAccountDTO struct {
+	Balances []struct {
+		Asset  string        `json:"asset"`
+		Free   utils.Decimal `json:"free"`
+		Locked utils.Decimal `json:"locked"`
+	} `json:"balances"`
+}
+
+// SpotOrderDTO represents a spot order of place and query order responses.
+type SpotOrderDTO struct {
+	Symbol              string        `json:"symbol"`
+	OrderID             string        `json:"orderId"`
+	ClientOrderID       string        `json:"clientOrderId"`
+	Status              string        `json:"status"`
+	ExecutedQty         utils.Decimal `json:"executedQty"`
+	CummulativeQuoteQty utils.Decimal `json:"cummulativeQuoteQty"`
+}
+
+// SpotTradeDTO represents a personal spot trade (fill).
+// https://www.mexc.com/api-docs/spot-v3/spot-account-trade#account-trade-list
+type SpotTradeDTO struct {
+	OrderID         string        `json:"orderId"`
+	Price           utils.Decimal `json:"price"`
+	Qty             utils.Decimal `json:"qty"`
+	Commission      utils.Decimal `json:"commission"` // positive - fee paid
+	CommissionAsset string        `json:"commissionAsset"`
+}
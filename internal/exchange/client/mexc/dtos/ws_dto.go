@@ -20,3 +20,15 @@ type WSTradeDTO struct {
 		TradeTime     int64   `json:"t"` // Trade time
 	} `json:"data"`
 }
+
+// WSDepthDTO represent order book depth data (push.depth.full).
+type WSDepthDTO struct {
+	Channel string `json:"channel"`
+	Symbol  string `json:"symbol"`
+	Ts      int64  `json:"ts"`
+	Data    struct {
+		Asks    [][]float64 `json:"asks"` // [price, quantity (contracts), order count]
+		Bids    [][]float64 `json:"bids"` // [price, quantity (contracts), order count]
+		Version int64       `json:"version"`
+	} `json:"data"`
+}
@@ -0,0 +1,81 @@
+package mexc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// doFuturesGet sends a signed GET request without params to the futures API and unmarshals the response body into out.
+// Errors are returned as {"success": false, "code": 602, "message": "..."} with http status 200.
+func (c *Client) doFuturesGet(ctx context.Context, path string, out interface{}) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	setSignedHeaders(req, c.cfg.Exchange.MEXC.APIKey, c.cfg.Exchange.MEXC.APISecret, "")
+
+	return c.doFutures(req, out)
+}
+
+// doFuturesPost sends a signed POST request with the JSON payload to the futures API and unmarshals
+// the response body into out, out may be nil.
+func (c *Client) doFuturesPost(ctx context.Context, path string, payload interface{}, out interface{}) ([]byte, error) {
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	setSignedHeaders(req, c.cfg.Exchange.MEXC.APIKey, c.cfg.Exchange.MEXC.APISecret, string(bodyBytes))
+
+	return c.doFutures(req, out)
+}
+
+func (c *Client) doFutures(req *http.Request, out interface{}) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var status struct {
+		Success bool   `json:"success"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !status.Success || status.Code != 0 {
+		return nil, fmt.Errorf("API error, code: %d, msg: %s", status.Code, status.Message)
+	}
+
+	if out == nil {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
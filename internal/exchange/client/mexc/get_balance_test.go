@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/utils/testutils"
 )
 
@@ -23,7 +24,7 @@ func Test_GetBalanceIntegration(t *testing.T) {
 
 	mexc := NewClient(cfg, logger)
 
-	balance, err := mexc.GetBalances(ctx)
+	balance, err := mexc.GetBalances(ctx, exchange.CategoryLinear)
 
 	require.NoError(t, err)
 	require.NotNil(t, balance)
@@ -9,11 +9,23 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
 	"github.com/lucrumx/bot/internal/models"
 )
 
-// GetBalances returns the balances of the user's account on the exchange.
-func (c *Client) GetBalances(ctx context.Context) ([]models.Balance, error) {
+const spotAccountURL = "/api/v3/account"
+
+// GetBalances returns the balances of the user's futures (linear) or spot account on the exchange.
+func (c *Client) GetBalances(ctx context.Context, category exchange.Category) ([]models.Balance, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotBalances(ctx)
+	default:
+		return nil, fmt.Errorf("MEXC | GetBalances: unsupported category %s", category)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/private/account/assets", nil)
 	if err != nil {
 		return nil, fmt.Errorf("MEXC | GetBalances: failed to create request: %w", err)
@@ -68,3 +80,23 @@ func (c *Client) GetBalances(ctx context.Context) ([]models.Balance, error) {
 
 	return result, nil
 }
+
+func (c *Client) getSpotBalances(ctx context.Context) ([]models.Balance, error) {
+	var raw dtos.SpotAccountDTO
+	if _, err := c.doSpotRequest(ctx, http.MethodGet, spotAccountURL, nil, true, &raw); err != nil {
+		return nil, fmt.Errorf("MEXC | GetBalances: spot: %w", err)
+	}
+
+	result := make([]models.Balance, 0, len(raw.Balances))
+	for _, b := range raw.Balances {
+		result = append(result, models.Balance{
+			ExchangeName: c.GetExchangeName(),
+			Asset:        b.Asset,
+			Free:         b.Free.Decimal,
+			Locked:       b.Locked.Decimal,
+			Total:        b.Free.Add(b.Locked.Decimal),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Base futures fee rates, used for symbols missing in the contract detail response.
+const (
+	defaultTakerFeeRate = 0.0002
+	defaultMakerFeeRate = 0
+)
+
+// GetFeeSchedule retrieves per-symbol fee rates from the MEXC contract details.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/contract/detail", nil)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("MEXC GetFeeSchedule: failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("MEXC GetFeeSchedule: http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("MEXC GetFeeSchedule: failed to read body: %w", err)
+	}
+
+	var raw struct {
+		Success bool `json:"success"`
+		Code    int  `json:"code"`
+		Data    []struct {
+			Symbol       string  `json:"symbol"`
+			TakerFeeRate float64 `json:"takerFeeRate"`
+			MakerFeeRate float64 `json:"makerFeeRate"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("MEXC GetFeeSchedule: failed to unmarshal: %w", err)
+	}
+
+	if !raw.Success || raw.Code != 0 {
+		return exchange.FeeSchedule{}, fmt.Errorf("MEXC GetFeeSchedule: API error, success: %t, code: %d", raw.Success, raw.Code)
+	}
+
+	schedule := exchange.FeeSchedule{
+		Default: exchange.FeeRate{Maker: defaultMakerFeeRate, Taker: defaultTakerFeeRate},
+		Symbols: make(map[string]exchange.FeeRate, len(raw.Data)),
+	}
+	for _, item := range raw.Data {
+		schedule.Symbols[normalizeTickerName(item.Symbol)] = exchange.FeeRate{
+			Maker: item.MakerFeeRate,
+			Taker: item.TakerFeeRate,
+		}
+	}
+
+	return schedule, nil
+}
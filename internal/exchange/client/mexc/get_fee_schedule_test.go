@@ -0,0 +1,39 @@
+package mexc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contract/detail", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"success":true,"code":0,"data":[
+			{"symbol":"BTC_USDT","takerFeeRate":0.0004,"makerFeeRate":0.0001}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			MEXC: config.MEXCConfig{
+				APIBaseURL: server.URL,
+			},
+		},
+	}
+
+	schedule, err := NewClient(cfg, zerolog.Nop()).GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FeeRate{Maker: 0.0001, Taker: 0.0004}, schedule.For("BTCUSDT"))
+	assert.Equal(t, exchange.FeeRate{Maker: defaultMakerFeeRate, Taker: defaultTakerFeeRate}, schedule.For("ETHUSDT"))
+}
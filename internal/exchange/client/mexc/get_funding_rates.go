@@ -0,0 +1,67 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// GetFundingRates retrieves current funding rates of all contracts from MEXC.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/contract/funding_rate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("MEXC GetFundingRates: failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("MEXC GetFundingRates: http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("MEXC GetFundingRates: failed to read body: %w", err)
+	}
+
+	var raw struct {
+		Success bool `json:"success"`
+		Code    int  `json:"code"`
+		Data    []struct {
+			Symbol         string  `json:"symbol"`
+			FundingRate    float64 `json:"fundingRate"`
+			CollectCycle   int64   `json:"collectCycle"` // hours
+			NextSettleTime int64   `json:"nextSettleTime"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("MEXC GetFundingRates: failed to unmarshal: %w", err)
+	}
+
+	if !raw.Success || raw.Code != 0 {
+		return nil, fmt.Errorf("MEXC GetFundingRates: API error, success: %t, code: %d", raw.Success, raw.Code)
+	}
+
+	result := make(map[string]exchange.FundingRate, len(raw.Data))
+	for _, item := range raw.Data {
+		interval := item.CollectCycle
+		if interval <= 0 {
+			interval = exchange.DefaultFundingIntervalHours
+		}
+
+		symbol := normalizeTickerName(item.Symbol)
+		result[symbol] = exchange.FundingRate{
+			Symbol:          symbol,
+			Rate:            item.FundingRate,
+			IntervalHours:   interval,
+			NextFundingTime: item.NextSettleTime,
+		}
+	}
+
+	return result, nil
+}
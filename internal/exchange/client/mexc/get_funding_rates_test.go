@@ -0,0 +1,43 @@
+package mexc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contract/funding_rate", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"success":true,"code":0,"data":[
+			{"symbol":"BTC_USDT","fundingRate":0.0001,"collectCycle":8,"nextSettleTime":1760000000000},
+			{"symbol":"ETH_USDT","fundingRate":-0.0004,"collectCycle":4,"nextSettleTime":1760000000000}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			MEXC: config.MEXCConfig{APIBaseURL: server.URL},
+		},
+	}
+
+	rates, err := NewClient(cfg, zerolog.Nop()).GetFundingRates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.0001,
+		IntervalHours:   8,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, int64(4), rates["ETHUSDT"].IntervalHours)
+}
@@ -10,10 +10,21 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
 )
 
-// GetInstruments retrieves contract specifications for all instruments from MEXC.
-func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+const spotExchangeInfoURL = "/api/v3/exchangeInfo"
+
+// GetInstruments retrieves contract specifications for all linear instruments or USDT spot symbols from MEXC.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	switch category {
+	case exchange.CategoryLinear:
+	case exchange.CategorySpot:
+		return c.getSpotInstruments(ctx)
+	default:
+		return nil, fmt.Errorf("MEXC GetInstruments: unsupported category %s", category)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/contract/detail", nil)
 	if err != nil {
 		return nil, fmt.Errorf("MEXC GetInstruments: failed to create request: %w", err)
@@ -108,3 +119,34 @@ func (c *Client) GetInstruments(ctx context.Context) (map[string]exchange.Instru
 
 	return result, nil
 }
+
+// getSpotInstruments retrieves USDT spot symbols. Spot quantity is in coins, so ContractSize is 1.
+func (c *Client) getSpotInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+	var raw dtos.SpotExchangeInfoDTO
+	if _, err := c.doSpotRequest(ctx, http.MethodGet, spotExchangeInfoURL, nil, false, &raw); err != nil {
+		return nil, fmt.Errorf("MEXC GetInstruments: spot: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(raw.Symbols))
+	for _, dto := range raw.Symbols {
+		if dto.Status != "1" || dto.QuoteAsset != "USDT" || !dto.IsSpotTradingAllowed {
+			continue
+		}
+
+		volStep := dto.BaseSizePrecision.Decimal
+		if !volStep.IsPositive() {
+			volStep = decimal.New(1, -dto.BaseAssetPrecision)
+		}
+
+		symbol := normalizeTickerName(dto.Symbol)
+		result[symbol] = exchange.Instrument{
+			Symbol:       symbol,
+			VolStep:      volStep,
+			MinVol:       volStep,
+			PriceStep:    decimal.New(1, -dto.QuotePrecision),
+			ContractSize: decimal.NewFromInt(1),
+		}
+	}
+
+	return result, nil
+}
@@ -6,17 +6,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
 )
 
-const getOrderURL = "/api/v1/private/order/get/"
+const (
+	getOrderURL     = "/api/v1/private/order/get/"
+	spotMyTradesURL = "/api/v3/myTrades"
+)
 
 // GetOrder returns the balances of the user's account on the exchange.
-func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, _ string) (exchange.ExchangeOrder, error) {
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	if category == exchange.CategorySpot {
+		return c.getSpotOrder(ctx, orderID, symbol)
+	}
+
 	if exchangeOrderID == "" {
 		return exchange.ExchangeOrder{}, fmt.Errorf("MEXC | GetOrder: exchangeOrderID is empty")
 	}
@@ -63,3 +73,50 @@ func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderI
 		Fees:            raw.Data.TotalFee.Decimal,
 	}, nil
 }
+
+// getSpotOrder retrieves the spot order by the client order id, fees are summed up from the trades of the order.
+// Base coin fees are converted to USDT by the fill price, fees paid in other assets (MX deduction) are not counted.
+func (c *Client) getSpotOrder(ctx context.Context, orderID uuid.UUID, symbol string) (exchange.ExchangeOrder, error) {
+	query := url.Values{
+		"symbol":            {symbol},
+		"origClientOrderId": {strings.ReplaceAll(orderID.String(), "-", "")},
+	}
+
+	var order dtos.SpotOrderDTO
+	if _, err := c.doSpotRequest(ctx, http.MethodGet, spotOrderURL, query, true, &order); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("MEXC | GetOrder: spot: %w", err)
+	}
+
+	tradesQuery := url.Values{
+		"symbol":  {symbol},
+		"orderId": {order.OrderID},
+	}
+
+	var trades []dtos.SpotTradeDTO
+	if _, err := c.doSpotRequest(ctx, http.MethodGet, spotMyTradesURL, tradesQuery, true, &trades); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("MEXC | GetOrder: spot trades: %w", err)
+	}
+
+	fees := decimal.Zero
+	for _, t := range trades {
+		switch {
+		case t.CommissionAsset == "USDT":
+			fees = fees.Sub(t.Commission.Decimal)
+		case t.CommissionAsset+"USDT" == symbol:
+			fees = fees.Sub(t.Commission.Mul(t.Price.Decimal))
+		}
+	}
+
+	avgPrice := decimal.Zero
+	if order.ExecutedQty.IsPositive() {
+		avgPrice = order.CummulativeQuoteQty.Div(order.ExecutedQty.Decimal)
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: order.OrderID,
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        avgPrice,
+		Fees:            fees,
+	}, nil
+}
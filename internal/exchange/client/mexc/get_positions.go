@@ -0,0 +1,88 @@
+package mexc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /api/v1/private/position/open_positions
+// Docs: https://www.mexc.com/api-docs/futures/account-and-trading-endpoints#get-the-users-current-holding-position
+// Open orders: GET /api/v1/private/order/list/open_orders
+
+const (
+	openPositionsURL = "/api/v1/private/position/open_positions"
+	openOrdersURL    = "/api/v1/private/order/list/open_orders"
+)
+
+// GetPositions returns open futures positions, qty is in contracts.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	var raw dtos.OpenPositionsResponseDTO
+	if _, err := c.doFuturesGet(ctx, openPositionsURL, &raw); err != nil {
+		return nil, fmt.Errorf("MEXC | GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw.Data))
+	for _, p := range raw.Data {
+		if !p.HoldVol.IsPositive() {
+			continue
+		}
+
+		side := models.OrderSideBuy
+		if p.PositionType == dtos.PositionTypeShort {
+			side = models.OrderSideSell
+		}
+
+		positions = append(positions, exchange.Position{
+			Symbol:     normalizeTickerName(p.Symbol),
+			Side:       side,
+			Qty:        p.HoldVol.Decimal,
+			EntryPrice: p.HoldAvgPrice.Decimal,
+		})
+	}
+
+	return positions, nil
+}
+
+// GetOpenOrders returns futures orders resting on the book (first page of 20 orders).
+// Spot open orders endpoint requires the symbol, so spot is not supported.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	if category != exchange.CategoryLinear {
+		return nil, fmt.Errorf("MEXC | GetOpenOrders: unsupported category %s", category)
+	}
+
+	var raw dtos.OpenOrdersResponseDTO
+	if _, err := c.doFuturesGet(ctx, openOrdersURL, &raw); err != nil {
+		return nil, fmt.Errorf("MEXC | GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw.Data))
+	for _, o := range raw.Data {
+		// orders placed not by the bot have no or foreign externalOid
+		orderID, err := parseUUIDFromHex(o.ExternalOID)
+		if err != nil {
+			orderID = uuid.Nil
+		}
+
+		side := models.OrderSideBuy
+		if o.Side == mexcSideOpenShort || o.Side == mexcSideCloseLong {
+			side = models.OrderSideSell
+		}
+
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         orderID,
+			ExchangeOrderID: o.OrderID,
+			Symbol:          normalizeTickerName(o.Symbol),
+			Side:            side,
+			Qty:             o.Vol.Decimal,
+			Price:           o.Price.Decimal,
+		})
+	}
+
+	return orders, nil
+}
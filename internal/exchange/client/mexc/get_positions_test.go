@@ -0,0 +1,83 @@
+package mexc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newFuturesTestClient(baseURL string) *Client {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			MEXC: config.MEXCConfig{
+				APIBaseURL: baseURL,
+				APIKey:     "test-api-key",
+				APISecret:  "test-api-secret",
+			},
+		},
+	}
+
+	return NewClient(cfg, zerolog.Nop())
+}
+
+func TestClient_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, openPositionsURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("ApiKey"))
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+
+		_, _ = w.Write([]byte(`{"success":true,"code":0,"data":[
+			{"symbol":"BTC_USDT","positionType":2,"holdVol":100,"holdAvgPrice":65000.5},
+			{"symbol":"TONCOIN_USDT","positionType":1,"holdVol":3,"holdAvgPrice":2.5}
+		]}`))
+	}))
+	defer server.Close()
+
+	positions, err := newFuturesTestClient(server.URL).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 2)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "100", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+	assert.Equal(t, "TONUSDT", positions[1].Symbol)
+	assert.Equal(t, models.OrderSideBuy, positions[1].Side)
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, openOrdersURL, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"success":true,"code":0,"data":[
+			{"orderId":"101716841474621953","symbol":"BTC_USDT","side":3,"vol":100,"price":66000,"externalOid":"550e8400e29b41d4a716446655440000"},
+			{"orderId":"101716841474621954","symbol":"ETH_USDT","side":1,"vol":5,"price":3000,"externalOid":"_m_abc"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := newFuturesTestClient(server.URL)
+
+	orders, err := c.GetOpenOrders(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), orders[0].OrderID)
+	assert.Equal(t, "101716841474621953", orders[0].ExchangeOrderID)
+	assert.Equal(t, models.OrderSideSell, orders[0].Side)
+	assert.Equal(t, "100", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+	assert.Equal(t, models.OrderSideBuy, orders[1].Side)
+
+	_, err = c.GetOpenOrders(t.Context(), exchange.CategorySpot)
+	assert.Error(t, err)
+}
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
 	"github.com/lucrumx/bot/internal/models"
 )
 
@@ -30,9 +32,11 @@ const (
 	mexcOrderTypeMarket   = 5 // market
 
 	// margin modes
-	mexcOpenTypeCross = 2
+	mexcOpenTypeIsolated = 1
+	mexcOpenTypeCross    = 2
 
 	createOrderURL = "/api/v1/private/order/create"
+	spotOrderURL   = "/api/v3/order"
 )
 
 // CreateOrder creates an order on the MEXC exchange.
@@ -41,6 +45,10 @@ func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
 		return err
 	}
 
+	if order.Market == models.OrderMarketSpot {
+		return c.submitSpotOrder(ctx, order, order.Side, spotOrderType(order))
+	}
+
 	side := mexcSideOpenLong
 	if order.Side == models.OrderSideSell {
 		side = mexcSideOpenShort
@@ -55,6 +63,15 @@ func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
 		return err
 	}
 
+	// spot has no positions, bought coins are sold back with a market order
+	if order.Market == models.OrderMarketSpot {
+		side := models.OrderSideSell
+		if order.Side == models.OrderSideSell {
+			side = models.OrderSideBuy
+		}
+		return c.submitSpotOrder(ctx, order, side, "MARKET")
+	}
+
 	// Buy means we had a long position → close long (side=4)
 	// Sell means we had a short position → close short (side=2)
 	side := mexcSideCloseLong
@@ -74,9 +91,12 @@ func (c *Client) submitOrder(ctx context.Context, order *models.Order, side int)
 		"vol":         order.Quantity.String(),
 		"side":        side,
 		"type":        orderType,
-		"openType":    mexcOpenTypeCross,
+		"openType":    openType(c.margin.Mode(order.Symbol)),
 		"externalOid": strings.ReplaceAll(order.ID.String(), "-", ""),
 	}
+	if leverage := c.margin.Leverage(order.Symbol); leverage > 0 {
+		payload["leverage"] = leverage
+	}
 
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -130,6 +150,35 @@ func (c *Client) submitOrder(ctx context.Context, order *models.Order, side int)
 	return nil
 }
 
+// submitSpotOrder places a spot order, quantity is in coins.
+// Docs: https://www.mexc.com/api-docs/spot-v3/spot-account-trade#new-order
+func (c *Client) submitSpotOrder(ctx context.Context, order *models.Order, side models.OrderSide, orderType string) error {
+	query := url.Values{
+		"symbol":           {order.Symbol},
+		"side":             {string(side)},
+		"type":             {orderType},
+		"quantity":         {order.Quantity.String()},
+		"newClientOrderId": {strings.ReplaceAll(order.ID.String(), "-", "")},
+	}
+	if orderType != "MARKET" {
+		query.Set("price", order.Price.String())
+	}
+
+	var raw dtos.SpotOrderDTO
+	body, err := c.doSpotRequest(ctx, http.MethodPost, spotOrderURL, query, true, &raw)
+	if err != nil {
+		return fmt.Errorf("MEXC | submitSpotOrder: %w", err)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	confirmed.ExchangeOrderID = raw.OrderID
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusNew
+
+	return nil
+}
+
 func validateOrder(order *models.Order) error {
 	if order.Type == models.OrderTypeLimit && order.Price.LessThanOrEqual(decimal.Zero) {
 		return fmt.Errorf("MEXC client limit order requires price > 0")
@@ -150,6 +199,23 @@ func validateOrder(order *models.Order) error {
 	return nil
 }
 
+// spotOrderType maps the cross-exchange order type + TimeInForce to MEXC spot order type.
+func spotOrderType(order *models.Order) string {
+	if order.Type == models.OrderTypeMarket {
+		return "MARKET"
+	}
+	switch order.TimeInForce {
+	case models.TimeInForcePostOnly:
+		return "LIMIT_MAKER"
+	case models.TimeInForceIOC:
+		return "IMMEDIATE_OR_CANCEL"
+	case models.TimeInForceFOK:
+		return "FILL_OR_KILL"
+	default:
+		return "LIMIT"
+	}
+}
+
 // mexcOrderTypeAndPrice maps the cross-exchange order type + TimeInForce to MEXC's single
 // numeric `type` field, and returns the price to send (0 for market).
 func mexcOrderTypeAndPrice(order *models.Order) (int, interface{}) {
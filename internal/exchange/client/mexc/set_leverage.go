@@ -1,8 +1,52 @@
 package mexc
 
-import "context"
+import (
+	"context"
+	"fmt"
 
-// SetLeverage sets the leverage for a symbol.
-func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error { //nolint
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Api description: https://www.mexc.com/api-docs/futures/account-and-trading-endpoints#switch-leverage
+
+const (
+	changeLeverageURL = "/api/v1/private/position/change_leverage"
+
+	// position types
+	mexcPositionTypeLong  = 1
+	mexcPositionTypeShort = 2
+)
+
+// SetLeverage sets the leverage of a symbol for both the long and the short position, in the margin mode
+// set by SetMarginMode. The leverage is also sent with every order on the symbol.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	for _, positionType := range []int{mexcPositionTypeLong, mexcPositionTypeShort} {
+		payload := map[string]interface{}{
+			"symbol":       denormalizeTickerName(symbol),
+			"leverage":     leverage,
+			"openType":     openType(c.margin.Mode(symbol)),
+			"positionType": positionType,
+		}
+
+		if _, err := c.doFuturesPost(ctx, changeLeverageURL, payload, nil); err != nil {
+			return fmt.Errorf("MEXC | SetLeverage: %w", err)
+		}
+	}
+
+	c.margin.SetLeverage(symbol, leverage)
 	return nil
 }
+
+// SetMarginMode sets the margin mode of a symbol. MEXC has no endpoint for it, the mode is sent as openType
+// with the leverage and every order on the symbol.
+func (c *Client) SetMarginMode(_ context.Context, symbol string, mode exchange.MarginMode) error {
+	c.margin.SetMode(symbol, mode)
+	return nil
+}
+
+func openType(mode exchange.MarginMode) int {
+	if mode == exchange.MarginModeIsolated {
+		return mexcOpenTypeIsolated
+	}
+	return mexcOpenTypeCross
+}
@@ -0,0 +1,66 @@
+package mexc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestClient_SetLeverage(t *testing.T) {
+	var payloads []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+
+		switch r.URL.Path {
+		case changeLeverageURL:
+			_, _ = w.Write([]byte(`{"success":true,"code":0}`))
+		case createOrderURL:
+			_, _ = w.Write([]byte(`{"success":true,"code":0,"data":{"orderId":"123"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := newFuturesTestClient(server.URL)
+	require.NoError(t, c.SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated))
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+
+	require.Len(t, payloads, 2)
+	for i, positionType := range []float64{mexcPositionTypeLong, mexcPositionTypeShort} {
+		assert.Equal(t, map[string]any{
+			"symbol":       "BTC_USDT",
+			"leverage":     float64(5),
+			"openType":     float64(mexcOpenTypeIsolated),
+			"positionType": positionType,
+		}, payloads[i])
+	}
+
+	// orders carry the margin mode and leverage of the symbol
+	order := &models.Order{
+		ID:       uuid.New(),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketLinear,
+		Quantity: decimal.NewFromInt(1),
+	}
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	require.Len(t, payloads, 3)
+	assert.Equal(t, float64(mexcOpenTypeIsolated), payloads[2]["openType"])
+	assert.Equal(t, float64(5), payloads[2]["leverage"])
+}
@@ -0,0 +1,66 @@
+package mexc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Spot REST API v3 lives on its own host and is signed differently from futures:
+// HMAC SHA256 of the query string goes to the signature param, api key to X-MEXC-APIKEY header.
+// Docs: https://www.mexc.com/api-docs/spot-v3/general-info#signed
+
+// doSpotRequest sends a request to the spot API, signed requests get timestamp and signature params.
+// Errors are returned as {"code": 10007, "msg": "..."} with non 2xx http status.
+func (c *Client) doSpotRequest(ctx context.Context, method string, path string, query url.Values, signed bool, out interface{}) ([]byte, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	rawQuery := query.Encode()
+	if signed {
+		query.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		rawQuery = query.Encode()
+
+		h := hmac.New(sha256.New, []byte(c.cfg.Exchange.MEXC.APISecret))
+		h.Write([]byte(rawQuery))
+		rawQuery += "&signature=" + hex.EncodeToString(h.Sum(nil))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.spotBaseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.URL.RawQuery = rawQuery
+	req.Header.Set("X-MEXC-APIKEY", c.cfg.Exchange.MEXC.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,117 @@
+package mexc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func newSpotTestClient(baseURL string) *Client {
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			MEXC: config.MEXCConfig{
+				SpotAPIBaseURL: baseURL,
+				APIKey:         "test-api-key",
+				APISecret:      "test-api-secret",
+			},
+		},
+	}
+
+	return NewClient(cfg, zerolog.Nop())
+}
+
+func TestClient_GetInstruments_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, spotExchangeInfoURL, r.URL.Path)
+		assert.Empty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte(`{"symbols":[
+			{"symbol":"BTCUSDT","status":"1","quoteAsset":"USDT","baseAssetPrecision":8,"quotePrecision":2,"baseSizePrecision":"0.000001","isSpotTradingAllowed":true},
+			{"symbol":"NEWUSDT","status":"1","quoteAsset":"USDT","baseAssetPrecision":2,"quotePrecision":6,"baseSizePrecision":"0","isSpotTradingAllowed":true},
+			{"symbol":"ETHBTC","status":"1","quoteAsset":"BTC","baseAssetPrecision":4,"quotePrecision":6,"baseSizePrecision":"0.0001","isSpotTradingAllowed":true},
+			{"symbol":"OLDUSDT","status":"3","quoteAsset":"USDT","baseAssetPrecision":2,"quotePrecision":4,"baseSizePrecision":"0.01","isSpotTradingAllowed":true}
+		]}`))
+	}))
+	defer server.Close()
+
+	instruments, err := newSpotTestClient(server.URL).GetInstruments(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, instruments, 2)
+
+	assert.Equal(t, "0.000001", instruments["BTCUSDT"].VolStep.String())
+	assert.Equal(t, "0.01", instruments["BTCUSDT"].PriceStep.String())
+	assert.Equal(t, "1", instruments["BTCUSDT"].ContractSize.String())
+	// zero baseSizePrecision falls back to baseAssetPrecision
+	assert.Equal(t, "0.01", instruments["NEWUSDT"].VolStep.String())
+}
+
+func TestClient_CreateOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, spotOrderURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MEXC-APIKEY"))
+
+		query := r.URL.Query()
+		assert.Equal(t, "BTCUSDT", query.Get("symbol"))
+		assert.Equal(t, "BUY", query.Get("side"))
+		assert.Equal(t, "LIMIT_MAKER", query.Get("type"))
+		assert.Equal(t, "0.01", query.Get("quantity"))
+		assert.Equal(t, "65000", query.Get("price"))
+		assert.Equal(t, "550e8400e29b41d4a716446655440000", query.Get("newClientOrderId"))
+		assert.NotEmpty(t, query.Get("timestamp"))
+		assert.NotEmpty(t, query.Get("signature"))
+
+		_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__443776347957968896","clientOrderId":"550e8400e29b41d4a716446655440000"}`))
+	}))
+	defer server.Close()
+
+	order := &models.Order{
+		ID:          uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Symbol:      "BTCUSDT",
+		Side:        models.OrderSideBuy,
+		Type:        models.OrderTypeLimit,
+		Market:      models.OrderMarketSpot,
+		TimeInForce: models.TimeInForcePostOnly,
+		Price:       decimal.RequireFromString("65000"),
+		Quantity:    decimal.RequireFromString("0.01"),
+	}
+
+	require.NoError(t, newSpotTestClient(server.URL).CreateOrder(t.Context(), order))
+	assert.Equal(t, "C02__443776347957968896", order.ExchangeOrderID)
+}
+
+func TestClient_GetOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case spotOrderURL:
+			assert.Equal(t, "550e8400e29b41d4a716446655440000", r.URL.Query().Get("origClientOrderId"))
+			_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__443776347957968896","status":"FILLED","executedQty":"0.02","cummulativeQuoteQty":"1300.002"}`))
+		case spotMyTradesURL:
+			assert.Equal(t, "C02__443776347957968896", r.URL.Query().Get("orderId"))
+			_, _ = w.Write([]byte(`[
+				{"orderId":"C02__443776347957968896","price":"65000","qty":"0.01","commission":"0.00001","commissionAsset":"BTC"},
+				{"orderId":"C02__443776347957968896","price":"65000.2","qty":"0.01","commission":"0.01","commissionAsset":"MX"}
+			]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	order, err := newSpotTestClient(server.URL).GetOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "", "BTCUSDT", exchange.CategorySpot)
+	require.NoError(t, err)
+
+	assert.Equal(t, "C02__443776347957968896", order.ExchangeOrderID)
+	assert.Equal(t, "65000.1", order.AvgPrice.String())
+	assert.Equal(t, "-0.65", order.Fees.String())
+}
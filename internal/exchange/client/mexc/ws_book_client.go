@@ -0,0 +1,275 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/mexc/dtos"
+)
+
+const (
+	// bookTickerDepthLimit is the number of levels requested for depth.full, only the first one is used for best bid/ask.
+	bookTickerDepthLimit = 5
+	// orderBookDepthLimit is the number of levels requested for depth.full used as L2 order book.
+	orderBookDepthLimit = 20
+)
+
+// wsBookClient represents a WebSocket client streaming depth.full of MEXC contract symbols.
+// T is exchange.BookTop for best bid/ask or exchange.OrderBookUpdate for L2 order book.
+type wsBookClient[T any] struct {
+	Metrics    *Metrics
+	cfg        *config.Config
+	wsMu       sync.Mutex
+	backoff    *exchange.Backoff
+	logger     zerolog.Logger
+	limit      int
+	mapMessage func(d dtos.WSDepthDTO) (T, bool)
+}
+
+func newWsBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		limit:      bookTickerDepthLimit,
+		mapMessage: mapDepthToBookTop,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		limit:      orderBookDepthLimit,
+		mapMessage: mapDepthToOrderBookUpdate,
+	}
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the depth of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.MEXC.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("mexc failed to dial websocket: %w", err)
+	}
+
+	if err = c.subscribe(wsConn, symbols); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("mexc failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads depth messages from the connection and reconnects with backoff until ctx is done.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("mexc failed to reconnect to MEXC depth websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("mexc reconnected to MEXC depth websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) subscribe(wsCon *websocket.Conn, symbols []string) error {
+	for _, symbol := range symbols {
+		payload := map[string]interface{}{
+			"method": "sub.depth.full",
+			"param": map[string]interface{}{
+				"symbol": denormalizeTickerName(symbol),
+				"limit":  c.limit,
+			},
+		}
+
+		if err := c.writeJSON(wsCon, payload); err != nil {
+			return fmt.Errorf("mexc failed to send depth subscription request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) readMessage(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("mexc failed to read message from MEXC depth websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		var message dtos.WSBaseMessage
+		if err = json.Unmarshal(messageByte, &message); err != nil {
+			c.logger.Warn().Err(err).Msg("mexc failed to unmarshal base message from MEXC depth websocket")
+			continue
+		}
+
+		if message.Channel != "push.depth.full" {
+			continue
+		}
+
+		var depthMessage dtos.WSDepthDTO
+		if err = json.Unmarshal(messageByte, &depthMessage); err != nil {
+			c.logger.Warn().Err(err).Msg("mexc failed to unmarshal depth message from MEXC websocket")
+			continue
+		}
+
+		update, ok := c.mapMessage(depthMessage)
+		if !ok {
+			continue
+		}
+
+		select {
+		case outChan <- update:
+		default:
+			c.Metrics.droppedBookUpdates.Add(1)
+		}
+	}
+}
+
+// mapDepthToBookTop takes the first level of both sides. Returns false if one of the sides is empty.
+func mapDepthToBookTop(d dtos.WSDepthDTO) (exchange.BookTop, bool) {
+	if len(d.Data.Bids) == 0 || len(d.Data.Asks) == 0 || len(d.Data.Bids[0]) < 2 || len(d.Data.Asks[0]) < 2 {
+		return exchange.BookTop{}, false
+	}
+
+	return exchange.BookTop{
+		Symbol:   normalizeTickerName(d.Symbol),
+		Category: exchange.CategoryLinear,
+		Ts:       d.Ts,
+		BidPrice: d.Data.Bids[0][0],
+		BidQty:   d.Data.Bids[0][1],
+		AskPrice: d.Data.Asks[0][0],
+		AskQty:   d.Data.Asks[0][1],
+	}, true
+}
+
+// mapDepthToOrderBookUpdate maps depth.full message to exchange.OrderBookUpdate, every message is a full snapshot.
+func mapDepthToOrderBookUpdate(d dtos.WSDepthDTO) (exchange.OrderBookUpdate, bool) {
+	return exchange.OrderBookUpdate{
+		Symbol:   normalizeTickerName(d.Symbol),
+		Category: exchange.CategoryLinear,
+		Ts:       d.Ts,
+		Snapshot: true,
+		Bids:     mapPriceLevels(d.Data.Bids),
+		Asks:     mapPriceLevels(d.Data.Asks),
+	}, true
+}
+
+func mapPriceLevels(levels [][]float64) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			continue
+		}
+		res = append(res, exchange.PriceLevel{Price: l[0], Qty: l[1]})
+	}
+	return res
+}
+
+func (c *wsBookClient[T]) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(time.Second * 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeJSON(wsConn, map[string]string{"method": "ping"}); err != nil {
+				c.logger.Warn().Err(err).Msg("mexc failed to send ping to MEXC depth websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCnt := c.Metrics.droppedBookUpdates.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("MEXC depth.full(%d) metrics: dropped book updates=%d, reconnects=%d", c.limit, droppedCnt, reconnectsCnt)
+			}
+		}
+	}
+}
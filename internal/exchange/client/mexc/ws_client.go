@@ -18,7 +18,9 @@ import (
 
 // Metrics holds metrics related to websocket client operations.
 type Metrics struct {
-	droppedTrades atomic.Uint64
+	droppedTrades      atomic.Uint64
+	droppedBookUpdates atomic.Uint64
+	reconnects         atomic.Uint64
 }
 
 // wsClient represents a WebSocket client for MEXC exchange.
@@ -26,6 +28,7 @@ type wsClient struct {
 	Metrics *Metrics
 	cfg     *config.Config
 	wsMu    sync.Mutex
+	backoff *exchange.Backoff
 	logger  zerolog.Logger
 }
 
@@ -33,6 +36,7 @@ func newWsClient(cfg *config.Config, logger zerolog.Logger) *wsClient {
 	return &wsClient{
 		Metrics: &Metrics{},
 		cfg:     cfg,
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
 		logger:  logger,
 	}
 }
@@ -48,23 +52,68 @@ func (c *wsClient) Start(ctx context.Context, symbols []string, category exchang
 		return fmt.Errorf("mexc websocket supports only %s trades", exchange.CategoryLinear)
 	}
 
-	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.MEXC.WSUrl, nil)
+	wsConn, err := c.connect(symbols)
 	if err != nil {
-		return fmt.Errorf("mexc failed to dial websocket: %w", err)
+		return err
 	}
 
-	err = c.subscribe(wsConn, symbols)
+	go c.serve(ctx, wsConn, symbols, category, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the trades of the given symbols.
+func (c *wsClient) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.MEXC.WSUrl, nil)
 	if err != nil {
-		return fmt.Errorf("mexc failed to subscribe to symbols: %w", err)
+		return nil, fmt.Errorf("mexc failed to dial websocket: %w", err)
 	}
 
-	go c.readMessage(ctx, wsConn, category, outChan)
-	go c.pingPongInterval(ctx, wsConn)
-	go c.logMetrics(ctx)
-	go func() {
-		<-ctx.Done()
+	if err = c.subscribe(wsConn, symbols); err != nil {
 		_ = wsConn.Close()
-	}()
+		return nil, fmt.Errorf("mexc failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads trades from the connection and reconnects with backoff (replaying subscriptions) until ctx is done.
+func (c *wsClient) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, category, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsClient) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("mexc failed to reconnect to MEXC websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("mexc reconnected to MEXC websocket")
+
+		return wsConn
+	}
 
 	return nil
 }
@@ -106,7 +155,6 @@ func (c *wsClient) readMessage(ctx context.Context, wsConn *websocket.Conn, cate
 				return
 			}
 			c.logger.Warn().Err(err).Msg("mexc failed to read message from MEXC websocket")
-			// TODO: something wrong with connection need to reconnect (reconnect not implemented yet)
 			return
 		}
 
@@ -185,8 +233,9 @@ func (c *wsClient) logMetrics(ctx context.Context) {
 			return
 		case <-ticker.C:
 			droppedTradesCnt := c.Metrics.droppedTrades.Load()
-			if droppedTradesCnt > 0 {
-				c.logger.Warn().Msgf("MEXC metrics: dropped trades=%d", droppedTradesCnt)
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedTradesCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("MEXC metrics: dropped trades=%d, reconnects=%d", droppedTradesCnt, reconnectsCnt)
 			}
 		}
 	}
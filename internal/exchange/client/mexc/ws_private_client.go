@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 
 	"github.com/lucrumx/bot/internal/config"
 	"github.com/lucrumx/bot/internal/exchange"
@@ -178,10 +179,15 @@ func (c *WsPrivateClient) handleMessage() error {
 			Str("orderId", d.OrderID).
 			Int("errorCode", d.ErrorCode).
 			Msg("MEXC ws private: order invalid")
-		return nil
 	}
 
-	if d.State != dtos.OrderStateFilled {
+	// the order is mapped when it ends: filled, or canceled / invalid at what was dealt so far
+	var canceled bool
+	switch d.State {
+	case dtos.OrderStateFilled:
+	case dtos.OrderStateCanceled, dtos.OrderStateInvalid:
+		canceled = true
+	default:
 		return nil
 	}
 
@@ -191,15 +197,21 @@ func (c *WsPrivateClient) handleMessage() error {
 		return nil
 	}
 
+	leavesQty := d.RemainVol
+	if canceled {
+		leavesQty = decimal.Zero
+	}
+
 	c.executionChannel <- exchange.OrderExecutionEvent{
 		OrderID:         orderID,
 		ExchangeOrderID: d.OrderID,
 		ExecPrice:       d.DealAvgPrice,
 		ExecQty:         d.DealVol,
 		ExecValue:       d.DealAvgPrice.Mul(d.DealVol),
-		LeavesQty:       d.RemainVol,
+		LeavesQty:       leavesQty,
 		OrderPrice:      d.Price,
 		OrderQty:        d.Vol,
+		Canceled:        canceled,
 	}
 
 	return nil
@@ -0,0 +1,35 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+// API: POST /api/v5/trade/cancel-order
+// Docs: https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-cancel-order
+
+// CancelOrder cancels a pending limit order by client order id.
+func (c *Client) CancelOrder(ctx context.Context, orderID uuid.UUID, _ string, symbol string, category exchange.Category) error {
+	payload := map[string]string{
+		"instId":  instIDFor(symbol, category),
+		"clOrdId": clientOrderID(orderID),
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodPost, cancelOrderURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("OKX CancelOrder: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.OrderAckDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("OKX CancelOrder: %w", err)
+	}
+
+	return nil
+}
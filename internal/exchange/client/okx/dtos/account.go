@@ -0,0 +1,34 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// BalanceDetailDTO represents balance of a currency of the trading account.
+type BalanceDetailDTO struct {
+	Ccy       string        `json:"ccy"`
+	Eq        utils.Decimal `json:"eq"`        // equity
+	AvailEq   utils.Decimal `json:"availEq"`   // available equity
+	FrozenBal utils.Decimal `json:"frozenBal"` // frozen balance (margin, orders)
+}
+
+// BalanceDTO represents the trading account balance.
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-balance
+type BalanceDTO struct {
+	TotalEq utils.Decimal      `json:"totalEq"`
+	Details []BalanceDetailDTO `json:"details"`
+}
+
+// LeverageDTO represents a response to a set leverage request.
+type LeverageDTO struct {
+	InstID  string `json:"instId"`
+	Lever   string `json:"lever"`
+	MgnMode string `json:"mgnMode"`
+}
+
+// PositionDTO represents a position of the positions response.
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-positions
+type PositionDTO struct {
+	InstID  string        `json:"instId"`
+	PosSide string        `json:"posSide"` // net (one-way mode), long, short
+	Pos     utils.Decimal `json:"pos"`     // in contracts, negative for short in net mode
+	AvgPx   utils.Decimal `json:"avgPx"`
+}
@@ -0,0 +1,49 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// TickerDTO represents a ticker of an instrument.
+// https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-tickers
+type TickerDTO struct {
+	InstID    string        `json:"instId"`
+	Last      utils.Decimal `json:"last"`
+	Open24h   utils.Decimal `json:"open24h"`
+	High24h   utils.Decimal `json:"high24h"`
+	Low24h    utils.Decimal `json:"low24h"`
+	Vol24h    utils.Decimal `json:"vol24h"`    // in contracts
+	VolCcy24h utils.Decimal `json:"volCcy24h"` // in base currency (coins) for swaps
+	Ts        string        `json:"ts"`
+}
+
+// InstrumentDTO represents a contract specification.
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-get-instruments
+type InstrumentDTO struct {
+	InstID    string `json:"instId"`
+	CtVal     string `json:"ctVal"` // contract value in ctValCcy (coins for linear swaps)
+	CtValCcy  string `json:"ctValCcy"`
+	CtType    string `json:"ctType"` // linear / inverse (swaps only)
+	SettleCcy string `json:"settleCcy"`
+	LotSz     string `json:"lotSz"` // in contracts (swaps) or base coin (spot)
+	MinSz     string `json:"minSz"` // in contracts (swaps) or base coin (spot)
+	TickSz    string `json:"tickSz"`
+	State     string `json:"state"` // live / suspend / preopen / test
+}
+
+// FundingRateDTO represents the current funding rate of a swap.
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-get-funding-rate
+type FundingRateDTO struct {
+	InstID          string            `json:"instId"`
+	FundingRate     utils.JSONFloat64 `json:"fundingRate"`
+	FundingTime     utils.Decimal     `json:"fundingTime"`     // settlement time of the current rate, ms
+	NextFundingTime utils.Decimal     `json:"nextFundingTime"` // ms, may be empty
+}
+
+// TradeFeeDTO represents fee rates of the account. Negative rate is a fee to pay, positive is a rebate.
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-fee-rates
+type TradeFeeDTO struct {
+	Level  string        `json:"level"`
+	Taker  utils.Decimal `json:"taker"`
+	Maker  utils.Decimal `json:"maker"`
+	TakerU utils.Decimal `json:"takerU"` // USDT-margined contracts, may be empty
+	MakerU utils.Decimal `json:"makerU"` // USDT-margined contracts, may be empty
+}
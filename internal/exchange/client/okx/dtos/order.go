@@ -0,0 +1,84 @@
+package dtos
+
+import "github.com/lucrumx/bot/internal/utils"
+
+// OrderSide represents the side of an order.
+type OrderSide string
+
+// OrderType represents the type of order. Time in force is a part of the order type on OKX.
+type OrderType string
+
+const (
+	// OrderSideBuy represents the buy side of an order.
+	OrderSideBuy OrderSide = "buy"
+	// OrderSideSell represents the sell side of an order.
+	OrderSideSell OrderSide = "sell"
+
+	// OrderTypeMarket represents a market order.
+	OrderTypeMarket OrderType = "market"
+	// OrderTypeLimit represents a limit GTC order.
+	OrderTypeLimit OrderType = "limit"
+	// OrderTypePostOnly represents a post-only limit order.
+	OrderTypePostOnly OrderType = "post_only"
+	// OrderTypeIOC represents an Immediate-or-cancel limit order.
+	OrderTypeIOC OrderType = "ioc"
+	// OrderTypeFOK represents a Fill-or-kill limit order.
+	OrderTypeFOK OrderType = "fok"
+
+	// TradeModeCross is cross margin trade mode.
+	TradeModeCross = "cross"
+	// TradeModeIsolated is isolated margin trade mode.
+	TradeModeIsolated = "isolated"
+	// TradeModeCash is non-margin trade mode (spot).
+	TradeModeCash = "cash"
+
+	// TargetCurrencyBase sets the size of spot market orders in base coin (market buy is in quote coin by default).
+	TargetCurrencyBase = "base_ccy"
+)
+
+// PlaceOrderDTO represents a request to place an order.
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-place-order
+type PlaceOrderDTO struct {
+	InstID     string    `json:"instId"`
+	TdMode     string    `json:"tdMode"`
+	Side       OrderSide `json:"side"`
+	OrdType    OrderType `json:"ordType"`
+	Sz         string    `json:"sz"` // in contracts (swaps) or base coin (spot)
+	Px         string    `json:"px,omitempty"`
+	TgtCcy     string    `json:"tgtCcy,omitempty"`
+	ClOrdID    string    `json:"clOrdId"`
+	ReduceOnly bool      `json:"reduceOnly,omitempty"`
+}
+
+// OrderAckDTO represents a result of place / cancel order request.
+type OrderAckDTO struct {
+	OrdID   string `json:"ordId"`
+	ClOrdID string `json:"clOrdId"`
+	SCode   string `json:"sCode"`
+	SMsg    string `json:"sMsg"`
+}
+
+// Order states that end the order before it's fully filled.
+const (
+	OrderStateCanceled    = "canceled"
+	OrderStateMMPCanceled = "mmp_canceled" // canceled by market maker protection
+)
+
+// OrderDTO represents an order of the order details response and the orders channel.
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-details
+type OrderDTO struct {
+	InstID    string        `json:"instId"`
+	OrdID     string        `json:"ordId"`
+	ClOrdID   string        `json:"clOrdId"`
+	Px        utils.Decimal `json:"px"`
+	Sz        utils.Decimal `json:"sz"`
+	AvgPx     utils.Decimal `json:"avgPx"`
+	AccFillSz utils.Decimal `json:"accFillSz"`
+	FillPx    utils.Decimal `json:"fillPx"`
+	FillSz    utils.Decimal `json:"fillSz"`
+	State     string        `json:"state"` // live / partially_filled / filled / canceled / mmp_canceled
+	Side      string        `json:"side"`
+	Fee       utils.Decimal `json:"fee"` // negative - fee paid
+	FeeCcy    string        `json:"feeCcy"`
+	Pnl       utils.Decimal `json:"pnl"`
+}
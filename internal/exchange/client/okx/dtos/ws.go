@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"encoding/json"
+
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+// WsArgDTO represents a channel subscription argument.
+type WsArgDTO struct {
+	Channel  string `json:"channel"`
+	InstID   string `json:"instId,omitempty"`
+	InstType string `json:"instType,omitempty"`
+}
+
+// WsMessageDTO represents a push or event message of OKX websocket.
+// Pushes have arg and data, events (subscribe, login, error) have event and code.
+type WsMessageDTO struct {
+	Event  string          `json:"event"`
+	Code   string          `json:"code"`
+	Msg    string          `json:"msg"`
+	Arg    WsArgDTO        `json:"arg"`
+	Action string          `json:"action"` // books: snapshot / update
+	Data   json.RawMessage `json:"data"`
+}
+
+// WsTradeDTO represents a trade of the trades channel.
+type WsTradeDTO struct {
+	InstID string            `json:"instId"`
+	Px     utils.JSONFloat64 `json:"px"`
+	Sz     utils.JSONFloat64 `json:"sz"` // in contracts
+	Side   string            `json:"side"`
+	Ts     int64             `json:"ts,string"`
+}
+
+// WsBookDTO represents order book data of bbo-tbt and books channels.
+// Levels are [price, size in contracts, deprecated, number of orders].
+type WsBookDTO struct {
+	Asks [][]utils.JSONFloat64 `json:"asks"`
+	Bids [][]utils.JSONFloat64 `json:"bids"`
+	Ts   int64                 `json:"ts,string"`
+}
@@ -0,0 +1,42 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const balanceURL = "/api/v5/account/balance"
+
+// GetBalances returns the balances of the user's trading (unified) account.
+// Spot and swaps share the trading account, so the balances are the same for both categories.
+func (c *Client) GetBalances(ctx context.Context, _ exchange.Category) ([]models.Balance, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, balanceURL, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetBalances: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.BalanceDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetBalances: %w", err)
+	}
+
+	result := make([]models.Balance, 0)
+	for _, account := range raw.Data {
+		for _, b := range account.Details {
+			result = append(result, models.Balance{
+				ExchangeName: c.GetExchangeName(),
+				Asset:        b.Ccy,
+				Free:         b.AvailEq.Decimal,
+				Locked:       b.Eq.Sub(b.AvailEq.Decimal),
+				Total:        b.Eq.Decimal,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetBalances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, balanceURL, r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("OK-ACCESS-KEY"))
+		assert.NotEmpty(t, r.Header.Get("OK-ACCESS-SIGN"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"totalEq":"1000.5","details":[
+			{"ccy":"USDT","eq":"1000","availEq":"800","frozenBal":"200"}
+		]}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	balances, err := c.GetBalances(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+
+	assert.Equal(t, "OKX", balances[0].ExchangeName)
+	assert.Equal(t, "USDT", balances[0].Asset)
+	assert.Equal(t, "800", balances[0].Free.String())
+	assert.Equal(t, "200", balances[0].Locked.String())
+	assert.Equal(t, "1000", balances[0].Total.String())
+}
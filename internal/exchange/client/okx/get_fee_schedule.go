@@ -0,0 +1,44 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+const tradeFeeURL = "/api/v5/account/trade-fee"
+
+// GetFeeSchedule retrieves the account fee rates of swaps from OKX. OKX has one rate per fee level, used as Default.
+// OKX rates are negative for fees and positive for rebates, FeeRate is a fee to pay, so the sign is flipped.
+func (c *Client) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, tradeFeeURL, url.Values{"instType": {"SWAP"}}, nil)
+	if err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("OKX GetFeeSchedule: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.TradeFeeDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return exchange.FeeSchedule{}, fmt.Errorf("OKX GetFeeSchedule: %w", err)
+	}
+
+	if len(raw.Data) == 0 {
+		return exchange.FeeSchedule{}, fmt.Errorf("OKX GetFeeSchedule: empty response")
+	}
+
+	fee := raw.Data[0]
+	maker, taker := fee.MakerU.Decimal, fee.TakerU.Decimal
+	if maker.IsZero() && taker.IsZero() {
+		maker, taker = fee.Maker.Decimal, fee.Taker.Decimal
+	}
+
+	return exchange.FeeSchedule{
+		Default: exchange.FeeRate{
+			Maker: maker.Neg().InexactFloat64(),
+			Taker: taker.Neg().InexactFloat64(),
+		},
+	}, nil
+}
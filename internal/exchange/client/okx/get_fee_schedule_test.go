@@ -0,0 +1,30 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetFeeSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tradeFeeURL, r.URL.Path)
+		assert.Equal(t, "SWAP", r.URL.Query().Get("instType"))
+		assert.NotEmpty(t, r.Header.Get("OK-ACCESS-SIGN"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"level":"Lv1","instType":"SWAP","taker":"-0.0005","maker":"-0.0002","takerU":"-0.0005","makerU":"-0.0002","takerUSDC":"","makerUSDC":"","ts":"1760000000000"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	fees, err := c.GetFeeSchedule(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0002, fees.Default.Maker)
+	assert.Equal(t, 0.0005, fees.Default.Taker)
+}
@@ -0,0 +1,50 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+const fundingRateURL = "/api/v5/public/funding-rate"
+
+// GetFundingRates retrieves current funding rates of all USDT-margined swaps from OKX (instId=ANY).
+// The interval is the time between the current and the next settlement.
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	req, err := c.newRequest(ctx, fundingRateURL, url.Values{"instId": {"ANY"}})
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetFundingRates: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.FundingRateDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetFundingRates: %w", err)
+	}
+
+	result := make(map[string]exchange.FundingRate, len(raw.Data))
+	for _, dto := range raw.Data {
+		if !isUSDTSwap(dto.InstID) {
+			continue
+		}
+
+		fundingTime := dto.FundingTime.IntPart()
+		interval := (dto.NextFundingTime.IntPart() - fundingTime) / time.Hour.Milliseconds()
+		if interval <= 0 {
+			interval = exchange.DefaultFundingIntervalHours
+		}
+
+		symbol := normalizeTickerName(dto.InstID)
+		result[symbol] = exchange.FundingRate{
+			Symbol:          symbol,
+			Rate:            float64(dto.FundingRate),
+			IntervalHours:   interval,
+			NextFundingTime: fundingTime,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetFundingRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fundingRateURL, r.URL.Path)
+		assert.Equal(t, "ANY", r.URL.Query().Get("instId"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","fundingRate":"0.0001","fundingTime":"1760000000000","nextFundingTime":"1760028800000"},
+			{"instId":"SOL-USDT-SWAP","fundingRate":"-0.0003","fundingTime":"1760000000000","nextFundingTime":"1760014400000"},
+			{"instId":"ETH-USDT-SWAP","fundingRate":"0.0002","fundingTime":"1760000000000","nextFundingTime":""},
+			{"instId":"BTC-USD-SWAP","fundingRate":"0.0001","fundingTime":"1760000000000","nextFundingTime":"1760028800000"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	rates, err := c.GetFundingRates(t.Context())
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+
+	assert.Equal(t, exchange.FundingRate{
+		Symbol:          "BTCUSDT",
+		Rate:            0.0001,
+		IntervalHours:   8,
+		NextFundingTime: 1760000000000,
+	}, rates["BTCUSDT"])
+	assert.Equal(t, -0.0003, rates["SOLUSDT"].Rate)
+	assert.Equal(t, int64(4), rates["SOLUSDT"].IntervalHours)
+	assert.Equal(t, int64(exchange.DefaultFundingIntervalHours), rates["ETHUSDT"].IntervalHours)
+}
@@ -0,0 +1,92 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+const instrumentsURL = "/api/v5/public/instruments"
+
+// instTypes maps the category to OKX instType.
+var instTypes = map[exchange.Category]string{
+	exchange.CategoryLinear: "SWAP",
+	exchange.CategorySpot:   "SPOT",
+}
+
+// GetInstruments retrieves specifications of USDT-margined swaps (linear) or USDT spot pairs from OKX.
+// Swaps are traded in contracts: lotSz/minSz are in contracts, ctVal is the contract value in coins (ContractSize).
+// Spot is traded in coins, so ContractSize is 1.
+func (c *Client) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	instType, ok := instTypes[category]
+	if !ok {
+		return nil, fmt.Errorf("OKX GetInstruments: unsupported category %s", category)
+	}
+
+	req, err := c.newRequest(ctx, instrumentsURL, url.Values{"instType": {instType}})
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetInstruments: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.InstrumentDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetInstruments: %w", err)
+	}
+
+	result := make(map[string]exchange.Instrument, len(raw.Data))
+	for _, dto := range raw.Data {
+		if dto.State != "live" {
+			continue
+		}
+		if category == exchange.CategoryLinear && (dto.CtType != "linear" || !isUSDTSwap(dto.InstID)) {
+			continue
+		}
+		if category == exchange.CategorySpot {
+			if !isUSDTSpot(dto.InstID) {
+				continue
+			}
+			dto.CtVal = "1"
+		}
+
+		instrument, err := mapInstrument(dto)
+		if err != nil {
+			return nil, fmt.Errorf("OKX GetInstruments: %w", err)
+		}
+
+		result[instrument.Symbol] = instrument
+	}
+
+	return result, nil
+}
+
+func mapInstrument(dto dtos.InstrumentDTO) (exchange.Instrument, error) {
+	volStep, err := decimal.NewFromString(dto.LotSz)
+	if err != nil {
+		return exchange.Instrument{}, fmt.Errorf("invalid lotSz for %s: %w", dto.InstID, err)
+	}
+	minVol, err := decimal.NewFromString(dto.MinSz)
+	if err != nil {
+		return exchange.Instrument{}, fmt.Errorf("invalid minSz for %s: %w", dto.InstID, err)
+	}
+	priceStep, err := decimal.NewFromString(dto.TickSz)
+	if err != nil {
+		return exchange.Instrument{}, fmt.Errorf("invalid tickSz for %s: %w", dto.InstID, err)
+	}
+	contractSize, err := decimal.NewFromString(dto.CtVal)
+	if err != nil || !contractSize.IsPositive() {
+		return exchange.Instrument{}, fmt.Errorf("invalid ctVal for %s: %q", dto.InstID, dto.CtVal)
+	}
+
+	return exchange.Instrument{
+		Symbol:       normalizeTickerName(dto.InstID),
+		VolStep:      volStep,
+		MinVol:       minVol,
+		PriceStep:    priceStep,
+		ContractSize: contractSize,
+	}, nil
+}
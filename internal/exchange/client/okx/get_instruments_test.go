@@ -0,0 +1,67 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetInstruments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, instrumentsURL, r.URL.Path)
+		assert.Equal(t, "SWAP", r.URL.Query().Get("instType"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","ctVal":"0.01","ctValCcy":"BTC","ctType":"linear","settleCcy":"USDT","lotSz":"0.01","minSz":"0.01","tickSz":"0.1","state":"live"},
+			{"instId":"PEPE-USDT-SWAP","ctVal":"10000000","ctValCcy":"PEPE","ctType":"linear","settleCcy":"USDT","lotSz":"1","minSz":"1","tickSz":"0.0000000001","state":"live"},
+			{"instId":"BTC-USD-SWAP","ctVal":"100","ctValCcy":"USD","ctType":"inverse","settleCcy":"BTC","lotSz":"1","minSz":"1","tickSz":"0.1","state":"live"},
+			{"instId":"LUNA-USDT-SWAP","ctVal":"1","ctValCcy":"LUNA","ctType":"linear","settleCcy":"USDT","lotSz":"1","minSz":"1","tickSz":"0.0001","state":"suspend"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	instruments, err := c.GetInstruments(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, instruments, 2)
+
+	btc := instruments["BTCUSDT"]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, "0.01", btc.VolStep.String())
+	assert.Equal(t, "0.01", btc.MinVol.String())
+	assert.Equal(t, "0.1", btc.PriceStep.String())
+	assert.Equal(t, "0.01", btc.ContractSize.String())
+
+	assert.Equal(t, "10000000", instruments["PEPEUSDT"].ContractSize.String())
+}
+
+func TestClient_GetInstruments_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "SPOT", r.URL.Query().Get("instType"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT","ctVal":"","lotSz":"0.00000001","minSz":"0.00001","tickSz":"0.1","state":"live"},
+			{"instId":"BTC-USDC","ctVal":"","lotSz":"0.00000001","minSz":"0.00001","tickSz":"0.1","state":"live"},
+			{"instId":"LUNA-USDT","ctVal":"","lotSz":"0.001","minSz":"1","tickSz":"0.0001","state":"suspend"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	instruments, err := c.GetInstruments(t.Context(), exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, instruments, 1)
+
+	btc := instruments["BTCUSDT"]
+	assert.Equal(t, "0.00000001", btc.VolStep.String())
+	assert.Equal(t, "0.00001", btc.MinVol.String())
+	assert.Equal(t, "1", btc.ContractSize.String())
+}
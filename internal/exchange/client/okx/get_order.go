@@ -0,0 +1,56 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+// GetOrder retrieves the order from the exchange by client order id.
+// OKX returns accumulated fee of the order negative (paid), the same way fees are added to the spread profit.
+// Spot buy fee is charged in the received base coin and is converted to USDT by the average price.
+func (c *Client) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	query := url.Values{
+		"instId":  {instIDFor(symbol, category)},
+		"clOrdId": {clientOrderID(orderID)},
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodGet, orderURL, query, nil)
+	if err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("OKX GetOrder: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.OrderDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return exchange.ExchangeOrder{}, fmt.Errorf("OKX GetOrder: %w", err)
+	}
+
+	if len(raw.Data) == 0 {
+		return exchange.ExchangeOrder{}, fmt.Errorf("OKX GetOrder: order %s not found", orderID)
+	}
+
+	order := raw.Data[0]
+	if exchangeOrderID == "" {
+		exchangeOrderID = order.OrdID
+	}
+
+	fees := order.Fee.Decimal
+	if order.FeeCcy != "" && order.FeeCcy != "USDT" {
+		fees = fees.Mul(order.AvgPx.Decimal)
+	}
+
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: exchangeOrderID,
+		ExchangeName:    c.GetExchangeName(),
+		AvgPrice:        order.AvgPx.Decimal,
+		Fees:            fees,
+		Profit:          order.Pnl.Decimal,
+	}, nil
+}
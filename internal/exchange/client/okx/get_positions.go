@@ -0,0 +1,99 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// API: GET /api/v5/account/positions
+// Docs: https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-positions
+// Open orders: GET /api/v5/trade/orders-pending
+
+const (
+	positionsURL     = "/api/v5/account/positions"
+	pendingOrdersURL = "/api/v5/trade/orders-pending"
+)
+
+// GetPositions returns open USDT perpetual swap positions, qty is in contracts.
+func (c *Client) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, positionsURL, url.Values{"instType": {instTypes[exchange.CategoryLinear]}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetPositions: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.PositionDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetPositions: %w", err)
+	}
+
+	positions := make([]exchange.Position, 0, len(raw.Data))
+	for _, p := range raw.Data {
+		if !isUSDTSwap(p.InstID) || p.Pos.IsZero() {
+			continue
+		}
+
+		side := models.OrderSideBuy
+		if p.Pos.IsNegative() || p.PosSide == "short" {
+			side = models.OrderSideSell
+		}
+
+		positions = append(positions, exchange.Position{
+			Symbol:     normalizeTickerName(p.InstID),
+			Side:       side,
+			Qty:        p.Pos.Abs(),
+			EntryPrice: p.AvgPx.Decimal,
+		})
+	}
+
+	return positions, nil
+}
+
+// GetOpenOrders returns orders resting on the book of the category.
+func (c *Client) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, pendingOrdersURL, url.Values{"instType": {instTypes[category]}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetOpenOrders: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.OrderDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetOpenOrders: %w", err)
+	}
+
+	orders := make([]exchange.OpenOrder, 0, len(raw.Data))
+	for _, o := range raw.Data {
+		if !isUSDTSwap(o.InstID) && !isUSDTSpot(o.InstID) {
+			continue
+		}
+
+		// orders placed not by the bot have no or foreign clOrdId
+		orderID, err := uuid.Parse(o.ClOrdID)
+		if err != nil {
+			orderID = uuid.Nil
+		}
+
+		side := models.OrderSideBuy
+		if o.Side == string(dtos.OrderSideSell) {
+			side = models.OrderSideSell
+		}
+
+		orders = append(orders, exchange.OpenOrder{
+			OrderID:         orderID,
+			ExchangeOrderID: o.OrdID,
+			Symbol:          normalizeTickerName(o.InstID),
+			Side:            side,
+			Qty:             o.Sz.Decimal,
+			Price:           o.Px.Decimal,
+		})
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,63 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+func TestClient_GetPositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, positionsURL, r.URL.Path)
+		assert.Equal(t, "SWAP", r.URL.Query().Get("instType"))
+		assert.NotEmpty(t, r.Header.Get("OK-ACCESS-SIGN"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","posSide":"net","pos":"-10","avgPx":"65000.5"},
+			{"instId":"BTC-USD-SWAP","posSide":"net","pos":"5","avgPx":"65000"},
+			{"instId":"ETH-USDT-SWAP","posSide":"net","pos":"0","avgPx":""}
+		]}`))
+	}))
+	defer server.Close()
+
+	positions, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetPositions(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTCUSDT", positions[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, positions[0].Side)
+	assert.Equal(t, "10", positions[0].Qty.String())
+	assert.Equal(t, "65000.5", positions[0].EntryPrice.String())
+}
+
+func TestClient_GetOpenOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pendingOrdersURL, r.URL.Path)
+		assert.Equal(t, "SWAP", r.URL.Query().Get("instType"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"550e8400e29b41d4a716446655440000","side":"sell","sz":"10","px":"66000","state":"live"},
+			{"instId":"ETH-USDT-SWAP","ordId":"312269865356374017","clOrdId":"","side":"buy","sz":"1","px":"3000","state":"live"}
+		]}`))
+	}))
+	defer server.Close()
+
+	orders, err := NewClient(getTestConfig(server.URL), zerolog.Nop()).GetOpenOrders(t.Context(), exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), orders[0].OrderID)
+	assert.Equal(t, "312269865356374016", orders[0].ExchangeOrderID)
+	assert.Equal(t, "BTCUSDT", orders[0].Symbol)
+	assert.Equal(t, models.OrderSideSell, orders[0].Side)
+	assert.Equal(t, "10", orders[0].Qty.String())
+	assert.Equal(t, uuid.Nil, orders[1].OrderID)
+}
@@ -0,0 +1,72 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+const tickersURL = "/api/v5/market/tickers"
+
+// GetTickers returns 24h tickers of USDT-margined swaps. All symbols are returned when symbols is empty.
+func (c *Client) GetTickers(ctx context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	if category != exchange.CategoryLinear {
+		return nil, fmt.Errorf("OKX GetTickers: unsupported category %s", category)
+	}
+
+	req, err := c.newRequest(ctx, tickersURL, url.Values{"instType": {"SWAP"}})
+	if err != nil {
+		return nil, fmt.Errorf("OKX GetTickers: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.TickerDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, fmt.Errorf("OKX GetTickers: %w", err)
+	}
+
+	filter := make(map[string]struct{}, len(symbols))
+	for _, s := range symbols {
+		filter[s] = struct{}{}
+	}
+
+	result := make([]exchange.Ticker, 0, len(raw.Data))
+	for _, dto := range raw.Data {
+		if !isUSDTSwap(dto.InstID) {
+			continue
+		}
+
+		t := mapTicker(dto)
+		if _, ok := filter[t.Symbol]; len(filter) > 0 && !ok {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func mapTicker(d dtos.TickerDTO) exchange.Ticker {
+	t := exchange.Ticker{
+		Symbol:       normalizeTickerName(d.InstID),
+		LastPrice:    d.Last.Decimal,
+		PrevPrice24h: d.Open24h.Decimal,
+		HighPrice24h: d.High24h.Decimal,
+		LowPrice24h:  d.Low24h.Decimal,
+		// volCcy24h of swaps is in coins
+		Turnover24h: d.VolCcy24h.Mul(d.Last.Decimal),
+	}
+
+	if d.Open24h.IsPositive() {
+		t.Price24hPcnt = d.Last.Sub(d.Open24h.Decimal).Div(d.Open24h.Decimal)
+	} else {
+		t.Price24hPcnt = decimal.Zero
+	}
+
+	return t
+}
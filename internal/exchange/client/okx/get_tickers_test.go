@@ -0,0 +1,44 @@
+package okx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestClient_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tickersURL, r.URL.Path)
+		assert.Equal(t, "SWAP", r.URL.Query().Get("instType"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instType":"SWAP","instId":"BTC-USDT-SWAP","last":"66000","open24h":"60000","high24h":"67000","low24h":"59000","vol24h":"2000000","volCcy24h":"20000","ts":"1760000000000"},
+			{"instType":"SWAP","instId":"ETH-USDT-SWAP","last":"2500","open24h":"2500","high24h":"2600","low24h":"2400","vol24h":"100","volCcy24h":"10","ts":"1760000000000"},
+			{"instType":"SWAP","instId":"BTC-USD-SWAP","last":"66000","open24h":"60000","high24h":"67000","low24h":"59000","vol24h":"1","volCcy24h":"1","ts":"1760000000000"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	tickers, err := c.GetTickers(t.Context(), nil, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 2)
+
+	btc := tickers[0]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Equal(t, "66000", btc.LastPrice.String())
+	assert.Equal(t, "0.1", btc.Price24hPcnt.String())
+	assert.Equal(t, "1320000000", btc.Turnover24h.String())
+
+	tickers, err = c.GetTickers(t.Context(), []string{"ETHUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 1)
+	assert.Equal(t, "ETHUSDT", tickers[0].Symbol)
+}
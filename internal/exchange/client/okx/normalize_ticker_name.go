@@ -0,0 +1,48 @@
+package okx
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+const (
+	swapSuffix = "-USDT-SWAP"
+	spotSuffix = "-USDT"
+)
+
+// normalizeTickerName converts OKX instId (swap or spot) to the common symbol: BTC-USDT-SWAP / BTC-USDT -> BTCUSDT.
+func normalizeTickerName(instID string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(instID, swapSuffix), spotSuffix) + "USDT"
+}
+
+// denormalizeTickerName converts the common symbol to OKX instId: BTCUSDT -> BTC-USDT-SWAP.
+func denormalizeTickerName(symbol string) string {
+	return strings.TrimSuffix(symbol, "USDT") + swapSuffix
+}
+
+// instIDFor converts the common symbol to OKX instId of the category: BTCUSDT -> BTC-USDT-SWAP (linear) / BTC-USDT (spot).
+func instIDFor(symbol string, category exchange.Category) string {
+	if category == exchange.CategorySpot {
+		return strings.TrimSuffix(symbol, "USDT") + spotSuffix
+	}
+	return denormalizeTickerName(symbol)
+}
+
+// isUSDTSpot reports whether instId is a spot pair quoted in USDT.
+func isUSDTSpot(instID string) bool {
+	return strings.HasSuffix(instID, spotSuffix) && strings.Count(instID, "-") == 1
+}
+
+// isUSDTSwap reports whether instId is a USDT-margined perpetual swap.
+func isUSDTSwap(instID string) bool {
+	return strings.HasSuffix(instID, swapSuffix)
+}
+
+// clientOrderID converts order id to OKX clOrdId, which allows only alphanumerics up to 32 chars (uuid without dashes).
+// uuid.Parse accepts this form, so the order id is parsed back from execution events as is.
+func clientOrderID(orderID uuid.UUID) string {
+	return strings.ReplaceAll(orderID.String(), "-", "")
+}
@@ -0,0 +1,156 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// Api description: https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-place-order
+// Swap orders are placed in net position mode with cross margin, positions are closed with reduceOnly orders.
+// Swap order quantity is in contracts, spot order quantity is in base coin.
+
+const (
+	orderURL       = "/api/v5/trade/order"
+	cancelOrderURL = "/api/v5/trade/cancel-order"
+)
+
+// CreateOrder sends an order to the exchange.
+// On success, mutates order: sets ExchangeOrderID, ExchangeName, Status, RawResponse.
+func (c *Client) CreateOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	return c.submitOrder(ctx, order, mapRequestDataToOrderDTO(order, c.tradeMode(order.Symbol)))
+}
+
+// CloseOrder closes an existing position by placing a reduce-only market order in the opposite direction.
+// Spot has no positions, so a spot leg is closed by a plain market order of the opposite side.
+func (c *Client) CloseOrder(ctx context.Context, order *models.Order) error {
+	if err := validateBeforeCreateOrder(order); err != nil {
+		return err
+	}
+
+	// flip side to close the position
+	side := dtos.OrderSideSell
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideBuy
+	}
+
+	payload := dtos.PlaceOrderDTO{
+		InstID:     denormalizeTickerName(order.Symbol),
+		TdMode:     c.tradeMode(order.Symbol),
+		Side:       side,
+		OrdType:    dtos.OrderTypeMarket,
+		Sz:         order.Quantity.String(),
+		ClOrdID:    clientOrderID(order.ID),
+		ReduceOnly: true,
+	}
+
+	if order.Market == models.OrderMarketSpot {
+		payload.InstID = instIDFor(order.Symbol, exchange.CategorySpot)
+		payload.TdMode = dtos.TradeModeCash
+		payload.TgtCcy = dtos.TargetCurrencyBase
+		payload.ReduceOnly = false
+	}
+
+	return c.submitOrder(ctx, order, payload)
+}
+
+func (c *Client) submitOrder(ctx context.Context, order *models.Order, payload dtos.PlaceOrderDTO) error {
+	req, err := c.newSignedRequest(ctx, http.MethodPost, orderURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("OKX client failed to create order request: %w", err)
+	}
+
+	var raw response[dtos.OrderAckDTO]
+	body, err := c.do(req, &raw)
+	if err != nil {
+		return fmt.Errorf("OKX client order failed: %w", err)
+	}
+
+	if len(raw.Data) == 0 {
+		return fmt.Errorf("OKX client order failed: empty response: %s", body)
+	}
+
+	confirmed := order
+	confirmed.ExchangeName = c.GetExchangeName()
+	confirmed.ExchangeOrderID = raw.Data[0].OrdID
+	confirmed.RawResponse = string(body)
+	confirmed.Status = models.OrderStatusNew
+
+	return nil
+}
+
+func validateBeforeCreateOrder(order *models.Order) error {
+	if order.Market != models.OrderMarketLinear && order.Market != models.OrderMarketSpot {
+		return fmt.Errorf("OKX client supports only linear and spot markets")
+	}
+
+	if order.Quantity.LessThanOrEqual(decimal.NewFromInt(0)) {
+		return fmt.Errorf("OKX client order quantity must be greater than 0")
+	}
+
+	if len(order.Symbol) <= 0 {
+		return fmt.Errorf("OKX client order symbol must be specified")
+	}
+
+	if order.ID == uuid.Nil {
+		return fmt.Errorf("OKX client order id must be valid uuid")
+	}
+
+	return nil
+}
+
+func mapRequestDataToOrderDTO(order *models.Order, tdMode string) dtos.PlaceOrderDTO {
+	side := dtos.OrderSideBuy
+	if order.Side == models.OrderSideSell {
+		side = dtos.OrderSideSell
+	}
+
+	payload := dtos.PlaceOrderDTO{
+		InstID:  denormalizeTickerName(order.Symbol),
+		TdMode:  tdMode,
+		Side:    side,
+		OrdType: dtos.OrderTypeMarket,
+		Sz:      order.Quantity.String(),
+		ClOrdID: clientOrderID(order.ID),
+	}
+
+	if order.Market == models.OrderMarketSpot {
+		payload.InstID = instIDFor(order.Symbol, exchange.CategorySpot)
+		payload.TdMode = dtos.TradeModeCash
+	}
+
+	if order.Type == models.OrderTypeLimit {
+		payload.OrdType = mapTimeInForce(order.TimeInForce)
+		payload.Px = order.Price.String()
+	} else if order.Market == models.OrderMarketSpot {
+		payload.TgtCcy = dtos.TargetCurrencyBase
+	}
+
+	return payload
+}
+
+// mapTimeInForce maps the generic models.TimeInForce to OKX limit order type.
+// Defaults to GTC limit when unspecified.
+func mapTimeInForce(tif models.TimeInForce) dtos.OrderType {
+	switch tif {
+	case models.TimeInForceIOC:
+		return dtos.OrderTypeIOC
+	case models.TimeInForceFOK:
+		return dtos.OrderTypeFOK
+	case models.TimeInForcePostOnly:
+		return dtos.OrderTypePostOnly
+	default:
+		return dtos.OrderTypeLimit
+	}
+}
@@ -0,0 +1,229 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+const testClOrdID = "550e8400e29b41d4a716446655440000"
+
+func newTestOrder() *models.Order {
+	return &models.Order{
+		ID:       uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Symbol:   "BTCUSDT",
+		Side:     models.OrderSideBuy,
+		Type:     models.OrderTypeMarket,
+		Market:   models.OrderMarketLinear,
+		Quantity: decimal.RequireFromString("2"),
+	}
+}
+
+func decodePlaceOrder(t *testing.T, r *http.Request) dtos.PlaceOrderDTO {
+	t.Helper()
+
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, orderURL, r.URL.Path)
+	assert.Equal(t, "test-api-key", r.Header.Get("OK-ACCESS-KEY"))
+
+	var payload dtos.PlaceOrderDTO
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+	return payload
+}
+
+func TestClient_CreateOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dtos.PlaceOrderDTO{
+			InstID:  "BTC-USDT-SWAP",
+			TdMode:  "cross",
+			Side:    dtos.OrderSideBuy,
+			OrdType: dtos.OrderTypeMarket,
+			Sz:      "2",
+			ClOrdID: testClOrdID,
+		}, decodePlaceOrder(t, r))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"clOrdId":"550e8400e29b41d4a716446655440000","ordId":"312269865356374016","tag":"","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+
+	assert.Equal(t, "OKX", order.ExchangeName)
+	assert.Equal(t, "312269865356374016", order.ExchangeOrderID)
+	assert.Equal(t, models.OrderStatusNew, order.Status)
+	assert.NotEmpty(t, order.RawResponse)
+}
+
+func TestClient_CreateOrder_Limit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceOrder(t, r)
+		assert.Equal(t, dtos.OrderSideSell, payload.Side)
+		assert.Equal(t, dtos.OrderTypePostOnly, payload.OrdType)
+		assert.Equal(t, "65100.5", payload.Px)
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"312269865356374017","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Side = models.OrderSideSell
+	order.Type = models.OrderTypeLimit
+	order.Price = decimal.RequireFromString("65100.5")
+	order.TimeInForce = models.TimeInForcePostOnly
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "312269865356374017", order.ExchangeOrderID)
+}
+
+func TestClient_CreateOrder_Validation(t *testing.T) {
+	c := NewClient(getTestConfig(""), zerolog.Nop())
+
+	order := newTestOrder()
+	order.Quantity = decimal.Zero
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+
+	order = newTestOrder()
+	order.Market = models.OrderMarket("INVERSE")
+	assert.Error(t, c.CreateOrder(t.Context(), order))
+}
+
+func TestClient_CreateOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dtos.PlaceOrderDTO{
+			InstID:  "BTC-USDT",
+			TdMode:  "cash",
+			Side:    dtos.OrderSideBuy,
+			OrdType: dtos.OrderTypeMarket,
+			Sz:      "2",
+			TgtCcy:  "base_ccy",
+			ClOrdID: testClOrdID,
+		}, decodePlaceOrder(t, r))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"312269865356374019","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+
+	require.NoError(t, c.CreateOrder(t.Context(), order))
+	assert.Equal(t, "312269865356374019", order.ExchangeOrderID)
+}
+
+func TestClient_CloseOrder_Spot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceOrder(t, r)
+		assert.Equal(t, "BTC-USDT", payload.InstID)
+		assert.Equal(t, "cash", payload.TdMode)
+		assert.Equal(t, dtos.OrderSideSell, payload.Side)
+		assert.Equal(t, "base_ccy", payload.TgtCcy)
+		assert.False(t, payload.ReduceOnly)
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"312269865356374020","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+	order.Market = models.OrderMarketSpot
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+}
+
+func TestClient_CloseOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := decodePlaceOrder(t, r)
+		// open order was buy, close flips the side
+		assert.Equal(t, dtos.OrderSideSell, payload.Side)
+		assert.Equal(t, dtos.OrderTypeMarket, payload.OrdType)
+		assert.True(t, payload.ReduceOnly)
+		assert.Equal(t, "2", payload.Sz)
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"312269865356374018","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	order := newTestOrder()
+
+	require.NoError(t, c.CloseOrder(t.Context(), order))
+	assert.Equal(t, "312269865356374018", order.ExchangeOrderID)
+}
+
+func TestClient_CancelOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, cancelOrderURL, r.URL.Path)
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"instId": "BTC-USDT-SWAP", "clOrdId": testClOrdID}, payload)
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"clOrdId":"550e8400e29b41d4a716446655440000","ordId":"312269865356374016","sCode":"0","sMsg":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	err := c.CancelOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "312269865356374016", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+}
+
+func TestClient_GetOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, orderURL, r.URL.Path)
+		assert.Equal(t, "BTC-USDT-SWAP", r.URL.Query().Get("instId"))
+		assert.Equal(t, testClOrdID, r.URL.Query().Get("clOrdId"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"550e8400e29b41d4a716446655440000",
+			"px":"","sz":"2","avgPx":"65000.1","accFillSz":"2","fillPx":"65000.1","fillSz":"1","state":"filled","side":"buy","fee":"-0.65","feeCcy":"USDT","pnl":"1.5"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+	orderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	order, err := c.GetOrder(t.Context(), orderID, "", "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+
+	assert.Equal(t, orderID, order.OrderID)
+	assert.Equal(t, "312269865356374016", order.ExchangeOrderID)
+	assert.Equal(t, "OKX", order.ExchangeName)
+	assert.Equal(t, "65000.1", order.AvgPrice.String())
+	assert.Equal(t, "-0.65", order.Fees.String())
+	assert.Equal(t, "1.5", order.Profit.String())
+}
+
+func TestClient_GetOrder_SpotBuyFeeInBaseCoin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "BTC-USDT", r.URL.Query().Get("instId"))
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT","ordId":"312269865356374019","clOrdId":"550e8400e29b41d4a716446655440000",
+			"px":"","sz":"2","avgPx":"65000","accFillSz":"2","state":"filled","side":"buy","fee":"-0.002","feeCcy":"BTC","pnl":"0"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	order, err := c.GetOrder(t.Context(), uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), "", "BTCUSDT", exchange.CategorySpot)
+	require.NoError(t, err)
+	assert.Equal(t, "-130", order.Fees.String())
+}
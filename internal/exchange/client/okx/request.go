@@ -0,0 +1,129 @@
+package okx
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// apiError is an error response of OKX API (code != "0").
+type apiError struct {
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("OKX api error, %s, %s", e.Code, e.Message)
+}
+
+// response is the common envelope of OKX API responses, data is always an array.
+type response[T any] struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []T    `json:"data"`
+}
+
+// sign returns base64 encoded HMAC SHA256 of the message.
+// Docs: https://www.okx.com/docs-v5/en/#overview-rest-authentication-signature
+func sign(secret, message string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// newRequest creates a public (unsigned) GET request.
+func (c *Client) newRequest(ctx context.Context, path string, query url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return req, nil
+}
+
+// newSignedRequest creates a private request. Query is used for GET, payload is sent as JSON body for POST.
+// Prehash string is timestamp + method + requestPath (with query) + body.
+func (c *Client) newSignedRequest(ctx context.Context, method string, path string, query url.Values, payload interface{}) (*http.Request, error) {
+	requestPath := path
+	if len(query) > 0 {
+		requestPath += "?" + query.Encode()
+	}
+
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+requestPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	cfg := c.cfg.Exchange.OKX
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("OK-ACCESS-KEY", cfg.APIKey)
+	req.Header.Set("OK-ACCESS-SIGN", sign(cfg.APISecret, timestamp+method+requestPath+string(body)))
+	req.Header.Set("OK-ACCESS-TIMESTAMP", timestamp)
+	req.Header.Set("OK-ACCESS-PASSPHRASE", cfg.Passphrase)
+
+	return req, nil
+}
+
+// do sends the request and returns the response body. OKX returns errors in the envelope (code != "0"),
+// for some of them with non 2xx http status.
+func (c *Client) do(req *http.Request, out interface{}) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// trade endpoints put the reason of a failure into data: {"code":"1","msg":"All operations failed","data":[{"sCode":"51008","sMsg":"..."}]}
+	var envelope response[struct {
+		SCode string `json:"sCode"`
+		SMsg  string `json:"sMsg"`
+	}]
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("unexpected http status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if envelope.Code != "0" {
+		if len(envelope.Data) > 0 && envelope.Data[0].SCode != "" && envelope.Data[0].SCode != "0" {
+			return nil, &apiError{Code: envelope.Data[0].SCode, Message: envelope.Data[0].SMsg}
+		}
+		return nil, &apiError{Code: envelope.Code, Message: envelope.Msg}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if out == nil {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return body, nil
+}
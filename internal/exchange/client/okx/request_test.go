@@ -0,0 +1,83 @@
+package okx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+func getTestConfig(baseURL string) *config.Config {
+	return &config.Config{
+		Exchange: config.ExchangeConfig{
+			OKX: config.OKXConfig{
+				APIBaseURL: baseURL,
+				APIKey:     "test-api-key",
+				APISecret:  "test-api-secret",
+				Passphrase: "test-passphrase",
+			},
+		},
+	}
+}
+
+func TestSign(t *testing.T) {
+	// printf "%s" "<message>" | openssl dgst -sha256 -hmac secret -binary | base64
+	assert.Equal(t, "5ktoTKif8DCJlIPb/3Kfd1A17bIRye6jpS9QBWj+9AU=", sign("secret", "2020-12-08T09:08:57.715ZGET/api/v5/account/balance"))
+}
+
+func TestClient_NewSignedRequest(t *testing.T) {
+	c := NewClient(getTestConfig("https://www.okx.com"), zerolog.Nop())
+
+	req, err := c.newSignedRequest(t.Context(), http.MethodPost, orderURL, nil, map[string]string{"instId": "BTC-USDT-SWAP"})
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"instId":"BTC-USDT-SWAP"}`, string(body))
+
+	timestamp := req.Header.Get("OK-ACCESS-TIMESTAMP")
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "test-api-key", req.Header.Get("OK-ACCESS-KEY"))
+	assert.Equal(t, "test-passphrase", req.Header.Get("OK-ACCESS-PASSPHRASE"))
+	assert.Equal(t, sign("test-api-secret", timestamp+http.MethodPost+orderURL+string(body)), req.Header.Get("OK-ACCESS-SIGN"))
+
+	// query is a part of the signed request path
+	req, err = c.newSignedRequest(t.Context(), http.MethodGet, orderURL, url.Values{"instId": {"BTC-USDT-SWAP"}}, nil)
+	require.NoError(t, err)
+
+	timestamp = req.Header.Get("OK-ACCESS-TIMESTAMP")
+	assert.Equal(t, sign("test-api-secret", timestamp+http.MethodGet+orderURL+"?instId=BTC-USDT-SWAP"), req.Header.Get("OK-ACCESS-SIGN"))
+}
+
+func TestClient_Do_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"code":"1","msg":"All operations failed","data":[{"ordId":"","clOrdId":"","sCode":"51008","sMsg":"Order failed. Insufficient USDT margin in account"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	req, err := c.newRequest(t.Context(), tickersURL, nil)
+	require.NoError(t, err)
+
+	_, err = c.do(req, nil)
+
+	var apiErr *apiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "51008", apiErr.Code)
+	assert.Equal(t, "Order failed. Insufficient USDT margin in account", apiErr.Message)
+}
+
+func TestNormalizeTickerName(t *testing.T) {
+	assert.Equal(t, "BTCUSDT", normalizeTickerName("BTC-USDT-SWAP"))
+	assert.Equal(t, "1000PEPEUSDT", normalizeTickerName("1000PEPE-USDT-SWAP"))
+	assert.Equal(t, "BTC-USDT-SWAP", denormalizeTickerName("BTCUSDT"))
+	assert.False(t, isUSDTSwap("BTC-USD-SWAP"))
+}
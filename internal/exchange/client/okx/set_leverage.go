@@ -0,0 +1,51 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+// Docs: https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-leverage
+
+const leverageURL = "/api/v5/account/set-leverage"
+
+// SetLeverage sets the leverage of a swap in the margin mode set by SetMarginMode, cross by default.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int64) error {
+	payload := map[string]string{
+		"instId":  denormalizeTickerName(symbol),
+		"lever":   strconv.FormatInt(leverage, 10),
+		"mgnMode": c.tradeMode(symbol),
+	}
+
+	req, err := c.newSignedRequest(ctx, http.MethodPost, leverageURL, nil, payload)
+	if err != nil {
+		return fmt.Errorf("OKX SetLeverage: failed to create request: %w", err)
+	}
+
+	var raw response[dtos.LeverageDTO]
+	if _, err := c.do(req, &raw); err != nil {
+		return fmt.Errorf("OKX SetLeverage: %w", err)
+	}
+
+	return nil
+}
+
+// SetMarginMode sets the margin mode of a swap. OKX takes the margin mode as tdMode of every order,
+// so it's only stored for SetLeverage and the orders on the symbol.
+func (c *Client) SetMarginMode(_ context.Context, symbol string, mode exchange.MarginMode) error {
+	c.margin.SetMode(symbol, mode)
+	return nil
+}
+
+// tradeMode returns tdMode of the swap orders on the symbol.
+func (c *Client) tradeMode(symbol string) string {
+	if c.margin.Mode(symbol) == exchange.MarginModeIsolated {
+		return dtos.TradeModeIsolated
+	}
+	return dtos.TradeModeCross
+}
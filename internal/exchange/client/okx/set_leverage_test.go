@@ -0,0 +1,51 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+func TestClient_SetLeverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, leverageURL, r.URL.Path)
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"instId": "BTC-USDT-SWAP", "lever": "5", "mgnMode": "cross"}, payload)
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","lever":"5","mgnMode":"cross","posSide":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 5))
+}
+
+func TestClient_SetLeverage_Isolated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "isolated", payload["mgnMode"])
+
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","lever":"3","mgnMode":"isolated","posSide":""}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(getTestConfig(server.URL), zerolog.Nop())
+
+	require.NoError(t, c.SetMarginMode(t.Context(), "BTCUSDT", exchange.MarginModeIsolated))
+	require.NoError(t, c.SetLeverage(t.Context(), "BTCUSDT", 3))
+	assert.Equal(t, dtos.TradeModeIsolated, c.tradeMode("BTCUSDT"))
+	assert.Equal(t, dtos.TradeModeCross, c.tradeMode("ETHUSDT"))
+}
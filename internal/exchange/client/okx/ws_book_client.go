@@ -0,0 +1,293 @@
+package okx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+	"github.com/lucrumx/bot/internal/utils"
+)
+
+const (
+	// bookTickerChannel is tick-by-tick best bid/ask.
+	bookTickerChannel = "bbo-tbt"
+	// orderBookChannel is 400 levels snapshot on subscribe and incremental updates every 100ms.
+	orderBookChannel = "books"
+
+	bookActionSnapshot = "snapshot"
+)
+
+// wsBookClient represents a WebSocket client streaming orderbook of OKX swaps.
+// T is exchange.BookTop for bbo-tbt or exchange.OrderBookUpdate for books.
+type wsBookClient[T any] struct {
+	Metrics    *Metrics
+	cfg        *config.Config
+	wsMu       sync.Mutex
+	backoff    *exchange.Backoff
+	logger     zerolog.Logger
+	channel    string
+	mapMessage func(push *dtos.WsMessageDTO) ([]T, error)
+}
+
+func newWsBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.BookTop] {
+	return &wsBookClient[exchange.BookTop]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		channel:    bookTickerChannel,
+		mapMessage: mapBookTicker,
+	}
+}
+
+func newWsOrderBookClient(cfg *config.Config, logger zerolog.Logger) *wsBookClient[exchange.OrderBookUpdate] {
+	return &wsBookClient[exchange.OrderBookUpdate]{
+		Metrics:    &Metrics{},
+		cfg:        cfg,
+		backoff:    exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:     logger,
+		channel:    orderBookChannel,
+		mapMessage: mapOrderBook,
+	}
+}
+
+func (c *wsBookClient[T]) writeMessage(wsConn *websocket.Conn, messageType int, data []byte) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteMessage(messageType, data)
+}
+
+func (c *wsBookClient[T]) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsBookClient[T]) Start(ctx context.Context, symbols []string, outChan chan<- T) error {
+	wsConn, err := c.connect(symbols)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the channel of the given symbols.
+func (c *wsBookClient[T]) connect(symbols []string) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.OKX.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("okx failed to dial websocket: %w", err)
+	}
+
+	if err = c.writeJSON(wsConn, subscribePayload(symbols, exchange.CategoryLinear, c.channel)); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("okx failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads book messages from the connection and reconnects with backoff until ctx is done.
+// On resubscribe OKX sends a new books snapshot, so the local book is rebuilt.
+func (c *wsBookClient[T]) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, outChan chan<- T) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsBookClient[T]) reconnect(ctx context.Context, symbols []string) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("okx failed to reconnect to OKX book websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("okx reconnected to OKX book websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsBookClient[T]) readMessage(ctx context.Context, wsConn *websocket.Conn, outChan chan<- T) {
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("okx failed to read message from OKX book websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage || bytes.Equal(messageByte, pongMessage) {
+			continue
+		}
+
+		var push dtos.WsMessageDTO
+		if err = json.Unmarshal(messageByte, &push); err != nil {
+			c.logger.Warn().Err(err).Msgf("okx failed to unmarshal message from OKX %s websocket: %s", c.channel, messageByte)
+			continue
+		}
+
+		if push.Event == "error" {
+			c.logger.Warn().Str("code", push.Code).Str("msg", push.Msg).Msgf("okx %s websocket error", c.channel)
+			continue
+		}
+
+		// subscribe events have no data
+		if push.Arg.Channel != c.channel || len(push.Data) == 0 {
+			continue
+		}
+
+		updates, err := c.mapMessage(&push)
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("okx failed to map message from OKX %s websocket: %s", c.channel, messageByte)
+			continue
+		}
+
+		for _, update := range updates {
+			select {
+			case outChan <- update:
+			default:
+				c.Metrics.droppedBookUpdates.Add(1)
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeMessage(wsConn, websocket.TextMessage, []byte("ping")); err != nil {
+				c.logger.Warn().Err(err).Msg("okx failed to send ping to OKX book websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsBookClient[T]) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCnt := c.Metrics.droppedBookUpdates.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("OKX %s metrics: dropped book updates=%d, reconnects=%d", c.channel, droppedCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+func mapBookTicker(push *dtos.WsMessageDTO) ([]exchange.BookTop, error) {
+	var data []dtos.WsBookDTO
+	if err := json.Unmarshal(push.Data, &data); err != nil {
+		return nil, err
+	}
+
+	symbol := normalizeTickerName(push.Arg.InstID)
+	res := make([]exchange.BookTop, 0, len(data))
+	for _, dto := range data {
+		if len(dto.Bids) == 0 || len(dto.Asks) == 0 {
+			continue
+		}
+
+		res = append(res, exchange.BookTop{
+			Symbol:   symbol,
+			Category: exchange.CategoryLinear,
+			Ts:       dto.Ts,
+			BidPrice: float64(dto.Bids[0][0]),
+			BidQty:   float64(dto.Bids[0][1]),
+			AskPrice: float64(dto.Asks[0][0]),
+			AskQty:   float64(dto.Asks[0][1]),
+		})
+	}
+
+	return res, nil
+}
+
+// mapOrderBook maps books push to exchange.OrderBookUpdate. The first push is a snapshot, the next ones are deltas
+// where size 0 removes the level.
+func mapOrderBook(push *dtos.WsMessageDTO) ([]exchange.OrderBookUpdate, error) {
+	var data []dtos.WsBookDTO
+	if err := json.Unmarshal(push.Data, &data); err != nil {
+		return nil, err
+	}
+
+	symbol := normalizeTickerName(push.Arg.InstID)
+	res := make([]exchange.OrderBookUpdate, 0, len(data))
+	for _, dto := range data {
+		res = append(res, exchange.OrderBookUpdate{
+			Symbol:   symbol,
+			Category: exchange.CategoryLinear,
+			Ts:       dto.Ts,
+			Snapshot: push.Action == bookActionSnapshot,
+			Bids:     mapPriceLevels(dto.Bids),
+			Asks:     mapPriceLevels(dto.Asks),
+		})
+	}
+
+	return res, nil
+}
+
+func mapPriceLevels(levels [][]utils.JSONFloat64) []exchange.PriceLevel {
+	res := make([]exchange.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if len(l) < 2 {
+			continue
+		}
+		res = append(res, exchange.PriceLevel{Price: float64(l[0]), Qty: float64(l[1])})
+	}
+	return res
+}
@@ -0,0 +1,263 @@
+package okx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+// OKX closes the connection if there is no message in 30 seconds, the client sends text "ping" and gets text "pong".
+// Docs: https://www.okx.com/docs-v5/en/#overview-websocket-connect
+
+const (
+	tradesChannel = "trades"
+	pingInterval  = 20 * time.Second
+)
+
+var pongMessage = []byte("pong")
+
+// Metrics holds metrics related to websocket client operations.
+type Metrics struct {
+	droppedTrades      atomic.Uint64
+	droppedBookUpdates atomic.Uint64
+	reconnects         atomic.Uint64
+}
+
+// wsClient represents a WebSocket client streaming trades of OKX swaps.
+type wsClient struct {
+	Metrics *Metrics
+	cfg     *config.Config
+	wsMu    sync.Mutex
+	backoff *exchange.Backoff
+	logger  zerolog.Logger
+}
+
+func newWsClient(cfg *config.Config, logger zerolog.Logger) *wsClient {
+	return &wsClient{
+		Metrics: &Metrics{},
+		cfg:     cfg,
+		backoff: exchange.NewBackoff(exchange.WsReconnectMinDelay, exchange.WsReconnectMaxDelay),
+		logger:  logger,
+	}
+}
+
+func (c *wsClient) writeMessage(wsConn *websocket.Conn, messageType int, data []byte) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteMessage(messageType, data)
+}
+
+func (c *wsClient) writeJSON(wsConn *websocket.Conn, payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return wsConn.WriteJSON(payload)
+}
+
+func (c *wsClient) Start(ctx context.Context, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) error {
+	if category != exchange.CategoryLinear && category != exchange.CategorySpot {
+		return fmt.Errorf("okx websocket supports only %s and %s trades", exchange.CategoryLinear, exchange.CategorySpot)
+	}
+
+	wsConn, err := c.connect(symbols, category)
+	if err != nil {
+		return err
+	}
+
+	go c.serve(ctx, wsConn, symbols, category, outChan)
+	go c.logMetrics(ctx)
+
+	return nil
+}
+
+// connect dials the websocket and subscribes to the trades of the given symbols (swaps or spot pairs).
+func (c *wsClient) connect(symbols []string, category exchange.Category) (*websocket.Conn, error) {
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.OKX.WSUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("okx failed to dial websocket: %w", err)
+	}
+
+	if err = c.writeJSON(wsConn, subscribePayload(symbols, category, tradesChannel)); err != nil {
+		_ = wsConn.Close()
+		return nil, fmt.Errorf("okx failed to subscribe to symbols: %w", err)
+	}
+
+	return wsConn, nil
+}
+
+// serve reads trades from the connection and reconnects with backoff (replaying subscriptions) until ctx is done.
+func (c *wsClient) serve(ctx context.Context, wsConn *websocket.Conn, symbols []string, category exchange.Category, outChan chan<- exchange.Trade) {
+	for {
+		connCtx, cancel := context.WithCancel(ctx)
+		go c.pingPongInterval(connCtx, wsConn)
+		go func(conn *websocket.Conn) {
+			<-connCtx.Done()
+			_ = conn.Close()
+		}(wsConn)
+
+		c.readMessage(connCtx, wsConn, category, outChan)
+		cancel()
+
+		wsConn = c.reconnect(ctx, symbols, category)
+		if wsConn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the websocket until it succeeds. Returns nil if ctx is done.
+func (c *wsClient) reconnect(ctx context.Context, symbols []string, category exchange.Category) *websocket.Conn {
+	for c.backoff.Wait(ctx) {
+		reconnects := c.Metrics.reconnects.Add(1)
+
+		wsConn, err := c.connect(symbols, category)
+		if err != nil {
+			c.logger.Warn().Err(err).Uint64("reconnects", reconnects).Msg("okx failed to reconnect to OKX websocket")
+			continue
+		}
+
+		c.backoff.Reset()
+		c.logger.Info().Uint64("reconnects", reconnects).Int("symbols", len(symbols)).Msg("okx reconnected to OKX websocket")
+
+		return wsConn
+	}
+
+	return nil
+}
+
+func (c *wsClient) readMessage(ctx context.Context, wsConn *websocket.Conn, category exchange.Category, outChan chan<- exchange.Trade) {
+	defer func() {
+		err := wsConn.Close()
+		if err != nil && ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("okx failed to close websocket connection")
+		}
+	}()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mt, messageByte, err := wsConn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Warn().Err(err).Msg("okx failed to read message from OKX websocket")
+			return
+		}
+
+		if mt != websocket.TextMessage || bytes.Equal(messageByte, pongMessage) {
+			continue
+		}
+
+		for _, trade := range c.mapTrades(messageByte, category) {
+			select {
+			case outChan <- trade:
+			default:
+				c.Metrics.droppedTrades.Add(1)
+			}
+		}
+	}
+}
+
+// mapTrades maps trades push to exchange.Trade. Events (subscribe, error) are skipped, errors are logged.
+func (c *wsClient) mapTrades(message []byte, category exchange.Category) []exchange.Trade {
+	var push dtos.WsMessageDTO
+	if err := json.Unmarshal(message, &push); err != nil {
+		c.logger.Warn().Err(err).Msg("okx failed to unmarshal message from OKX websocket")
+		return nil
+	}
+
+	if push.Event == "error" {
+		c.logger.Warn().Str("code", push.Code).Str("msg", push.Msg).Msg("okx websocket error")
+		return nil
+	}
+
+	if push.Arg.Channel != tradesChannel || len(push.Data) == 0 {
+		return nil
+	}
+
+	var data []dtos.WsTradeDTO
+	if err := json.Unmarshal(push.Data, &data); err != nil {
+		c.logger.Warn().Err(err).Msg("okx failed to unmarshal trade message from OKX websocket")
+		return nil
+	}
+
+	trades := make([]exchange.Trade, 0, len(data))
+	for _, val := range data {
+		side := exchange.Buy
+		if val.Side == string(dtos.OrderSideSell) {
+			side = exchange.Sell
+		}
+
+		trades = append(trades, exchange.Trade{
+			Symbol:   normalizeTickerName(val.InstID),
+			Category: category,
+			Ts:       val.Ts,
+			Price:    float64(val.Px),
+			Volume:   float64(val.Sz),
+			Side:     side,
+		})
+	}
+
+	return trades
+}
+
+func (c *wsClient) pingPongInterval(ctx context.Context, wsConn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeMessage(wsConn, websocket.TextMessage, []byte("ping")); err != nil {
+				c.logger.Warn().Err(err).Msg("okx failed to send ping to OKX websocket")
+				return
+			}
+		}
+	}
+}
+
+func (c *wsClient) logMetrics(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			droppedTradesCnt := c.Metrics.droppedTrades.Load()
+			reconnectsCnt := c.Metrics.reconnects.Load()
+			if droppedTradesCnt > 0 || reconnectsCnt > 0 {
+				c.logger.Warn().Msgf("OKX metrics: dropped trades=%d, reconnects=%d", droppedTradesCnt, reconnectsCnt)
+			}
+		}
+	}
+}
+
+// subscribePayload builds a subscribe request to the channel of all given symbols of the category.
+func subscribePayload(symbols []string, category exchange.Category, channel string) map[string]interface{} {
+	args := make([]dtos.WsArgDTO, 0, len(symbols))
+	for _, symbol := range symbols {
+		args = append(args, dtos.WsArgDTO{Channel: channel, InstID: instIDFor(symbol, category)})
+	}
+
+	return map[string]interface{}{
+		"op":   "subscribe",
+		"args": args,
+	}
+}
@@ -0,0 +1,149 @@
+package okx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+var testUpgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// newTestWsServer starts a websocket stand-in, which checks the subscription request and pushes messages.
+func newTestWsServer(t *testing.T, wantArgs []dtos.WsArgDTO, messages ...string) (*httptest.Server, *config.Config) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		var sub struct {
+			Op   string          `json:"op"`
+			Args []dtos.WsArgDTO `json:"args"`
+		}
+		require.NoError(t, conn.ReadJSON(&sub))
+		assert.Equal(t, "subscribe", sub.Op)
+		assert.Equal(t, wantArgs, sub.Args)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"subscribe","arg":{"channel":"`+wantArgs[0].Channel+`"},"connId":"a4d3ae55"}`)))
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	cfg := &config.Config{
+		Exchange: config.ExchangeConfig{
+			OKX: config.OKXConfig{
+				WSUrl: "ws" + strings.TrimPrefix(server.URL, "http"),
+			},
+		},
+	}
+
+	return server, cfg
+}
+
+func TestWsClient_Trades(t *testing.T) {
+	server, cfg := newTestWsServer(t,
+		[]dtos.WsArgDTO{{Channel: "trades", InstID: "BTC-USDT-SWAP"}, {Channel: "trades", InstID: "ETH-USDT-SWAP"}},
+		`{"arg":{"channel":"trades","instId":"BTC-USDT-SWAP"},"data":[
+			{"instId":"BTC-USDT-SWAP","tradeId":"130639474","px":"65000.1","sz":"50","side":"sell","ts":"1630048897897","count":"3"},
+			{"instId":"BTC-USDT-SWAP","tradeId":"130639475","px":"65000.2","sz":"1","side":"buy","ts":"1630048897898","count":"1"}
+		]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	trades := make(chan exchange.Trade, 10)
+	client := newWsClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT", "ETHUSDT"}, exchange.CategoryLinear, trades))
+
+	assert.Equal(t, exchange.Trade{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1630048897897,
+		Price:    65000.1,
+		Volume:   50,
+		Side:     exchange.Sell,
+	}, <-trades)
+
+	second := <-trades
+	assert.Equal(t, 65000.2, second.Price)
+	assert.Equal(t, exchange.Buy, second.Side)
+}
+
+func TestWsBookClient_BookTicker(t *testing.T) {
+	server, cfg := newTestWsServer(t,
+		[]dtos.WsArgDTO{{Channel: "bbo-tbt", InstID: "BTC-USDT-SWAP"}},
+		`{"arg":{"channel":"bbo-tbt","instId":"BTC-USDT-SWAP"},"data":[{"asks":[["65000.2","40","0","3"]],"bids":[["65000.1","31","0","2"]],"ts":"1670324386802","seqId":363996337}]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.BookTop, 10)
+	client := newWsBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, out))
+
+	assert.Equal(t, exchange.BookTop{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1670324386802,
+		BidPrice: 65000.1,
+		BidQty:   31,
+		AskPrice: 65000.2,
+		AskQty:   40,
+	}, <-out)
+}
+
+func TestWsBookClient_OrderBook(t *testing.T) {
+	server, cfg := newTestWsServer(t,
+		[]dtos.WsArgDTO{{Channel: "books", InstID: "BTC-USDT-SWAP"}},
+		`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"snapshot","data":[{"asks":[["65000.2","7","0","1"]],"bids":[["65000.1","15","0","2"],["65000","20","0","1"]],"ts":"1597026383085","checksum":-855196043,"prevSeqId":-1,"seqId":123456}]}`,
+		`{"arg":{"channel":"books","instId":"BTC-USDT-SWAP"},"action":"update","data":[{"asks":[],"bids":[["65000.1","0","0","0"]],"ts":"1597026383185","checksum":-855196043,"prevSeqId":123456,"seqId":123457}]}`,
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	out := make(chan exchange.OrderBookUpdate, 10)
+	client := newWsOrderBookClient(cfg, zerolog.Nop())
+	require.NoError(t, client.Start(ctx, []string{"BTCUSDT"}, out))
+
+	assert.Equal(t, exchange.OrderBookUpdate{
+		Symbol:   "BTCUSDT",
+		Category: exchange.CategoryLinear,
+		Ts:       1597026383085,
+		Snapshot: true,
+		Bids:     []exchange.PriceLevel{{Price: 65000.1, Qty: 15}, {Price: 65000, Qty: 20}},
+		Asks:     []exchange.PriceLevel{{Price: 65000.2, Qty: 7}},
+	}, <-out)
+
+	delta := <-out
+	assert.False(t, delta.Snapshot)
+	assert.Equal(t, []exchange.PriceLevel{{Price: 65000.1, Qty: 0}}, delta.Bids)
+	assert.Empty(t, delta.Asks)
+}
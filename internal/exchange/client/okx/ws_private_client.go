@@ -0,0 +1,270 @@
+package okx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+// Docs: https://www.okx.com/docs-v5/en/#overview-websocket-login
+// Docs: https://www.okx.com/docs-v5/en/#order-book-trading-trade-ws-order-channel
+
+const (
+	ordersChannel = "orders"
+	loginPath     = "/users/self/verify"
+	loginTimeout  = 10 * time.Second
+)
+
+// WsPrivateClient handles the private websocket of OKX (login, orders channel).
+type WsPrivateClient struct {
+	cfg    *config.Config
+	logger zerolog.Logger
+	wsConn *websocket.Conn
+	wsMu   sync.Mutex
+
+	executionChannel    chan exchange.OrderExecutionEvent
+	executionSubscribed bool
+}
+
+// NewWsPrivateClient initializes a WsPrivateClient with the given configuration and logger for private WebSocket connections.
+func NewWsPrivateClient(cfg *config.Config, logger zerolog.Logger) *WsPrivateClient {
+	return &WsPrivateClient{
+		cfg:    cfg,
+		logger: logger,
+
+		executionChannel:    make(chan exchange.OrderExecutionEvent, 100),
+		executionSubscribed: false,
+	}
+}
+
+// Start connects to the private websocket, logs in, subscribes to orders of swaps and starts message handling and ping routines.
+func (c *WsPrivateClient) Start(ctx context.Context) error {
+	if c.executionSubscribed {
+		return nil
+	}
+
+	wsConn, _, err := websocket.DefaultDialer.Dial(c.cfg.Exchange.OKX.WSPrivateURL, nil)
+	if err != nil {
+		return fmt.Errorf("OKX ws private: failed to connect to websocket: %w", err)
+	}
+
+	c.wsConn = wsConn
+
+	if err := c.login(); err != nil {
+		_ = wsConn.Close()
+		return err
+	}
+
+	subscribe := map[string]interface{}{
+		"op":   "subscribe",
+		"args": []dtos.WsArgDTO{{Channel: ordersChannel, InstType: "ANY"}}, // swaps and spot
+	}
+	if err := c.writeJSON(subscribe); err != nil {
+		_ = wsConn.Close()
+		return fmt.Errorf("OKX ws private: failed to subscribe to orders: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = wsConn.Close()
+	}()
+
+	go c.pingPongInterval(ctx)
+
+	go func() {
+		defer c.closeChannels()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := c.handleMessage()
+				if err != nil {
+					c.logger.Error().Err(err).Str("exchange", "OKX").Msg("error processing message")
+					_ = wsConn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	c.executionSubscribed = true
+
+	return nil
+}
+
+// SubscribeToExecutions returns the execution events channel.
+func (c *WsPrivateClient) SubscribeToExecutions() (<-chan exchange.OrderExecutionEvent, error) {
+	return c.executionChannel, nil
+}
+
+func (c *WsPrivateClient) writeJSON(payload interface{}) error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return c.wsConn.WriteJSON(payload)
+}
+
+// login sends the login request and waits for its result. Sign is base64 HMAC SHA256 of timestamp (seconds) + GET + /users/self/verify.
+func (c *WsPrivateClient) login() error {
+	cfg := c.cfg.Exchange.OKX
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	payload := map[string]interface{}{
+		"op": "login",
+		"args": []map[string]string{{
+			"apiKey":     cfg.APIKey,
+			"passphrase": cfg.Passphrase,
+			"timestamp":  timestamp,
+			"sign":       sign(cfg.APISecret, timestamp+http.MethodGet+loginPath),
+		}},
+	}
+
+	if err := c.writeJSON(payload); err != nil {
+		return fmt.Errorf("OKX ws private: failed to send login request: %w", err)
+	}
+
+	_ = c.wsConn.SetReadDeadline(time.Now().Add(loginTimeout))
+	defer func() { _ = c.wsConn.SetReadDeadline(time.Time{}) }()
+
+	for {
+		_, raw, err := c.wsConn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("OKX ws private: failed to read login response: %w", err)
+		}
+
+		var r dtos.WsMessageDTO
+		if err := json.Unmarshal(raw, &r); err != nil {
+			continue
+		}
+
+		switch r.Event {
+		case "login":
+			return nil
+		case "error":
+			return fmt.Errorf("OKX ws private: login failed: %s, %s", r.Code, r.Msg)
+		}
+	}
+}
+
+func (c *WsPrivateClient) handleMessage() error {
+	mt, raw, err := c.wsConn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("OKX ws private: failed to read message from. Network issue? %v", err)
+	}
+
+	if mt != websocket.TextMessage {
+		c.logger.Warn().Str("exchange", "OKX").Msg("unexpected message type")
+		return nil
+	}
+
+	if bytes.Equal(raw, pongMessage) {
+		return nil
+	}
+
+	var r dtos.WsMessageDTO
+	if err := json.Unmarshal(raw, &r); err != nil {
+		c.logger.Warn().Err(err).Str("exchange", "OKX").Msg("OKX ws private: failed to unmarshal response message")
+		return nil
+	}
+
+	if r.Event != "" {
+		if r.Event == "error" {
+			c.logger.Warn().Str("code", r.Code).Str("msg", r.Msg).Msg("OKX ws private: error event")
+		}
+		return nil
+	}
+
+	if r.Arg.Channel != ordersChannel {
+		c.logger.Debug().Str("channel", r.Arg.Channel).Msg("OKX ws private: skip message")
+		return nil
+	}
+
+	var orders []dtos.OrderDTO
+	if err := json.Unmarshal(r.Data, &orders); err != nil {
+		c.logger.Warn().Err(err).Msg("OKX ws private: failed to unmarshal orders message from OKX private websocket")
+		return nil
+	}
+
+	for _, order := range orders {
+		event, ok := c.handleExecutionEvent(&order)
+		if !ok {
+			continue
+		}
+		// Blocking, but channel has buffer
+		c.executionChannel <- event
+	}
+
+	return nil
+}
+
+// handleExecutionEvent maps order update to exchange.OrderExecutionEvent. Only fills (fillSz > 0) and canceled orders
+// are mapped, the latter with Canceled set and no leaves qty. Orders which were not placed by the bot (clOrdId is
+// not uuid) are skipped. Quantities are in contracts for swaps and in base coin for spot.
+func (c *WsPrivateClient) handleExecutionEvent(order *dtos.OrderDTO) (exchange.OrderExecutionEvent, bool) {
+	canceled := order.State == dtos.OrderStateCanceled || order.State == dtos.OrderStateMMPCanceled
+	if !order.FillSz.IsPositive() && !canceled {
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	orderID, err := uuid.Parse(order.ClOrdID)
+	if err != nil {
+		c.logger.Debug().Str("client_order_id", order.ClOrdID).Msg("OKX ws private: skip execution of foreign order")
+		return exchange.OrderExecutionEvent{}, false
+	}
+
+	leavesQty := order.Sz.Sub(order.AccFillSz.Decimal)
+	if canceled {
+		leavesQty = decimal.Zero
+	}
+
+	return exchange.OrderExecutionEvent{
+		OrderID:         orderID,
+		ExchangeOrderID: order.OrdID,
+		ExecPrice:       order.AvgPx.Decimal,
+		ExecQty:         order.AccFillSz.Decimal,
+		ExecValue:       order.AvgPx.Mul(order.AccFillSz.Decimal),
+		LeavesQty:       leavesQty,
+		OrderPrice:      order.Px.Decimal,
+		OrderQty:        order.Sz.Decimal,
+		Canceled:        canceled,
+	}, true
+}
+
+func (c *WsPrivateClient) pingPongInterval(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.wsMu.Lock()
+			err := c.wsConn.WriteMessage(websocket.TextMessage, []byte("ping"))
+			c.wsMu.Unlock()
+			if err != nil {
+				c.logger.Warn().Err(err).Str("exchange", "OKX").Msg("OKX ws private: failed to send ping")
+				return
+			}
+		}
+	}
+}
+
+func (c *WsPrivateClient) closeChannels() {
+	close(c.executionChannel)
+}
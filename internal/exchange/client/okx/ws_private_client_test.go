@@ -0,0 +1,120 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange/client/okx/dtos"
+)
+
+func TestWsPrivateClient_Happy(t *testing.T) {
+	wsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		var login struct {
+			Op   string              `json:"op"`
+			Args []map[string]string `json:"args"`
+		}
+		require.NoError(t, conn.ReadJSON(&login))
+		assert.Equal(t, "login", login.Op)
+		require.Len(t, login.Args, 1)
+		assert.Equal(t, "test-api-key", login.Args[0]["apiKey"])
+		assert.Equal(t, "test-passphrase", login.Args[0]["passphrase"])
+		assert.Equal(t, sign("test-api-secret", login.Args[0]["timestamp"]+"GET/users/self/verify"), login.Args[0]["sign"])
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"a4d3ae55"}`)))
+
+		var sub map[string]interface{}
+		require.NoError(t, conn.ReadJSON(&sub))
+		assert.Equal(t, "subscribe", sub["op"])
+
+		messages := []string{
+			`{"event":"subscribe","arg":{"channel":"orders","instType":"SWAP"},"connId":"a4d3ae55"}`,
+			// new order, no fill yet - skipped
+			`{"arg":{"channel":"orders","instType":"SWAP","uid":"1"},"data":[{"instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"550e8400e29b41d4a716446655440000","px":"","sz":"2","avgPx":"","accFillSz":"0","fillPx":"","fillSz":"0","state":"live","side":"buy","fee":"0","pnl":"0"}]}`,
+			// order placed manually - skipped
+			`{"arg":{"channel":"orders","instType":"SWAP","uid":"1"},"data":[{"instId":"BTC-USDT-SWAP","ordId":"312269865356374017","clOrdId":"","px":"","sz":"1","avgPx":"65000","accFillSz":"1","fillPx":"65000","fillSz":"1","state":"filled","side":"sell","fee":"-0.3","pnl":"0"}]}`,
+			`{"arg":{"channel":"orders","instType":"SWAP","uid":"1"},"data":[{"instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"550e8400e29b41d4a716446655440000","px":"","sz":"2","avgPx":"50000.5","accFillSz":"2","fillPx":"50000.5","fillSz":"2","state":"filled","side":"buy","fee":"-0.5","pnl":"0"}]}`,
+		}
+		for _, m := range messages {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(m)))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer wsSrv.Close()
+
+	cfg := getTestConfig("")
+	cfg.Exchange.OKX.WSPrivateURL = "ws" + strings.TrimPrefix(wsSrv.URL, "http")
+
+	client := NewWsPrivateClient(cfg, zerolog.Nop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.Start(ctx))
+
+	execCh, err := client.SubscribeToExecutions()
+	require.NoError(t, err)
+
+	select {
+	case event := <-execCh:
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", event.OrderID.String())
+		assert.Equal(t, "312269865356374016", event.ExchangeOrderID)
+		assert.Equal(t, "50000.5", event.ExecPrice.String())
+		assert.Equal(t, "2", event.ExecQty.String())
+		assert.Equal(t, "100001", event.ExecValue.String())
+		assert.True(t, event.LeavesQty.IsZero())
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for execution event")
+	}
+}
+
+func TestWsPrivateClient_LoginFailed(t *testing.T) {
+	wsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		_, _, _ = conn.ReadMessage()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"error","code":"60009","msg":"Login failed.","connId":"a4d3ae55"}`))
+	}))
+	defer wsSrv.Close()
+
+	cfg := getTestConfig("")
+	cfg.Exchange.OKX.WSPrivateURL = "ws" + strings.TrimPrefix(wsSrv.URL, "http")
+
+	err := NewWsPrivateClient(cfg, zerolog.Nop()).Start(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "60009")
+}
+
+// A partly filled order canceled by the exchange ends at its filled qty
+func TestWsPrivateClient_HandleExecutionEvent_Canceled(t *testing.T) {
+	client := NewWsPrivateClient(getTestConfig(""), zerolog.Nop())
+
+	var order dtos.OrderDTO
+	require.NoError(t, json.Unmarshal([]byte(`{"instId":"BTC-USDT-SWAP","ordId":"312269865356374016","clOrdId":"550e8400e29b41d4a716446655440000","px":"","sz":"2","avgPx":"50000","accFillSz":"1","fillPx":"","fillSz":"0","state":"canceled","side":"buy","fee":"-0.2","pnl":"0"}`), &order))
+
+	event, ok := client.handleExecutionEvent(&order)
+	require.True(t, ok)
+	assert.True(t, event.Canceled)
+	assert.Equal(t, "1", event.ExecQty.String())
+	assert.Equal(t, "50000", event.ExecPrice.String())
+	assert.True(t, event.LeavesQty.IsZero())
+}
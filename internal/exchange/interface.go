@@ -20,20 +20,35 @@ const (
 	CategoryLinear Category = "linear"
 )
 
+// OrderMarket returns the order market of the category.
+func (c Category) OrderMarket() models.OrderMarket {
+	if c == CategorySpot {
+		return models.OrderMarketSpot
+	}
+	return models.OrderMarketLinear
+}
+
 // Provider represents an exchange provider (ByBit, Binance, BingX, and etc.).
 //
 //mockery:generate: true
 type Provider interface {
 	GetExchangeName() string
 	GetTickers(ctx context.Context, symbols []string, category Category) ([]Ticker, error)
-	GetInstruments(ctx context.Context) (map[string]Instrument, error)
+	GetInstruments(ctx context.Context, category Category) (map[string]Instrument, error)
+	GetFeeSchedule(ctx context.Context) (FeeSchedule, error)
+	GetFundingRates(ctx context.Context) (map[string]FundingRate, error)
 	SubscribeTrades(ctx context.Context, symbols []string, category Category) (<-chan Trade, error)
+	SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan BookTop, error)
+	SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan OrderBookUpdate, error)
 	CreateOrder(ctx context.Context, order *models.Order) error
 	CloseOrder(ctx context.Context, order *models.Order) error
-	CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) error
-	GetBalances(ctx context.Context) ([]models.Balance, error)
+	CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category Category) error
+	GetBalances(ctx context.Context, category Category) ([]models.Balance, error)
 	SetLeverage(ctx context.Context, symbol string, leverage int64) error
-	GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (ExchangeOrder, error)
+	SetMarginMode(ctx context.Context, symbol string, mode MarginMode) error
+	GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category Category) (ExchangeOrder, error)
+	GetPositions(ctx context.Context) ([]Position, error)
+	GetOpenOrders(ctx context.Context, category Category) ([]OpenOrder, error)
 	//
 	SubscribeExecutions(ctx context.Context) (<-chan OrderExecutionEvent, error)
 }
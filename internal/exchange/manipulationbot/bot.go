@@ -13,6 +13,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/exchange"
 	"github.com/lucrumx/bot/internal/notifier"
 )
@@ -26,6 +27,8 @@ type Bot struct {
 	detector *detector
 	workers  []*worker
 	started  time.Time
+	// tradeTime - detect on the trade timestamps, see SetTradeTime
+	tradeTime bool
 
 	tradeCounter uint64
 }
@@ -41,9 +44,30 @@ func NewBot(provider exchange.Provider, notif notifier.Notifier, cfg Config, log
 	}
 }
 
+// SetClock sets the time source of the bot and its detector, the replayed one in replays. Must be called
+// before Run.
+func (b *Bot) SetClock(c clock.Clock) {
+	b.detector.clock = c
+}
+
+// SetTradeTime makes the workers detect on the timestamp of the processed trade instead of the clock. The
+// replayed clock runs ahead of the trades still queued in the bot, the trade time doesn't depend on the
+// replay speed. Must be called before Run.
+func (b *Bot) SetTradeTime() {
+	b.tradeTime = true
+}
+
+// now returns the time the trade is evaluated at.
+func (b *Bot) now(trade exchange.Trade) time.Time {
+	if b.tradeTime {
+		return time.UnixMilli(trade.Ts)
+	}
+	return b.detector.clock.Now()
+}
+
 // Run starts subscriptions and processing loop.
 func (b *Bot) Run(ctx context.Context) error {
-	b.started = time.Now()
+	b.started = b.detector.clock.Now()
 
 	symbols, err := b.resolveSymbols(ctx)
 	if err != nil {
@@ -289,16 +313,11 @@ func (w *worker) processTrade(trade exchange.Trade) {
 		w.states[trade.Symbol] = state
 	}
 
-	switch trade.Category {
-	case exchange.CategorySpot:
-		state.spot.AddTrade(trade)
-	case exchange.CategoryLinear:
-		state.perp.AddTrade(trade)
-	default:
+	if !state.addTrade(trade) {
 		return
 	}
 
-	sig := w.bot.detector.evaluate(trade.Symbol, state, w.bot.started)
+	sig := w.bot.detector.evaluate(trade.Symbol, state, w.bot.started, w.bot.now(trade))
 	if sig == nil {
 		return
 	}
@@ -1,6 +1,10 @@
 package manipulationbot
 
-import "time"
+import (
+	"time"
+
+	"github.com/lucrumx/bot/internal/config"
+)
 
 // Config controls the detector sensitivity.
 type Config struct {
@@ -51,3 +55,41 @@ func DefaultConfig() Config {
 		RPSTimerInterval:   30 * time.Second,
 	}
 }
+
+// ConfigFromYAML returns DefaultConfig with the values set in the config file.
+func ConfigFromYAML(raw config.ManipulationBotConfig) Config {
+	defaults := DefaultConfig()
+
+	if len(raw.Symbols) > 0 {
+		defaults.Symbols = raw.Symbols
+	}
+	if raw.WindowSize > 0 {
+		defaults.WindowSize = raw.WindowSize
+	}
+	if raw.CheckInterval > 0 {
+		defaults.CheckInterval = raw.CheckInterval
+	}
+	if raw.StartupDelay > 0 {
+		defaults.StartupDelay = raw.StartupDelay
+	}
+	if raw.AlertCooldown > 0 {
+		defaults.AlertCooldown = raw.AlertCooldown
+	}
+	if raw.MinSpotATRPct > 0 {
+		defaults.MinSpotATRPct = raw.MinSpotATRPct
+	}
+	if raw.MinATRRatio > 0 {
+		defaults.MinATRRatio = raw.MinATRRatio
+	}
+	if raw.MinPerpTurnover24h > 0 {
+		defaults.MinPerpTurnover24h = raw.MinPerpTurnover24h
+	}
+	if raw.MaxSpotTurnover24h > 0 {
+		defaults.MaxSpotTurnover24h = raw.MaxSpotTurnover24h
+	}
+	if raw.RPSTimerInterval > 0 {
+		defaults.RPSTimerInterval = raw.RPSTimerInterval
+	}
+
+	return defaults
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"math"
 	"time"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
 )
 
 type signal struct {
@@ -24,11 +27,12 @@ type symbolState struct {
 }
 
 type detector struct {
-	cfg Config
+	cfg   Config
+	clock clock.Clock
 }
 
 func newDetector(cfg Config) *detector {
-	return &detector{cfg: cfg}
+	return &detector{cfg: cfg, clock: clock.Real()}
 }
 
 func newSymbolState(cfg Config) *symbolState {
@@ -43,15 +47,28 @@ func newSymbolState(cfg Config) *symbolState {
 	}
 }
 
-func (d *detector) evaluate(symbol string, state *symbolState, startedAt time.Time) *signal {
-	if time.Since(startedAt) < d.cfg.StartupDelay {
+// addTrade adds the trade to the window of its market, false for the categories other than spot and linear.
+func (s *symbolState) addTrade(trade exchange.Trade) bool {
+	switch trade.Category {
+	case exchange.CategorySpot:
+		s.spot.AddTrade(trade)
+	case exchange.CategoryLinear:
+		s.perp.AddTrade(trade)
+	default:
+		return false
+	}
+	return true
+}
+
+func (d *detector) evaluate(symbol string, state *symbolState, startedAt, now time.Time) *signal {
+	if now.Sub(startedAt) < d.cfg.StartupDelay {
 		return nil
 	}
 
-	if time.Since(state.lastCheck) < d.cfg.CheckInterval {
+	if now.Sub(state.lastCheck) < d.cfg.CheckInterval {
 		return nil
 	}
-	state.lastCheck = time.Now()
+	state.lastCheck = now
 
 	nowSec := minInt64(state.spot.lastTs, state.perp.lastTs)
 	lookbackSec := int64(d.cfg.WindowSize / time.Second)
@@ -73,11 +90,11 @@ func (d *detector) evaluate(symbol string, state *symbolState, startedAt time.Ti
 	if atrRatio < d.cfg.MinATRRatio {
 		return nil
 	}
-	if time.Since(state.lastAlert) < d.cfg.AlertCooldown {
+	if now.Sub(state.lastAlert) < d.cfg.AlertCooldown {
 		return nil
 	}
 
-	state.lastAlert = time.Now()
+	state.lastAlert = now
 
 	return &signal{
 		Symbol:     symbol,
@@ -90,6 +107,46 @@ func (d *detector) evaluate(symbol string, state *symbolState, startedAt time.Ti
 	}
 }
 
+// SignalDetector runs the detector over the spot and perp trades of one exchange synchronously and without
+// notifications, for offline runs over recorded trades (the parameter sweep).
+// NOT safe for concurrent use.
+type SignalDetector struct {
+	detector  *detector
+	states    map[string]*symbolState
+	startedAt time.Time
+}
+
+// NewSignalDetector creates a SignalDetector. The startup delay counts from the first processed trade.
+func NewSignalDetector(cfg Config, c clock.Clock) *SignalDetector {
+	return &SignalDetector{
+		detector: &detector{cfg: cfg, clock: c},
+		states:   make(map[string]*symbolState),
+	}
+}
+
+// Process adds the trade to the state of its symbol and returns the spot/perp ATR ratio if the bot would
+// alert on it.
+func (d *SignalDetector) Process(trade exchange.Trade) (float64, bool) {
+	if d.startedAt.IsZero() {
+		d.startedAt = d.detector.clock.Now()
+	}
+
+	state, ok := d.states[trade.Symbol]
+	if !ok {
+		state = newSymbolState(d.detector.cfg)
+		d.states[trade.Symbol] = state
+	}
+	if !state.addTrade(trade) {
+		return 0, false
+	}
+
+	sig := d.detector.evaluate(trade.Symbol, state, d.startedAt, d.detector.clock.Now())
+	if sig == nil {
+		return 0, false
+	}
+	return sig.ATRRatio, true
+}
+
 func (s *signal) Message(exchangeName string) string {
 	return fmt.Sprintf(
 		"<b>ATR spot-vs-perp signal</b>\n"+
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/exchange"
 )
 
@@ -27,7 +28,7 @@ func TestDetector_EvaluateSignalsOnSpotLedMove(t *testing.T) {
 		addSyntheticTrade(state.perp, exchange.CategoryLinear, baseTs+int64(i*1000), 100+float64(i)*0.08, 100)
 	}
 
-	sig := d.evaluate("ROAMUSDT", state, time.Now().Add(-time.Hour))
+	sig := d.evaluate("ROAMUSDT", state, time.Now().Add(-time.Hour), time.Now())
 	require.NotNil(t, sig)
 	require.Equal(t, "ROAMUSDT", sig.Symbol)
 	require.Greater(t, sig.SpotATRPct, cfg.MinSpotATRPct)
@@ -52,10 +53,42 @@ func TestDetector_EvaluateSkipsWhenATRRatioTooLow(t *testing.T) {
 		addSyntheticTrade(state.perp, exchange.CategoryLinear, baseTs+int64(i*1000), 100+float64(i)*0.09, 100)
 	}
 
-	sig := d.evaluate("ROAMUSDT", state, time.Now().Add(-time.Hour))
+	sig := d.evaluate("ROAMUSDT", state, time.Now().Add(-time.Hour), time.Now())
 	require.Nil(t, sig)
 }
 
+func TestSignalDetector_Process(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WindowSize = 10 * time.Second
+	cfg.CheckInterval = 0
+	cfg.StartupDelay = 5 * time.Second
+	cfg.AlertCooldown = time.Hour
+	cfg.MinSpotATRPct = 0.10
+	cfg.MinATRRatio = 1.5
+
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	replayed := clock.NewManual(start)
+	d := NewSignalDetector(cfg, replayed)
+
+	var signals []float64
+	for i := 0; i < 20; i++ {
+		ts := start.Add(time.Duration(i) * time.Second)
+		replayed.Set(ts)
+		for _, trade := range []exchange.Trade{
+			{Symbol: "ROAMUSDT", Category: exchange.CategorySpot, Ts: ts.UnixMilli(), Price: 100 + float64(i)*0.40, Volume: 1},
+			{Symbol: "ROAMUSDT", Category: exchange.CategoryLinear, Ts: ts.UnixMilli(), Price: 100 + float64(i)*0.08, Volume: 1},
+		} {
+			if ratio, ok := d.Process(trade); ok {
+				signals = append(signals, ratio)
+			}
+		}
+	}
+
+	// one signal after the startup delay, the rest are within the cooldown
+	require.Len(t, signals, 1)
+	require.Greater(t, signals[0], cfg.MinATRRatio)
+}
+
 func addSyntheticTrade(w *marketWindow, category exchange.Category, tsMs int64, price float64, turnover float64) {
 	w.AddTrade(exchange.Trade{
 		Symbol:   "ROAMUSDT",
@@ -0,0 +1,13 @@
+package exchange
+
+// BookTop represents the best bid/ask (top of the order book) for a symbol.
+// Quantities are in exchange units: coins for ByBit/BingX/Binance, contracts for MEXC/OKX/Gate (see Instrument.ContractSize).
+type BookTop struct {
+	Symbol   string
+	Category Category
+	Ts       int64
+	BidPrice float64
+	BidQty   float64
+	AskPrice float64
+	AskQty   float64
+}
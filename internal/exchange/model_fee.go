@@ -0,0 +1,22 @@
+package exchange
+
+// FeeRate is a trading fee rate as a fraction of the order value (0.0005 = 0.05%).
+type FeeRate struct {
+	Maker float64
+	Taker float64
+}
+
+// FeeSchedule is the fee schedule of an exchange account: per-symbol rates where the exchange provides them,
+// Default for the rest.
+type FeeSchedule struct {
+	Default FeeRate
+	Symbols map[string]FeeRate
+}
+
+// For returns the fee rate of the symbol.
+func (s FeeSchedule) For(symbol string) FeeRate {
+	if rate, ok := s.Symbols[symbol]; ok {
+		return rate
+	}
+	return s.Default
+}
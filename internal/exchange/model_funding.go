@@ -0,0 +1,13 @@
+package exchange
+
+// DefaultFundingIntervalHours is used when an exchange does not report the funding interval of a symbol.
+const DefaultFundingIntervalHours = 8
+
+// FundingRate is the current funding rate of a perpetual contract.
+// Rate is a fraction of the position value paid by longs to shorts each interval (negative - shorts pay longs).
+type FundingRate struct {
+	Symbol          string
+	Rate            float64
+	IntervalHours   int64
+	NextFundingTime int64 // ms
+}
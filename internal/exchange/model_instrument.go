@@ -8,5 +8,5 @@ type Instrument struct {
 	VolStep      decimal.Decimal
 	MinVol       decimal.Decimal
 	PriceStep    decimal.Decimal
-	ContractSize decimal.Decimal // 1 for ByBit/BingX (qty in coins), >1 for MEXC (qty in contracts)
+	ContractSize decimal.Decimal // 1 for ByBit/BingX/Binance (qty in coins), contract value in coins for MEXC/OKX/Gate (qty in contracts)
 }
@@ -0,0 +1,71 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+)
+
+// MarginMode is the margin mode of perpetual positions.
+type MarginMode string
+
+const (
+	// MarginModeCross shares the margin of the whole account between positions.
+	MarginModeCross MarginMode = "cross"
+	// MarginModeIsolated limits the margin of a position to the margin assigned to it.
+	MarginModeIsolated MarginMode = "isolated"
+)
+
+// AccountMarginModeSetter is implemented by clients whose margin mode applies to the whole account. It is
+// set at startup only when opted in, SetMarginMode of such clients does nothing.
+type AccountMarginModeSetter interface {
+	SetAccountMarginMode(ctx context.Context, mode MarginMode) error
+}
+
+// MarginSettings keeps the margin mode and leverage set for each symbol, for clients that have to pass
+// them with every order or apply them together. The zero value is ready to use and safe for concurrent use.
+type MarginSettings struct {
+	mu        sync.RWMutex
+	modes     map[string]MarginMode
+	leverages map[string]int64
+}
+
+// Mode returns the margin mode of the symbol, cross if it was not set.
+func (s *MarginSettings) Mode(symbol string) MarginMode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if mode, ok := s.modes[symbol]; ok {
+		return mode
+	}
+	return MarginModeCross
+}
+
+// SetMode stores the margin mode of the symbol.
+func (s *MarginSettings) SetMode(symbol string, mode MarginMode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.modes == nil {
+		s.modes = make(map[string]MarginMode)
+	}
+	s.modes[symbol] = mode
+}
+
+// Leverage returns the leverage of the symbol, 0 if it was not set.
+func (s *MarginSettings) Leverage(symbol string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.leverages[symbol]
+}
+
+// SetLeverage stores the leverage of the symbol.
+func (s *MarginSettings) SetLeverage(symbol string, leverage int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leverages == nil {
+		s.leverages = make(map[string]int64)
+	}
+	s.leverages[symbol] = leverage
+}
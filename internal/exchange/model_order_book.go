@@ -0,0 +1,21 @@
+package exchange
+
+// PriceLevel is one price level of the order book. Qty is in exchange units (see BookTop).
+type PriceLevel struct {
+	Price float64
+	Qty   float64
+}
+
+// OrderBookUpdate is an L2 order book message from websocket.
+// Snapshot replaces the whole book, otherwise levels are deltas: Qty 0 removes the level, any other Qty replaces it.
+// UpdateID is the sequence of the updates of the symbol on exchanges that send one (ByBit), a delta follows
+// the previous update with UpdateID + 1. 0 - the exchange has no sequence.
+type OrderBookUpdate struct {
+	Symbol   string
+	Category Category
+	Ts       int64
+	Snapshot bool
+	UpdateID int64
+	Bids     []PriceLevel
+	Asks     []PriceLevel
+}
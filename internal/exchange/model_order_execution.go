@@ -5,16 +5,20 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// OrderExecutionEvent represents the execution of an order.
+// OrderExecutionEvent represents the execution of an order. A partially filled order emits several events,
+// each of them carries the cumulative filled qty of the order, not the qty of the last fill. An order whose
+// rest is canceled or expired by the exchange (e.g. IOC market order out of liquidity) ends with a Canceled
+// event: LeavesQty is zero and ExecQty is what was filled, possibly nothing.
 type OrderExecutionEvent struct {
 	OrderID         uuid.UUID
 	ExchangeOrderID string
-	ExecPrice       decimal.Decimal
-	ExecQty         decimal.Decimal
+	ExecPrice       decimal.Decimal // average price of all fills of the order
+	ExecQty         decimal.Decimal // cumulative filled qty of the order
 	ExecValue       decimal.Decimal
-	LeavesQty       decimal.Decimal
+	LeavesQty       decimal.Decimal // zero when the order is fully filled, canceled or expired
 	OrderPrice      decimal.Decimal
 	OrderQty        decimal.Decimal
+	Canceled        bool // the exchange canceled or expired the rest of the order, no more fills follow
 }
 
 // type ExecutedOrders map[uuid.UUID]OrderExecutionEvent
@@ -0,0 +1,29 @@
+package exchange
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// Position is an open perpetual futures position on the exchange.
+// Qty is in exchange units like Instrument.VolStep (coins or contracts) and always positive, Side tells the direction.
+type Position struct {
+	Symbol     string
+	Side       models.OrderSide // BUY - long, SELL - short
+	Qty        decimal.Decimal
+	EntryPrice decimal.Decimal
+}
+
+// OpenOrder is an order resting on the exchange book.
+// OrderID is our client order id, uuid.Nil if the order was not placed by the bot (e.g. manually).
+// Qty is the original quantity in exchange units.
+type OpenOrder struct {
+	OrderID         uuid.UUID
+	ExchangeOrderID string
+	Symbol          string
+	Side            models.OrderSide
+	Qty             decimal.Decimal
+	Price           decimal.Decimal
+}
@@ -0,0 +1,101 @@
+package exchange
+
+import (
+	"sort"
+	"sync"
+)
+
+// OrderBook is an in-memory L2 order book of one symbol maintained from websocket snapshots and deltas.
+// Safe for concurrent use.
+type OrderBook struct {
+	mu     sync.RWMutex
+	symbol string
+	bids   map[float64]float64 // price -> qty
+	asks   map[float64]float64 // price -> qty
+	ts     int64
+	synced bool // true after the first snapshot, deltas before it are ignored
+	// updateID is the UpdateID of the last applied update, a delta after a gap in the sequence unsyncs the book
+	updateID int64
+}
+
+// NewOrderBook creates a new empty OrderBook (constructor).
+func NewOrderBook(symbol string) *OrderBook {
+	return &OrderBook{
+		symbol: symbol,
+		bids:   make(map[float64]float64),
+		asks:   make(map[float64]float64),
+	}
+}
+
+// Apply applies snapshot or delta to the book. A delta that doesn't follow the last applied update (a lost
+// delta) empties the book until the next snapshot, the exchange client resubscribes to get one.
+func (b *OrderBook) Apply(update OrderBookUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case update.Snapshot:
+		b.bids = make(map[float64]float64, len(update.Bids))
+		b.asks = make(map[float64]float64, len(update.Asks))
+		b.synced = true
+	case !b.synced:
+		return
+	case update.UpdateID != 0 && b.updateID != 0 && update.UpdateID != b.updateID+1:
+		b.bids = make(map[float64]float64)
+		b.asks = make(map[float64]float64)
+		b.ts = 0
+		b.synced = false
+		b.updateID = 0
+		return
+	}
+
+	applyLevels(b.bids, update.Bids)
+	applyLevels(b.asks, update.Asks)
+	b.ts = update.Ts
+	b.updateID = update.UpdateID
+}
+
+// Bids returns bid levels sorted from the best (highest price) to the worst.
+func (b *OrderBook) Bids() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	levels := levelsOf(b.bids)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	return levels
+}
+
+// Asks returns ask levels sorted from the best (lowest price) to the worst.
+func (b *OrderBook) Asks() []PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	levels := levelsOf(b.asks)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	return levels
+}
+
+// Ts returns the timestamp (ms) of the last applied update.
+func (b *OrderBook) Ts() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+func applyLevels(side map[float64]float64, levels []PriceLevel) {
+	for _, l := range levels {
+		if l.Qty <= 0 {
+			delete(side, l.Price)
+			continue
+		}
+		side[l.Price] = l.Qty
+	}
+}
+
+func levelsOf(side map[float64]float64) []PriceLevel {
+	levels := make([]PriceLevel, 0, len(side))
+	for price, qty := range side {
+		levels = append(levels, PriceLevel{Price: price, Qty: qty})
+	}
+	return levels
+}
@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBook_SnapshotAndDeltas(t *testing.T) {
+	book := NewOrderBook("BTCUSDT")
+
+	// delta before snapshot is ignored
+	book.Apply(OrderBookUpdate{Bids: []PriceLevel{{Price: 1, Qty: 1}}})
+	assert.Empty(t, book.Bids())
+
+	book.Apply(OrderBookUpdate{
+		Snapshot: true,
+		Ts:       100,
+		Bids:     []PriceLevel{{Price: 99, Qty: 1}, {Price: 100, Qty: 2}},
+		Asks:     []PriceLevel{{Price: 102, Qty: 1}, {Price: 101, Qty: 3}},
+	})
+
+	assert.Equal(t, []PriceLevel{{Price: 100, Qty: 2}, {Price: 99, Qty: 1}}, book.Bids())
+	assert.Equal(t, []PriceLevel{{Price: 101, Qty: 3}, {Price: 102, Qty: 1}}, book.Asks())
+
+	book.Apply(OrderBookUpdate{
+		Ts:   200,
+		Bids: []PriceLevel{{Price: 100, Qty: 0}, {Price: 99.5, Qty: 4}},
+		Asks: []PriceLevel{{Price: 101, Qty: 1}},
+	})
+
+	assert.Equal(t, []PriceLevel{{Price: 99.5, Qty: 4}, {Price: 99, Qty: 1}}, book.Bids())
+	assert.Equal(t, []PriceLevel{{Price: 101, Qty: 1}, {Price: 102, Qty: 1}}, book.Asks())
+	assert.Equal(t, int64(200), book.Ts())
+
+	// new snapshot replaces the whole book
+	book.Apply(OrderBookUpdate{
+		Snapshot: true,
+		Ts:       300,
+		Bids:     []PriceLevel{{Price: 98, Qty: 1}},
+		Asks:     []PriceLevel{{Price: 103, Qty: 1}},
+	})
+
+	assert.Equal(t, []PriceLevel{{Price: 98, Qty: 1}}, book.Bids())
+	assert.Equal(t, []PriceLevel{{Price: 103, Qty: 1}}, book.Asks())
+}
+
+func TestOrderBook_SequenceGap(t *testing.T) {
+	book := NewOrderBook("BTCUSDT")
+
+	book.Apply(OrderBookUpdate{
+		Snapshot: true,
+		UpdateID: 10,
+		Ts:       100,
+		Bids:     []PriceLevel{{Price: 100, Qty: 2}},
+		Asks:     []PriceLevel{{Price: 101, Qty: 3}},
+	})
+	book.Apply(OrderBookUpdate{UpdateID: 11, Ts: 200, Bids: []PriceLevel{{Price: 99, Qty: 1}}})
+	assert.Len(t, book.Bids(), 2)
+
+	// update 12 is lost: the book is empty until the next snapshot
+	book.Apply(OrderBookUpdate{UpdateID: 13, Ts: 300, Bids: []PriceLevel{{Price: 100, Qty: 0}}})
+	assert.Empty(t, book.Bids())
+	assert.Empty(t, book.Asks())
+	assert.Zero(t, book.Ts())
+
+	book.Apply(OrderBookUpdate{UpdateID: 14, Ts: 400, Asks: []PriceLevel{{Price: 102, Qty: 1}}})
+	assert.Empty(t, book.Asks())
+
+	book.Apply(OrderBookUpdate{Snapshot: true, UpdateID: 20, Ts: 500, Bids: []PriceLevel{{Price: 98, Qty: 1}}})
+	book.Apply(OrderBookUpdate{UpdateID: 21, Ts: 600, Asks: []PriceLevel{{Price: 103, Qty: 1}}})
+	assert.Equal(t, []PriceLevel{{Price: 98, Qty: 1}}, book.Bids())
+	assert.Equal(t, []PriceLevel{{Price: 103, Qty: 1}}, book.Asks())
+	assert.Equal(t, int64(600), book.Ts())
+}
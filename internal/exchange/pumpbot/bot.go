@@ -14,6 +14,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 
+	"github.com/lucrumx/bot/internal/clock"
 	"github.com/lucrumx/bot/internal/config"
 
 	"github.com/lucrumx/bot/internal/notifier"
@@ -34,7 +35,9 @@ type Bot struct {
 	checkInterval           time.Duration
 	alertStep               float64
 
+	clock     clock.Clock
 	startTime time.Time
+	tradeTime bool // detect on the trade timestamps, see SetTradeTime
 
 	logger   zerolog.Logger
 	notifier notifier.Notifier
@@ -57,14 +60,35 @@ func NewBot(provider exchange.Provider, notif notifier.Notifier, cfg *config.Con
 
 		rpsTimerIntervalInSec: cfg.Exchange.Bot.RpsTimerInterval,
 
+		clock:    clock.Real(),
 		logger:   logger,
 		notifier: notif,
 	}
 }
 
+// SetClock sets the time source, the replayed one in replays. Must be called before StartBot.
+func (b *Bot) SetClock(c clock.Clock) {
+	b.clock = c
+}
+
+// SetTradeTime makes the workers detect on the timestamp of the processed trade instead of the clock. The
+// replayed clock runs ahead of the trades still queued in the bot, the trade time doesn't depend on the
+// replay speed. Must be called before StartBot.
+func (b *Bot) SetTradeTime() {
+	b.tradeTime = true
+}
+
+// now returns the time the trade is checked at.
+func (b *Bot) now(trade exchange.Trade) time.Time {
+	if b.tradeTime {
+		return time.UnixMilli(trade.Ts)
+	}
+	return b.clock.Now()
+}
+
 // StartBot starts the bot engine and returns a channel of trades.
 func (b *Bot) StartBot(ctx context.Context) (<-chan exchange.Trade, error) {
-	b.startTime = time.Now()
+	b.startTime = b.clock.Now()
 	b.logger.Info().Msg("bot engine: starting bot and getting tickers")
 
 	tickers, err := b.provider.GetTickers(ctx, []string{}, exchange.CategoryLinear)
@@ -0,0 +1,102 @@
+package pumpbot
+
+import (
+	"time"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Detector detects pumps the way the workers of the bot do, synchronously and without notifications,
+// for offline runs over recorded trades (the parameter sweep).
+// NOT safe for concurrent use.
+type Detector struct {
+	cfg       config.BotConfig
+	clock     clock.Clock
+	startTime time.Time
+	windows   map[string]*Window
+}
+
+// NewDetector creates a Detector. The startup delay counts from the first processed trade.
+func NewDetector(cfg config.BotConfig, c clock.Clock) *Detector {
+	return &Detector{
+		cfg:     cfg,
+		clock:   c,
+		windows: make(map[string]*Window),
+	}
+}
+
+// Process adds the trade to the window of its symbol and returns the price change in percent if the bot
+// would alert on it.
+func (d *Detector) Process(trade exchange.Trade) (float64, bool) {
+	now := d.clock.Now()
+	if d.startTime.IsZero() {
+		d.startTime = now
+	}
+
+	window, ok := d.windows[trade.Symbol]
+	if !ok {
+		window = NewWindow(d.cfg.PumpInterval)
+		d.windows[trade.Symbol] = window
+	}
+	window.AddTrade(trade)
+
+	return checkPump(window, now, d.startTime, d.cfg)
+}
+
+// detectorConfig returns the detection settings of the bot.
+func (b *Bot) detectorConfig() config.BotConfig {
+	return config.BotConfig{
+		CheckInterval:     b.checkInterval,
+		StartupDelay:      b.startupDelay,
+		PumpInterval:      b.pumpInterval,
+		TargetPriceChange: b.targetPriceChange,
+		AlertStep:         b.alertStep,
+	}
+}
+
+// checkPump returns the price change of the window in percent and whether it is an alert: a new pump or
+// the next alert step of the current one. The alert state of the window is updated on alert.
+func checkPump(win *Window, now, startTime time.Time, cfg config.BotConfig) (float64, bool) {
+	if now.Sub(startTime) < cfg.StartupDelay {
+		return 0, false
+	}
+
+	// Throttling
+	if !win.CanCheck(now, cfg.CheckInterval) {
+		return 0, false
+	}
+
+	change, isGrow := win.CheckGrow(now, cfg.PumpInterval, cfg.TargetPriceChange)
+	if !isGrow {
+		return 0, false
+	}
+
+	lastAlertTime, lastAlertLevel := win.GetAlertState()
+
+	// Новый это памп или продолжение старого
+	// Если с прошлого алерта прошло времени больше, чем длина окна,
+	// значит старый памп закончился, поймали новый.
+	isNewPump := now.Sub(lastAlertTime) > time.Duration(cfg.PumpInterval)*time.Second
+
+	needAlert := false
+
+	if isNewPump {
+		needAlert = true
+	} else {
+		// Памп продолжается. Проверяем, выросли ли мы на "шаг" (например, +5%)
+		// Текущий рост >= Прошлый уровень + Шаг
+		// Пример: 22% >= 15% + 5% -> True
+		nextThreshold := lastAlertLevel + cfg.AlertStep
+		if change > nextThreshold {
+			needAlert = true
+		}
+	}
+
+	if needAlert {
+		win.UpdateAlertState(now, change)
+	}
+
+	return change, needAlert
+}
@@ -0,0 +1,43 @@
+package pumpbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestDetector_Process(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	replayed := clock.NewManual(start)
+
+	d := NewDetector(config.BotConfig{
+		PumpInterval:      900,
+		TargetPriceChange: 10,
+		StartupDelay:      5 * time.Minute,
+		CheckInterval:     5 * time.Second,
+		AlertStep:         5,
+	}, replayed)
+
+	// the same pump as TestWorker_CheckPump_ReplayedClock
+	var changes []float64
+	for s := 0; s < 1800; s++ {
+		price := 1.0
+		if s > 1200 {
+			price = 1.0 + float64(s-1200)*0.001
+		}
+		ts := start.Add(time.Duration(s) * time.Second)
+		replayed.Set(ts)
+		if change, ok := d.Process(exchange.Trade{Symbol: "PUMPUSDT", Ts: ts.UnixMilli(), Price: price}); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	assert.Len(t, changes, 10)
+	assert.GreaterOrEqual(t, changes[0], 10.0)
+	assert.Greater(t, changes[9], 55.0)
+}
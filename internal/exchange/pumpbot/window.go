@@ -75,14 +75,14 @@ func (w *Window) AddTrade(trade exchange.Trade) {
 	w.lastTs = ts
 }
 
-// CheckGrow evaluates if the price increase over a given interval exceeds a target percentage.
+// CheckGrow evaluates if the price increase over a given interval up to now exceeds a target percentage.
 // It returns the percentage change and a boolean indicating whether the growth condition is met or not.
-func (w *Window) CheckGrow(interval int, targetPercent float64) (float64, bool) {
+func (w *Window) CheckGrow(nowTime time.Time, interval int, targetPercent float64) (float64, bool) {
 	if int64(interval) >= w.windowSize || w.lastTs == 0 {
 		return 0, false
 	}
 
-	now := time.Now().Unix()
+	now := nowTime.Unix()
 	pastTs := now - int64(interval)
 
 	// ---- текущая цена ----
@@ -126,9 +126,9 @@ func (w *Window) CheckGrow(interval int, targetPercent float64) (float64, bool)
 }
 
 // CanCheck determines if the specified minimum interval has elapsed since the last check and updates the last check time.
-func (w *Window) CanCheck(minInterval time.Duration) bool {
-	if time.Since(w.lastCheck) >= minInterval {
-		w.lastCheck = time.Now()
+func (w *Window) CanCheck(now time.Time, minInterval time.Duration) bool {
+	if now.Sub(w.lastCheck) >= minInterval {
+		w.lastCheck = now
 		return true
 	}
 	return false
@@ -139,8 +139,8 @@ func (w *Window) GetAlertState() (time.Time, float64) {
 	return w.lastAlertTime, w.lastAlertLevel
 }
 
-// UpdateAlertState updates the last alert time and level with the specified level.
-func (w *Window) UpdateAlertState(level float64) {
-	w.lastAlertTime = time.Now()
+// UpdateAlertState sets the last alert time to now and the level to the specified level.
+func (w *Window) UpdateAlertState(now time.Time, level float64) {
+	w.lastAlertTime = now
 	w.lastAlertLevel = level
 }
@@ -69,12 +69,12 @@ func TestWindow_CheckGrow(t *testing.T) {
 	})
 
 	// если росто больше порога
-	change, isGrow := w.CheckGrow(900, 15.0)
+	change, isGrow := w.CheckGrow(time.Unix(now, 0), 900, 15.0)
 	assert.True(t, isGrow)
 	assert.Equal(t, 20.0, change)
 
 	// если рост меньше порога
-	_, isGrow = w.CheckGrow(900, 25.0)
+	_, isGrow = w.CheckGrow(time.Unix(now, 0), 900, 25.0)
 	assert.False(t, isGrow)
 }
 
@@ -82,7 +82,7 @@ func TestWindow_AlertState(t *testing.T) {
 	w := NewWindow(100)
 	level := 15.5
 
-	w.UpdateAlertState(level)
+	w.UpdateAlertState(time.Now(), level)
 	alertTime, alertLevel := w.GetAlertState()
 
 	assert.WithinDuration(t, time.Now(), alertTime, time.Second)
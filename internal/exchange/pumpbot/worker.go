@@ -43,48 +43,13 @@ func (w *worker) processTrade(trade exchange.Trade) {
 
 	atomic.AddUint64(&w.bot.tradeCounter, 1)
 
-	w.checkPump(trade.Symbol, window)
+	w.checkPump(trade.Symbol, window, w.bot.now(trade))
 }
 
-func (w *worker) checkPump(symbol string, win *Window) {
-	if time.Since(w.bot.startTime) < w.bot.startupDelay {
-		return
-	}
-
-	// Throttling
-	if !win.CanCheck(w.bot.checkInterval) {
-		return
-	}
-
-	change, isGrow := win.CheckGrow(w.bot.pumpInterval, w.bot.targetPriceChange)
-	if !isGrow {
-		return
-	}
-
-	lastAlertTime, lastAlertLevel := win.GetAlertState()
-
-	// Новый это памп или продолжение старого
-	// Если с прошлого алерта прошло времени больше, чем длина окна,
-	// значит старый памп закончился, поймали новый.
-	isNewPump := time.Since(lastAlertTime) > time.Duration(w.bot.pumpInterval)*time.Second
-
-	needAlert := false
-
-	if isNewPump {
-		needAlert = true
-	} else {
-		// Памп продолжается. Проверяем, выросли ли мы на "шаг" (например, +5%)
-		// Текущий рост >= Прошлый уровень + Шаг
-		// Пример: 22% >= 15% + 5% -> True
-		nextThreshold := lastAlertLevel + w.bot.alertStep
-		if change > nextThreshold {
-			needAlert = true
-		}
-	}
+func (w *worker) checkPump(symbol string, win *Window, now time.Time) {
+	change, needAlert := checkPump(win, now, w.bot.startTime, w.bot.detectorConfig())
 
 	if needAlert {
-		win.UpdateAlertState(change)
-
 		priceChangePct := decimal.NewFromFloat(change).StringFixed(2) + "%"
 
 		w.bot.logger.Warn().
@@ -0,0 +1,49 @@
+package pumpbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/notifier"
+)
+
+func TestWorker_CheckPump_ReplayedClock(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	replayed := clock.NewManual(start)
+	notif := notifier.NewLogNotifier(zerolog.Nop(), replayed)
+
+	bot := &Bot{
+		pumpInterval:      900,
+		targetPriceChange: 10,
+		startupDelay:      5 * time.Minute,
+		checkInterval:     5 * time.Second,
+		alertStep:         5,
+		clock:             replayed,
+		startTime:         start,
+		logger:            zerolog.Nop(),
+		notifier:          notif,
+	}
+	w := &worker{bot: bot, windows: make(map[string]*Window)}
+
+	// flat for 20 minutes, then +0.1% a second for 10 minutes
+	for s := 0; s < 1800; s++ {
+		price := 1.0
+		if s > 1200 {
+			price = 1.0 + float64(s-1200)*0.001
+		}
+		ts := start.Add(time.Duration(s) * time.Second)
+		replayed.Set(ts)
+		w.processTrade(exchange.Trade{Symbol: "PUMPUSDT", Ts: ts.UnixMilli(), Price: price})
+	}
+
+	// +10% at 10:21:40, then every +5% step
+	assert.Equal(t, int64(10), notif.Count())
+	lastAlert, level := w.windows["PUMPUSDT"].GetAlertState()
+	assert.True(t, lastAlert.After(start.Add(29*time.Minute)))
+	assert.Greater(t, level, 55.0)
+}
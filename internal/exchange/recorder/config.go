@@ -0,0 +1,38 @@
+package recorder
+
+import (
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Source is a trade stream to record: all symbols of the exchange category when Symbols is empty.
+type Source struct {
+	Exchange string
+	Category exchange.Category
+	Symbols  []string
+}
+
+// Config controls what is recorded and how the files are laid out.
+type Config struct {
+	// Dir is the root directory of the dataset, files go to <Dir>/<exchange>/<category>/<date>/<time>.jsonl.gz.
+	Dir string
+
+	// Partition is the time window of one file, trades are partitioned by their exchange timestamp (UTC).
+	Partition time.Duration
+
+	// FlushInterval is how often the written trades are flushed to disk, a crash loses at most that much.
+	FlushInterval time.Duration
+
+	// Sources are the recorded streams.
+	Sources []Source
+}
+
+// DefaultConfig returns hourly files flushed every few seconds.
+func DefaultConfig() Config {
+	return Config{
+		Dir:           "data/trades",
+		Partition:     time.Hour,
+		FlushInterval: 5 * time.Second,
+	}
+}
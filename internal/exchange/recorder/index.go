@@ -0,0 +1,221 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// IndexFile is the name of the dataset index in the root directory.
+const IndexFile = "index.jsonl"
+
+// IndexEntry describes a data file, it is appended to the index when the file is closed.
+type IndexEntry struct {
+	Path     string            `json:"path"` // relative to the root directory
+	Exchange string            `json:"exchange"`
+	Category exchange.Category `json:"category"`
+	FromMs   int64             `json:"from_ms"` // min trade timestamp
+	ToMs     int64             `json:"to_ms"`   // max trade timestamp
+	Count    int64             `json:"count"`
+	Symbols  []string          `json:"symbols"`
+}
+
+// add accounts the record in the entry.
+func (e *IndexEntry) add(rec Record) {
+	if e.Count == 0 || rec.Ts < e.FromMs {
+		e.FromMs = rec.Ts
+	}
+	if e.Count == 0 || rec.Ts > e.ToMs {
+		e.ToMs = rec.Ts
+	}
+	e.Count++
+}
+
+// merge adds the other entry of the same file.
+func (e *IndexEntry) merge(other IndexEntry) {
+	e.FromMs = min(e.FromMs, other.FromMs)
+	e.ToMs = max(e.ToMs, other.ToMs)
+	e.Count += other.Count
+	e.Symbols = mergeSymbols(e.Symbols, other.Symbols)
+}
+
+func mergeSymbols(a, b []string) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for _, s := range a {
+		set[s] = struct{}{}
+	}
+	for _, s := range b {
+		set[s] = struct{}{}
+	}
+	symbols := make([]string, 0, len(set))
+	for s := range set {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// indexWriter appends entries to the index of the root directory, safe for concurrent use.
+type indexWriter struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newIndexWriter(root string) *indexWriter {
+	return &indexWriter{path: filepath.Join(root, IndexFile)}
+}
+
+// Append appends the entry as a JSON line.
+func (w *indexWriter) Append(entry IndexEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadIndex reads the index of the root directory, entries of the same file are merged. Sorted by FromMs.
+func ReadIndex(root string) ([]IndexEntry, error) {
+	f, err := os.Open(filepath.Join(root, IndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	byPath := make(map[string]*IndexEntry)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var entry IndexEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("index line %d: %w", line, err)
+		}
+		if prev, ok := byPath[entry.Path]; ok {
+			prev.merge(entry)
+			continue
+		}
+		byPath[entry.Path] = &entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]IndexEntry, 0, len(byPath))
+	for _, entry := range byPath {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].FromMs != entries[j].FromMs {
+			return entries[i].FromMs < entries[j].FromMs
+		}
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, nil
+}
+
+// FindFiles returns the entries of the exchange category with trades in [from, to].
+func FindFiles(entries []IndexEntry, exchangeName string, category exchange.Category, from, to time.Time) []IndexEntry {
+	var found []IndexEntry
+	for _, entry := range entries {
+		if entry.Exchange != exchangeName || entry.Category != category {
+			continue
+		}
+		if entry.ToMs < from.UnixMilli() || entry.FromMs > to.UnixMilli() {
+			continue
+		}
+		found = append(found, entry)
+	}
+	return found
+}
+
+// RepairIndex adds the data files missing in the index (not closed because of a crash) to it, returns
+// the number of added files.
+func RepairIndex(root string) (int, error) {
+	entries, err := ReadIndex(root)
+	if err != nil {
+		return 0, err
+	}
+	indexed := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		indexed[entry.Path] = struct{}{}
+	}
+
+	var missing []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".jsonl.gz") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := indexed[rel]; !ok {
+			missing = append(missing, rel)
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	index := newIndexWriter(root)
+	for _, rel := range missing {
+		// <exchange>/<category>/<date>/<file>
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != 4 {
+			return 0, fmt.Errorf("unexpected data file path %s", rel)
+		}
+
+		entry := IndexEntry{Path: rel, Exchange: parts[0], Category: exchange.Category(parts[1])}
+		var symbols []string
+		err := ReadFile(root, rel, func(rec Record) error {
+			entry.add(rec)
+			symbols = append(symbols, rec.Symbol)
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		entry.Symbols = mergeSymbols(nil, symbols)
+
+		if err := index.Append(entry); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(missing), nil
+}
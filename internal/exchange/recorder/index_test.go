@@ -0,0 +1,61 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestRepairIndex(t *testing.T) {
+	dir := t.TempDir()
+	index := newIndexWriter(dir)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// closed file, already in the index
+	w := newFileWriter(dir, "ByBit", exchange.CategoryLinear, time.Hour, index, zerolog.Nop())
+	require.NoError(t, w.Write(Record{Ts: start.UnixMilli(), Symbol: "BTCUSDT", Price: 100}))
+	require.NoError(t, w.Close())
+
+	// the same partition after a crash: flushed but never closed, the tail of the gzip stream is lost
+	w = newFileWriter(dir, "ByBit", exchange.CategoryLinear, time.Hour, index, zerolog.Nop())
+	require.NoError(t, w.Write(Record{Ts: start.Add(time.Minute).UnixMilli(), Symbol: "ETHUSDT", Price: 10}))
+	require.NoError(t, w.Write(Record{Ts: start.Add(2 * time.Minute).UnixMilli(), Symbol: "SOLUSDT", Price: 1}))
+	require.NoError(t, w.Flush())
+	path := filepath.Join(dir, w.entry.Path)
+	require.NoError(t, w.file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-3], 0o644))
+
+	repaired, err := RepairIndex(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repaired)
+
+	entries, err := ReadIndex(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ByBit/linear/2026-03-01/100000.jsonl.gz", entries[0].Path)
+	assert.Equal(t, "ByBit/linear/2026-03-01/100000-1.jsonl.gz", entries[1].Path)
+	assert.Equal(t, exchange.CategoryLinear, entries[1].Category)
+	assert.Equal(t, int64(2), entries[1].Count)
+	assert.Equal(t, []string{"ETHUSDT", "SOLUSDT"}, entries[1].Symbols)
+
+	// nothing left to repair
+	repaired, err = RepairIndex(dir)
+	require.NoError(t, err)
+	assert.Zero(t, repaired)
+}
+
+func TestRepairIndex_EmptyDir(t *testing.T) {
+	repaired, err := RepairIndex(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, repaired)
+}
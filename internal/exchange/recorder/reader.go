@@ -0,0 +1,84 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileReader reads the records of a data file one by one. NOT safe for concurrent use.
+type FileReader struct {
+	path string
+	file *os.File
+	gz   *gzip.Reader
+	r    *bufio.Reader
+}
+
+// OpenFile opens the data file at path relative to the root directory.
+func OpenFile(root, path string) (*FileReader, error) {
+	f, err := os.Open(filepath.Join(root, path))
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &FileReader{path: path, file: f, gz: gz, r: bufio.NewReader(gz)}, nil
+}
+
+// Next returns the next record, io.EOF after the last one. A file cut by a crash is read up to the last
+// complete record.
+func (r *FileReader) Next() (Record, error) {
+	line, err := r.r.ReadBytes('\n')
+	if err != nil {
+		// the last line without '\n' is a record cut by a crash
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("%s: %w", r.path, err)
+	}
+
+	var rec Record
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return Record{}, fmt.Errorf("%s: %w", r.path, err)
+	}
+	return rec, nil
+}
+
+// Close closes the file.
+func (r *FileReader) Close() error {
+	_ = r.gz.Close()
+	return r.file.Close()
+}
+
+// ReadFile calls fn for every record of the data file at path relative to the root directory, stops at
+// the first error of fn. A file cut by a crash is read up to the last complete record.
+func ReadFile(root, path string, fn func(Record) error) error {
+	r, err := OpenFile(root, path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
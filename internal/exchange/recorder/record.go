@@ -0,0 +1,25 @@
+package recorder
+
+import "github.com/lucrumx/bot/internal/exchange"
+
+// Record is a recorded trade, one JSON line of a data file. The exchange and category are in the file path.
+type Record struct {
+	Ts     int64         `json:"ts"`      // exchange timestamp, ms
+	RecvTs int64         `json:"recv_ts"` // receive timestamp, ms
+	Symbol string        `json:"symbol"`
+	Price  float64       `json:"price"`
+	Volume float64       `json:"volume"`
+	Side   exchange.Side `json:"side"`
+}
+
+// Trade returns the recorded trade.
+func (r Record) Trade(category exchange.Category) exchange.Trade {
+	return exchange.Trade{
+		Symbol:   r.Symbol,
+		Category: category,
+		Ts:       r.Ts,
+		Price:    r.Price,
+		Volume:   r.Volume,
+		Side:     r.Side,
+	}
+}
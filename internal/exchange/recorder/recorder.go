@@ -0,0 +1,166 @@
+// Package recorder records the raw trade streams of exchanges to compressed, time-partitioned files
+// with an index, building a historical dataset for research and replay.
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// Recorder subscribes to the trades of the configured sources and writes them to the dataset directory.
+type Recorder struct {
+	clients map[string]exchange.Provider // by exchange name
+	cfg     Config
+	logger  zerolog.Logger
+	index   *indexWriter
+}
+
+// NewRecorder creates a new Recorder.
+func NewRecorder(clients []exchange.Provider, cfg Config, logger zerolog.Logger) *Recorder {
+	byName := make(map[string]exchange.Provider, len(clients))
+	for _, client := range clients {
+		byName[client.GetExchangeName()] = client
+	}
+
+	return &Recorder{
+		clients: byName,
+		cfg:     cfg,
+		logger:  logger,
+		index:   newIndexWriter(cfg.Dir),
+	}
+}
+
+// Run records all sources until ctx is cancelled, then closes the open files. Returns the first failure
+// of a source, the others are stopped then.
+func (r *Recorder) Run(ctx context.Context) error {
+	if len(r.cfg.Sources) == 0 {
+		return fmt.Errorf("recorder: no sources")
+	}
+
+	repaired, err := RepairIndex(r.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("recorder: repair index: %w", err)
+	}
+	if repaired > 0 {
+		r.logger.Warn().Int("files", repaired).Msg("recorder: files not closed by the previous run added to the index")
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streams := make([]<-chan exchange.Trade, 0, len(r.cfg.Sources))
+	for _, source := range r.cfg.Sources {
+		ch, err := r.subscribe(subCtx, source)
+		if err != nil {
+			return err
+		}
+		streams = append(streams, ch)
+	}
+
+	errCh := make(chan error, len(streams))
+	var wg sync.WaitGroup
+	for i, source := range r.cfg.Sources {
+		wg.Add(1)
+		go func(source Source, ch <-chan exchange.Trade) {
+			defer wg.Done()
+			if err := r.record(subCtx, source, ch); err != nil {
+				errCh <- err
+				cancel()
+			}
+		}(source, streams[i])
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// subscribe subscribes to the trades of the source, all symbols of the category if none are configured.
+func (r *Recorder) subscribe(ctx context.Context, source Source) (<-chan exchange.Trade, error) {
+	client, ok := r.clients[source.Exchange]
+	if !ok {
+		return nil, fmt.Errorf("recorder: unknown exchange %s", source.Exchange)
+	}
+
+	symbols := source.Symbols
+	if len(symbols) == 0 {
+		instruments, err := client.GetInstruments(ctx, source.Category)
+		if err != nil {
+			return nil, fmt.Errorf("recorder: get %s instruments on %s: %w", source.Category, source.Exchange, err)
+		}
+		for symbol := range instruments {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+	}
+
+	ch, err := client.SubscribeTrades(ctx, symbols, source.Category)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: subscribe to %s trades on %s: %w", source.Category, source.Exchange, err)
+	}
+
+	r.logger.Info().
+		Str("exchange", source.Exchange).
+		Str("category", string(source.Category)).
+		Int("symbols", len(symbols)).
+		Msg("recorder: subscribed to trades")
+
+	return ch, nil
+}
+
+// record writes the trades of the source until ctx is cancelled or the stream is closed.
+func (r *Recorder) record(ctx context.Context, source Source, ch <-chan exchange.Trade) error {
+	w := newFileWriter(r.cfg.Dir, source.Exchange, source.Category, r.cfg.Partition, r.index, r.logger)
+	defer func() {
+		if err := w.Close(); err != nil {
+			r.logger.Error().Err(err).Str("exchange", source.Exchange).Msg("recorder: failed to close file")
+		}
+	}()
+
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.Flush(); err != nil {
+				return fmt.Errorf("recorder: flush %s %s: %w", source.Exchange, source.Category, err)
+			}
+		case trade, ok := <-ch:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("recorder: %s %s trade channel closed", source.Exchange, source.Category)
+			}
+
+			rec := Record{
+				Ts:     trade.Ts,
+				RecvTs: time.Now().UnixMilli(),
+				Symbol: trade.Symbol,
+				Price:  trade.Price,
+				Volume: trade.Volume,
+				Side:   trade.Side,
+			}
+			if rec.Ts <= 0 {
+				rec.Ts = rec.RecvTs
+			}
+			if err := w.Write(rec); err != nil {
+				return fmt.Errorf("recorder: %w", err)
+			}
+		}
+	}
+}
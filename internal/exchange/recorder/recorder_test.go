@@ -0,0 +1,105 @@
+package recorder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+func readAll(t *testing.T, root, path string) []Record {
+	t.Helper()
+
+	var records []Record
+	require.NoError(t, ReadFile(root, path, func(rec Record) error {
+		records = append(records, rec)
+		return nil
+	}))
+	return records
+}
+
+func TestRecorder_Run(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	trades := make(chan exchange.Trade)
+
+	bybit := exchangeMocks.NewMockProvider(t)
+	bybit.EXPECT().GetExchangeName().Return("ByBit")
+	bybit.EXPECT().GetInstruments(mock.Anything, exchange.CategoryLinear).Return(map[string]exchange.Instrument{
+		"ETHUSDT": {Symbol: "ETHUSDT"},
+		"BTCUSDT": {Symbol: "BTCUSDT"},
+	}, nil)
+	bybit.EXPECT().SubscribeTrades(mock.Anything, []string{"BTCUSDT", "ETHUSDT"}, exchange.CategoryLinear).Return(trades, nil)
+
+	cfg := DefaultConfig()
+	cfg.Dir = dir
+	cfg.Sources = []Source{{Exchange: "ByBit", Category: exchange.CategoryLinear}}
+	rec := NewRecorder([]exchange.Provider{bybit}, cfg, zerolog.Nop())
+
+	done := make(chan error)
+	go func() { done <- rec.Run(ctx) }()
+
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	trades <- exchange.Trade{Symbol: "BTCUSDT", Ts: hour.Add(59 * time.Minute).UnixMilli(), Price: 100, Volume: 1, Side: exchange.Buy}
+	trades <- exchange.Trade{Symbol: "ETHUSDT", Ts: hour.Add(61 * time.Minute).UnixMilli(), Price: 10, Volume: 2, Side: exchange.Sell}
+	// a late trade goes to the open file
+	trades <- exchange.Trade{Symbol: "BTCUSDT", Ts: hour.Add(59*time.Minute + time.Second).UnixMilli(), Price: 101, Volume: 1, Side: exchange.Buy}
+
+	cancel()
+	require.NoError(t, <-done)
+
+	entries, err := ReadIndex(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "ByBit/linear/2026-03-01/100000.jsonl.gz", entries[0].Path)
+	assert.Equal(t, int64(1), entries[0].Count)
+	assert.Equal(t, []string{"BTCUSDT"}, entries[0].Symbols)
+
+	assert.Equal(t, "ByBit/linear/2026-03-01/110000.jsonl.gz", entries[1].Path)
+	assert.Equal(t, int64(2), entries[1].Count)
+	assert.Equal(t, hour.Add(59*time.Minute+time.Second).UnixMilli(), entries[1].FromMs)
+	assert.Equal(t, hour.Add(61*time.Minute).UnixMilli(), entries[1].ToMs)
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, entries[1].Symbols)
+
+	records := readAll(t, dir, entries[1].Path)
+	require.Len(t, records, 2)
+	assert.Equal(t, "ETHUSDT", records[0].Symbol)
+	assert.Equal(t, exchange.Sell, records[0].Side)
+	assert.InDelta(t, 10, records[0].Price, 1e-9)
+	assert.Positive(t, records[0].RecvTs)
+
+	found := FindFiles(entries, "ByBit", exchange.CategoryLinear, hour.Add(time.Hour), hour.Add(2*time.Hour))
+	require.Len(t, found, 1)
+	assert.Equal(t, entries[1].Path, found[0].Path)
+	assert.Empty(t, FindFiles(entries, "MEXC", exchange.CategoryLinear, hour, hour.Add(2*time.Hour)))
+}
+
+func TestRecorder_Run_ChannelClosed(t *testing.T) {
+	trades := make(chan exchange.Trade)
+	close(trades)
+
+	mexc := exchangeMocks.NewMockProvider(t)
+	mexc.EXPECT().GetExchangeName().Return("MEXC")
+	mexc.EXPECT().SubscribeTrades(mock.Anything, []string{"BTCUSDT"}, exchange.CategorySpot).Return(trades, nil)
+
+	cfg := DefaultConfig()
+	cfg.Dir = t.TempDir()
+	cfg.Sources = []Source{{Exchange: "MEXC", Category: exchange.CategorySpot, Symbols: []string{"BTCUSDT"}}}
+
+	err := NewRecorder([]exchange.Provider{mexc}, cfg, zerolog.Nop()).Run(t.Context())
+	assert.ErrorContains(t, err, "MEXC spot trade channel closed")
+
+	cfg.Sources = []Source{{Exchange: "OKX", Category: exchange.CategoryLinear}}
+	err = NewRecorder([]exchange.Provider{mexc}, cfg, zerolog.Nop()).Run(t.Context())
+	assert.ErrorContains(t, err, "unknown exchange OKX")
+}
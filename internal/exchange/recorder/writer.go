@@ -0,0 +1,148 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// fileWriter writes the records of one exchange category to gzip JSONL files, one per partition.
+// A record of an earlier partition than the open file (late trade) goes to the open file, the index keeps
+// the real time range of every file. Existing files are never appended to (a file cut by a crash would hide
+// the rest), a restart within the partition opens <time>-1.jsonl.gz and so on. NOT safe for concurrent use.
+type fileWriter struct {
+	root         string
+	exchangeName string
+	category     exchange.Category
+	partition    time.Duration
+	index        *indexWriter
+	logger       zerolog.Logger
+
+	start   time.Time // partition of the open file
+	file    *os.File
+	gz      *gzip.Writer
+	enc     *json.Encoder
+	entry   IndexEntry
+	symbols map[string]struct{}
+}
+
+func newFileWriter(root, exchangeName string, category exchange.Category, partition time.Duration, index *indexWriter, logger zerolog.Logger) *fileWriter {
+	return &fileWriter{
+		root:         root,
+		exchangeName: exchangeName,
+		category:     category,
+		partition:    partition,
+		index:        index,
+		logger:       logger,
+	}
+}
+
+// filePath returns the path of the n-th file of the partition relative to the root directory.
+func (w *fileWriter) filePath(start time.Time, n int) string {
+	name := start.Format("150405")
+	if n > 0 {
+		name += "-" + strconv.Itoa(n)
+	}
+	return filepath.Join(w.exchangeName, string(w.category), start.Format("2006-01-02"), name+".jsonl.gz")
+}
+
+// Write writes the record, rotating the file when the record starts a new partition.
+func (w *fileWriter) Write(rec Record) error {
+	start := time.UnixMilli(rec.Ts).UTC().Truncate(w.partition)
+	if w.file == nil || start.After(w.start) {
+		if err := w.Close(); err != nil {
+			return err
+		}
+		if err := w.open(start); err != nil {
+			return err
+		}
+	}
+
+	if err := w.enc.Encode(rec); err != nil {
+		return fmt.Errorf("failed to write %s: %w", w.entry.Path, err)
+	}
+	w.entry.add(rec)
+	w.symbols[rec.Symbol] = struct{}{}
+
+	return nil
+}
+
+func (w *fileWriter) open(start time.Time) error {
+	if err := os.MkdirAll(filepath.Join(w.root, filepath.Dir(w.filePath(start, 0))), 0o755); err != nil {
+		return err
+	}
+
+	var rel string
+	var file *os.File
+	for n := 0; ; n++ {
+		rel = w.filePath(start, n)
+		var err error
+		file, err = os.OpenFile(filepath.Join(w.root, rel), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+	}
+
+	w.start = start
+	w.file = file
+	w.gz = gzip.NewWriter(file)
+	w.enc = json.NewEncoder(w.gz)
+	w.entry = IndexEntry{Path: rel, Exchange: w.exchangeName, Category: w.category}
+	w.symbols = make(map[string]struct{})
+
+	w.logger.Info().Str("path", rel).Msg("recorder: file opened")
+	return nil
+}
+
+// Flush writes the buffered records to disk.
+func (w *fileWriter) Flush() error {
+	if w.file == nil {
+		return nil
+	}
+	if err := w.gz.Flush(); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// Close closes the open file and adds it to the index.
+func (w *fileWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+
+	gzErr := w.gz.Close()
+	fileErr := w.file.Close()
+	w.file = nil
+	if gzErr != nil {
+		return gzErr
+	}
+	if fileErr != nil {
+		return fileErr
+	}
+
+	w.entry.Symbols = mergeSymbols(nil, keys(w.symbols))
+	w.logger.Info().Str("path", w.entry.Path).Int64("count", w.entry.Count).Msg("recorder: file closed")
+
+	return w.index.Append(w.entry)
+}
+
+func keys(set map[string]struct{}) []string {
+	result := make([]string, 0, len(set))
+	for k := range set {
+		result = append(result, k)
+	}
+	return result
+}
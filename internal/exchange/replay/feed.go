@@ -0,0 +1,334 @@
+// Package replay plays a dataset written by the recorder back into the bots through exchange.Provider,
+// driving their clock with the timestamps of the replayed trades, so a bot run over historical data
+// produces the alerts it would have produced live.
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+// minSleep is the smallest pacing delay worth sleeping for, shorter ones are carried over to the next trade.
+const minSleep = time.Millisecond
+
+// Config is the configuration of a replay.
+type Config struct {
+	Dir   string    // dataset directory of the recorder
+	From  time.Time // zero - from the start of the dataset
+	To    time.Time // zero - to the end of the dataset
+	Speed float64   // 1 - original speed, N - N times faster
+}
+
+// Stats is the summary of a replay.
+type Stats struct {
+	Trades int64
+	FromMs int64 // first replayed trade
+	ToMs   int64 // last replayed trade
+}
+
+// Feed plays the trades of a dataset to the subscriptions made through its providers. The trades of all
+// exchanges and categories are merged by timestamp, the clock is moved to the timestamp of every trade
+// before it is sent.
+//
+// The clock is shared by all bots of the replay and runs ahead of the trades still queued in a bot by their
+// queue latency times the speed, so the bots detect on the timestamps of the trades they process (SetTradeTime)
+// and the alerts don't depend on the speed. Keep the speed low enough for the bots not to drop trades when
+// their queues are full, it is logged by them.
+type Feed struct {
+	cfg     Config
+	logger  zerolog.Logger
+	clock   *clock.Manual
+	entries []recorder.IndexEntry
+
+	mu          sync.Mutex
+	subs        []*subscription
+	subscribed  chan struct{} // signalled on every subscription
+	instruments map[string]map[exchange.Category]map[string]exchange.Instrument
+}
+
+type subscription struct {
+	ctx          context.Context
+	exchangeName string
+	category     exchange.Category
+	symbols      map[string]struct{}
+
+	mu     sync.Mutex // guards sending against closing
+	ch     chan exchange.Trade
+	closed bool
+}
+
+// NewFeed reads the index of the dataset. The clock starts at cfg.From, or at the first trade of the dataset.
+func NewFeed(cfg Config, logger zerolog.Logger) (*Feed, error) {
+	if cfg.Speed <= 0 {
+		return nil, fmt.Errorf("replay: speed must be positive, got %v", cfg.Speed)
+	}
+	if !cfg.From.IsZero() && !cfg.To.IsZero() && cfg.To.Before(cfg.From) {
+		return nil, fmt.Errorf("replay: to %s is before from %s", cfg.To, cfg.From)
+	}
+
+	entries, err := recorder.ReadIndex(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("replay: read index: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("replay: no data in %s", cfg.Dir)
+	}
+
+	start := cfg.From
+	if start.IsZero() {
+		start = time.UnixMilli(entries[0].FromMs)
+	}
+
+	return &Feed{
+		cfg:         cfg,
+		logger:      logger,
+		clock:       clock.NewManual(start),
+		entries:     entries,
+		subscribed:  make(chan struct{}, 1),
+		instruments: make(map[string]map[exchange.Category]map[string]exchange.Instrument),
+	}, nil
+}
+
+// Clock returns the replayed clock, to be set on the bots.
+func (f *Feed) Clock() clock.Clock {
+	return f.clock
+}
+
+// Provider returns the provider of the exchange, see provider for the supported methods.
+func (f *Feed) Provider(exchangeName string) exchange.Provider {
+	return &provider{feed: f, exchangeName: exchangeName}
+}
+
+// SetInstruments sets the instruments GetInstruments returns for the exchange category, the dataset has none.
+func (f *Feed) SetInstruments(exchangeName string, category exchange.Category, instruments map[string]exchange.Instrument) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.instruments[exchangeName] == nil {
+		f.instruments[exchangeName] = make(map[exchange.Category]map[string]exchange.Instrument)
+	}
+	f.instruments[exchangeName][category] = instruments
+}
+
+// symbols returns the symbols of the exchange category in the replayed range.
+func (f *Feed) symbols(exchangeName string, category exchange.Category) []string {
+	var symbols []string
+	seen := make(map[string]struct{})
+	for _, entry := range f.files(exchangeName, category) {
+		for _, symbol := range entry.Symbols {
+			if _, ok := seen[symbol]; ok {
+				continue
+			}
+			seen[symbol] = struct{}{}
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// files returns the data files of the exchange category in the replayed range.
+func (f *Feed) files(exchangeName string, category exchange.Category) []recorder.IndexEntry {
+	from, to := f.cfg.From, f.cfg.To
+	if from.IsZero() {
+		from = time.UnixMilli(0)
+	}
+	if to.IsZero() {
+		to = time.UnixMilli(math.MaxInt64)
+	}
+	return recorder.FindFiles(f.entries, exchangeName, category, from, to)
+}
+
+func (f *Feed) subscribe(ctx context.Context, exchangeName string, symbols []string, category exchange.Category) <-chan exchange.Trade {
+	sub := &subscription{
+		ctx:          ctx,
+		exchangeName: exchangeName,
+		category:     category,
+		symbols:      make(map[string]struct{}, len(symbols)),
+		ch:           make(chan exchange.Trade),
+	}
+	for _, symbol := range symbols {
+		sub.symbols[symbol] = struct{}{}
+	}
+
+	// closed on cancel as the live clients do
+	go func() {
+		<-ctx.Done()
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+		sub.closed = true
+		close(sub.ch)
+	}()
+
+	f.mu.Lock()
+	f.subs = append(f.subs, sub)
+	f.mu.Unlock()
+
+	select {
+	case f.subscribed <- struct{}{}:
+	default:
+	}
+
+	return sub.ch
+}
+
+// waitSubscriptions waits until there are at least n subscriptions.
+func (f *Feed) waitSubscriptions(ctx context.Context, n int) ([]*subscription, error) {
+	for {
+		f.mu.Lock()
+		subs := f.subs
+		f.mu.Unlock()
+		if len(subs) >= n {
+			return subs, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-f.subscribed:
+		}
+	}
+}
+
+// Play waits for the given number of subscriptions, then plays the dataset to them. Returns when all trades
+// are sent or ctx is cancelled. The channels stay open after the last trade, so the bots finish their
+// queues; they are closed when the subscription context is cancelled.
+func (f *Feed) Play(ctx context.Context, subscriptions int) (Stats, error) {
+	subs, err := f.waitSubscriptions(ctx, subscriptions)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	type stream struct {
+		exchangeName string
+		category     exchange.Category
+	}
+	streams := make(map[stream]struct{})
+	var entries []recorder.IndexEntry
+	for _, sub := range subs {
+		key := stream{exchangeName: sub.exchangeName, category: sub.category}
+		if _, ok := streams[key]; ok {
+			continue
+		}
+		streams[key] = struct{}{}
+		entries = append(entries, f.files(sub.exchangeName, sub.category)...)
+	}
+	// the order of the subscriptions depends on the bots, the order of equal timestamps must not
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].FromMs != entries[j].FromMs {
+			return entries[i].FromMs < entries[j].FromMs
+		}
+		return entries[i].Path < entries[j].Path
+	})
+
+	m := newMerger(f.cfg.Dir, entries)
+	defer m.Close()
+
+	f.logger.Info().
+		Int("files", len(entries)).
+		Int("subscriptions", len(subs)).
+		Float64("speed", f.cfg.Speed).
+		Msg("replay: playing")
+
+	var stats Stats
+	var wallStart time.Time
+	for {
+		rec, entry, err := m.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("replay: %w", err)
+		}
+
+		ts := time.UnixMilli(rec.Ts)
+		if !f.cfg.From.IsZero() && ts.Before(f.cfg.From) {
+			continue
+		}
+		if !f.cfg.To.IsZero() && ts.After(f.cfg.To) {
+			continue
+		}
+
+		if stats.Trades == 0 {
+			stats.FromMs = rec.Ts
+			wallStart = time.Now()
+		}
+		if err := f.pace(ctx, wallStart, rec.Ts-stats.FromMs); err != nil {
+			return stats, err
+		}
+
+		f.clock.Set(ts)
+		trade := rec.Trade(entry.Category)
+		for _, sub := range subs {
+			if sub.exchangeName != entry.Exchange || sub.category != entry.Category {
+				continue
+			}
+			if _, ok := sub.symbols[rec.Symbol]; !ok {
+				continue
+			}
+			sub.send(ctx, trade)
+		}
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		stats.Trades++
+		stats.ToMs = max(stats.ToMs, rec.Ts)
+	}
+
+	f.logger.Info().
+		Int64("trades", stats.Trades).
+		Time("from", time.UnixMilli(stats.FromMs)).
+		Time("to", time.UnixMilli(stats.ToMs)).
+		Msg("replay: finished")
+
+	return stats, nil
+}
+
+// pace sleeps until the trade elapsedMs after the first one is due at the configured speed.
+func (f *Feed) pace(ctx context.Context, wallStart time.Time, elapsedMs int64) error {
+	if elapsedMs <= 0 {
+		return nil
+	}
+
+	due := wallStart.Add(time.Duration(float64(elapsedMs) / f.cfg.Speed * float64(time.Millisecond)))
+	wait := time.Until(due)
+	if wait < minSleep {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send blocks until the subscriber takes the trade or either context is cancelled.
+func (s *subscription) send(ctx context.Context, trade exchange.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- trade:
+	case <-s.ctx.Done():
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,204 @@
+package replay
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+var start = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// writeFile writes a data file and its index entry.
+func writeFile(t *testing.T, dir, exchangeName string, category exchange.Category, name string, records ...recorder.Record) {
+	t.Helper()
+
+	rel := filepath.Join(exchangeName, string(category), start.Format("2006-01-02"), name+".jsonl.gz")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(rel)), 0o755))
+
+	f, err := os.Create(filepath.Join(dir, rel))
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+
+	entry := recorder.IndexEntry{Path: rel, Exchange: exchangeName, Category: category}
+	seen := make(map[string]struct{})
+	for i, rec := range records {
+		require.NoError(t, enc.Encode(rec))
+		if i == 0 || rec.Ts < entry.FromMs {
+			entry.FromMs = rec.Ts
+		}
+		entry.ToMs = max(entry.ToMs, rec.Ts)
+		entry.Count++
+		if _, ok := seen[rec.Symbol]; !ok {
+			seen[rec.Symbol] = struct{}{}
+			entry.Symbols = append(entry.Symbols, rec.Symbol)
+		}
+	}
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	line, err := json.Marshal(entry)
+	require.NoError(t, err)
+	index, err := os.OpenFile(filepath.Join(dir, recorder.IndexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = index.Write(append(line, '\n'))
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+}
+
+func rec(sec int, symbol string, price float64) recorder.Record {
+	return recorder.Record{Ts: start.Add(time.Duration(sec) * time.Second).UnixMilli(), Symbol: symbol, Price: price, Volume: 1, Side: exchange.Buy}
+}
+
+type received struct {
+	trade exchange.Trade
+	now   time.Time
+}
+
+// collect reads the channel until n trades are received, noting the clock at every trade.
+func collect(t *testing.T, feed *Feed, ch <-chan exchange.Trade, n int) <-chan []received {
+	t.Helper()
+
+	out := make(chan []received, 1)
+	go func() {
+		var result []received
+		for trade := range ch {
+			result = append(result, received{trade: trade, now: feed.Clock().Now()})
+			if len(result) == n {
+				break
+			}
+		}
+		out <- result
+	}()
+	return out
+}
+
+func TestFeed_Play(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "100000",
+		rec(0, "BTCUSDT", 100), rec(2, "ETHUSDT", 10), rec(4, "BTCUSDT", 101))
+	writeFile(t, dir, "ByBit", exchange.CategorySpot, "100000",
+		rec(1, "BTCUSDT", 99), rec(3, "BTCUSDT", 98))
+	// another exchange, not subscribed
+	writeFile(t, dir, "MEXC", exchange.CategoryLinear, "100000", rec(1, "BTCUSDT", 50))
+
+	feed, err := NewFeed(Config{Dir: dir, Speed: 1000}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.True(t, feed.Clock().Now().Equal(start))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	bybit := feed.Provider("ByBit")
+	assert.Equal(t, "ByBit", bybit.GetExchangeName())
+
+	tickers, err := bybit.GetTickers(ctx, nil, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, tickers, 2)
+	assert.Equal(t, "BTCUSDT", tickers[0].Symbol)
+	assert.Equal(t, "ETHUSDT", tickers[1].Symbol)
+
+	_, err = bybit.GetInstruments(ctx, exchange.CategoryLinear)
+	assert.ErrorIs(t, err, ErrNotSupported)
+	feed.SetInstruments("ByBit", exchange.CategoryLinear, map[string]exchange.Instrument{"BTCUSDT": {Symbol: "BTCUSDT"}})
+	instruments, err := bybit.GetInstruments(ctx, exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.Len(t, instruments, 1)
+
+	linear, err := bybit.SubscribeTrades(ctx, []string{"BTCUSDT", "ETHUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	spot, err := bybit.SubscribeTrades(ctx, []string{"BTCUSDT"}, exchange.CategorySpot)
+	require.NoError(t, err)
+
+	linearOut := collect(t, feed, linear, 3)
+	spotOut := collect(t, feed, spot, 2)
+
+	stats, err := feed.Play(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Trades)
+	assert.Equal(t, rec(0, "", 0).Ts, stats.FromMs)
+	assert.Equal(t, rec(4, "", 0).Ts, stats.ToMs)
+
+	linearTrades := <-linearOut
+	require.Len(t, linearTrades, 3)
+	assert.Equal(t, "BTCUSDT", linearTrades[0].trade.Symbol)
+	assert.Equal(t, exchange.CategoryLinear, linearTrades[0].trade.Category)
+	assert.Equal(t, "ETHUSDT", linearTrades[1].trade.Symbol)
+	assert.InDelta(t, 101, linearTrades[2].trade.Price, 1e-9)
+
+	spotTrades := <-spotOut
+	require.Len(t, spotTrades, 2)
+	assert.Equal(t, exchange.CategorySpot, spotTrades[0].trade.Category)
+	assert.InDelta(t, 99, spotTrades[0].trade.Price, 1e-9)
+
+	// the clock is moved to every trade before it is sent
+	for _, r := range append(linearTrades, spotTrades...) {
+		assert.GreaterOrEqual(t, r.now.UnixMilli(), r.trade.Ts)
+	}
+	assert.True(t, feed.Clock().Now().Equal(start.Add(4*time.Second)))
+
+	cancel()
+	_, ok := <-linear
+	assert.False(t, ok, "channel is closed on cancel")
+}
+
+func TestFeed_Play_Range(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "100000",
+		rec(0, "BTCUSDT", 100), rec(10, "BTCUSDT", 101), rec(20, "BTCUSDT", 102))
+
+	feed, err := NewFeed(Config{Dir: dir, From: start.Add(5 * time.Second), To: start.Add(15 * time.Second), Speed: 1000}, zerolog.Nop())
+	require.NoError(t, err)
+	assert.True(t, feed.Clock().Now().Equal(start.Add(5*time.Second)))
+
+	ch, err := feed.Provider("ByBit").SubscribeTrades(t.Context(), []string{"BTCUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	out := collect(t, feed, ch, 1)
+
+	stats, err := feed.Play(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Trades)
+
+	trades := <-out
+	require.Len(t, trades, 1)
+	assert.InDelta(t, 101, trades[0].trade.Price, 1e-9)
+}
+
+func TestFeed_Play_Speed(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "100000",
+		rec(0, "BTCUSDT", 100), rec(1, "BTCUSDT", 101), rec(2, "BTCUSDT", 102))
+
+	// 2s of data at 10x
+	feed, err := NewFeed(Config{Dir: dir, Speed: 10}, zerolog.Nop())
+	require.NoError(t, err)
+
+	ch, err := feed.Provider("ByBit").SubscribeTrades(t.Context(), []string{"BTCUSDT"}, exchange.CategoryLinear)
+	require.NoError(t, err)
+	out := collect(t, feed, ch, 3)
+
+	began := time.Now()
+	_, err = feed.Play(t.Context(), 1)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(began), 190*time.Millisecond)
+	assert.Len(t, <-out, 3)
+}
+
+func TestNewFeed_Errors(t *testing.T) {
+	_, err := NewFeed(Config{Dir: t.TempDir(), Speed: 1}, zerolog.Nop())
+	assert.ErrorContains(t, err, "no data")
+
+	_, err = NewFeed(Config{Dir: t.TempDir()}, zerolog.Nop())
+	assert.ErrorContains(t, err, "speed must be positive")
+}
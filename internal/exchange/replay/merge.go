@@ -0,0 +1,119 @@
+package replay
+
+import (
+	"container/heap"
+	"errors"
+	"io"
+
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+// source is a data file in the merge. A file is opened when the merge reaches its first trade, so only the
+// files overlapping the current time are open.
+type source struct {
+	entry  recorder.IndexEntry
+	reader *recorder.FileReader // nil until opened
+	next   recorder.Record      // next record of the open file
+	key    int64                // FromMs of an unopened file, timestamp of the next record otherwise
+	seq    int                  // tie breaker, keeps the order stable between runs
+}
+
+// sourceHeap orders the sources by key, then by seq.
+type sourceHeap []*source
+
+func (h sourceHeap) Len() int { return len(h) }
+
+func (h sourceHeap) Less(i, j int) bool {
+	if h[i].key != h[j].key {
+		return h[i].key < h[j].key
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h sourceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *sourceHeap) Push(x any) { *h = append(*h, x.(*source)) }
+
+func (h *sourceHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// merger merges the records of data files by timestamp. Records within a file are in arrival order, so
+// a late trade of a file comes after the newer trades of the other files merged before it.
+// NOT safe for concurrent use.
+type merger struct {
+	root string
+	h    sourceHeap
+}
+
+func newMerger(root string, entries []recorder.IndexEntry) *merger {
+	m := &merger{root: root, h: make(sourceHeap, 0, len(entries))}
+	for i, entry := range entries {
+		m.h = append(m.h, &source{entry: entry, key: entry.FromMs, seq: i})
+	}
+	heap.Init(&m.h)
+	return m
+}
+
+// Next returns the next record and its file entry, io.EOF when all files are read.
+func (m *merger) Next() (recorder.Record, recorder.IndexEntry, error) {
+	for m.h.Len() > 0 {
+		src := m.h[0]
+
+		if src.reader == nil {
+			reader, err := recorder.OpenFile(m.root, src.entry.Path)
+			if err != nil {
+				return recorder.Record{}, recorder.IndexEntry{}, err
+			}
+			src.reader = reader
+			if err := m.advanceHead(); err != nil {
+				return recorder.Record{}, recorder.IndexEntry{}, err
+			}
+			continue
+		}
+
+		rec, entry := src.next, src.entry
+		if err := m.advanceHead(); err != nil {
+			return recorder.Record{}, recorder.IndexEntry{}, err
+		}
+		return rec, entry, nil
+	}
+
+	return recorder.Record{}, recorder.IndexEntry{}, io.EOF
+}
+
+// advanceHead reads the next record of the head source and restores the heap order, the source is removed
+// at the end of its file.
+func (m *merger) advanceHead() error {
+	src := m.h[0]
+
+	rec, err := src.reader.Next()
+	if errors.Is(err, io.EOF) {
+		_ = src.reader.Close()
+		heap.Pop(&m.h)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	src.next = rec
+	src.key = rec.Ts
+	heap.Fix(&m.h, 0)
+	return nil
+}
+
+// Close closes the open files.
+func (m *merger) Close() {
+	for _, src := range m.h {
+		if src.reader != nil {
+			_ = src.reader.Close()
+		}
+	}
+	m.h = nil
+}
@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// ErrNotSupported is returned by the provider methods a replay has no data for.
+var ErrNotSupported = errors.New("replay: not supported")
+
+// provider is the exchange.Provider of one exchange of a Feed. It serves the trades of the dataset,
+// the tickers of the recorded symbols (no prices and turnover, the turnover filters of the bots must be off)
+// and the instruments set with Feed.SetInstruments. Everything else returns ErrNotSupported.
+type provider struct {
+	feed         *Feed
+	exchangeName string
+}
+
+func (p *provider) GetExchangeName() string {
+	return p.exchangeName
+}
+
+func (p *provider) GetTickers(_ context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	wanted := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = struct{}{}
+	}
+
+	recorded := p.feed.symbols(p.exchangeName, category)
+	sort.Strings(recorded)
+
+	tickers := make([]exchange.Ticker, 0, len(recorded))
+	for _, symbol := range recorded {
+		if _, ok := wanted[symbol]; len(wanted) > 0 && !ok {
+			continue
+		}
+		tickers = append(tickers, exchange.Ticker{Symbol: symbol})
+	}
+	return tickers, nil
+}
+
+func (p *provider) GetInstruments(_ context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	p.feed.mu.Lock()
+	defer p.feed.mu.Unlock()
+
+	instruments, ok := p.feed.instruments[p.exchangeName][category]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s instruments of %s", ErrNotSupported, category, p.exchangeName)
+	}
+	return instruments, nil
+}
+
+func (p *provider) SubscribeTrades(ctx context.Context, symbols []string, category exchange.Category) (<-chan exchange.Trade, error) {
+	return p.feed.subscribe(ctx, p.exchangeName, symbols, category), nil
+}
+
+func (p *provider) GetFeeSchedule(context.Context) (exchange.FeeSchedule, error) {
+	return exchange.FeeSchedule{}, ErrNotSupported
+}
+
+func (p *provider) GetFundingRates(context.Context) (map[string]exchange.FundingRate, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) SubscribeBookTicker(context.Context, []string) (<-chan exchange.BookTop, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) SubscribeOrderBook(context.Context, []string) (<-chan exchange.OrderBookUpdate, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) CreateOrder(context.Context, *models.Order) error {
+	return ErrNotSupported
+}
+
+func (p *provider) CloseOrder(context.Context, *models.Order) error {
+	return ErrNotSupported
+}
+
+func (p *provider) CancelOrder(context.Context, uuid.UUID, string, string, exchange.Category) error {
+	return ErrNotSupported
+}
+
+func (p *provider) GetBalances(context.Context, exchange.Category) ([]models.Balance, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) SetLeverage(context.Context, string, int64) error {
+	return ErrNotSupported
+}
+
+func (p *provider) SetMarginMode(context.Context, string, exchange.MarginMode) error {
+	return ErrNotSupported
+}
+
+func (p *provider) GetOrder(context.Context, uuid.UUID, string, string, exchange.Category) (exchange.ExchangeOrder, error) {
+	return exchange.ExchangeOrder{}, ErrNotSupported
+}
+
+func (p *provider) GetPositions(context.Context) ([]exchange.Position, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) GetOpenOrders(context.Context, exchange.Category) ([]exchange.OpenOrder, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *provider) SubscribeExecutions(context.Context) (<-chan exchange.OrderExecutionEvent, error) {
+	return nil, ErrNotSupported
+}
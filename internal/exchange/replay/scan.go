@@ -0,0 +1,92 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+// Scan reads the trades of the exchanges (all recorded ones if empty) in the range of cfg merged by timestamp
+// and passes them to fn as fast as they are read: there is no pacing and no clock, cfg.Speed is ignored.
+// Stops at the first error of fn or when ctx is cancelled.
+func Scan(ctx context.Context, cfg Config, exchanges []string, fn func(exchangeName string, trade exchange.Trade) error) (Stats, error) {
+	if !cfg.From.IsZero() && !cfg.To.IsZero() && cfg.To.Before(cfg.From) {
+		return Stats{}, fmt.Errorf("replay: to %s is before from %s", cfg.To, cfg.From)
+	}
+
+	index, err := recorder.ReadIndex(cfg.Dir)
+	if err != nil {
+		return Stats{}, fmt.Errorf("replay: read index: %w", err)
+	}
+
+	from, to := cfg.From, cfg.To
+	if from.IsZero() {
+		from = time.UnixMilli(0)
+	}
+	if to.IsZero() {
+		to = time.UnixMilli(math.MaxInt64)
+	}
+
+	wanted := make(map[string]struct{}, len(exchanges))
+	for _, name := range exchanges {
+		wanted[name] = struct{}{}
+	}
+
+	var entries []recorder.IndexEntry
+	for _, entry := range index {
+		if _, ok := wanted[entry.Exchange]; len(wanted) > 0 && !ok {
+			continue
+		}
+		if entry.ToMs < from.UnixMilli() || entry.FromMs > to.UnixMilli() {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return Stats{}, fmt.Errorf("replay: no data in %s", cfg.Dir)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].FromMs != entries[j].FromMs {
+			return entries[i].FromMs < entries[j].FromMs
+		}
+		return entries[i].Path < entries[j].Path
+	})
+
+	m := newMerger(cfg.Dir, entries)
+	defer m.Close()
+
+	var stats Stats
+	for {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		rec, entry, err := m.Next()
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, fmt.Errorf("replay: %w", err)
+		}
+		if rec.Ts < from.UnixMilli() || rec.Ts > to.UnixMilli() {
+			continue
+		}
+
+		if err := fn(entry.Exchange, rec.Trade(entry.Category)); err != nil {
+			return stats, err
+		}
+
+		if stats.Trades == 0 {
+			stats.FromMs = rec.Ts
+		}
+		stats.Trades++
+		stats.ToMs = max(stats.ToMs, rec.Ts)
+	}
+}
@@ -0,0 +1,56 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "10", rec(0, "BTCUSDT", 100), rec(2, "BTCUSDT", 101), rec(4, "BTCUSDT", 102))
+	writeFile(t, dir, "ByBit", exchange.CategorySpot, "10", rec(1, "BTCUSDT", 99), rec(3, "BTCUSDT", 100))
+	writeFile(t, dir, "BingX", exchange.CategoryLinear, "10", rec(1, "BTCUSDT", 105))
+
+	type scanned struct {
+		exchangeName string
+		category     exchange.Category
+		price        float64
+	}
+	var got []scanned
+	stats, err := Scan(context.Background(), Config{Dir: dir, To: start.Add(3 * time.Second)}, []string{"ByBit"},
+		func(exchangeName string, trade exchange.Trade) error {
+			got = append(got, scanned{exchangeName: exchangeName, category: trade.Category, price: trade.Price})
+			return nil
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, []scanned{
+		{"ByBit", exchange.CategoryLinear, 100},
+		{"ByBit", exchange.CategorySpot, 99},
+		{"ByBit", exchange.CategoryLinear, 101},
+		{"ByBit", exchange.CategorySpot, 100},
+	}, got)
+	assert.Equal(t, int64(4), stats.Trades)
+	assert.Equal(t, start.UnixMilli(), stats.FromMs)
+	assert.Equal(t, start.Add(3*time.Second).UnixMilli(), stats.ToMs)
+}
+
+func TestScan_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "10", rec(0, "BTCUSDT", 100), rec(1, "BTCUSDT", 101))
+
+	_, err := Scan(context.Background(), Config{Dir: dir}, []string{"OKX"}, func(string, exchange.Trade) error { return nil })
+	assert.Error(t, err)
+
+	stop := errors.New("stop")
+	stats, err := Scan(context.Background(), Config{Dir: dir}, nil, func(string, exchange.Trade) error { return stop })
+	assert.ErrorIs(t, err, stop)
+	assert.Zero(t, stats.Trades)
+}
@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/pumpbot"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+)
+
+// alertsStub collects the sent alerts.
+type alertsStub struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (n *alertsStub) Send(message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func (n *alertsStub) sorted() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	messages := append([]string(nil), n.messages...)
+	sort.Strings(messages)
+	return messages
+}
+
+// replayPump plays the dataset into the pump bot at the speed and returns its alerts.
+func replayPump(t *testing.T, dir string, speed float64, expected int) []string {
+	t.Helper()
+
+	feed, err := NewFeed(Config{Dir: dir, Speed: speed}, zerolog.Nop())
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.Exchange.Bot = config.BotConfig{
+		PumpInterval:      2,
+		TargetPriceChange: 5,
+		AlertStep:         5,
+		RpsTimerInterval:  60,
+	}
+	notif := &alertsStub{}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	bot := pumpbot.NewBot(feed.Provider("ByBit"), notif, cfg, zerolog.Nop())
+	bot.SetClock(feed.Clock())
+	bot.SetTradeTime()
+	trades, err := bot.StartBot(ctx)
+	require.NoError(t, err)
+	go func() {
+		for range trades {
+		}
+	}()
+
+	_, err = feed.Play(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(notif.sorted()) == expected }, 2*time.Second, 10*time.Millisecond)
+	return notif.sorted()
+}
+
+func TestFeed_Play_SameAlertsAtAnySpeed(t *testing.T) {
+	dir := t.TempDir()
+
+	// 3s of trades every 100ms: PUMPUSDT is flat for 1.5s, then grows 1% a trade; FLATUSDT doesn't move
+	var records []recorder.Record
+	for i := 0; i < 30; i++ {
+		ts := start.Add(time.Duration(i) * 100 * time.Millisecond).UnixMilli()
+		price := 100.0
+		if i > 15 {
+			price += float64(i - 15)
+		}
+		records = append(records,
+			recorder.Record{Ts: ts, Symbol: "FLATUSDT", Price: 10, Volume: 1, Side: exchange.Buy},
+			recorder.Record{Ts: ts, Symbol: "PUMPUSDT", Price: price, Volume: 1, Side: exchange.Buy},
+		)
+	}
+	writeFile(t, dir, "ByBit", exchange.CategoryLinear, "100000", records...)
+
+	original := replayPump(t, dir, 1, 2)
+	require.Len(t, original, 2)
+	assert.Equal(t, original, replayPump(t, dir, 1000, 2))
+}
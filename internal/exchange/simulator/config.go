@@ -0,0 +1,55 @@
+package simulator
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+)
+
+// FillRule tells which trades fill a resting limit order.
+type FillRule string
+
+const (
+	// FillTouch fills a resting order by any trade at its limit price or better.
+	FillTouch FillRule = "touch"
+	// FillThrough fills a resting order only by a trade strictly better than its limit price: the queue
+	// ahead of the order at its price level is unknown, so a trade at the price may not have reached it.
+	FillThrough FillRule = "through"
+)
+
+// Config is the configuration of a simulated exchange.
+type Config struct {
+	// Latency is the time between an order request and the order reaching the book.
+	Latency time.Duration
+
+	// SlippagePercent is how much worse than the top of the book (or the last trade) market orders fill.
+	SlippagePercent float64
+
+	// Fees are charged on every fill: maker for resting limit orders, taker for the rest. Zero - the fee
+	// schedule of the market provider.
+	Fees exchange.FeeRate
+
+	// FillRule tells which trades fill resting limit orders.
+	FillRule FillRule
+
+	// VolumeLimited fills a resting limit order by at most the volume of the crossing trade, so large orders
+	// fill partially over several trades. Otherwise the whole order fills on the first crossing trade.
+	VolumeLimited bool
+
+	// Balance is the initial USDT of each category (spot and linear accounts are separate).
+	Balance decimal.Decimal
+}
+
+// DefaultConfig returns a conservative venue: 50ms latency, ByBit VIP0 fees, limit orders filled
+// only by trades through their price.
+func DefaultConfig() Config {
+	return Config{
+		Latency:         50 * time.Millisecond,
+		SlippagePercent: 0.02,
+		Fees:            exchange.FeeRate{Maker: 0.0002, Taker: 0.00055},
+		FillRule:        FillThrough,
+		Balance:         decimal.NewFromInt(10_000),
+	}
+}
@@ -0,0 +1,448 @@
+// Package simulator provides a simulated exchange: orders are filled against the trades and book tops of
+// a market data provider (a replay in backtests, a live client in paper trading) with configured latency,
+// slippage, fees and limit fill rules, and balances and positions are kept in memory.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// executionsBuffer is the capacity of an execution channel, events above it wait in the subscriber queue.
+const executionsBuffer = 256
+
+// Stats is the summary of the simulated account.
+type Stats struct {
+	Orders      int             // accepted orders
+	Fills       int             // fills of the orders
+	Fees        decimal.Decimal // paid fees in USDT, negative as GetOrder reports them
+	RealizedPnL decimal.Decimal // price PnL of the closed quantity in USDT, fees are not included
+}
+
+// Exchange is a simulated exchange implementing exchange.Provider. Market data requests are served by
+// the market provider, the trades and book tops passing through SubscribeTrades / SubscribeBookTicker
+// fill the orders, so orders of a symbol fill only while its market data is subscribed.
+//
+// Orders reach the book after the configured latency of the clock, in the order they were sent. A market
+// order fills in full at the top of the book (the last trade price without book tops) with slippage and
+// the taker fee. A limit order crossing the book fills the same way at its price or better, IOC/FOK orders
+// not crossing it are cancelled, the rest rest on the book and fill at their price with the maker fee
+// by trades satisfying the fill rule. Close orders are reduce-only. Margin of linear positions is not
+// checked. Safe for concurrent use.
+type Exchange struct {
+	market exchange.Provider
+	cfg    Config
+	clock  clock.Clock
+	logger zerolog.Logger
+
+	mu          sync.Mutex
+	seq         int64
+	quotes      map[quoteKey]*quote
+	orders      map[uuid.UUID]*order
+	pending     []*order              // sent, not in the book yet, by seq
+	resting     map[quoteKey][]*order // in the book
+	instruments map[exchange.Category]map[string]exchange.Instrument
+	fees        *exchange.FeeSchedule // of the market, loaded on first use when no fees are configured
+	usdt        map[exchange.Category]decimal.Decimal
+	coins       map[string]*holding // spot balances by asset
+	positions   map[string]*holding // linear positions by symbol, signed qty
+	subs        []*subscriber
+	stats       Stats
+}
+
+type quoteKey struct {
+	category exchange.Category
+	symbol   string
+}
+
+// quote is the last known market of a symbol, zero prices are unknown.
+type quote struct {
+	last float64
+	bid  float64
+	ask  float64
+}
+
+// holding is a spot balance or a linear position with its average entry price. Qty is in exchange units,
+// signed for positions (negative - short).
+type holding struct {
+	qty   decimal.Decimal
+	entry decimal.Decimal
+}
+
+type subscriber struct {
+	ctx   context.Context
+	ch    chan exchange.OrderExecutionEvent
+	queue []exchange.OrderExecutionEvent
+	wake  chan struct{}
+}
+
+// New creates a simulated exchange on top of the market data of market, timed by c.
+func New(market exchange.Provider, cfg Config, c clock.Clock, logger zerolog.Logger) *Exchange {
+	return &Exchange{
+		market:      market,
+		cfg:         cfg,
+		clock:       c,
+		logger:      logger.With().Str("simulator", market.GetExchangeName()).Logger(),
+		quotes:      make(map[quoteKey]*quote),
+		orders:      make(map[uuid.UUID]*order),
+		resting:     make(map[quoteKey][]*order),
+		instruments: make(map[exchange.Category]map[string]exchange.Instrument),
+		usdt: map[exchange.Category]decimal.Decimal{
+			exchange.CategorySpot:   cfg.Balance,
+			exchange.CategoryLinear: cfg.Balance,
+		},
+		coins:     make(map[string]*holding),
+		positions: make(map[string]*holding),
+	}
+}
+
+// Stats returns the summary of the account.
+func (s *Exchange) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// GetExchangeName returns the name of the market exchange.
+func (s *Exchange) GetExchangeName() string {
+	return s.market.GetExchangeName()
+}
+
+// GetTickers returns the tickers of the market.
+func (s *Exchange) GetTickers(ctx context.Context, symbols []string, category exchange.Category) ([]exchange.Ticker, error) {
+	return s.market.GetTickers(ctx, symbols, category)
+}
+
+// GetInstruments returns the instruments of the market.
+func (s *Exchange) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	return s.market.GetInstruments(ctx, category)
+}
+
+// GetFeeSchedule returns the configured fees for all symbols, or the fee schedule of the market when no
+// fees are configured.
+func (s *Exchange) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
+	if s.cfg.Fees != (exchange.FeeRate{}) {
+		return exchange.FeeSchedule{Default: s.cfg.Fees}, nil
+	}
+	return s.market.GetFeeSchedule(ctx)
+}
+
+// GetFundingRates returns the funding rates of the market, funding is not charged.
+func (s *Exchange) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	return s.market.GetFundingRates(ctx)
+}
+
+// SubscribeTrades subscribes to the trades of the market, every trade fills the orders it crosses
+// before it is passed on.
+func (s *Exchange) SubscribeTrades(ctx context.Context, symbols []string, category exchange.Category) (<-chan exchange.Trade, error) {
+	in, err := s.market.SubscribeTrades(ctx, symbols, category)
+	if err != nil {
+		return nil, err
+	}
+	return forward(ctx, in, s.onTrade), nil
+}
+
+// SubscribeBookTicker subscribes to the book tops of the market, every top fills the orders it crosses
+// before it is passed on.
+func (s *Exchange) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	in, err := s.market.SubscribeBookTicker(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	return forward(ctx, in, s.onBookTop), nil
+}
+
+// SubscribeOrderBook subscribes to the order books of the market, they don't fill orders.
+func (s *Exchange) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	return s.market.SubscribeOrderBook(ctx, symbols)
+}
+
+// forward passes the values of in to the returned channel after apply, the channel is closed with in.
+func forward[T any](ctx context.Context, in <-chan T, apply func(T)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range in {
+			apply(v)
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// CreateOrder sends the order, it reaches the book after the latency.
+func (s *Exchange) CreateOrder(ctx context.Context, order *models.Order) error {
+	return s.send(ctx, order, false)
+}
+
+// CloseOrder closes the position of the order side by a market order of the opposite side, reduce-only on
+// perpetuals. It reaches the book after the latency.
+func (s *Exchange) CloseOrder(ctx context.Context, order *models.Order) error {
+	return s.send(ctx, order, true)
+}
+
+// CancelOrder cancels the rest of an order not filled yet.
+func (s *Exchange) CancelOrder(_ context.Context, orderID uuid.UUID, _ string, _ string, _ exchange.Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[orderID]
+	if !ok {
+		return fmt.Errorf("simulator %s: order %s not found", s.GetExchangeName(), orderID)
+	}
+	if !o.active() {
+		return fmt.Errorf("simulator %s: order %s is %s", s.GetExchangeName(), orderID, o.status)
+	}
+	s.cancel(o)
+	return nil
+}
+
+// GetBalances returns the USDT of the category account and, for spot, the coins held.
+func (s *Exchange) GetBalances(_ context.Context, category exchange.Category) ([]models.Balance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usdt := s.usdt[category]
+	balances := []models.Balance{{ExchangeName: s.GetExchangeName(), Asset: "USDT", Free: usdt, Total: usdt}}
+	if category != exchange.CategorySpot {
+		return balances, nil
+	}
+	for asset, h := range s.coins {
+		if h.qty.IsZero() {
+			continue
+		}
+		balances = append(balances, models.Balance{ExchangeName: s.GetExchangeName(), Asset: asset, Free: h.qty, Total: h.qty})
+	}
+	return balances, nil
+}
+
+// SetLeverage does nothing, margin is not simulated.
+func (s *Exchange) SetLeverage(_ context.Context, _ string, _ int64) error {
+	return nil
+}
+
+// SetMarginMode does nothing, margin is not simulated.
+func (s *Exchange) SetMarginMode(_ context.Context, _ string, _ exchange.MarginMode) error {
+	return nil
+}
+
+// GetOrder returns the average price, fees and realized PnL of the order.
+func (s *Exchange) GetOrder(_ context.Context, orderID uuid.UUID, exchangeOrderID string, _ string, _ exchange.Category) (exchange.ExchangeOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[orderID]
+	if !ok {
+		return exchange.ExchangeOrder{}, fmt.Errorf("simulator %s: order %s not found", s.GetExchangeName(), orderID)
+	}
+	return exchange.ExchangeOrder{
+		OrderID:         orderID,
+		ExchangeOrderID: exchangeOrderID,
+		ExchangeName:    s.GetExchangeName(),
+		AvgPrice:        o.avgPrice(),
+		Fees:            o.fees,
+		Profit:          o.profit,
+	}, nil
+}
+
+// GetPositions returns the open linear positions.
+func (s *Exchange) GetPositions(_ context.Context) ([]exchange.Position, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var positions []exchange.Position
+	for symbol, h := range s.positions {
+		if h.qty.IsZero() {
+			continue
+		}
+		side := models.OrderSideBuy
+		if h.qty.IsNegative() {
+			side = models.OrderSideSell
+		}
+		positions = append(positions, exchange.Position{Symbol: symbol, Side: side, Qty: h.qty.Abs(), EntryPrice: h.entry})
+	}
+	return positions, nil
+}
+
+// GetOpenOrders returns the orders of the category in the book.
+func (s *Exchange) GetOpenOrders(_ context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var open []exchange.OpenOrder
+	for key, orders := range s.resting {
+		if key.category != category {
+			continue
+		}
+		for _, o := range orders {
+			open = append(open, exchange.OpenOrder{
+				OrderID:         o.id,
+				ExchangeOrderID: o.exchangeOrderID,
+				Symbol:          o.symbol,
+				Side:            o.side,
+				Qty:             o.qty,
+				Price:           o.price,
+			})
+		}
+	}
+	return open, nil
+}
+
+// SubscribeExecutions returns a channel of the fills of all orders, closed when ctx is cancelled.
+// Every subscriber receives all events in the order of the fills.
+func (s *Exchange) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
+	sub := &subscriber{
+		ctx:  ctx,
+		ch:   make(chan exchange.OrderExecutionEvent, executionsBuffer),
+		wake: make(chan struct{}, 1),
+	}
+
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	s.mu.Unlock()
+
+	go s.deliver(sub)
+	return sub.ch, nil
+}
+
+// deliver sends the queued events of the subscriber, the queue lets fills be emitted under the mutex
+// without waiting for the subscriber.
+func (s *Exchange) deliver(sub *subscriber) {
+	defer close(sub.ch)
+	for {
+		select {
+		case <-sub.ctx.Done():
+			s.unsubscribe(sub)
+			return
+		case <-sub.wake:
+		}
+
+		s.mu.Lock()
+		events := sub.queue
+		sub.queue = nil
+		s.mu.Unlock()
+
+		for _, event := range events {
+			select {
+			case sub.ch <- event:
+			case <-sub.ctx.Done():
+				s.unsubscribe(sub)
+				return
+			}
+		}
+	}
+}
+
+func (s *Exchange) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, other := range s.subs {
+		if other == sub {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// emit queues the execution event of the order for all subscribers. Caller must hold the mutex.
+func (s *Exchange) emit(o *order) {
+	event := exchange.OrderExecutionEvent{
+		OrderID:         o.id,
+		ExchangeOrderID: o.exchangeOrderID,
+		ExecPrice:       o.avgPrice(),
+		ExecQty:         o.filled,
+		ExecValue:       o.value,
+		LeavesQty:       o.qty.Sub(o.filled),
+		OrderPrice:      o.price,
+		OrderQty:        o.qty,
+	}
+	for _, sub := range s.subs {
+		sub.queue = append(sub.queue, event)
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// contractSize returns the coins in one exchange unit of the symbol, instruments are loaded from
+// the market on first use. Unknown symbols are traded in coins.
+func (s *Exchange) contractSize(ctx context.Context, category exchange.Category, symbol string) decimal.Decimal {
+	s.mu.Lock()
+	instruments, ok := s.instruments[category]
+	s.mu.Unlock()
+
+	if !ok {
+		loaded, err := s.market.GetInstruments(ctx, category)
+		if err != nil {
+			s.logger.Warn().Err(err).Str("category", string(category)).Msg("simulator: no instruments, quantities are in coins")
+		}
+		s.mu.Lock()
+		s.instruments[category] = loaded
+		s.mu.Unlock()
+		instruments = loaded
+	}
+
+	if inst, ok := instruments[symbol]; ok && inst.ContractSize.IsPositive() {
+		return inst.ContractSize
+	}
+	return decimal.NewFromInt(1)
+}
+
+// feeRate returns the fee rate of the symbol: the configured one, or the one of the market schedule loaded
+// on first use. Without both the default fees are charged.
+func (s *Exchange) feeRate(ctx context.Context, symbol string) exchange.FeeRate {
+	if s.cfg.Fees != (exchange.FeeRate{}) {
+		return s.cfg.Fees
+	}
+
+	s.mu.Lock()
+	fees := s.fees
+	s.mu.Unlock()
+
+	if fees == nil {
+		loaded, err := s.market.GetFeeSchedule(ctx)
+		if err != nil {
+			s.logger.Warn().Err(err).Msg("simulator: no fee schedule, default fees are charged")
+			loaded = exchange.FeeSchedule{Default: DefaultConfig().Fees}
+		}
+		s.mu.Lock()
+		s.fees = &loaded
+		s.mu.Unlock()
+		fees = &loaded
+	}
+	return fees.For(symbol)
+}
+
+// activateOn puts the orders due by the time the latency timer fires into the book.
+func (s *Exchange) activateOn(ctx context.Context, timer <-chan time.Time) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activateDue(s.clock.Now())
+}
+
+// baseAsset returns the coin of a USDT pair.
+func baseAsset(symbol string) string {
+	return strings.TrimSuffix(symbol, "USDT")
+}
@@ -0,0 +1,356 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+	exchangeMocks "github.com/lucrumx/bot/internal/testmocks/exchange"
+)
+
+var start = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type testVenue struct {
+	sim        *Exchange
+	clock      *clock.Manual
+	market     map[exchange.Category]chan exchange.Trade
+	trades     map[exchange.Category]<-chan exchange.Trade
+	executions <-chan exchange.OrderExecutionEvent
+}
+
+// newTestVenue creates a simulator trading BTCUSDT on both categories of a mocked market.
+func newTestVenue(t *testing.T, cfg Config) *testVenue {
+	t.Helper()
+
+	market := exchangeMocks.NewMockProvider(t)
+	market.EXPECT().GetExchangeName().Return("ByBit")
+	market.EXPECT().GetInstruments(mock.Anything, mock.Anything).
+		Return(map[string]exchange.Instrument{"BTCUSDT": {Symbol: "BTCUSDT", ContractSize: decimal.NewFromInt(1)}}, nil).
+		Maybe()
+	market.EXPECT().GetFeeSchedule(mock.Anything).
+		Return(exchange.FeeSchedule{
+			Default: exchange.FeeRate{Maker: 0.0002, Taker: 0.00055},
+			Symbols: map[string]exchange.FeeRate{"BTCUSDT": {Maker: 0.0001, Taker: 0.0004}},
+		}, nil).
+		Maybe()
+
+	v := &testVenue{
+		clock:  clock.NewManual(start),
+		market: make(map[exchange.Category]chan exchange.Trade),
+		trades: make(map[exchange.Category]<-chan exchange.Trade),
+	}
+	v.sim = New(market, cfg, v.clock, zerolog.Nop())
+
+	for _, category := range []exchange.Category{exchange.CategoryLinear, exchange.CategorySpot} {
+		ch := make(chan exchange.Trade)
+		market.EXPECT().SubscribeTrades(mock.Anything, []string{"BTCUSDT"}, category).Return(ch, nil).Once()
+		trades, err := v.sim.SubscribeTrades(t.Context(), []string{"BTCUSDT"}, category)
+		require.NoError(t, err)
+		v.market[category] = ch
+		v.trades[category] = trades
+	}
+
+	executions, err := v.sim.SubscribeExecutions(t.Context())
+	require.NoError(t, err)
+	v.executions = executions
+
+	return v
+}
+
+// trade passes a trade through the simulator and waits until it's processed.
+func (v *testVenue) trade(t *testing.T, category exchange.Category, price, volume float64) {
+	t.Helper()
+	v.market[category] <- exchange.Trade{Symbol: "BTCUSDT", Category: category, Price: price, Volume: volume}
+	got := <-v.trades[category]
+	assert.InDelta(t, price, got.Price, 1e-9)
+}
+
+func (v *testVenue) nextExecution(t *testing.T) exchange.OrderExecutionEvent {
+	t.Helper()
+	select {
+	case event := <-v.executions:
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no execution")
+		return exchange.OrderExecutionEvent{}
+	}
+}
+
+func (v *testVenue) noExecution(t *testing.T) {
+	t.Helper()
+	select {
+	case event := <-v.executions:
+		assert.Failf(t, "unexpected execution", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func newOrder(market models.OrderMarket, side models.OrderSide, qty float64, price float64) *models.Order {
+	order := &models.Order{
+		ID:       uuid.New(),
+		Market:   market,
+		Symbol:   "BTCUSDT",
+		Side:     side,
+		Type:     models.OrderTypeMarket,
+		Quantity: decimal.NewFromFloat(qty),
+	}
+	if price > 0 {
+		order.Type = models.OrderTypeLimit
+		order.TimeInForce = models.TimeInForceGTC
+		order.Price = decimal.NewFromFloat(price)
+	}
+	return order
+}
+
+func TestExchange_MarketOrder(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{
+		Latency:         100 * time.Millisecond,
+		SlippagePercent: 0.1,
+		Fees:            exchange.FeeRate{Maker: 0.0002, Taker: 0.001},
+		FillRule:        FillThrough,
+	})
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	open := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 2, 0)
+	require.NoError(t, v.sim.CreateOrder(ctx, open))
+	assert.Equal(t, "sim-1", open.ExchangeOrderID)
+
+	// in flight until the latency has passed
+	v.noExecution(t)
+	v.clock.Advance(100 * time.Millisecond)
+
+	event := v.nextExecution(t)
+	assert.Equal(t, open.ID, event.OrderID)
+	assert.Equal(t, "sim-1", event.ExchangeOrderID)
+	assert.True(t, event.ExecPrice.Equal(decimal.RequireFromString("100.1")), event.ExecPrice.String())
+	assert.True(t, event.ExecQty.Equal(decimal.NewFromInt(2)))
+	assert.True(t, event.LeavesQty.IsZero())
+
+	info, err := v.sim.GetOrder(ctx, open.ID, open.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.True(t, info.Fees.Equal(decimal.RequireFromString("-0.2002")), info.Fees.String())
+	assert.True(t, info.Profit.IsZero())
+
+	positions, err := v.sim.GetPositions(ctx)
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, models.OrderSideBuy, positions[0].Side)
+	assert.True(t, positions[0].Qty.Equal(decimal.NewFromInt(2)))
+
+	// closing the long for more than the position is cut to it
+	v.trade(t, exchange.CategoryLinear, 110, 1)
+	closing := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 3, 0)
+	require.NoError(t, v.sim.CloseOrder(ctx, closing))
+	v.clock.Advance(100 * time.Millisecond)
+
+	event = v.nextExecution(t)
+	assert.Equal(t, closing.ID, event.OrderID)
+	assert.True(t, event.ExecPrice.Equal(decimal.RequireFromString("109.89")), event.ExecPrice.String())
+	assert.True(t, event.ExecQty.Equal(decimal.NewFromInt(2)))
+	assert.True(t, event.LeavesQty.IsZero())
+
+	info, err = v.sim.GetOrder(ctx, closing.ID, closing.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.True(t, info.Profit.Equal(decimal.RequireFromString("19.58")), info.Profit.String())
+
+	positions, err = v.sim.GetPositions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+
+	stats := v.sim.Stats()
+	assert.Equal(t, 2, stats.Orders)
+	assert.Equal(t, 2, stats.Fills)
+	assert.True(t, stats.RealizedPnL.Equal(decimal.RequireFromString("19.58")))
+}
+
+func TestExchange_MarketFees(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{FillRule: FillThrough, Balance: decimal.NewFromInt(1000)})
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	schedule, err := v.sim.GetFeeSchedule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, exchange.FeeRate{Maker: 0.0001, Taker: 0.0004}, schedule.For("BTCUSDT"))
+
+	order := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 0)
+	require.NoError(t, v.sim.CreateOrder(ctx, order))
+	v.nextExecution(t)
+
+	info, err := v.sim.GetOrder(ctx, order.ID, order.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.True(t, info.Fees.Equal(decimal.RequireFromString("-0.04")), info.Fees.String())
+}
+
+func TestExchange_LimitOrder_FillRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    FillRule
+		atLimit bool // filled by a trade at the limit price, a trade through it fills with both rules
+	}{
+		{name: "touch", rule: FillTouch, atLimit: true},
+		{name: "through", rule: FillThrough, atLimit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			v := newTestVenue(t, Config{Fees: exchange.FeeRate{Maker: 0.0002, Taker: 0.001}, FillRule: tt.rule})
+			v.trade(t, exchange.CategoryLinear, 100, 1)
+
+			order := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 99)
+			require.NoError(t, v.sim.CreateOrder(ctx, order))
+
+			v.trade(t, exchange.CategoryLinear, 99.5, 1)
+			v.trade(t, exchange.CategoryLinear, 99, 1)
+			if !tt.atLimit {
+				v.noExecution(t)
+				open, err := v.sim.GetOpenOrders(ctx, exchange.CategoryLinear)
+				require.NoError(t, err)
+				require.Len(t, open, 1)
+				assert.Equal(t, order.ID, open[0].OrderID)
+
+				v.trade(t, exchange.CategoryLinear, 98.9, 1)
+			}
+
+			event := v.nextExecution(t)
+			assert.True(t, event.ExecPrice.Equal(decimal.NewFromInt(99)), "resting orders fill at their price")
+			assert.True(t, event.LeavesQty.IsZero())
+
+			info, err := v.sim.GetOrder(ctx, order.ID, order.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear)
+			require.NoError(t, err)
+			assert.True(t, info.Fees.Equal(decimal.RequireFromString("-0.0198")), "maker fee: %s", info.Fees)
+		})
+	}
+}
+
+func TestExchange_LimitOrder_Crossing(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{Fees: exchange.FeeRate{Maker: 0.0002, Taker: 0.001}, FillRule: FillThrough})
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	// a limit above the market takes it at the market price and pays the taker fee
+	order := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 101)
+	require.NoError(t, v.sim.CreateOrder(ctx, order))
+
+	event := v.nextExecution(t)
+	assert.True(t, event.ExecPrice.Equal(decimal.NewFromInt(100)))
+
+	info, err := v.sim.GetOrder(ctx, order.ID, order.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.True(t, info.Fees.Equal(decimal.RequireFromString("-0.1")), info.Fees.String())
+}
+
+func TestExchange_LimitOrder_VolumeLimited(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{FillRule: FillThrough, VolumeLimited: true})
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	order := newOrder(models.OrderMarketLinear, models.OrderSideSell, 3, 101)
+	require.NoError(t, v.sim.CreateOrder(ctx, order))
+
+	v.trade(t, exchange.CategoryLinear, 102, 2)
+	event := v.nextExecution(t)
+	assert.True(t, event.ExecQty.Equal(decimal.NewFromInt(2)))
+	assert.True(t, event.LeavesQty.Equal(decimal.NewFromInt(1)))
+
+	v.trade(t, exchange.CategoryLinear, 101.5, 5)
+	event = v.nextExecution(t)
+	assert.True(t, event.ExecQty.Equal(decimal.NewFromInt(3)), "cumulative qty")
+	assert.True(t, event.LeavesQty.IsZero())
+	assert.True(t, event.ExecPrice.Equal(decimal.NewFromInt(101)))
+}
+
+func TestExchange_CancelOrder(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{Latency: time.Second, FillRule: FillTouch})
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	// cancelled in flight, never reaches the book
+	inFlight := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 0)
+	require.NoError(t, v.sim.CreateOrder(ctx, inFlight))
+	require.NoError(t, v.sim.CancelOrder(ctx, inFlight.ID, inFlight.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear))
+
+	resting := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 90)
+	require.NoError(t, v.sim.CreateOrder(ctx, resting))
+	v.clock.Advance(time.Second)
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	require.NoError(t, v.sim.CancelOrder(ctx, resting.ID, resting.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear))
+	assert.ErrorContains(t, v.sim.CancelOrder(ctx, resting.ID, resting.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear), "CANCELED")
+	assert.ErrorContains(t, v.sim.CancelOrder(ctx, uuid.New(), "", "BTCUSDT", exchange.CategoryLinear), "not found")
+
+	open, err := v.sim.GetOpenOrders(ctx, exchange.CategoryLinear)
+	require.NoError(t, err)
+	assert.Empty(t, open)
+
+	v.trade(t, exchange.CategoryLinear, 80, 1)
+	v.noExecution(t)
+	assert.Equal(t, 0, v.sim.Stats().Fills)
+}
+
+func TestExchange_CloseOrder_NothingToReduce(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, DefaultConfig())
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	order := newOrder(models.OrderMarketLinear, models.OrderSideBuy, 1, 0)
+	require.NoError(t, v.sim.CloseOrder(ctx, order))
+	v.clock.Advance(time.Second)
+	v.trade(t, exchange.CategoryLinear, 100, 1)
+
+	v.noExecution(t)
+	assert.ErrorContains(t, v.sim.CancelOrder(ctx, order.ID, order.ExchangeOrderID, "BTCUSDT", exchange.CategoryLinear), "CANCELED")
+	positions, err := v.sim.GetPositions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+func TestExchange_Spot(t *testing.T) {
+	ctx := t.Context()
+	v := newTestVenue(t, Config{Fees: exchange.FeeRate{Taker: 0.001}, Balance: decimal.NewFromInt(1000)})
+	v.trade(t, exchange.CategorySpot, 100, 1)
+
+	assert.ErrorContains(t, v.sim.CreateOrder(ctx, newOrder(models.OrderMarketSpot, models.OrderSideBuy, 11, 0)), "insufficient USDT")
+	assert.ErrorContains(t, v.sim.CreateOrder(ctx, newOrder(models.OrderMarketSpot, models.OrderSideSell, 1, 0)), "insufficient BTC")
+
+	require.NoError(t, v.sim.CreateOrder(ctx, newOrder(models.OrderMarketSpot, models.OrderSideBuy, 2, 0)))
+	v.nextExecution(t)
+
+	// the buy fee is charged in the coin
+	balances, err := v.sim.GetBalances(ctx, exchange.CategorySpot)
+	require.NoError(t, err)
+	byAsset := make(map[string]decimal.Decimal)
+	for _, b := range balances {
+		byAsset[b.Asset] = b.Free
+	}
+	assert.True(t, byAsset["USDT"].Equal(decimal.NewFromInt(800)), byAsset["USDT"].String())
+	assert.True(t, byAsset["BTC"].Equal(decimal.RequireFromString("1.998")), byAsset["BTC"].String())
+
+	// the linear account is separate
+	balances, err = v.sim.GetBalances(ctx, exchange.CategoryLinear)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.True(t, balances[0].Free.Equal(decimal.NewFromInt(1000)))
+
+	v.trade(t, exchange.CategorySpot, 110, 1)
+	sell := newOrder(models.OrderMarketSpot, models.OrderSideBuy, 1.998, 0)
+	require.NoError(t, v.sim.CloseOrder(ctx, sell))
+	event := v.nextExecution(t)
+	assert.True(t, event.ExecPrice.Equal(decimal.NewFromInt(110)))
+
+	balances, err = v.sim.GetBalances(ctx, exchange.CategorySpot)
+	require.NoError(t, err)
+	require.Len(t, balances, 1, "no coins left")
+	// 800 + 1.998 * 110 * 0.999
+	assert.True(t, balances[0].Free.Equal(decimal.RequireFromString("1019.56022")), balances[0].Free.String())
+}
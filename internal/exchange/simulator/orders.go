@@ -0,0 +1,485 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// order is an order sent to the simulated exchange. Quantities are in exchange units.
+type order struct {
+	id              uuid.UUID
+	exchangeOrderID string
+	key             quoteKey
+	symbol          string
+	side            models.OrderSide
+	orderType       models.OrderType
+	timeInForce     models.TimeInForce
+	price           decimal.Decimal // limit price, zero for market orders
+	qty             decimal.Decimal
+	reduceOnly      bool
+	contractSize    decimal.Decimal
+	feeRate         exchange.FeeRate
+	due             time.Time // when the order reaches the book
+
+	status models.OrderStatus // PENDING until the order reaches the book
+	filled decimal.Decimal
+	value  decimal.Decimal // sum of filled qty * price
+	fees   decimal.Decimal // negative
+	profit decimal.Decimal
+}
+
+// active reports whether the order can still fill.
+func (o *order) active() bool {
+	switch o.status {
+	case models.OrderStatusPending, models.OrderStatusNew, models.OrderStatusPartiallyFilled:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *order) remaining() decimal.Decimal {
+	return o.qty.Sub(o.filled)
+}
+
+func (o *order) avgPrice() decimal.Decimal {
+	if o.filled.IsZero() {
+		return decimal.Zero
+	}
+	return o.value.Div(o.filled)
+}
+
+// send validates the order and queues it for the book. A closing order is sent as the live clients do:
+// a market order of the opposite side, reduce-only on perpetuals.
+func (s *Exchange) send(ctx context.Context, m *models.Order, closing bool) error {
+	category := exchange.CategoryLinear
+	if m.Market == models.OrderMarketSpot {
+		category = exchange.CategorySpot
+	}
+
+	side, orderType := m.Side, m.Type
+	if closing {
+		side = models.OrderSideSell
+		if m.Side == models.OrderSideSell {
+			side = models.OrderSideBuy
+		}
+		orderType = models.OrderTypeMarket
+	}
+
+	if !m.Quantity.IsPositive() {
+		return fmt.Errorf("simulator %s: order qty must be positive, got %s", s.GetExchangeName(), m.Quantity)
+	}
+	if orderType == models.OrderTypeLimit && !m.Price.IsPositive() {
+		return fmt.Errorf("simulator %s: limit price must be positive, got %s", s.GetExchangeName(), m.Price)
+	}
+	contractSize := s.contractSize(ctx, category, m.Symbol)
+	feeRate := s.feeRate(ctx, m.Symbol)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := &order{
+		id:           m.ID,
+		key:          quoteKey{category: category, symbol: m.Symbol},
+		symbol:       m.Symbol,
+		side:         side,
+		orderType:    orderType,
+		timeInForce:  m.TimeInForce,
+		qty:          m.Quantity,
+		reduceOnly:   closing && category == exchange.CategoryLinear,
+		contractSize: contractSize,
+		feeRate:      feeRate,
+		due:          s.clock.Now().Add(s.cfg.Latency),
+		status:       models.OrderStatusPending,
+	}
+	if orderType == models.OrderTypeLimit {
+		o.price = m.Price
+	}
+	if err := s.checkBalance(o); err != nil {
+		return err
+	}
+
+	s.seq++
+	o.exchangeOrderID = "sim-" + strconv.FormatInt(s.seq, 10)
+	m.ExchangeOrderID = o.exchangeOrderID
+
+	s.orders[o.id] = o
+	s.stats.Orders++
+
+	if s.cfg.Latency <= 0 {
+		s.place(o)
+		return nil
+	}
+	s.pending = append(s.pending, o)
+	// the timer is taken before returning, so a clock moved right after the request releases the order
+	go s.activateOn(ctx, s.clock.After(s.cfg.Latency))
+	return nil
+}
+
+// checkBalance rejects spot orders the account can't pay for: buys without the USDT at the limit price
+// or the current market, sells without the coins. Caller must hold the mutex.
+func (s *Exchange) checkBalance(o *order) error {
+	if o.key.category != exchange.CategorySpot {
+		return nil
+	}
+
+	if o.side == models.OrderSideSell {
+		held := decimal.Zero
+		if h, ok := s.coins[baseAsset(o.symbol)]; ok {
+			held = h.qty
+		}
+		if held.LessThan(o.qty) {
+			return fmt.Errorf("simulator %s: insufficient %s: %s, need %s", s.GetExchangeName(), baseAsset(o.symbol), held, o.qty)
+		}
+		return nil
+	}
+
+	price := o.price
+	if price.IsZero() {
+		price = s.takerPrice(o)
+	}
+	cost := o.qty.Mul(o.contractSize).Mul(price)
+	if s.usdt[exchange.CategorySpot].LessThan(cost) {
+		return fmt.Errorf("simulator %s: insufficient USDT: %s, need %s", s.GetExchangeName(), s.usdt[exchange.CategorySpot], cost.StringFixed(2))
+	}
+	return nil
+}
+
+// activateDue puts the pending orders due by now into the book in the order they were sent.
+// Caller must hold the mutex.
+func (s *Exchange) activateDue(now time.Time) {
+	pending := s.pending[:0]
+	var due []*order
+	for _, o := range s.pending {
+		if o.due.After(now) {
+			pending = append(pending, o)
+			continue
+		}
+		due = append(due, o)
+	}
+	s.pending = pending
+
+	for _, o := range due {
+		if o.status != models.OrderStatusPending {
+			continue // cancelled in flight
+		}
+		s.place(o)
+	}
+}
+
+// place puts the order into the book: it takes the market if it can, rests otherwise.
+// Caller must hold the mutex.
+func (s *Exchange) place(o *order) {
+	o.status = models.OrderStatusNew
+
+	top := s.top(o)
+	if top.IsZero() {
+		// no market yet, a market order fills on the first quote
+		if o.timeInForce == models.TimeInForceIOC || o.timeInForce == models.TimeInForceFOK {
+			s.cancel(o)
+			return
+		}
+		s.resting[o.key] = append(s.resting[o.key], o)
+		return
+	}
+
+	if o.orderType == models.OrderTypeMarket {
+		s.fill(o, o.remaining(), s.takerPrice(o), false)
+		return
+	}
+
+	crosses := top.LessThanOrEqual(o.price)
+	if o.side == models.OrderSideSell {
+		crosses = top.GreaterThanOrEqual(o.price)
+	}
+	switch {
+	case crosses && o.timeInForce == models.TimeInForcePostOnly:
+		s.cancel(o)
+	case crosses:
+		s.fill(o, o.remaining(), s.takerPrice(o), false)
+	case o.timeInForce == models.TimeInForceIOC || o.timeInForce == models.TimeInForceFOK:
+		s.cancel(o)
+	default:
+		s.resting[o.key] = append(s.resting[o.key], o)
+	}
+}
+
+// top returns the side of the book the order takes: the best ask for buys, the best bid for sells,
+// the last trade price without book tops. Zero if the market of the symbol is unknown.
+// Caller must hold the mutex.
+func (s *Exchange) top(o *order) decimal.Decimal {
+	q, ok := s.quotes[o.key]
+	if !ok {
+		return decimal.Zero
+	}
+
+	top := q.ask
+	if o.side == models.OrderSideSell {
+		top = q.bid
+	}
+	if top == 0 {
+		top = q.last
+	}
+	return decimal.NewFromFloat(top)
+}
+
+// takerPrice returns the price the order takes the market at: the top of the book with slippage, capped
+// by the limit price of limit orders. Zero if the market of the symbol is unknown. Caller must hold the mutex.
+func (s *Exchange) takerPrice(o *order) decimal.Decimal {
+	top := s.top(o)
+	if top.IsZero() {
+		return decimal.Zero
+	}
+
+	slippage := decimal.NewFromFloat(s.cfg.SlippagePercent).Div(hundred)
+	if o.side == models.OrderSideSell {
+		slippage = slippage.Neg()
+	}
+	price := top.Mul(decimal.NewFromInt(1).Add(slippage))
+	if o.price.IsPositive() {
+		if o.side == models.OrderSideBuy {
+			price = decimal.Min(price, o.price)
+		} else {
+			price = decimal.Max(price, o.price)
+		}
+	}
+	return price
+}
+
+// onTrade updates the last price of the symbol and fills the resting orders the trade crosses.
+func (s *Exchange) onTrade(trade exchange.Trade) {
+	key := quoteKey{category: trade.Category, symbol: trade.Symbol}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activateDue(s.clock.Now())
+	s.quote(key).last = trade.Price
+	s.match(key, trade.Price, trade.Volume, trade.Price, trade.Volume)
+}
+
+// onBookTop updates the best bid and ask of the symbol and fills the resting orders the book crosses.
+func (s *Exchange) onBookTop(top exchange.BookTop) {
+	category := top.Category
+	if category == "" {
+		category = exchange.CategoryLinear
+	}
+	key := quoteKey{category: category, symbol: top.Symbol}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activateDue(s.clock.Now())
+	q := s.quote(key)
+	q.bid, q.ask = top.BidPrice, top.AskPrice
+	s.match(key, top.AskPrice, top.AskQty, top.BidPrice, top.BidQty)
+}
+
+func (s *Exchange) quote(key quoteKey) *quote {
+	q, ok := s.quotes[key]
+	if !ok {
+		q = &quote{}
+		s.quotes[key] = q
+	}
+	return q
+}
+
+// match fills the resting orders of the symbol: buys by sellers at sellPrice, sells by buyers at buyPrice.
+// The volumes limit the fills with VolumeLimited. Caller must hold the mutex.
+func (s *Exchange) match(key quoteKey, sellPrice, sellVolume, buyPrice, buyVolume float64) {
+	orders := s.resting[key]
+	if len(orders) == 0 {
+		return
+	}
+
+	budget := map[models.OrderSide]decimal.Decimal{
+		models.OrderSideBuy:  decimal.NewFromFloat(sellVolume),
+		models.OrderSideSell: decimal.NewFromFloat(buyVolume),
+	}
+	for _, o := range orders {
+		if !o.active() {
+			continue
+		}
+
+		if o.orderType == models.OrderTypeMarket {
+			if price := s.takerPrice(o); price.IsPositive() {
+				s.fill(o, o.remaining(), price, false)
+			}
+			continue
+		}
+
+		price := sellPrice
+		if o.side == models.OrderSideSell {
+			price = buyPrice
+		}
+		if price == 0 || !s.crosses(o, decimal.NewFromFloat(price)) {
+			continue
+		}
+
+		qty := o.remaining()
+		if s.cfg.VolumeLimited {
+			qty = decimal.Min(qty, budget[o.side])
+			if !qty.IsPositive() {
+				continue
+			}
+			budget[o.side] = budget[o.side].Sub(qty)
+		}
+		s.fill(o, qty, o.price, true)
+	}
+}
+
+// crosses reports whether the market at price fills the resting limit order by the fill rule.
+func (s *Exchange) crosses(o *order, price decimal.Decimal) bool {
+	cmp := price.Cmp(o.price)
+	if o.side == models.OrderSideSell {
+		cmp = -cmp
+	}
+	if s.cfg.FillRule == FillTouch {
+		return cmp <= 0
+	}
+	return cmp < 0
+}
+
+// fill executes qty of the order at price, updates the account and emits the execution event.
+// Reduce-only orders and spot sells are cut to what can be reduced, the cut is final.
+// Caller must hold the mutex.
+func (s *Exchange) fill(o *order, qty, price decimal.Decimal, maker bool) {
+	if limit, ok := s.reducible(o); ok && limit.LessThan(qty) {
+		if !limit.IsPositive() {
+			s.logger.Warn().Str("symbol", o.symbol).Str("order_id", o.id.String()).Msg("simulator: nothing to reduce, order cancelled")
+			s.cancel(o)
+			return
+		}
+		o.qty = o.filled.Add(limit)
+		qty = limit
+	}
+
+	rate := decimal.NewFromFloat(o.feeRate.Taker)
+	if maker {
+		rate = decimal.NewFromFloat(o.feeRate.Maker)
+	}
+	coins := qty.Mul(o.contractSize)
+	notional := coins.Mul(price)
+	fee := notional.Mul(rate)
+
+	var profit decimal.Decimal
+	if o.key.category == exchange.CategorySpot {
+		h := s.holding(s.coins, baseAsset(o.symbol))
+		if o.side == models.OrderSideBuy {
+			// the fee is charged in the bought coin
+			s.usdt[exchange.CategorySpot] = s.usdt[exchange.CategorySpot].Sub(notional)
+			bought := qty.Mul(decimal.NewFromInt(1).Sub(rate))
+			h.entry = h.entry.Mul(h.qty).Add(price.Mul(bought)).Div(h.qty.Add(bought))
+			h.qty = h.qty.Add(bought)
+		} else {
+			profit = price.Sub(h.entry).Mul(coins)
+			h.qty = h.qty.Sub(qty)
+			s.usdt[exchange.CategorySpot] = s.usdt[exchange.CategorySpot].Add(notional).Sub(fee)
+		}
+	} else {
+		signed := qty
+		if o.side == models.OrderSideSell {
+			signed = qty.Neg()
+		}
+		profit = s.holding(s.positions, o.symbol).trade(signed, price).Mul(o.contractSize)
+		s.usdt[exchange.CategoryLinear] = s.usdt[exchange.CategoryLinear].Add(profit).Sub(fee)
+	}
+
+	o.filled = o.filled.Add(qty)
+	o.value = o.value.Add(qty.Mul(price))
+	o.fees = o.fees.Sub(fee)
+	o.profit = o.profit.Add(profit)
+	o.status = models.OrderStatusPartiallyFilled
+	if !o.remaining().IsPositive() {
+		o.status = models.OrderStatusFilled
+		s.unrest(o)
+	}
+
+	s.stats.Fills++
+	s.stats.Fees = s.stats.Fees.Sub(fee)
+	s.stats.RealizedPnL = s.stats.RealizedPnL.Add(profit)
+
+	s.emit(o)
+}
+
+// reducible returns the most the order may fill: reduce-only orders are limited by the opposite position,
+// spot sells by the coins held. False if the order is not limited. Caller must hold the mutex.
+func (s *Exchange) reducible(o *order) (decimal.Decimal, bool) {
+	if o.key.category == exchange.CategorySpot {
+		if o.side != models.OrderSideSell {
+			return decimal.Zero, false
+		}
+		return s.holding(s.coins, baseAsset(o.symbol)).qty, true
+	}
+	if !o.reduceOnly {
+		return decimal.Zero, false
+	}
+
+	position := s.holding(s.positions, o.symbol).qty
+	if o.side == models.OrderSideBuy {
+		position = position.Neg()
+	}
+	return decimal.Max(position, decimal.Zero), true
+}
+
+func (s *Exchange) holding(holdings map[string]*holding, key string) *holding {
+	h, ok := holdings[key]
+	if !ok {
+		h = &holding{}
+		holdings[key] = h
+	}
+	return h
+}
+
+// trade adds signed qty at price to the position and returns the price PnL of the closed part per unit
+// of qty. A position flipped by the trade is entered at price.
+func (h *holding) trade(signed, price decimal.Decimal) decimal.Decimal {
+	if h.qty.IsZero() || h.qty.Sign() == signed.Sign() {
+		total := h.qty.Add(signed)
+		h.entry = h.entry.Mul(h.qty.Abs()).Add(price.Mul(signed.Abs())).Div(total.Abs())
+		h.qty = total
+		return decimal.Zero
+	}
+
+	closed := decimal.Min(h.qty.Abs(), signed.Abs())
+	pnl := price.Sub(h.entry).Mul(closed)
+	if h.qty.IsNegative() {
+		pnl = pnl.Neg()
+	}
+
+	h.qty = h.qty.Add(signed)
+	switch {
+	case h.qty.IsZero():
+		h.entry = decimal.Zero
+	case h.qty.Sign() == signed.Sign():
+		h.entry = price
+	}
+	return pnl
+}
+
+// cancel cancels the rest of the order. Caller must hold the mutex.
+func (s *Exchange) cancel(o *order) {
+	o.status = models.OrderStatusCanceled
+	s.unrest(o)
+}
+
+// unrest removes the order from the book. Caller must hold the mutex.
+func (s *Exchange) unrest(o *order) {
+	orders := s.resting[o.key]
+	for i, other := range orders {
+		if other == o {
+			s.resting[o.key] = append(orders[:i:i], orders[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Param is the search space of a detector parameter: the listed values, or the range from Min to Max
+// (a range also has its grid values by step).
+type Param struct {
+	Name   string
+	Values []float64
+	// Min and Max bound the random search of a range, both zero for a list.
+	Min float64
+	Max float64
+}
+
+// ParseParam parses a parameter space: name=v1,v2,... or name=min:max:step.
+func ParseParam(spec string) (Param, error) {
+	name, values, ok := strings.Cut(spec, "=")
+	if !ok || name == "" || values == "" {
+		return Param{}, fmt.Errorf("sweep: invalid param %q, expected name=v1,v2 or name=min:max:step", spec)
+	}
+
+	param := Param{Name: name}
+
+	if bounds := strings.Split(values, ":"); len(bounds) > 1 {
+		if len(bounds) != 3 {
+			return Param{}, fmt.Errorf("sweep: invalid range of %s %q, expected min:max:step", name, values)
+		}
+		nums, err := parseFloats(name, bounds)
+		if err != nil {
+			return Param{}, err
+		}
+		minValue, maxValue, step := nums[0], nums[1], nums[2]
+		if step <= 0 || maxValue < minValue {
+			return Param{}, fmt.Errorf("sweep: invalid range of %s %q", name, values)
+		}
+
+		param.Min, param.Max = minValue, maxValue
+		// rounded so that 0.1 steps don't accumulate float errors
+		for i := 0; ; i++ {
+			v := math.Round((minValue+float64(i)*step)*1e9) / 1e9
+			if v > maxValue+1e-9 {
+				break
+			}
+			param.Values = append(param.Values, v)
+		}
+		return param, nil
+	}
+
+	nums, err := parseFloats(name, strings.Split(values, ","))
+	if err != nil {
+		return Param{}, err
+	}
+	param.Values = nums
+	return param, nil
+}
+
+func parseFloats(name string, values []string) ([]float64, error) {
+	nums := make([]float64, 0, len(values))
+	for _, value := range values {
+		num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("sweep: invalid value of %s %q: %w", name, value, err)
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
+}
+
+// isRange tells whether the random search samples the parameter from Min to Max.
+func (p Param) isRange() bool {
+	return p.Max > p.Min
+}
+
+// Params is a set of parameter values by name.
+type Params map[string]float64
+
+// String returns the values sorted by name: "alert_step=5 target_price_change=10".
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"="+strconv.FormatFloat(p[name], 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Grid returns every combination of the parameter values, in the order of params.
+func Grid(params []Param) []Params {
+	sets := []Params{{}}
+	for _, param := range params {
+		next := make([]Params, 0, len(sets)*len(param.Values))
+		for _, set := range sets {
+			for _, value := range param.Values {
+				combined := make(Params, len(set)+1)
+				for name, v := range set {
+					combined[name] = v
+				}
+				combined[param.Name] = value
+				next = append(next, combined)
+			}
+		}
+		sets = next
+	}
+	return sets
+}
+
+// Random returns n parameter sets: ranges are sampled uniformly from Min to Max, lists pick one of the values.
+func Random(params []Param, n int, rng *rand.Rand) []Params {
+	sets := make([]Params, 0, n)
+	for i := 0; i < n; i++ {
+		set := make(Params, len(params))
+		for _, param := range params {
+			if param.isRange() {
+				set[param.Name] = math.Round((param.Min+rng.Float64()*(param.Max-param.Min))*1e6) / 1e6
+				continue
+			}
+			set[param.Name] = param.Values[rng.Intn(len(param.Values))]
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
@@ -0,0 +1,56 @@
+package sweep
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParam(t *testing.T) {
+	param, err := ParseParam("min_spread_percent=0.5:1:0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "min_spread_percent", param.Name)
+	assert.Equal(t, []float64{0.5, 0.6, 0.7, 0.8, 0.9, 1}, param.Values)
+	assert.Equal(t, 0.5, param.Min)
+	assert.Equal(t, 1.0, param.Max)
+
+	param, err = ParseParam("alert_step=3, 5,10")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{3, 5, 10}, param.Values)
+	assert.False(t, param.isRange())
+
+	for _, spec := range []string{"alert_step", "=1", "alert_step=", "alert_step=a", "alert_step=1:2", "alert_step=2:1:1", "alert_step=1:2:0"} {
+		_, err := ParseParam(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	sets := Grid([]Param{
+		{Name: "a", Values: []float64{1, 2}},
+		{Name: "b", Values: []float64{10, 20, 30}},
+	})
+
+	require.Len(t, sets, 6)
+	assert.Equal(t, "a=1 b=10", sets[0].String())
+	assert.Equal(t, "a=2 b=30", sets[5].String())
+}
+
+func TestRandom(t *testing.T) {
+	params := []Param{
+		{Name: "a", Values: []float64{1, 2}, Min: 1, Max: 2},
+		{Name: "b", Values: []float64{10, 20}},
+	}
+
+	sets := Random(params, 50, rand.New(rand.NewSource(1)))
+	require.Len(t, sets, 50)
+	for _, set := range sets {
+		assert.GreaterOrEqual(t, set["a"], 1.0)
+		assert.LessOrEqual(t, set["a"], 2.0)
+		assert.Contains(t, []float64{10, 20}, set["b"])
+	}
+	// the same seed - the same sets
+	assert.Equal(t, sets, Random(params, 50, rand.New(rand.NewSource(1))))
+}
@@ -0,0 +1,122 @@
+package sweep
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucrumx/bot/internal/exchange/replay"
+)
+
+// Result is the outcome of a parameter set. PnL is in percent of the notional of a position (of one leg for
+// arbitrage), net of taker fees.
+type Result struct {
+	Params  Params
+	Signals int
+	// Evaluated are the signals closed by the detector (arbitrage) or held for the horizon.
+	Evaluated int
+	// Hits are the evaluated signals with positive PnL.
+	Hits int
+	// Open are the signals not evaluated by the end of the dataset, they are not in the PnL.
+	Open       int
+	PnLPercent float64
+}
+
+func (r *Result) add(pnlPercent float64) {
+	r.Evaluated++
+	if pnlPercent > 0 {
+		r.Hits++
+	}
+	r.PnLPercent += pnlPercent
+}
+
+// HitRate is the share of the evaluated signals with positive PnL.
+func (r Result) HitRate() float64 {
+	if r.Evaluated == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(r.Evaluated)
+}
+
+// AvgPnLPercent is the PnL per evaluated signal.
+func (r Result) AvgPnLPercent() float64 {
+	if r.Evaluated == 0 {
+		return 0
+	}
+	return r.PnLPercent / float64(r.Evaluated)
+}
+
+// Report is the ranked outcome of a sweep.
+type Report struct {
+	Detector Detector
+	Replay   replay.Stats
+	Params   []Param
+	// Results are ranked by PnL, then by hit rate.
+	Results []Result
+}
+
+func (r *Report) rank() {
+	sort.SliceStable(r.Results, func(i, j int) bool {
+		a, b := r.Results[i], r.Results[j]
+		if a.PnLPercent != b.PnLPercent {
+			return a.PnLPercent > b.PnLPercent
+		}
+		if a.HitRate() != b.HitRate() {
+			return a.HitRate() > b.HitRate()
+		}
+		return a.Params.String() < b.Params.String()
+	})
+}
+
+// Write prints the summary and the top ranked results, all of them if top is zero.
+func (r Report) Write(w io.Writer, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"#"}
+	for _, param := range r.Params {
+		header = append(header, strings.ToUpper(param.Name))
+	}
+	header = append(header, "SIGNALS", "EVALUATED", "HIT RATE", "OPEN", "PNL %", "AVG PNL %")
+
+	lines := []string{
+		fmt.Sprintf("Detector\t%s", r.Detector),
+		fmt.Sprintf("Period\t%s - %s", time.UnixMilli(r.Replay.FromMs).UTC().Format(time.RFC3339), time.UnixMilli(r.Replay.ToMs).UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Trades\t%d", r.Replay.Trades),
+		fmt.Sprintf("Parameter sets\t%d", len(r.Results)),
+		"",
+		strings.Join(header, "\t"),
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return err
+		}
+	}
+
+	for i, res := range r.Results {
+		if top > 0 && i == top {
+			break
+		}
+
+		row := []string{strconv.Itoa(i + 1)}
+		for _, param := range r.Params {
+			row = append(row, strconv.FormatFloat(res.Params[param.Name], 'f', -1, 64))
+		}
+		row = append(row,
+			strconv.Itoa(res.Signals),
+			strconv.Itoa(res.Evaluated),
+			fmt.Sprintf("%.1f%%", res.HitRate()*100),
+			strconv.Itoa(res.Open),
+			fmt.Sprintf("%.4f", res.PnLPercent),
+			fmt.Sprintf("%.4f", res.AvgPnLPercent()),
+		)
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
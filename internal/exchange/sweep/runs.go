@@ -0,0 +1,272 @@
+package sweep
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/arbitragebot"
+	"github.com/lucrumx/bot/internal/exchange/manipulationbot"
+	"github.com/lucrumx/bot/internal/exchange/pumpbot"
+	"github.com/lucrumx/bot/internal/models"
+)
+
+// Detector is a detector the sweep tunes.
+type Detector string
+
+const (
+	// DetectorArbitrage is the spread detector of the arbitrage bot: a signal opens a spread, it is
+	// evaluated when the detector closes it.
+	DetectorArbitrage Detector = "arbitrage"
+	// DetectorPump is the pump detector: a signal is evaluated as a long held for the horizon.
+	DetectorPump Detector = "pump"
+	// DetectorManipulation is the spot-vs-perp ATR detector: a signal is evaluated as a perp long held
+	// for the horizon.
+	DetectorManipulation Detector = "manipulation"
+)
+
+// run is one parameter set of the sweep. Trades are passed in timestamp order with the clock of the run
+// already moved to them.
+type run interface {
+	process(exchangeName string, trade exchange.Trade)
+	result() Result
+}
+
+// setters apply the parameters of a detector to its configuration, by parameter name.
+var (
+	arbitrageParams = map[string]func(cfg *config.ArbitrageBotConfig, v float64){
+		"min_spread_percent":       func(cfg *config.ArbitrageBotConfig, v float64) { cfg.MinSpreadPercent = v },
+		"percent_for_close_spread": func(cfg *config.ArbitrageBotConfig, v float64) { cfg.PercentForCloseSpread = v },
+		"max_age_ms":               func(cfg *config.ArbitrageBotConfig, v float64) { cfg.MaxAgeMs = int64(v) },
+		"slippage_percent":         func(cfg *config.ArbitrageBotConfig, v float64) { cfg.SlippagePercent = v },
+	}
+
+	pumpParams = map[string]func(cfg *config.BotConfig, v float64){
+		"target_price_change": func(cfg *config.BotConfig, v float64) { cfg.TargetPriceChange = v },
+		"alert_step":          func(cfg *config.BotConfig, v float64) { cfg.AlertStep = v },
+		"pump_interval":       func(cfg *config.BotConfig, v float64) { cfg.PumpInterval = int(v) },
+		"check_interval":      func(cfg *config.BotConfig, v float64) { cfg.CheckInterval = seconds(v) },
+		"startup_delay":       func(cfg *config.BotConfig, v float64) { cfg.StartupDelay = seconds(v) },
+	}
+
+	manipulationParams = map[string]func(cfg *manipulationbot.Config, v float64){
+		"min_atr_ratio":    func(cfg *manipulationbot.Config, v float64) { cfg.MinATRRatio = v },
+		"min_spot_atr_pct": func(cfg *manipulationbot.Config, v float64) { cfg.MinSpotATRPct = v },
+		"window_size":      func(cfg *manipulationbot.Config, v float64) { cfg.WindowSize = seconds(v) },
+		"check_interval":   func(cfg *manipulationbot.Config, v float64) { cfg.CheckInterval = seconds(v) },
+		"startup_delay":    func(cfg *manipulationbot.Config, v float64) { cfg.StartupDelay = seconds(v) },
+		"alert_cooldown":   func(cfg *manipulationbot.Config, v float64) { cfg.AlertCooldown = seconds(v) },
+	}
+)
+
+// seconds converts a duration parameter, given in seconds.
+func seconds(v float64) time.Duration {
+	return time.Duration(v * float64(time.Second))
+}
+
+// applyParams applies the parameters with the setters, an unknown parameter is an error.
+func applyParams[C any](detector Detector, setters map[string]func(*C, float64), cfg *C, params Params) error {
+	for name, value := range params {
+		set, ok := setters[name]
+		if !ok {
+			return fmt.Errorf("sweep: unknown %s param %q", detector, name)
+		}
+		set(cfg, value)
+	}
+	return nil
+}
+
+// newRun creates the run of the parameter set over the detector settings of base.
+func newRun(base *config.Config, cfg Config, params Params, c clock.Clock) (run, error) {
+	feePercent := cfg.TakerFee * 100
+
+	switch cfg.Detector {
+	case DetectorArbitrage:
+		botCfg := *base
+		if err := applyParams(cfg.Detector, arbitrageParams, &botCfg.Exchange.ArbitrageBot, params); err != nil {
+			return nil, err
+		}
+		return newArbitrageRun(&botCfg, params, cfg.TakerFee, c), nil
+
+	case DetectorPump:
+		botCfg := base.Exchange.Bot
+		if err := applyParams(cfg.Detector, pumpParams, &botCfg, params); err != nil {
+			return nil, err
+		}
+		return newForwardRun(params, cfg.Horizon, feePercent, func() signalSource {
+			detector := pumpbot.NewDetector(botCfg, c)
+			return func(trade exchange.Trade) bool {
+				if trade.Category != exchange.CategoryLinear {
+					return false
+				}
+				_, ok := detector.Process(trade)
+				return ok
+			}
+		}), nil
+
+	case DetectorManipulation:
+		detectorCfg := manipulationbot.ConfigFromYAML(base.Exchange.ManipulationBot)
+		if err := applyParams(cfg.Detector, manipulationParams, &detectorCfg, params); err != nil {
+			return nil, err
+		}
+		return newForwardRun(params, cfg.Horizon, feePercent, func() signalSource {
+			detector := manipulationbot.NewSignalDetector(detectorCfg, c)
+			return func(trade exchange.Trade) bool {
+				_, ok := detector.Process(trade)
+				return ok
+			}
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("sweep: unknown detector %q", cfg.Detector)
+	}
+}
+
+// arbitrageRun feeds the last trade prices of every venue to the spread detector. An opened spread is bought
+// and sold at the prices of the signal and closed at the last prices of the venues when the detector closes it.
+type arbitrageRun struct {
+	detector *arbitragebot.SpreadDetector
+	fees     arbitragebot.FeeSchedules
+	takerFee float64
+
+	prices map[string]map[string]arbitragebot.PricePoint // symbol -> venue -> last trade
+	open   map[string]*arbitragebot.SpreadEvent          // opening events by symbol#buy#sell
+
+	res Result
+}
+
+func newArbitrageRun(cfg *config.Config, params Params, takerFee float64, c clock.Clock) *arbitrageRun {
+	r := &arbitrageRun{
+		detector: arbitragebot.NewSpreadDetector(cfg),
+		fees:     make(arbitragebot.FeeSchedules),
+		takerFee: takerFee,
+		prices:   make(map[string]map[string]arbitragebot.PricePoint),
+		open:     make(map[string]*arbitragebot.SpreadEvent),
+		res:      Result{Params: params},
+	}
+	r.detector.SetClock(c)
+	r.detector.SetFees(r.fees)
+	return r
+}
+
+func (r *arbitrageRun) process(exchangeName string, trade exchange.Trade) {
+	// the detector reads the map, new exchanges are added as they appear
+	if _, ok := r.fees[exchangeName]; !ok {
+		r.fees[exchangeName] = exchange.FeeSchedule{Default: exchange.FeeRate{Taker: r.takerFee}}
+	}
+
+	venue := arbitragebot.Venue{Exchange: exchangeName, Category: trade.Category}.Name()
+	prices, ok := r.prices[trade.Symbol]
+	if !ok {
+		prices = make(map[string]arbitragebot.PricePoint)
+		r.prices[trade.Symbol] = prices
+	}
+	prices[venue] = arbitragebot.PricePoint{Price: trade.Price, TsMs: trade.Ts}
+
+	for _, event := range r.detector.Detect(trade.Symbol, prices) {
+		key := event.Symbol + "#" + event.BuyOnExchange + "#" + event.SellOnExchange
+
+		switch event.Status {
+		case models.ArbitrageSpreadOpened:
+			r.res.Signals++
+			r.open[key] = event
+		case models.ArbitrageSpreadClosed:
+			opened, ok := r.open[key]
+			if !ok {
+				continue
+			}
+			delete(r.open, key)
+
+			// the long is sold on the buy venue, the short is bought back on the sell venue
+			pnl := (prices[event.BuyOnExchange].Price-opened.BuyPrice)/opened.BuyPrice*100 +
+				(opened.SellPrice-prices[event.SellOnExchange].Price)/opened.SellPrice*100 -
+				4*r.takerFee*100
+			r.res.add(pnl)
+		}
+	}
+}
+
+func (r *arbitrageRun) result() Result {
+	res := r.res
+	res.Open = len(r.open)
+	return res
+}
+
+// signalSource tells whether the trade is a signal of the detector.
+type signalSource func(trade exchange.Trade) bool
+
+// pendingSignal is a signal waiting for its horizon.
+type pendingSignal struct {
+	price float64
+	dueMs int64
+}
+
+// forwardRun evaluates the signals of an alerting detector as longs entered at the price of the signalling
+// trade and exited at the first trade of the symbol after the horizon. There is a detector per exchange,
+// the exit is priced by the perp trades of the exchange.
+type forwardRun struct {
+	horizonMs  int64
+	feePercent float64
+	newSource  func() signalSource
+
+	sources map[string]signalSource    // by exchange
+	pending map[string][]pendingSignal // by exchange#symbol, in due order
+	last    map[string]float64         // last perp price by exchange#symbol
+
+	res Result
+}
+
+func newForwardRun(params Params, horizon time.Duration, feePercent float64, newSource func() signalSource) *forwardRun {
+	return &forwardRun{
+		horizonMs:  horizon.Milliseconds(),
+		feePercent: feePercent,
+		newSource:  newSource,
+		sources:    make(map[string]signalSource),
+		pending:    make(map[string][]pendingSignal),
+		last:       make(map[string]float64),
+		res:        Result{Params: params},
+	}
+}
+
+func (r *forwardRun) process(exchangeName string, trade exchange.Trade) {
+	key := exchangeName + "#" + trade.Symbol
+
+	if trade.Category == exchange.CategoryLinear {
+		r.last[key] = trade.Price
+
+		pending := r.pending[key]
+		for len(pending) > 0 && pending[0].dueMs <= trade.Ts {
+			// entry and exit are taker orders
+			r.res.add((trade.Price-pending[0].price)/pending[0].price*100 - 2*r.feePercent)
+			pending = pending[1:]
+		}
+		r.pending[key] = pending
+	}
+
+	source, ok := r.sources[exchangeName]
+	if !ok {
+		source = r.newSource()
+		r.sources[exchangeName] = source
+	}
+	if !source(trade) {
+		return
+	}
+
+	r.res.Signals++
+	entry, ok := r.last[key]
+	if !ok {
+		// no perp price to enter at
+		return
+	}
+	r.pending[key] = append(r.pending[key], pendingSignal{price: entry, dueMs: trade.Ts + r.horizonMs})
+}
+
+func (r *forwardRun) result() Result {
+	res := r.res
+	for _, pending := range r.pending {
+		res.Open += len(pending)
+	}
+	return res
+}
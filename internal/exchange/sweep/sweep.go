@@ -0,0 +1,167 @@
+// Package sweep tunes the thresholds of the detectors over a dataset of the recorder: the detectors run
+// over the recorded trades for every set of a parameter grid or random search, the signals are evaluated
+// by simulated PnL and the sets are ranked by it.
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+)
+
+// batchSize is the number of trades passed to the workers at once.
+const batchSize = 1024
+
+// Config is the configuration of a sweep.
+type Config struct {
+	Detector Detector
+	// Replay is the dataset and its range, the speed is not used: trades are processed as fast as they are read.
+	Replay replay.Config
+	// Exchanges are the recorded exchanges to run over, all of them if empty.
+	Exchanges []string
+	// Params are the search spaces, the parameters not listed keep the values of the config file.
+	Params []Param
+	// Random is the number of random parameter sets, zero - the full grid.
+	Random int
+	Seed   int64
+	// Workers is the number of parallel workers, the number of CPUs by default.
+	Workers int
+	// Horizon is the holding time of the pump and manipulation signals.
+	Horizon time.Duration
+	// TakerFee is the taker fee rate paid on every entry and exit.
+	TakerFee float64
+}
+
+// Sets returns the parameter sets of the sweep.
+func (c Config) Sets() []Params {
+	if c.Random > 0 {
+		return Random(c.Params, c.Random, rand.New(rand.NewSource(c.Seed)))
+	}
+	return Grid(c.Params)
+}
+
+// scanned is a trade of the dataset.
+type scanned struct {
+	exchangeName string
+	trade        exchange.Trade
+}
+
+// worker runs its share of the parameter sets over every trade, with its own clock.
+type worker struct {
+	clock *clock.Manual
+	runs  []run
+	in    chan []scanned
+}
+
+func (w *worker) start(wg *sync.WaitGroup) {
+	defer wg.Done()
+	for batch := range w.in {
+		for _, s := range batch {
+			w.clock.Set(time.UnixMilli(s.trade.Ts))
+			for _, r := range w.runs {
+				r.process(s.exchangeName, s.trade)
+			}
+		}
+	}
+}
+
+// Run runs the sweep over the detector settings of base. The dataset is read once, its trades are passed
+// to all workers; a worker runs its parameter sets one after another on every trade.
+func Run(ctx context.Context, base *config.Config, cfg Config, logger zerolog.Logger) (*Report, error) {
+	if cfg.Detector != DetectorArbitrage && cfg.Horizon <= 0 {
+		return nil, fmt.Errorf("sweep: horizon must be positive, got %s", cfg.Horizon)
+	}
+
+	sets := cfg.Sets()
+	if len(sets) == 0 {
+		return nil, errors.New("sweep: no parameter sets")
+	}
+
+	numWorkers := cfg.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	numWorkers = min(numWorkers, len(sets))
+
+	workers := make([]*worker, numWorkers)
+	for i := range workers {
+		workers[i] = &worker{
+			clock: clock.NewManual(time.UnixMilli(0)),
+			in:    make(chan []scanned, 4),
+		}
+	}
+	for i, params := range sets {
+		w := workers[i%numWorkers]
+		r, err := newRun(base, cfg, params, w.clock)
+		if err != nil {
+			return nil, err
+		}
+		w.runs = append(w.runs, r)
+	}
+
+	logger.Info().
+		Str("detector", string(cfg.Detector)).
+		Int("sets", len(sets)).
+		Int("workers", numWorkers).
+		Msg("sweep: running")
+
+	started := time.Now()
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go w.start(&wg)
+	}
+
+	batch := make([]scanned, 0, batchSize)
+	flush := func() {
+		// the workers only read the batch
+		for _, w := range workers {
+			w.in <- batch
+		}
+		batch = make([]scanned, 0, batchSize)
+	}
+
+	stats, err := replay.Scan(ctx, cfg.Replay, cfg.Exchanges, func(exchangeName string, trade exchange.Trade) error {
+		batch = append(batch, scanned{exchangeName: exchangeName, trade: trade})
+		if len(batch) == batchSize {
+			flush()
+		}
+		return nil
+	})
+	if err == nil && len(batch) > 0 {
+		flush()
+	}
+	for _, w := range workers {
+		close(w.in)
+	}
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Detector: cfg.Detector, Replay: stats, Params: cfg.Params}
+	for _, w := range workers {
+		for _, r := range w.runs {
+			report.Results = append(report.Results, r.result())
+		}
+	}
+	report.rank()
+
+	logger.Info().
+		Int64("trades", stats.Trades).
+		Dur("took", time.Since(started)).
+		Msg("sweep: finished")
+
+	return report, nil
+}
@@ -0,0 +1,143 @@
+package sweep
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/clock"
+	"github.com/lucrumx/bot/internal/config"
+	"github.com/lucrumx/bot/internal/exchange"
+	"github.com/lucrumx/bot/internal/exchange/recorder"
+	"github.com/lucrumx/bot/internal/exchange/replay"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// writeDataset writes the prices, one trade every 10 seconds, as a recorded dataset of the exchange.
+func writeDataset(t *testing.T, dir, exchangeName string, prices ...float64) {
+	t.Helper()
+
+	rel := filepath.Join(exchangeName, string(exchange.CategoryLinear), start.Format("2006-01-02"), "12.jsonl.gz")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(rel)), 0o755))
+
+	f, err := os.Create(filepath.Join(dir, rel))
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for i, price := range prices {
+		ts := start.Add(time.Duration(i) * 10 * time.Second).UnixMilli()
+		require.NoError(t, enc.Encode(recorder.Record{Ts: ts, RecvTs: ts, Symbol: "BTCUSDT", Price: price, Volume: 1, Side: exchange.Buy}))
+	}
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	entry := recorder.IndexEntry{
+		Path:     rel,
+		Exchange: exchangeName,
+		Category: exchange.CategoryLinear,
+		Symbols:  []string{"BTCUSDT"},
+		FromMs:   start.UnixMilli(),
+		ToMs:     start.Add(time.Duration(len(prices)-1) * 10 * time.Second).UnixMilli(),
+		Count:    int64(len(prices)),
+	}
+	line, err := json.Marshal(entry)
+	require.NoError(t, err)
+	index, err := os.OpenFile(filepath.Join(dir, recorder.IndexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = index.Write(append(line, '\n'))
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+}
+
+func TestRun_Arbitrage(t *testing.T) {
+	dir := t.TempDir()
+	// BingX is 3% above ByBit for 30 seconds, then the prices converge
+	writeDataset(t, dir, "ByBit", 100, 100, 100, 100, 100, 100)
+	writeDataset(t, dir, "BingX", 100, 103, 103, 103, 100, 100)
+
+	base := &config.Config{}
+	base.Exchange.ArbitrageBot.MaxAgeMs = 60_000
+	base.Exchange.ArbitrageBot.PercentForCloseSpread = 0.5
+
+	minSpread, err := ParseParam("min_spread_percent=1,2,5")
+	require.NoError(t, err)
+
+	report, err := Run(context.Background(), base, Config{
+		Detector: DetectorArbitrage,
+		Replay:   replay.Config{Dir: dir},
+		Params:   []Param{minSpread},
+		Workers:  2,
+		TakerFee: 0.0005,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), report.Replay.Trades)
+	require.Len(t, report.Results, 3)
+
+	// 3% on the short leg less 4 taker fees of 0.05%; the same for 1% and 2%, ranked by params
+	for i, minSpread := range []float64{1, 2} {
+		res := report.Results[i]
+		assert.Equal(t, minSpread, res.Params["min_spread_percent"])
+		assert.Equal(t, 1, res.Signals)
+		assert.Equal(t, 1, res.Hits)
+		assert.InDelta(t, 3.0/103*100-0.2, res.PnLPercent, 1e-9)
+		assert.InDelta(t, 1.0, res.HitRate(), 1e-9)
+	}
+	assert.Equal(t, 5.0, report.Results[2].Params["min_spread_percent"])
+	assert.Zero(t, report.Results[2].Signals)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out, 2))
+	assert.Contains(t, out.String(), "MIN_SPREAD_PERCENT")
+	assert.Contains(t, out.String(), "2.7126")
+	assert.NotContains(t, out.String(), "\n3 ")
+}
+
+func TestRun_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeDataset(t, dir, "ByBit", 100, 100)
+
+	_, err := Run(context.Background(), &config.Config{}, Config{
+		Detector: DetectorArbitrage,
+		Replay:   replay.Config{Dir: dir},
+		Params:   []Param{{Name: "target_price_change", Values: []float64{10}}},
+	}, zerolog.Nop())
+	assert.ErrorContains(t, err, "unknown arbitrage param")
+
+	_, err = Run(context.Background(), &config.Config{}, Config{Detector: DetectorPump, Replay: replay.Config{Dir: dir}}, zerolog.Nop())
+	assert.ErrorContains(t, err, "horizon")
+}
+
+func TestForwardRun_Pump(t *testing.T) {
+	replayed := clock.NewManual(start)
+
+	base := &config.Config{}
+	base.Exchange.Bot = config.BotConfig{PumpInterval: 60, TargetPriceChange: 5, AlertStep: 100, CheckInterval: time.Second}
+
+	r, err := newRun(base, Config{Detector: DetectorPump, Horizon: time.Minute, TakerFee: 0.001}, Params{"target_price_change": 5}, replayed)
+	require.NoError(t, err)
+
+	// +1% every 10 seconds: the pump is detected above +5% at 106, a minute later the price is 112
+	for i := 0; i <= 12; i++ {
+		ts := start.Add(time.Duration(i) * 10 * time.Second)
+		replayed.Set(ts)
+		r.process("ByBit", exchange.Trade{Symbol: "PUMPUSDT", Category: exchange.CategoryLinear, Ts: ts.UnixMilli(), Price: 100 + float64(i)})
+	}
+
+	res := r.result()
+	assert.Equal(t, 1, res.Signals)
+	assert.Equal(t, 1, res.Evaluated)
+	assert.Equal(t, 1, res.Hits)
+	assert.Zero(t, res.Open)
+	assert.InDelta(t, 6.0/106*100-0.2, res.PnLPercent, 1e-9)
+}
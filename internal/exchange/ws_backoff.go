@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// WsReconnectMinDelay is the delay before the first reconnect attempt of a websocket client.
+	WsReconnectMinDelay = time.Second
+	// WsReconnectMaxDelay caps the delay between websocket reconnect attempts.
+	WsReconnectMaxDelay = 30 * time.Second
+)
+
+// Backoff computes exponentially growing delays between websocket reconnect attempts.
+type Backoff struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+	attempt  int
+}
+
+// NewBackoff creates a new Backoff (constructor).
+func NewBackoff(minDelay, maxDelay time.Duration) *Backoff {
+	return &Backoff{
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+	}
+}
+
+// Next returns the delay for the next attempt and advances the attempt counter.
+func (b *Backoff) Next() time.Duration {
+	delay := b.minDelay << b.attempt
+	if delay <= 0 || delay > b.maxDelay {
+		delay = b.maxDelay
+	} else {
+		b.attempt++
+	}
+
+	return delay
+}
+
+// Reset starts the delay sequence from the beginning, should be called after a successful reconnect.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
+
+// Wait sleeps for the next delay. Returns false if ctx was canceled while waiting.
+func (b *Backoff) Wait(ctx context.Context) bool {
+	timer := time.NewTimer(b.Next())
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,31 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Next(t *testing.T) {
+	b := NewBackoff(time.Second, 5*time.Second)
+
+	assert.Equal(t, time.Second, b.Next())
+	assert.Equal(t, 2*time.Second, b.Next())
+	assert.Equal(t, 4*time.Second, b.Next())
+	assert.Equal(t, 5*time.Second, b.Next())
+	assert.Equal(t, 5*time.Second, b.Next())
+
+	b.Reset()
+	assert.Equal(t, time.Second, b.Next())
+}
+
+func TestBackoff_WaitCanceled(t *testing.T) {
+	b := NewBackoff(time.Minute, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.False(t, b.Wait(ctx))
+}
@@ -0,0 +1,50 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+// StreamWsClient defines an interface for starting a websocket client to stream market data (book tickers, order books)
+// for specified symbols into an output channel.
+type StreamWsClient[T any] interface {
+	Start(ctx context.Context, symbols []string, outChan chan<- T) error
+}
+
+// StreamWSClientFactory defines a function type that creates a StreamWsClient instance based on configuration.
+type StreamWSClientFactory[T any] func(cfg *config.Config) StreamWsClient[T]
+
+// StreamManager splits market data subscriptions into chunks of symbols, one websocket client per chunk,
+// the same way WSManager does for trades.
+type StreamManager[T any] struct {
+	cfg     *config.Config
+	factory StreamWSClientFactory[T]
+}
+
+// NewStreamManager creates a new StreamManager (constructor).
+func NewStreamManager[T any](cfg *config.Config, factory StreamWSClientFactory[T]) *StreamManager[T] {
+	return &StreamManager[T]{
+		cfg:     cfg,
+		factory: factory,
+	}
+}
+
+// Subscribe starts websocket clients for the given symbols and streams their messages to the returned channel.
+func (m *StreamManager[T]) Subscribe(ctx context.Context, symbols []string) (<-chan T, error) {
+	outChan := make(chan T, m.cfg.Exchange.WsClient.BufferSize)
+
+	for _, chunk := range chunkSymbols(symbols) {
+		if err := m.factory(m.cfg).Start(ctx, chunk, outChan); err != nil {
+			return nil, fmt.Errorf("failed to start ws client: %w", err)
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		close(outChan)
+	}()
+
+	return outChan, nil
+}
@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/config"
+)
+
+type mockBookWsClient struct {
+	started *int
+}
+
+func (m *mockBookWsClient) Start(_ context.Context, symbols []string, outChan chan<- BookTop) error {
+	*m.started++
+	for _, s := range symbols {
+		outChan <- BookTop{Symbol: s, BidPrice: 100, AskPrice: 100.1, Ts: time.Now().UnixMilli()}
+	}
+	return nil
+}
+
+func TestStreamManager_Subscribe(t *testing.T) {
+	cfg := config.Config{
+		Exchange: config.ExchangeConfig{
+			WsClient: config.WsClientConfig{
+				BufferSize: 5000,
+			},
+		},
+	}
+
+	started := 0
+	manager := NewStreamManager(&cfg, func(_ *config.Config) StreamWsClient[BookTop] {
+		return &mockBookWsClient{started: &started}
+	})
+
+	symbols := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		symbols = append(symbols, fmt.Sprintf("S%dUSDT", i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bookCh, err := manager.Subscribe(ctx, symbols)
+	require.NoError(t, err)
+
+	cancel()
+
+	cnt := 0
+	for range bookCh {
+		cnt++
+	}
+
+	assert.Equal(t, 3, started)
+	assert.Equal(t, len(symbols), cnt)
+}
@@ -41,10 +41,22 @@ type ArbitrageSpread struct {
 	MaxSpreadPercent decimal.Decimal       `gorm:"type:decimal(10,4);not null"`
 	Status           ArbitrageSpreadStatus `gorm:"type:varchar(20);not null"`
 
+	// Net spread: gross spread minus taker fees of both legs for open and close and slippage
+	NetSpreadPercent    decimal.Decimal `gorm:"type:decimal(10,4);not null;default:0"`
+	MaxNetSpreadPercent decimal.Decimal `gorm:"type:decimal(10,4);not null;default:0"`
+
 	Profit *decimal.Decimal `gorm:"type:decimal(28,12);null"`
+	// FundingProfit is funding received (positive) or paid (negative) by both legs while the position was held.
+	// Included in Profit.
+	FundingProfit *decimal.Decimal `gorm:"type:decimal(28,12);null"`
 
 	OpenBuyOrderID   uuid.UUID `gorm:"type:uuid;"`
 	OpenSellOrderID  uuid.UUID `gorm:"type:uuid;"`
 	CloseBuyOrderID  uuid.UUID `gorm:"type:uuid;"`
 	CloseSellOrderID uuid.UUID `gorm:"type:uuid;"`
+	// RebalanceOrderID is the order evening out the open legs, its PnL and fees are included in Profit.
+	RebalanceOrderID uuid.UUID `gorm:"type:uuid;"`
+
+	// Paper spreads are traded on simulated exchanges.
+	Paper bool `gorm:"type:boolean;not null;default:false"`
 }
@@ -39,25 +39,48 @@ const (
 	ArbitrageTradeStatusRecoveryRequired ArbitrageTradeStatus = "RECOVERY_REQUIRED"
 )
 
-// ArbitrageTrade арбитражная сделка
+// ArbitrageTrade арбитражная сделка, состояние Position движка, переживает рестарт бота
 type ArbitrageTrade struct {
 	ID                 uuid.UUID            `gorm:"type:uuid;primary_key;default:uuidv7()"`
 	SpreadKey          string               `gorm:"type:text;not null;"` // symbol + buyExchange + sellExchange
 	Symbol             string               `gorm:"type:text;not null;"`
 	BuyExchange        string               `gorm:"type:text;not null;"`
 	SellExchange       string               `gorm:"type:text;not null;"`
-	Status             ArbitrageTradeStatus `gorm:"type:text;not null;"`
+	Status             ArbitrageTradeStatus `gorm:"type:text;not null;index:idx_arbitrage_trade_status"`
 	OpenSignalAt       time.Time            `gorm:"type:timestamptz;"`
 	CloseSignalAt      time.Time            `gorm:"type:timestamptz;"`
 	OpenedAt           time.Time            `gorm:"type:timestamptz;"`
 	ClosedAt           time.Time            `gorm:"type:timestamptz;"`
 	TargetMarginUSDT   decimal.Decimal      `gorm:"type:decimal(28,12);"` // свои
 	TargetNotionalUSDT decimal.Decimal      `gorm:"type:decimal(28,12);"` // с учетом плеча
+	QtyCoins           decimal.Decimal      `gorm:"type:decimal(28,12);"` // объем в монетах, по нему закрываются ноги
 	OpenBuyOrderID     uuid.UUID            `gorm:"type:uuid;"`
-	OpenSellOrderID    string               `gorm:"type:uuid;"`
-	CloseBuyOrderID    string               `gorm:"type:uuid;"`
-	CloseSellOrderID   string               `gorm:"type:uuid"`
-	LastError          string               `gorm:"type:text;"`
-	CreatedAt          time.Time            `gorm:"type:timestamptz;default:now()"`
-	UpdatedAt          time.Time            `gorm:"type:timestamptz;"`
+	OpenSellOrderID    uuid.UUID            `gorm:"type:uuid;"`
+	CloseBuyOrderID    uuid.UUID            `gorm:"type:uuid;"`
+	CloseSellOrderID   uuid.UUID            `gorm:"type:uuid"`
+
+	// id заявок на бирже, нужны для отмены (MEXC)
+	OpenBuyExchangeOrderID  string `gorm:"type:text;"`
+	OpenSellExchangeOrderID string `gorm:"type:text;"`
+
+	// исполнение ног, объем может быть частичным
+	OpenBuyFilledQty   decimal.Decimal `gorm:"type:decimal(28,12);"`
+	OpenBuyAvgPrice    decimal.Decimal `gorm:"type:decimal(28,12);"`
+	OpenSellFilledQty  decimal.Decimal `gorm:"type:decimal(28,12);"`
+	OpenSellAvgPrice   decimal.Decimal `gorm:"type:decimal(28,12);"`
+	CloseBuyFilledQty  decimal.Decimal `gorm:"type:decimal(28,12);"`
+	CloseBuyAvgPrice   decimal.Decimal `gorm:"type:decimal(28,12);"`
+	CloseSellFilledQty decimal.Decimal `gorm:"type:decimal(28,12);"`
+	CloseSellAvgPrice  decimal.Decimal `gorm:"type:decimal(28,12);"`
+
+	// нога подтверждена - заявка исполнена полностью (остаток нулевой)
+	OpenBuyConfirmed   bool `gorm:"type:boolean;not null;default:false"`
+	OpenSellConfirmed  bool `gorm:"type:boolean;not null;default:false"`
+	CloseBuyConfirmed  bool `gorm:"type:boolean;not null;default:false"`
+	CloseSellConfirmed bool `gorm:"type:boolean;not null;default:false"`
+
+	LastError string    `gorm:"type:text;"`
+	Paper     bool      `gorm:"type:boolean;not null;default:false"` // бумажная сделка, ноги исполнены симулятором
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlacklistEntry bans opening arbitrage positions on a symbol. An empty ExchangeName bans the symbol on all
+// exchanges, otherwise only pairs with the exchange are banned. A nil ExpiresAt never expires.
+type BlacklistEntry struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuidv7();primaryKey"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:now()"`
+	ExpiresAt *time.Time `gorm:"type:timestamptz;index:idx_blacklist_entry_expires_at"`
+
+	Symbol       string `gorm:"type:text;not null;index:idx_blacklist_entry_symbol"`
+	ExchangeName string `gorm:"type:text;not null;default:''"`
+	Reason       string `gorm:"type:text;not null"`
+}
+
+// IsActive returns true if the entry has not expired at now.
+func (e *BlacklistEntry) IsActive(now time.Time) bool {
+	return e.ExpiresAt == nil || now.Before(*e.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FundingRate is a snapshot of the funding rate of a perpetual contract on an exchange.
+type FundingRate struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuidv7();primaryKey"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now();index:idx_funding_rate_exchange_symbol_created_at"`
+
+	ExchangeName    string          `gorm:"type:text;not null;index:idx_funding_rate_exchange_symbol_created_at"`
+	Symbol          string          `gorm:"type:text;not null;index:idx_funding_rate_exchange_symbol_created_at"`
+	Rate            decimal.Decimal `gorm:"type:decimal(18,10);not null"`
+	IntervalHours   int64           `gorm:"not null"`
+	NextFundingTime *time.Time      `gorm:"type:timestamptz;"`
+}
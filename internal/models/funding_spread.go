@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FundingSpread is a funding-arbitrage signal: long on the exchange with the lower funding rate,
+// short on the exchange with the higher one.
+type FundingSpread struct {
+	ID uuid.UUID `gorm:"type:uuid;default:uuidv7();primaryKey"`
+
+	CreatedAt time.Time  `gorm:"type:timestamptz;default:now();index:idx_funding_spread_created_at"`
+	ClosedAt  *time.Time `gorm:"type:timestamptz;"`
+	UpdatedAt time.Time
+
+	Symbol        string `gorm:"type:text;not null;index:idx_funding_spread_symbol_exchanges"`
+	LongExchange  string `gorm:"type:text;not null;index:idx_funding_spread_symbol_exchanges"`
+	ShortExchange string `gorm:"type:text;not null;index:idx_funding_spread_symbol_exchanges"`
+
+	LongRate  decimal.Decimal `gorm:"type:decimal(18,10);not null"`
+	ShortRate decimal.Decimal `gorm:"type:decimal(18,10);not null"`
+
+	// Annualized funding differential, percent
+	AnnualizedPercent decimal.Decimal `gorm:"type:decimal(12,4);not null"`
+	// Annualized differential minus round trip taker fees amortized over the holding period, percent
+	NetAnnualizedPercent decimal.Decimal `gorm:"type:decimal(12,4);not null"`
+}
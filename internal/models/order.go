@@ -92,6 +92,7 @@ type Order struct {
 	RawResponse      string          `gorm:"type:text;"`
 	Fees             decimal.Decimal `gorm:"type:numeric(38,18);"`
 	Profit           decimal.Decimal `gorm:"type:numeric(38,18);"`
+	Paper            bool            `gorm:"type:boolean;not null;default:false"` // filled by a simulated exchange
 	CreatedAt        time.Time       `gorm:"type:timestamptz;default:now()"`
 	UpdatedAt        time.Time       `gorm:"type:timestamptz;"`
 }
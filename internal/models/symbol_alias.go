@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SymbolAlias maps ExchangeSymbol of a venue to the canonical Symbol the arbitrage bot trades the asset under.
+// ExchangeName is an exchange name for perpetuals or "<exchange>:spot" for spot. One coin of ExchangeSymbol
+// is Multiplier canonical coins, e.g. 1000 for 1000PEPEUSDT.
+type SymbolAlias struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuidv7();primaryKey"`
+	CreatedAt time.Time `gorm:"type:timestamptz;default:now()"`
+
+	Symbol         string          `gorm:"type:text;not null"`
+	ExchangeName   string          `gorm:"type:text;not null;uniqueIndex:idx_symbol_alias_exchange_symbol"`
+	ExchangeSymbol string          `gorm:"type:text;not null;uniqueIndex:idx_symbol_alias_exchange_symbol"`
+	Multiplier     decimal.Decimal `gorm:"type:decimal(28,12);not null;default:1"`
+}
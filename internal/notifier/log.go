@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lucrumx/bot/internal/clock"
+)
+
+// LogNotifier writes the messages to the log instead of sending them, stamped with the time of its clock.
+// Used by replays, where the clock is the replayed one.
+type LogNotifier struct {
+	logger zerolog.Logger
+	clock  clock.Clock
+	count  atomic.Int64
+}
+
+// NewLogNotifier constructor.
+func NewLogNotifier(logger zerolog.Logger, c clock.Clock) *LogNotifier {
+	return &LogNotifier{logger: logger, clock: c}
+}
+
+// Send logs the message.
+func (n *LogNotifier) Send(message string) error {
+	n.count.Add(1)
+	n.logger.Info().Time("at", n.clock.Now()).Str("message", message).Msg("notification")
+	return nil
+}
+
+// Count returns the number of sent messages.
+func (n *LogNotifier) Count() int64 {
+	return n.count.Load()
+}
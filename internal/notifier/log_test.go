@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucrumx/bot/internal/clock"
+)
+
+func TestLogNotifier_Send(t *testing.T) {
+	var buf bytes.Buffer
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	n := NewLogNotifier(zerolog.New(&buf), clock.NewManual(at))
+
+	require.NoError(t, n.Send("<b>PUMP</b>"))
+	require.NoError(t, n.Send("second"))
+
+	assert.Equal(t, int64(2), n.Count())
+	assert.Contains(t, buf.String(), `"message":"<b>PUMP</b>"`)
+	assert.Contains(t, buf.String(), at.Format(time.RFC3339))
+}
@@ -36,6 +36,11 @@ func InitDB(cfg *config.Config) *gorm.DB {
 		&models.ArbitrageSpread{},
 		&models.Order{},
 		&models.Balance{},
+		&models.FundingRate{},
+		&models.FundingSpread{},
+		&models.ArbitrageTrade{},
+		&models.BlacklistEntry{},
+		&models.SymbolAlias{},
 	}
 
 	for _, m := range modelsToMigrate {
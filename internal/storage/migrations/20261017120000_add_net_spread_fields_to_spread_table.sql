@@ -0,0 +1,9 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE arbitrage_spreads ADD net_spread_percent decimal(10, 4) NOT NULL DEFAULT 0;
+ALTER TABLE arbitrage_spreads ADD max_net_spread_percent decimal(10, 4) NOT NULL DEFAULT 0;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE arbitrage_spreads DROP COLUMN net_spread_percent;
+ALTER TABLE arbitrage_spreads DROP COLUMN max_net_spread_percent;
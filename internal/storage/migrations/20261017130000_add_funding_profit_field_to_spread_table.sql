@@ -0,0 +1,7 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE arbitrage_spreads ADD funding_profit decimal(28, 12) NULL;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE arbitrage_spreads DROP COLUMN funding_profit;
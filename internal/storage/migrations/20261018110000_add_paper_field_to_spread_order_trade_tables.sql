@@ -0,0 +1,11 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE arbitrage_spreads ADD paper boolean NOT NULL DEFAULT false;
+ALTER TABLE orders ADD paper boolean NOT NULL DEFAULT false;
+ALTER TABLE arbitrage_trades ADD paper boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE arbitrage_spreads DROP COLUMN paper;
+ALTER TABLE orders DROP COLUMN paper;
+ALTER TABLE arbitrage_trades DROP COLUMN paper;
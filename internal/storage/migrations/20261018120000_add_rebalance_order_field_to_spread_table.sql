@@ -0,0 +1,7 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE arbitrage_spreads ADD rebalance_order_id uuid NULL;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE arbitrage_spreads DROP COLUMN rebalance_order_id;
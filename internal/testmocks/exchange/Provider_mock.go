@@ -42,16 +42,16 @@ func (_m *MockProvider) EXPECT() *MockProvider_Expecter {
 }
 
 // CancelOrder provides a mock function for the type MockProvider
-func (_mock *MockProvider) CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) error {
-	ret := _mock.Called(ctx, orderID, exchangeOrderID, symbol)
+func (_mock *MockProvider) CancelOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) error {
+	ret := _mock.Called(ctx, orderID, exchangeOrderID, symbol, category)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CancelOrder")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
-		r0 = returnFunc(ctx, orderID, exchangeOrderID, symbol)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, exchange.Category) error); ok {
+		r0 = returnFunc(ctx, orderID, exchangeOrderID, symbol, category)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,11 +68,12 @@ type MockProvider_CancelOrder_Call struct {
 //   - orderID uuid.UUID
 //   - exchangeOrderID string
 //   - symbol string
-func (_e *MockProvider_Expecter) CancelOrder(ctx interface{}, orderID interface{}, exchangeOrderID interface{}, symbol interface{}) *MockProvider_CancelOrder_Call {
-	return &MockProvider_CancelOrder_Call{Call: _e.mock.On("CancelOrder", ctx, orderID, exchangeOrderID, symbol)}
+//   - category exchange.Category
+func (_e *MockProvider_Expecter) CancelOrder(ctx interface{}, orderID interface{}, exchangeOrderID interface{}, symbol interface{}, category interface{}) *MockProvider_CancelOrder_Call {
+	return &MockProvider_CancelOrder_Call{Call: _e.mock.On("CancelOrder", ctx, orderID, exchangeOrderID, symbol, category)}
 }
 
-func (_c *MockProvider_CancelOrder_Call) Run(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string)) *MockProvider_CancelOrder_Call {
+func (_c *MockProvider_CancelOrder_Call) Run(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category)) *MockProvider_CancelOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -90,11 +91,16 @@ func (_c *MockProvider_CancelOrder_Call) Run(run func(ctx context.Context, order
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 exchange.Category
+		if args[4] != nil {
+			arg4 = args[4].(exchange.Category)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -105,7 +111,7 @@ func (_c *MockProvider_CancelOrder_Call) Return(err error) *MockProvider_CancelO
 	return _c
 }
 
-func (_c *MockProvider_CancelOrder_Call) RunAndReturn(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) error) *MockProvider_CancelOrder_Call {
+func (_c *MockProvider_CancelOrder_Call) RunAndReturn(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) error) *MockProvider_CancelOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -225,8 +231,8 @@ func (_c *MockProvider_CreateOrder_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // GetBalances provides a mock function for the type MockProvider
-func (_mock *MockProvider) GetBalances(ctx context.Context) ([]models.Balance, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockProvider) GetBalances(ctx context.Context, category exchange.Category) ([]models.Balance, error) {
+	ret := _mock.Called(ctx, category)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBalances")
@@ -234,18 +240,18 @@ func (_mock *MockProvider) GetBalances(ctx context.Context) ([]models.Balance, e
 
 	var r0 []models.Balance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Balance, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) ([]models.Balance, error)); ok {
+		return returnFunc(ctx, category)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Balance); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) []models.Balance); ok {
+		r0 = returnFunc(ctx, category)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Balance)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, exchange.Category) error); ok {
+		r1 = returnFunc(ctx, category)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -259,18 +265,24 @@ type MockProvider_GetBalances_Call struct {
 
 // GetBalances is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockProvider_Expecter) GetBalances(ctx interface{}) *MockProvider_GetBalances_Call {
-	return &MockProvider_GetBalances_Call{Call: _e.mock.On("GetBalances", ctx)}
+//   - category exchange.Category
+func (_e *MockProvider_Expecter) GetBalances(ctx interface{}, category interface{}) *MockProvider_GetBalances_Call {
+	return &MockProvider_GetBalances_Call{Call: _e.mock.On("GetBalances", ctx, category)}
 }
 
-func (_c *MockProvider_GetBalances_Call) Run(run func(ctx context.Context)) *MockProvider_GetBalances_Call {
+func (_c *MockProvider_GetBalances_Call) Run(run func(ctx context.Context, category exchange.Category)) *MockProvider_GetBalances_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 exchange.Category
+		if args[1] != nil {
+			arg1 = args[1].(exchange.Category)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -281,7 +293,7 @@ func (_c *MockProvider_GetBalances_Call) Return(balances []models.Balance, err e
 	return _c
 }
 
-func (_c *MockProvider_GetBalances_Call) RunAndReturn(run func(ctx context.Context) ([]models.Balance, error)) *MockProvider_GetBalances_Call {
+func (_c *MockProvider_GetBalances_Call) RunAndReturn(run func(ctx context.Context, category exchange.Category) ([]models.Balance, error)) *MockProvider_GetBalances_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -330,24 +342,84 @@ func (_c *MockProvider_GetExchangeName_Call) RunAndReturn(run func() string) *Mo
 	return _c
 }
 
-// GetInstruments provides a mock function for the type MockProvider
-func (_mock *MockProvider) GetInstruments(ctx context.Context) (map[string]exchange.Instrument, error) {
+// GetFeeSchedule provides a mock function for the type MockProvider
+func (_mock *MockProvider) GetFeeSchedule(ctx context.Context) (exchange.FeeSchedule, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInstruments")
+		panic("no return value specified for GetFeeSchedule")
 	}
 
-	var r0 map[string]exchange.Instrument
+	var r0 exchange.FeeSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (exchange.FeeSchedule, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) exchange.FeeSchedule); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(exchange.FeeSchedule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProvider_GetFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeeSchedule'
+type MockProvider_GetFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// GetFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProvider_Expecter) GetFeeSchedule(ctx interface{}) *MockProvider_GetFeeSchedule_Call {
+	return &MockProvider_GetFeeSchedule_Call{Call: _e.mock.On("GetFeeSchedule", ctx)}
+}
+
+func (_c *MockProvider_GetFeeSchedule_Call) Run(run func(ctx context.Context)) *MockProvider_GetFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_GetFeeSchedule_Call) Return(feeSchedule exchange.FeeSchedule, err error) *MockProvider_GetFeeSchedule_Call {
+	_c.Call.Return(feeSchedule, err)
+	return _c
+}
+
+func (_c *MockProvider_GetFeeSchedule_Call) RunAndReturn(run func(ctx context.Context) (exchange.FeeSchedule, error)) *MockProvider_GetFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFundingRates provides a mock function for the type MockProvider
+func (_mock *MockProvider) GetFundingRates(ctx context.Context) (map[string]exchange.FundingRate, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFundingRates")
+	}
+
+	var r0 map[string]exchange.FundingRate
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]exchange.Instrument, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]exchange.FundingRate, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]exchange.Instrument); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]exchange.FundingRate); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]exchange.Instrument)
+			r0 = ret.Get(0).(map[string]exchange.FundingRate)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -358,6 +430,68 @@ func (_mock *MockProvider) GetInstruments(ctx context.Context) (map[string]excha
 	return r0, r1
 }
 
+// MockProvider_GetFundingRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFundingRates'
+type MockProvider_GetFundingRates_Call struct {
+	*mock.Call
+}
+
+// GetFundingRates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProvider_Expecter) GetFundingRates(ctx interface{}) *MockProvider_GetFundingRates_Call {
+	return &MockProvider_GetFundingRates_Call{Call: _e.mock.On("GetFundingRates", ctx)}
+}
+
+func (_c *MockProvider_GetFundingRates_Call) Run(run func(ctx context.Context)) *MockProvider_GetFundingRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_GetFundingRates_Call) Return(stringToFundingRate map[string]exchange.FundingRate, err error) *MockProvider_GetFundingRates_Call {
+	_c.Call.Return(stringToFundingRate, err)
+	return _c
+}
+
+func (_c *MockProvider_GetFundingRates_Call) RunAndReturn(run func(ctx context.Context) (map[string]exchange.FundingRate, error)) *MockProvider_GetFundingRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInstruments provides a mock function for the type MockProvider
+func (_mock *MockProvider) GetInstruments(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error) {
+	ret := _mock.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstruments")
+	}
+
+	var r0 map[string]exchange.Instrument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) (map[string]exchange.Instrument, error)); ok {
+		return returnFunc(ctx, category)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) map[string]exchange.Instrument); ok {
+		r0 = returnFunc(ctx, category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]exchange.Instrument)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, exchange.Category) error); ok {
+		r1 = returnFunc(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockProvider_GetInstruments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstruments'
 type MockProvider_GetInstruments_Call struct {
 	*mock.Call
@@ -365,18 +499,24 @@ type MockProvider_GetInstruments_Call struct {
 
 // GetInstruments is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockProvider_Expecter) GetInstruments(ctx interface{}) *MockProvider_GetInstruments_Call {
-	return &MockProvider_GetInstruments_Call{Call: _e.mock.On("GetInstruments", ctx)}
+//   - category exchange.Category
+func (_e *MockProvider_Expecter) GetInstruments(ctx interface{}, category interface{}) *MockProvider_GetInstruments_Call {
+	return &MockProvider_GetInstruments_Call{Call: _e.mock.On("GetInstruments", ctx, category)}
 }
 
-func (_c *MockProvider_GetInstruments_Call) Run(run func(ctx context.Context)) *MockProvider_GetInstruments_Call {
+func (_c *MockProvider_GetInstruments_Call) Run(run func(ctx context.Context, category exchange.Category)) *MockProvider_GetInstruments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 exchange.Category
+		if args[1] != nil {
+			arg1 = args[1].(exchange.Category)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -387,14 +527,82 @@ func (_c *MockProvider_GetInstruments_Call) Return(stringToInstrument map[string
 	return _c
 }
 
-func (_c *MockProvider_GetInstruments_Call) RunAndReturn(run func(ctx context.Context) (map[string]exchange.Instrument, error)) *MockProvider_GetInstruments_Call {
+func (_c *MockProvider_GetInstruments_Call) RunAndReturn(run func(ctx context.Context, category exchange.Category) (map[string]exchange.Instrument, error)) *MockProvider_GetInstruments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenOrders provides a mock function for the type MockProvider
+func (_mock *MockProvider) GetOpenOrders(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error) {
+	ret := _mock.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenOrders")
+	}
+
+	var r0 []exchange.OpenOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) ([]exchange.OpenOrder, error)); ok {
+		return returnFunc(ctx, category)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, exchange.Category) []exchange.OpenOrder); ok {
+		r0 = returnFunc(ctx, category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]exchange.OpenOrder)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, exchange.Category) error); ok {
+		r1 = returnFunc(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProvider_GetOpenOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenOrders'
+type MockProvider_GetOpenOrders_Call struct {
+	*mock.Call
+}
+
+// GetOpenOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category exchange.Category
+func (_e *MockProvider_Expecter) GetOpenOrders(ctx interface{}, category interface{}) *MockProvider_GetOpenOrders_Call {
+	return &MockProvider_GetOpenOrders_Call{Call: _e.mock.On("GetOpenOrders", ctx, category)}
+}
+
+func (_c *MockProvider_GetOpenOrders_Call) Run(run func(ctx context.Context, category exchange.Category)) *MockProvider_GetOpenOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 exchange.Category
+		if args[1] != nil {
+			arg1 = args[1].(exchange.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_GetOpenOrders_Call) Return(openOrders []exchange.OpenOrder, err error) *MockProvider_GetOpenOrders_Call {
+	_c.Call.Return(openOrders, err)
+	return _c
+}
+
+func (_c *MockProvider_GetOpenOrders_Call) RunAndReturn(run func(ctx context.Context, category exchange.Category) ([]exchange.OpenOrder, error)) *MockProvider_GetOpenOrders_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetOrder provides a mock function for the type MockProvider
-func (_mock *MockProvider) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (exchange.ExchangeOrder, error) {
-	ret := _mock.Called(ctx, orderID, exchangeOrderID, symbol)
+func (_mock *MockProvider) GetOrder(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error) {
+	ret := _mock.Called(ctx, orderID, exchangeOrderID, symbol, category)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOrder")
@@ -402,16 +610,16 @@ func (_mock *MockProvider) GetOrder(ctx context.Context, orderID uuid.UUID, exch
 
 	var r0 exchange.ExchangeOrder
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (exchange.ExchangeOrder, error)); ok {
-		return returnFunc(ctx, orderID, exchangeOrderID, symbol)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, exchange.Category) (exchange.ExchangeOrder, error)); ok {
+		return returnFunc(ctx, orderID, exchangeOrderID, symbol, category)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) exchange.ExchangeOrder); ok {
-		r0 = returnFunc(ctx, orderID, exchangeOrderID, symbol)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, exchange.Category) exchange.ExchangeOrder); ok {
+		r0 = returnFunc(ctx, orderID, exchangeOrderID, symbol, category)
 	} else {
 		r0 = ret.Get(0).(exchange.ExchangeOrder)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
-		r1 = returnFunc(ctx, orderID, exchangeOrderID, symbol)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, exchange.Category) error); ok {
+		r1 = returnFunc(ctx, orderID, exchangeOrderID, symbol, category)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -428,11 +636,12 @@ type MockProvider_GetOrder_Call struct {
 //   - orderID uuid.UUID
 //   - exchangeOrderID string
 //   - symbol string
-func (_e *MockProvider_Expecter) GetOrder(ctx interface{}, orderID interface{}, exchangeOrderID interface{}, symbol interface{}) *MockProvider_GetOrder_Call {
-	return &MockProvider_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, orderID, exchangeOrderID, symbol)}
+//   - category exchange.Category
+func (_e *MockProvider_Expecter) GetOrder(ctx interface{}, orderID interface{}, exchangeOrderID interface{}, symbol interface{}, category interface{}) *MockProvider_GetOrder_Call {
+	return &MockProvider_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, orderID, exchangeOrderID, symbol, category)}
 }
 
-func (_c *MockProvider_GetOrder_Call) Run(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string)) *MockProvider_GetOrder_Call {
+func (_c *MockProvider_GetOrder_Call) Run(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category)) *MockProvider_GetOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -450,11 +659,16 @@ func (_c *MockProvider_GetOrder_Call) Run(run func(ctx context.Context, orderID
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 exchange.Category
+		if args[4] != nil {
+			arg4 = args[4].(exchange.Category)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -465,7 +679,69 @@ func (_c *MockProvider_GetOrder_Call) Return(exchangeOrder exchange.ExchangeOrde
 	return _c
 }
 
-func (_c *MockProvider_GetOrder_Call) RunAndReturn(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string) (exchange.ExchangeOrder, error)) *MockProvider_GetOrder_Call {
+func (_c *MockProvider_GetOrder_Call) RunAndReturn(run func(ctx context.Context, orderID uuid.UUID, exchangeOrderID string, symbol string, category exchange.Category) (exchange.ExchangeOrder, error)) *MockProvider_GetOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPositions provides a mock function for the type MockProvider
+func (_mock *MockProvider) GetPositions(ctx context.Context) ([]exchange.Position, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPositions")
+	}
+
+	var r0 []exchange.Position
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]exchange.Position, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []exchange.Position); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]exchange.Position)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProvider_GetPositions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPositions'
+type MockProvider_GetPositions_Call struct {
+	*mock.Call
+}
+
+// GetPositions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProvider_Expecter) GetPositions(ctx interface{}) *MockProvider_GetPositions_Call {
+	return &MockProvider_GetPositions_Call{Call: _e.mock.On("GetPositions", ctx)}
+}
+
+func (_c *MockProvider_GetPositions_Call) Run(run func(ctx context.Context)) *MockProvider_GetPositions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_GetPositions_Call) Return(positions []exchange.Position, err error) *MockProvider_GetPositions_Call {
+	_c.Call.Return(positions, err)
+	return _c
+}
+
+func (_c *MockProvider_GetPositions_Call) RunAndReturn(run func(ctx context.Context) ([]exchange.Position, error)) *MockProvider_GetPositions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -607,6 +883,137 @@ func (_c *MockProvider_SetLeverage_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// SetMarginMode provides a mock function for the type MockProvider
+func (_mock *MockProvider) SetMarginMode(ctx context.Context, symbol string, mode exchange.MarginMode) error {
+	ret := _mock.Called(ctx, symbol, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMarginMode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, exchange.MarginMode) error); ok {
+		r0 = returnFunc(ctx, symbol, mode)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProvider_SetMarginMode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMarginMode'
+type MockProvider_SetMarginMode_Call struct {
+	*mock.Call
+}
+
+// SetMarginMode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbol string
+//   - mode exchange.MarginMode
+func (_e *MockProvider_Expecter) SetMarginMode(ctx interface{}, symbol interface{}, mode interface{}) *MockProvider_SetMarginMode_Call {
+	return &MockProvider_SetMarginMode_Call{Call: _e.mock.On("SetMarginMode", ctx, symbol, mode)}
+}
+
+func (_c *MockProvider_SetMarginMode_Call) Run(run func(ctx context.Context, symbol string, mode exchange.MarginMode)) *MockProvider_SetMarginMode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 exchange.MarginMode
+		if args[2] != nil {
+			arg2 = args[2].(exchange.MarginMode)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_SetMarginMode_Call) Return(err error) *MockProvider_SetMarginMode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProvider_SetMarginMode_Call) RunAndReturn(run func(ctx context.Context, symbol string, mode exchange.MarginMode) error) *MockProvider_SetMarginMode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeBookTicker provides a mock function for the type MockProvider
+func (_mock *MockProvider) SubscribeBookTicker(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error) {
+	ret := _mock.Called(ctx, symbols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeBookTicker")
+	}
+
+	var r0 <-chan exchange.BookTop
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (<-chan exchange.BookTop, error)); ok {
+		return returnFunc(ctx, symbols)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) <-chan exchange.BookTop); ok {
+		r0 = returnFunc(ctx, symbols)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan exchange.BookTop)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, symbols)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProvider_SubscribeBookTicker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeBookTicker'
+type MockProvider_SubscribeBookTicker_Call struct {
+	*mock.Call
+}
+
+// SubscribeBookTicker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbols []string
+func (_e *MockProvider_Expecter) SubscribeBookTicker(ctx interface{}, symbols interface{}) *MockProvider_SubscribeBookTicker_Call {
+	return &MockProvider_SubscribeBookTicker_Call{Call: _e.mock.On("SubscribeBookTicker", ctx, symbols)}
+}
+
+func (_c *MockProvider_SubscribeBookTicker_Call) Run(run func(ctx context.Context, symbols []string)) *MockProvider_SubscribeBookTicker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_SubscribeBookTicker_Call) Return(bookCh <-chan exchange.BookTop, err error) *MockProvider_SubscribeBookTicker_Call {
+	_c.Call.Return(bookCh, err)
+	return _c
+}
+
+func (_c *MockProvider_SubscribeBookTicker_Call) RunAndReturn(run func(ctx context.Context, symbols []string) (<-chan exchange.BookTop, error)) *MockProvider_SubscribeBookTicker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SubscribeExecutions provides a mock function for the type MockProvider
 func (_mock *MockProvider) SubscribeExecutions(ctx context.Context) (<-chan exchange.OrderExecutionEvent, error) {
 	ret := _mock.Called(ctx)
@@ -669,6 +1076,74 @@ func (_c *MockProvider_SubscribeExecutions_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// SubscribeOrderBook provides a mock function for the type MockProvider
+func (_mock *MockProvider) SubscribeOrderBook(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error) {
+	ret := _mock.Called(ctx, symbols)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeOrderBook")
+	}
+
+	var r0 <-chan exchange.OrderBookUpdate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (<-chan exchange.OrderBookUpdate, error)); ok {
+		return returnFunc(ctx, symbols)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) <-chan exchange.OrderBookUpdate); ok {
+		r0 = returnFunc(ctx, symbols)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan exchange.OrderBookUpdate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, symbols)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProvider_SubscribeOrderBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeOrderBook'
+type MockProvider_SubscribeOrderBook_Call struct {
+	*mock.Call
+}
+
+// SubscribeOrderBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - symbols []string
+func (_e *MockProvider_Expecter) SubscribeOrderBook(ctx interface{}, symbols interface{}) *MockProvider_SubscribeOrderBook_Call {
+	return &MockProvider_SubscribeOrderBook_Call{Call: _e.mock.On("SubscribeOrderBook", ctx, symbols)}
+}
+
+func (_c *MockProvider_SubscribeOrderBook_Call) Run(run func(ctx context.Context, symbols []string)) *MockProvider_SubscribeOrderBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProvider_SubscribeOrderBook_Call) Return(orderBookCh <-chan exchange.OrderBookUpdate, err error) *MockProvider_SubscribeOrderBook_Call {
+	_c.Call.Return(orderBookCh, err)
+	return _c
+}
+
+func (_c *MockProvider_SubscribeOrderBook_Call) RunAndReturn(run func(ctx context.Context, symbols []string) (<-chan exchange.OrderBookUpdate, error)) *MockProvider_SubscribeOrderBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SubscribeTrades provides a mock function for the type MockProvider
 func (_mock *MockProvider) SubscribeTrades(ctx context.Context, symbols []string, category exchange.Category) (<-chan exchange.Trade, error) {
 	ret := _mock.Called(ctx, symbols, category)